@@ -8,13 +8,16 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
-	"strconv"
 	"strings"
 	"time"
 
 	"github.com/manicminer/hamilton/auth"
 	"github.com/manicminer/hamilton/environments"
+	"github.com/manicminer/hamilton/errors"
+	"github.com/manicminer/hamilton/logging"
 	"github.com/manicminer/hamilton/odata"
+	"github.com/manicminer/hamilton/retry"
+	"github.com/manicminer/hamilton/telemetry"
 )
 
 type ApiVersion string
@@ -24,11 +27,8 @@ const (
 	Version20 ApiVersion = "2.0"
 )
 
-const (
-	defaultInitialBackoff = 5 * time.Second
-	defaultBackoffCap     = 64 * time.Second
-	requestAttempts       = 10
-)
+// RetryPolicy determines whether a failed request should be retried, and how long to wait beforehand.
+type RetryPolicy = retry.Policy
 
 // ValidStatusFunc is a function that tests whether an HTTP response is considered valid for the particular request.
 type ValidStatusFunc func(response *http.Response, o *odata.OData) bool
@@ -66,16 +66,38 @@ type Client struct {
 	// Authorizer is anything that can provide an access token with which to authorize requests.
 	Authorizer auth.Authorizer
 
-	httpClient GraphClient
+	// RetryPolicy determines whether and when failed requests are retried. Set to retry.Disabled to disable retries.
+	RetryPolicy RetryPolicy
+
+	// HttpClient is the HTTP client used to send requests, which defaults to http.DefaultClient.
+	// Use transport.NewClient to build a client with a chain of middleware.
+	HttpClient GraphClient
+
+	// Logger optionally receives details of each attempt at sending a request, with sensitive values redacted.
+	Logger logging.Logger
+
+	// LogBodies enables logging of request and response bodies. Although known sensitive values are redacted, bodies
+	// may still contain personal or otherwise confidential information.
+	LogBodies bool
+
+	// Tracer optionally creates spans for each request, and for each attempt at sending a request.
+	Tracer telemetry.Tracer
+
+	// Meter optionally records the latency of each attempt at sending a request, and counts of attempts and throttled
+	// attempts.
+	Meter telemetry.Meter
 }
 
 // NewClient returns a new Client configured with the specified API version and tenant ID.
 func NewClient(apiVersion ApiVersion, tenantId string) Client {
+	retryPolicy := retry.DefaultPolicy()
+	retryPolicy.InitialBackoff = 5 * time.Second
 	return Client{
-		Endpoint:   environments.AadGraphGlobal.Endpoint,
-		ApiVersion: apiVersion,
-		TenantId:   tenantId,
-		httpClient: http.DefaultClient,
+		Endpoint:    environments.AadGraphGlobal.Endpoint,
+		ApiVersion:  apiVersion,
+		TenantId:    tenantId,
+		RetryPolicy: retryPolicy,
+		HttpClient:  http.DefaultClient,
 	}
 }
 
@@ -95,8 +117,19 @@ func (c Client) buildUri(uri Uri) (string, error) {
 }
 
 // performRequest is used by the package to send an HTTP request to the API.
-func (c Client) performRequest(req *http.Request, input HttpRequestInput) (*http.Response, int, *odata.OData, error) {
-	var status int
+func (c Client) performRequest(req *http.Request, input HttpRequestInput) (resp *http.Response, status int, o *odata.OData, err error) {
+	entity := telemetry.Entity(req.URL.Path, string(c.ApiVersion), c.TenantId)
+	if c.Tracer != nil {
+		ctx, span := c.Tracer.Start(req.Context(), "aadgraph.request",
+			telemetry.String(telemetry.AttributeServiceName, "aadgraph"),
+			telemetry.String(telemetry.AttributeHttpMethod, req.Method),
+			telemetry.String(telemetry.AttributeHttpUrl, req.URL.String()),
+			telemetry.String(telemetry.AttributeEntity, entity))
+		req = req.WithContext(ctx)
+		defer func() {
+			telemetry.EndSpan(span, err, telemetry.Int(telemetry.AttributeHttpStatus, status))
+		}()
+	}
 
 	if c.Authorizer != nil {
 		token, err := c.Authorizer.Token()
@@ -113,34 +146,43 @@ func (c Client) performRequest(req *http.Request, input HttpRequestInput) (*http
 		req.Header.Add("User-Agent", c.UserAgent)
 	}
 
-	var resp *http.Response
-	var o *odata.OData
-	var err error
+	policy := c.retryPolicy(req.Context())
 
-	var backoffPower func(int64, int64) int64
-	backoffPower = func(base, exp int64) int64 {
-		if exp <= 1 {
-			return base
-		}
-		return base * backoffPower(base, exp-1)
+	httpClient := c.HttpClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
 	}
 
-	var attempts, backoff, multiplier int64
-	for attempts = 0; attempts < requestAttempts; attempts++ {
-		// sleep after the previous failed attempt
-		if attempts > 0 {
-			time.Sleep(time.Duration(backoff))
+	start := time.Now()
+	for attempt := 1; ; attempt++ {
+		// rewind the request body after a previous failed attempt
+		if attempt > 1 && req.GetBody != nil {
+			if req.Body, err = req.GetBody(); err != nil {
+				return nil, status, nil, err
+			}
 		}
 
-		// default exponential backoff
-		multiplier++
-		backoff = int64(defaultInitialBackoff) * backoffPower(2, multiplier)
-		if cap := int64(defaultBackoffCap); backoff > cap {
-			backoff = cap
+		attemptReq := req
+		attemptCtx, attemptSpan := telemetry.StartSpan(req.Context(), c.Tracer, "aadgraph.attempt")
+		if c.Tracer != nil {
+			attemptReq = req.WithContext(attemptCtx)
+		}
+		attemptStart := time.Now()
+		resp, err = httpClient.Do(attemptReq)
+		latency := time.Since(attemptStart)
+		telemetry.RecordAttempt(req.Context(), attemptSpan, c.Meter, entity, attempt, req, resp, latency, err)
+		if c.Logger != nil {
+			c.Logger.Log(req.Context(), logging.NewEntry(req, resp, attempt, latency, err, c.LogBodies))
 		}
-
-		resp, err = c.httpClient.Do(req)
 		if err != nil {
+			if req.Context().Err() == nil {
+				if delay, ok := policy.Retry(retry.Attempt{Number: attempt, Start: start, Request: req, Err: err}); ok {
+					if err := retry.Sleep(req.Context(), delay); err != nil {
+						return nil, status, nil, err
+					}
+					continue
+				}
+			}
 			return nil, status, nil, err
 		}
 
@@ -150,45 +192,54 @@ func (c Client) performRequest(req *http.Request, input HttpRequestInput) (*http
 		}
 
 		status = resp.StatusCode
-		if !containsStatusCode(input.GetValidStatusCodes(), status) {
-			f := input.GetValidStatusFunc()
-			if f != nil && f(resp, o) {
-				return resp, status, o, nil
-			}
-
-			// rate limiting
-			if containsStatusCode([]int{424, 429, 503}, status) {
-				if o.Error != nil && o.Error.Values != nil {
-					for _, v := range *o.Error.Values {
-						if v.Item == "BackoffTime" {
-							if r, err := strconv.ParseFloat(v.Value, 64); err == nil && r > 0 {
-								// BackoffTime detected, use that instead of default backoff
-								backoff = int64(r * float64(time.Second))
-								multiplier = 0
-							}
-							break
-						}
-					}
-				}
-				continue
-			}
+		if containsStatusCode(input.GetValidStatusCodes(), status) {
+			return resp, status, o, nil
+		}
+		if f := input.GetValidStatusFunc(); f != nil && f(resp, o) {
+			return resp, status, o, nil
+		}
 
-			var errText string
-			switch {
-			case o.Error != nil && o.Error.String() != "":
-				errText = fmt.Sprintf("OData error: %s", o.Error)
-			default:
-				defer resp.Body.Close()
-				respBody, _ := ioutil.ReadAll(resp.Body)
-				errText = fmt.Sprintf("response: %s", respBody)
+		// rate limiting and transient errors
+		if delay, ok := policy.Retry(retry.Attempt{Number: attempt, Start: start, Request: req, Response: resp, OData: o}); ok {
+			resp.Body.Close()
+			if err := retry.Sleep(req.Context(), delay); err != nil {
+				return nil, status, o, err
 			}
-			return nil, status, o, fmt.Errorf("unexpected status %d with %s", resp.StatusCode, errText)
+			continue
 		}
 
-		break
+		return nil, status, o, newApiError(req, resp, o, attempt-1)
 	}
+}
 
-	return resp, status, o, nil
+// retryPolicy is used by the package to determine the retry policy for a request, which can be overridden by the
+// request context using retry.WithPolicy.
+func (c Client) retryPolicy(ctx context.Context) RetryPolicy {
+	if p, ok := retry.FromContext(ctx); ok {
+		return p
+	}
+	if c.RetryPolicy == nil {
+		return retry.DefaultPolicy()
+	}
+	return c.RetryPolicy
+}
+
+// newApiError is used by the package to build an error describing an unexpected response.
+func newApiError(req *http.Request, resp *http.Response, o *odata.OData, retries int) error {
+	e := &errors.ApiError{
+		Method:     req.Method,
+		Url:        req.URL.String(),
+		StatusCode: resp.StatusCode,
+		Retries:    retries,
+	}
+	if o != nil && o.Error != nil {
+		e.OData = o.Error
+	}
+	if e.OData == nil || e.OData.String() == "" {
+		defer resp.Body.Close()
+		e.ResponseBody, _ = ioutil.ReadAll(resp.Body)
+	}
+	return e
 }
 
 // containsStatusCode determines whether the returned status code is in the []int of expected status codes.
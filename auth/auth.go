@@ -6,12 +6,14 @@ import (
 	"crypto/x509"
 	"fmt"
 	"io/ioutil"
+	"net/http"
 	"strings"
 
 	"golang.org/x/crypto/pkcs12"
 	"golang.org/x/oauth2"
 
 	"github.com/manicminer/hamilton/environments"
+	"github.com/manicminer/hamilton/telemetry"
 )
 
 // Authorizer is anything that can return an access token for authorizing API connections
@@ -44,6 +46,13 @@ const (
 // environment. If any authentication mechanism fails due to misconfiguration or some other error, the function
 // will return (nil, error) and later mechanisms will not be attempted.
 func (c *Config) NewAuthorizer(ctx context.Context, api Api) (Authorizer, error) {
+	if c.HttpClient != nil {
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, c.HttpClient)
+	}
+	if c.Tracer != nil {
+		ctx = telemetry.WithTracer(ctx, c.Tracer)
+	}
+
 	if c.EnableClientCertAuth && strings.TrimSpace(c.TenantID) != "" && strings.TrimSpace(c.ClientID) != "" && strings.TrimSpace(c.ClientCertPath) != "" {
 		a, err := NewClientCertificateAuthorizer(ctx, c.Environment, api, c.Version, c.TenantID, c.ClientID, c.ClientCertPath, c.ClientCertPassword)
 		if err != nil {
@@ -149,6 +158,20 @@ func NewClientSecretAuthorizer(ctx context.Context, environment environments.Env
 	return conf.TokenSource(ctx, ClientCredentialsSecretType), nil
 }
 
+// httpClient returns the HTTP client to use for requesting tokens, which is the provided client if not nil, or else
+// any client set in ctx using the oauth2.HTTPClient context key, or else http.DefaultClient.
+func httpClient(ctx context.Context, client *http.Client) *http.Client {
+	if client != nil {
+		return client
+	}
+	if ctx != nil {
+		if c, ok := ctx.Value(oauth2.HTTPClient).(*http.Client); ok && c != nil {
+			return c
+		}
+	}
+	return http.DefaultClient
+}
+
 func TokenEndpoint(endpoint environments.AzureADEndpoint, tenant string, version TokenVersion) (e string) {
 	if tenant == "" {
 		tenant = "common"
@@ -180,3 +203,29 @@ func resource(env environments.Environment, api Api) (r string) {
 	}
 	return
 }
+
+// tracedAuthorizer creates a span for each token acquired from source
+type tracedAuthorizer struct {
+	ctx    context.Context
+	tracer telemetry.Tracer
+	method string
+	source Authorizer
+}
+
+// Token acquires a token from the source authorizer within a span
+func (a tracedAuthorizer) Token() (*oauth2.Token, error) {
+	_, span := a.tracer.Start(a.ctx, "auth.Token", telemetry.String(telemetry.AttributeAuthMethod, a.method))
+	token, err := a.source.Token()
+	telemetry.EndSpan(span, err)
+	return token, err
+}
+
+// traced wraps src with a tracedAuthorizer when a tracer has been set in ctx using telemetry.WithTracer, otherwise
+// src is returned unchanged.
+func traced(ctx context.Context, method string, src Authorizer) Authorizer {
+	tracer := telemetry.TracerFromContext(ctx)
+	if tracer == nil {
+		return src
+	}
+	return tracedAuthorizer{ctx: ctx, tracer: tracer, method: method, source: src}
+}
@@ -86,11 +86,11 @@ func NewAzureCliConfig(api Api, tenantId string) (*AzureCliConfig, error) {
 
 // TokenSource provides a source for obtaining access tokens using AzureCliAuthorizer.
 func (c *AzureCliConfig) TokenSource(ctx context.Context) Authorizer {
-	return &AzureCliAuthorizer{
+	return traced(ctx, "AzureCli", &AzureCliAuthorizer{
 		TenantID: c.TenantID,
 		ctx:      ctx,
 		conf:     c,
-	}
+	})
 }
 
 // checkAzVersion tries to determine the version of Azure CLI in the path and checks for a compatible version
@@ -73,15 +73,19 @@ type ClientCredentialsConfig struct {
 	// request.  If empty, the value of TokenURL is used as the
 	// intended audience.
 	Audience string
+
+	// HttpClient optionally specifies the HTTP client used to request tokens. When nil, any client set in the
+	// context using the oauth2.HTTPClient key is used, otherwise http.DefaultClient.
+	HttpClient *http.Client
 }
 
 // TokenSource provides a source for obtaining access tokens using clientAssertionAuthorizer or clientSecretAuthorizer.
 func (c *ClientCredentialsConfig) TokenSource(ctx context.Context, authType ClientCredentialsType) (source Authorizer) {
 	switch authType {
 	case ClientCredentialsAssertionType:
-		source = CachedAuthorizer(clientAssertionAuthorizer{ctx, c})
+		source = CachedAuthorizer(traced(ctx, "ClientAssertion", clientAssertionAuthorizer{ctx, c}))
 	case ClientCredentialsSecretType:
-		source = CachedAuthorizer(clientSecretAuthorizer{ctx, c})
+		source = CachedAuthorizer(traced(ctx, "ClientSecret", clientSecretAuthorizer{ctx, c}))
 	}
 	return
 }
@@ -217,7 +221,7 @@ func (a clientAssertionAuthorizer) Token() (*oauth2.Token, error) {
 		v["scope"] = []string{strings.Join(a.conf.Scopes, " ")}
 	}
 
-	return clientCredentialsToken(a.ctx, a.conf.TokenURL, &v)
+	return clientCredentialsToken(a.ctx, httpClient(a.ctx, a.conf.HttpClient), a.conf.TokenURL, &v)
 }
 
 // parseKey returns an rsa.PrivateKey containing the provided binary key data.
@@ -258,10 +262,10 @@ func (a clientSecretAuthorizer) Token() (*oauth2.Token, error) {
 		v["scope"] = []string{strings.Join(a.conf.Scopes, " ")}
 	}
 
-	return clientCredentialsToken(a.ctx, a.conf.TokenURL, &v)
+	return clientCredentialsToken(a.ctx, httpClient(a.ctx, a.conf.HttpClient), a.conf.TokenURL, &v)
 }
 
-func clientCredentialsToken(ctx context.Context, endpoint string, params *url.Values) (*oauth2.Token, error) {
+func clientCredentialsToken(ctx context.Context, client *http.Client, endpoint string, params *url.Values) (*oauth2.Token, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewBuffer([]byte(params.Encode())))
 	if err != nil {
 		return nil, fmt.Errorf("clientCredentialsToken: failed to build request")
@@ -270,7 +274,7 @@ func clientCredentialsToken(ctx context.Context, endpoint string, params *url.Va
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("clientCredentialsToken: cannot request token: %v", err)
 	}
@@ -1,6 +1,11 @@
 package auth
 
-import "github.com/manicminer/hamilton/environments"
+import (
+	"net/http"
+
+	"github.com/manicminer/hamilton/environments"
+	"github.com/manicminer/hamilton/telemetry"
+)
 
 type TokenVersion int
 
@@ -47,4 +52,11 @@ type Config struct {
 
 	// Specifies the password to authenticate with using client secret authentication
 	ClientSecret string
+
+	// Specifies a custom HTTP client used to request tokens, for example one built using transport.NewClient.
+	// Ignored when using Azure CLI authentication.
+	HttpClient *http.Client
+
+	// Specifies an optional tracer used to create a span each time a new token is acquired
+	Tracer telemetry.Tracer
 }
@@ -33,7 +33,7 @@ func (a *MsiAuthorizer) Token() (*oauth2.Token, error) {
 	}
 	url := fmt.Sprintf("%s?%s", a.conf.MsiEndpoint, query.Encode())
 
-	body, err := azureMetadata(a.ctx, url)
+	body, err := azureMetadata(a.ctx, a.conf.HttpClient, url)
 	if err != nil {
 		return nil, fmt.Errorf("MsiAuthorizer: failed to request token from metadata endpoint: %v", err)
 	}
@@ -79,6 +79,10 @@ type MsiConfig struct {
 	MsiApiVersion string
 	MsiEndpoint   string
 	Resource      string
+
+	// HttpClient optionally specifies the HTTP client used to request tokens. When nil, any client set in the
+	// context using the oauth2.HTTPClient key is used, otherwise a default client.
+	HttpClient *http.Client
 }
 
 // NewMsiConfig returns a new MsiConfig with a configured metadata endpoint and resource.
@@ -101,7 +105,7 @@ func NewMsiConfig(ctx context.Context, resource string, msiEndpoint string) (*Ms
 		"format":      []string{"text"},
 	}.Encode()
 
-	_, err = azureMetadata(ctx, e.String())
+	_, err = azureMetadata(ctx, nil, e.String())
 	if err != nil {
 		return nil, fmt.Errorf("NewMsiConfig: could not validate MSI endpoint: %v", err)
 	}
@@ -115,10 +119,10 @@ func NewMsiConfig(ctx context.Context, resource string, msiEndpoint string) (*Ms
 
 // TokenSource provides a source for obtaining access tokens using MsiAuthorizer.
 func (c *MsiConfig) TokenSource(ctx context.Context) Authorizer {
-	return CachedAuthorizer(&MsiAuthorizer{ctx: ctx, conf: c})
+	return CachedAuthorizer(traced(ctx, "Msi", &MsiAuthorizer{ctx: ctx, conf: c}))
 }
 
-func azureMetadata(ctx context.Context, url string) (body []byte, err error) {
+func azureMetadata(ctx context.Context, client *http.Client, url string) (body []byte, err error) {
 	var req *http.Request
 	req, err = http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
 	if err != nil {
@@ -127,11 +131,13 @@ func azureMetadata(ctx context.Context, url string) (body []byte, err error) {
 	req.Header = http.Header{
 		"Metadata": []string{"true"},
 	}
-	client := &http.Client{
-		Timeout: msiDefaultTimeout,
+	// the metadata endpoint is not always reachable, so ensure a timeout is set
+	c := *httpClient(ctx, client)
+	if c.Timeout == 0 {
+		c.Timeout = msiDefaultTimeout
 	}
 	var resp *http.Response
-	resp, err = client.Do(req)
+	resp, err = c.Do(req)
 	if err != nil {
 		return
 	}
@@ -140,7 +146,7 @@ func azureMetadata(ctx context.Context, url string) (body []byte, err error) {
 		return
 	}
 	defer resp.Body.Close()
-	if c := resp.StatusCode; c < 200 || c > 299 {
+	if s := resp.StatusCode; s < 200 || s > 299 {
 		err = fmt.Errorf("received HTTP status %d", resp.StatusCode)
 		return
 	}
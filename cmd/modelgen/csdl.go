@@ -0,0 +1,182 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// edmx is the root element of a CSDL metadata document.
+type edmx struct {
+	XMLName      xml.Name `xml:"Edmx"`
+	DataServices struct {
+		Schemas []schema `xml:"Schema"`
+	} `xml:"DataServices"`
+}
+
+// schema is a CSDL schema, which declares types in a namespace.
+type schema struct {
+	Namespace    string       `xml:"Namespace,attr"`
+	Alias        string       `xml:"Alias,attr"`
+	EntityTypes  []structured `xml:"EntityType"`
+	ComplexTypes []structured `xml:"ComplexType"`
+	EnumTypes    []enum       `xml:"EnumType"`
+}
+
+// structured is a CSDL entity type or complex type.
+type structured struct {
+	Name       string     `xml:"Name,attr"`
+	BaseType   string     `xml:"BaseType,attr"`
+	Abstract   bool       `xml:"Abstract,attr"`
+	Properties []property `xml:"Property"`
+
+	// namespace and entity are populated when the metadata is indexed.
+	namespace string
+	entity    bool
+}
+
+// property is a structural property of an entity type or complex type. Navigation properties are not modelled.
+type property struct {
+	Name string `xml:"Name,attr"`
+	Type string `xml:"Type,attr"`
+}
+
+// enum is a CSDL enumeration type.
+type enum struct {
+	Name    string `xml:"Name,attr"`
+	IsFlags bool   `xml:"IsFlags,attr"`
+	Members []struct {
+		Name string `xml:"Name,attr"`
+	} `xml:"Member"`
+
+	namespace string
+}
+
+// metadata is an indexed CSDL metadata document.
+type metadata struct {
+	// aliases maps each schema alias to its namespace.
+	aliases map[string]string
+
+	// structured and enums are keyed by qualified name, e.g. "microsoft.graph.group".
+	structured map[string]*structured
+	enums      map[string]*enum
+
+	// derived maps the qualified name of each type to the qualified names of types which directly derive from it.
+	derived map[string][]string
+}
+
+// parseMetadata reads and indexes a CSDL metadata document.
+func parseMetadata(r io.Reader) (*metadata, error) {
+	var doc edmx
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("parsing metadata: %v", err)
+	}
+	if len(doc.DataServices.Schemas) == 0 {
+		return nil, fmt.Errorf("parsing metadata: no schemas found")
+	}
+
+	m := &metadata{
+		aliases:    make(map[string]string),
+		structured: make(map[string]*structured),
+		enums:      make(map[string]*enum),
+		derived:    make(map[string][]string),
+	}
+	for _, s := range doc.DataServices.Schemas {
+		if s.Alias != "" {
+			m.aliases[s.Alias] = s.Namespace
+		}
+	}
+	for _, s := range doc.DataServices.Schemas {
+		for i := range s.EntityTypes {
+			t := &s.EntityTypes[i]
+			t.namespace, t.entity = s.Namespace, true
+			m.structured[s.Namespace+"."+t.Name] = t
+		}
+		for i := range s.ComplexTypes {
+			t := &s.ComplexTypes[i]
+			t.namespace = s.Namespace
+			m.structured[s.Namespace+"."+t.Name] = t
+		}
+		for i := range s.EnumTypes {
+			e := &s.EnumTypes[i]
+			e.namespace = s.Namespace
+			m.enums[s.Namespace+"."+e.Name] = e
+		}
+	}
+	for name, t := range m.structured {
+		if t.BaseType != "" {
+			base := m.qualify(t.BaseType)
+			m.derived[base] = append(m.derived[base], name)
+		}
+	}
+	return m, nil
+}
+
+// qualify returns the qualified name of a type reference, replacing any schema alias with its namespace.
+func (m *metadata) qualify(name string) string {
+	if i := strings.LastIndex(name, "."); i > 0 {
+		if ns, ok := m.aliases[name[:i]]; ok {
+			return ns + name[i:]
+		}
+	}
+	return name
+}
+
+// resolve returns the qualified name of a type, which may be specified by its qualified name, an alias-qualified name
+// or, when it is unambiguous, its unqualified name.
+func (m *metadata) resolve(name string) (string, error) {
+	name = m.qualify(name)
+	if _, ok := m.structured[name]; ok {
+		return name, nil
+	}
+	if _, ok := m.enums[name]; ok {
+		return name, nil
+	}
+
+	var matches []string
+	for qualified := range m.structured {
+		if strings.HasSuffix(qualified, "."+name) {
+			matches = append(matches, qualified)
+		}
+	}
+	for qualified := range m.enums {
+		if strings.HasSuffix(qualified, "."+name) {
+			matches = append(matches, qualified)
+		}
+	}
+	sort.Strings(matches)
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("type %q not found in metadata", name)
+	case 1:
+		return matches[0], nil
+	}
+	return "", fmt.Errorf("type %q is ambiguous, specify one of: %s", name, strings.Join(matches, ", "))
+}
+
+// properties returns the properties of a structured type, including those inherited from its base types.
+func (m *metadata) properties(t *structured) []property {
+	var ret []property
+	if t.BaseType != "" {
+		if base, ok := m.structured[m.qualify(t.BaseType)]; ok {
+			ret = m.properties(base)
+		}
+	}
+	return append(ret, t.Properties...)
+}
+
+// polymorphic reports whether instances of a structured type need to be distinguished using their @odata.type, i.e.
+// whether the type has derived types, or derives from a type other than a root type such as microsoft.graph.entity.
+func (m *metadata) polymorphic(name string) bool {
+	if len(m.derived[name]) > 0 {
+		return true
+	}
+	t := m.structured[name]
+	if t.BaseType == "" {
+		return false
+	}
+	base, ok := m.structured[m.qualify(t.BaseType)]
+	return ok && base.BaseType != ""
+}
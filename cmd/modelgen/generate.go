@@ -0,0 +1,262 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+)
+
+// primitives maps CSDL primitive types to Go types. Primitive types which are not listed are generated as
+// json.RawMessage.
+var primitives = map[string]string{
+	"Edm.Binary":         "string",
+	"Edm.Boolean":        "bool",
+	"Edm.Byte":           "int32",
+	"Edm.Date":           "string",
+	"Edm.DateTimeOffset": "time.Time",
+	"Edm.Decimal":        "float64",
+	"Edm.Double":         "float64",
+	"Edm.Duration":       "string",
+	"Edm.Guid":           "string",
+	"Edm.Int16":          "int32",
+	"Edm.Int32":          "int32",
+	"Edm.Int64":          "int64",
+	"Edm.SByte":          "int32",
+	"Edm.Single":         "float64",
+	"Edm.String":         "string",
+	"Edm.TimeOfDay":      "string",
+}
+
+// generator emits Go source for types declared in CSDL metadata.
+type generator struct {
+	metadata *metadata
+
+	// pkg is the name of the generated package.
+	pkg string
+
+	// source describes the metadata document in the generated header.
+	source string
+
+	// renames overrides the Go names of types, keyed by their unqualified or qualified CSDL name.
+	renames map[string]string
+
+	imports map[string]bool
+}
+
+// generate returns formatted Go source for the specified types, along with any complex types and enumerations they
+// depend on.
+func (g *generator) generate(names []string) ([]byte, error) {
+	types, err := g.closure(names)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(types, func(i, j int) bool {
+		return g.typeName(types[i]) < g.typeName(types[j])
+	})
+
+	g.imports = make(map[string]bool)
+	var body bytes.Buffer
+	var discriminators []string
+	for _, name := range types {
+		if e, ok := g.metadata.enums[name]; ok {
+			g.writeEnum(&body, name, e)
+			continue
+		}
+		t := g.metadata.structured[name]
+		if err := g.writeStruct(&body, name, t); err != nil {
+			return nil, err
+		}
+		if g.metadata.polymorphic(name) && !t.Abstract {
+			discriminators = append(discriminators, name)
+		}
+	}
+	if len(discriminators) > 0 {
+		body.WriteString("// OData types of the generated models, used to set or match the @odata.type of polymorphic objects.\n")
+		body.WriteString("const (\n")
+		for _, name := range discriminators {
+			fmt.Fprintf(&body, "ODataType%s = %q\n", g.typeName(name), "#"+name)
+		}
+		body.WriteString(")\n")
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by modelgen from %s. DO NOT EDIT.\n\n", g.source)
+	fmt.Fprintf(&out, "package %s\n\n", g.pkg)
+	if len(g.imports) > 0 {
+		var imports []string
+		for i := range g.imports {
+			imports = append(imports, i)
+		}
+		sort.Strings(imports)
+		out.WriteString("import (\n")
+		for _, i := range imports {
+			fmt.Fprintf(&out, "%q\n", i)
+		}
+		out.WriteString(")\n\n")
+	}
+	out.Write(body.Bytes())
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated source: %v", err)
+	}
+	return src, nil
+}
+
+// closure returns the qualified names of the specified types and of all complex, entity and enumeration types
+// referenced by their properties.
+func (g *generator) closure(names []string) ([]string, error) {
+	seen := make(map[string]bool)
+	var queue []string
+	for _, n := range names {
+		name, err := g.metadata.resolve(n)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[name] {
+			seen[name] = true
+			queue = append(queue, name)
+		}
+	}
+
+	for i := 0; i < len(queue); i++ {
+		t, ok := g.metadata.structured[queue[i]]
+		if !ok {
+			continue
+		}
+		for _, p := range g.metadata.properties(t) {
+			ref, _ := elementType(p.Type)
+			if strings.HasPrefix(ref, "Edm.") {
+				continue
+			}
+			ref = g.metadata.qualify(ref)
+			_, isStructured := g.metadata.structured[ref]
+			_, isEnum := g.metadata.enums[ref]
+			if !isStructured && !isEnum {
+				return nil, fmt.Errorf("property %q of %s.%s has unknown type %q", p.Name, t.namespace, t.Name, p.Type)
+			}
+			if !seen[ref] {
+				seen[ref] = true
+				queue = append(queue, ref)
+			}
+		}
+	}
+	return queue, nil
+}
+
+// writeStruct writes a struct for an entity type or complex type. Inherited properties are included, and polymorphic
+// types have an ODataType field.
+func (g *generator) writeStruct(w *bytes.Buffer, name string, t *structured) error {
+	kind := "complex"
+	if t.entity {
+		kind = "entity"
+	}
+	typeName := g.typeName(name)
+	fmt.Fprintf(w, "// %s models the %s %s type.\n", typeName, name, kind)
+	fmt.Fprintf(w, "type %s struct {\n", typeName)
+	if g.metadata.polymorphic(name) {
+		w.WriteString("ODataType *string `json:\"@odata.type,omitempty\"`\n")
+	}
+	seen := make(map[string]bool)
+	for _, p := range g.metadata.properties(t) {
+		if seen[p.Name] {
+			continue
+		}
+		seen[p.Name] = true
+		fieldType, err := g.fieldType(p.Type)
+		if err != nil {
+			return fmt.Errorf("property %q of %s: %v", p.Name, name, err)
+		}
+		fmt.Fprintf(w, "%s %s `json:\"%s,omitempty\"`\n", fieldName(p.Name), fieldType, p.Name)
+	}
+	w.WriteString("}\n\n")
+	return nil
+}
+
+// writeEnum writes a string type for an enumeration, along with a constant for each of its members.
+func (g *generator) writeEnum(w *bytes.Buffer, name string, e *enum) {
+	typeName := g.typeName(name)
+	if e.IsFlags {
+		fmt.Fprintf(w, "// %s models the %s enumeration type.\n// Multiple values may be combined, separated by commas.\n", typeName, name)
+	} else {
+		fmt.Fprintf(w, "// %s models the %s enumeration type.\n", typeName, name)
+	}
+	fmt.Fprintf(w, "type %s string\n\n", typeName)
+	if len(e.Members) == 0 {
+		return
+	}
+	w.WriteString("const (\n")
+	for _, m := range e.Members {
+		fmt.Fprintf(w, "%s%s %s = %q\n", typeName, exportName(m.Name), typeName, m.Name)
+	}
+	w.WriteString(")\n\n")
+}
+
+// fieldType returns the Go type of a property with the specified CSDL type. All fields are pointers, so that unset
+// values are omitted when marshaling.
+func (g *generator) fieldType(csdlType string) (string, error) {
+	ref, collection := elementType(csdlType)
+	var elem string
+	if strings.HasPrefix(ref, "Edm.") {
+		var ok bool
+		if elem, ok = primitives[ref]; !ok {
+			elem = "json.RawMessage"
+		}
+		switch {
+		case strings.HasPrefix(elem, "time."):
+			g.imports["time"] = true
+		case strings.HasPrefix(elem, "json."):
+			g.imports["encoding/json"] = true
+		}
+	} else {
+		ref = g.metadata.qualify(ref)
+		_, isStructured := g.metadata.structured[ref]
+		_, isEnum := g.metadata.enums[ref]
+		if !isStructured && !isEnum {
+			return "", fmt.Errorf("unknown type %q", csdlType)
+		}
+		elem = g.typeName(ref)
+	}
+	if collection {
+		return "*[]" + elem, nil
+	}
+	return "*" + elem, nil
+}
+
+// typeName returns the Go name of the type with the specified qualified name.
+func (g *generator) typeName(qualified string) string {
+	if name, ok := g.renames[qualified]; ok {
+		return name
+	}
+	unqualified := qualified[strings.LastIndex(qualified, ".")+1:]
+	if name, ok := g.renames[unqualified]; ok {
+		return name
+	}
+	return exportName(unqualified)
+}
+
+// elementType returns the type of the elements of a collection type, or the type itself when it is not a collection.
+func elementType(csdlType string) (string, bool) {
+	if strings.HasPrefix(csdlType, "Collection(") && strings.HasSuffix(csdlType, ")") {
+		return csdlType[len("Collection(") : len(csdlType)-1], true
+	}
+	return csdlType, false
+}
+
+// fieldName returns the Go name of a property, following the convention used by the hand-written models.
+func fieldName(name string) string {
+	if name == "id" {
+		return "ID"
+	}
+	return exportName(name)
+}
+
+// exportName returns name with its first letter in upper case.
+func exportName(name string) string {
+	if name == "" {
+		return name
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func TestGenerate(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "models.go")
+	err := run([]string{
+		"-metadata", filepath.Join("testdata", "metadata.xml"),
+		"-types", "group, ipNamedLocation, countryNamedLocation, iPv4CidrRange, microsoft.graph.signInFrequencySessionControl, callRecords.callRecord",
+		"-rename", "ipNamedLocation=IPNamedLocation,graph.iPv4CidrRange=IPv4CIDRRange",
+		"-output", output,
+	})
+	if err != nil {
+		t.Fatalf("run(): %v", err)
+	}
+	got, err := ioutil.ReadFile(output)
+	if err != nil {
+		t.Fatalf("reading output: %v", err)
+	}
+
+	golden := filepath.Join("testdata", "models.go.golden")
+	if *update {
+		if err := ioutil.WriteFile(golden, got, 0644); err != nil {
+			t.Fatalf("writing golden file: %v", err)
+		}
+	}
+	want, err := ioutil.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("reading golden file: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("generated models do not match %s, run `go test ./cmd/modelgen -update` to update it\ngot:\n%s", golden, got)
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	f, err := os.Open(filepath.Join("testdata", "metadata.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	m, err := parseMetadata(f)
+	if err != nil {
+		t.Fatalf("parseMetadata(): %v", err)
+	}
+
+	for _, test := range []struct {
+		types []string
+		err   string
+	}{
+		{[]string{"user"}, `type "user" not found in metadata`},
+		{[]string{"graph.entity", "microsoft.graph.nope"}, `type "microsoft.graph.nope" not found in metadata`},
+	} {
+		g := &generator{metadata: m, pkg: "msgraph", source: "metadata.xml"}
+		if _, err := g.generate(test.types); err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("generate(%v): expected error %q, got: %v", test.types, test.err, err)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+// Command modelgen generates model structs from a Microsoft Graph CSDL metadata document.
+//
+// The metadata document for each API version can be saved from its $metadata endpoint, e.g.
+//
+//	curl -o beta.xml 'https://graph.microsoft.com/beta/$metadata'
+//	curl -o v1.0.xml 'https://graph.microsoft.com/v1.0/$metadata'
+//
+// Models are generated for the specified entity, complex and enumeration types, along with the complex and
+// enumeration types they depend on. Inherited properties are included in each struct, polymorphic types are given an
+// ODataType field, and a constant is generated for the @odata.type of each polymorphic type so that derived types can
+// be told apart. For example:
+//
+//	go run ./cmd/modelgen -metadata beta.xml -types group,namedLocation,ipNamedLocation,countryNamedLocation \
+//	  -rename ipNamedLocation=IPNamedLocation -output models_generated.go
+//
+// Types may be specified by their unqualified name, e.g. "group", or qualified name, e.g. "microsoft.graph.group".
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "modelgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	flags := flag.NewFlagSet("modelgen", flag.ContinueOnError)
+	metadataPath := flags.String("metadata", "", "path to a saved CSDL metadata document (required)")
+	types := flags.String("types", "", "comma-separated list of types to generate (required)")
+	pkg := flags.String("package", "msgraph", "name of the generated package")
+	output := flags.String("output", "", "path of the generated file (default stdout)")
+	renames := flags.String("rename", "", "comma-separated list of name=GoName pairs overriding generated type names")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *metadataPath == "" || *types == "" {
+		flags.Usage()
+		return fmt.Errorf("-metadata and -types must be specified")
+	}
+
+	f, err := os.Open(*metadataPath)
+	if err != nil {
+		return fmt.Errorf("opening metadata: %v", err)
+	}
+	defer f.Close()
+	m, err := parseMetadata(f)
+	if err != nil {
+		return err
+	}
+
+	g := &generator{
+		metadata: m,
+		pkg:      *pkg,
+		source:   filepath.Base(*metadataPath),
+		renames:  make(map[string]string),
+	}
+	for _, pair := range splitList(*renames) {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return fmt.Errorf("invalid rename %q, expected name=GoName", pair)
+		}
+		g.renames[m.qualify(parts[0])] = parts[1]
+	}
+
+	src, err := g.generate(splitList(*types))
+	if err != nil {
+		return err
+	}
+	if *output == "" {
+		_, err = os.Stdout.Write(src)
+		return err
+	}
+	if err := ioutil.WriteFile(*output, src, 0644); err != nil {
+		return fmt.Errorf("writing output: %v", err)
+	}
+	return nil
+}
+
+// splitList splits a comma-separated list, ignoring empty items and surrounding whitespace.
+func splitList(s string) []string {
+	var ret []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			ret = append(ret, item)
+		}
+	}
+	return ret
+}
@@ -1,6 +1,12 @@
 package errors
 
-import "fmt"
+import (
+	goerrors "errors"
+	"fmt"
+	"net/http"
+
+	"github.com/manicminer/hamilton/odata"
+)
 
 // AlreadyExistsError is an error returned when an entity or object being created already exists.
 type AlreadyExistsError struct {
@@ -12,3 +18,103 @@ type AlreadyExistsError struct {
 func (e AlreadyExistsError) Error() string {
 	return fmt.Sprintf("%s with ID %q already exists", e.Obj, e.Id)
 }
+
+// ApiError is an error returned when an API request receives an unexpected response.
+// Use errors.As with a *ApiError target to inspect it, or one of the helper functions in this package.
+type ApiError struct {
+	// Method is the HTTP method of the failed request.
+	Method string
+
+	// Url is the URL of the failed request.
+	Url string
+
+	// StatusCode is the HTTP status code of the final response.
+	StatusCode int
+
+	// Retries is the number of times the request was retried before failing.
+	Retries int
+
+	// OData is the parsed OData error from the response, if present.
+	OData *odata.Error
+
+	// ResponseBody is the raw response body, populated when no OData error could be parsed.
+	ResponseBody []byte
+}
+
+// Error returns an error string for ApiError.
+func (e ApiError) Error() string {
+	var errText string
+	switch {
+	case e.OData != nil && e.OData.String() != "":
+		errText = fmt.Sprintf("OData error: %s", e.OData)
+	default:
+		errText = fmt.Sprintf("response: %s", e.ResponseBody)
+	}
+	return fmt.Sprintf("%s %s: unexpected status %d with %s", e.Method, e.Url, e.StatusCode, errText)
+}
+
+// Code returns the OData error code, or an empty string when there is no OData error.
+func (e ApiError) Code() string {
+	if e.OData != nil && e.OData.Code != nil {
+		return *e.OData.Code
+	}
+	return ""
+}
+
+// RequestId returns the request-id from the OData error, which is useful when reporting issues to Microsoft.
+func (e ApiError) RequestId() string {
+	for inner := e.OData; inner != nil; inner = inner.InnerError {
+		if inner.RequestId != nil {
+			return *inner.RequestId
+		}
+	}
+	return ""
+}
+
+// AsApiError returns the *ApiError found in the error chain of err, or nil when there is none.
+func AsApiError(err error) *ApiError {
+	var e *ApiError
+	if goerrors.As(err, &e) {
+		return e
+	}
+	return nil
+}
+
+// IsNotFound returns true when err was caused by an API response indicating the requested object does not exist.
+func IsNotFound(err error) bool {
+	if e := AsApiError(err); e != nil {
+		return e.StatusCode == http.StatusNotFound || e.Code() == "Request_ResourceNotFound"
+	}
+	return false
+}
+
+// IsThrottled returns true when err was caused by an API response indicating the request was throttled.
+func IsThrottled(err error) bool {
+	if e := AsApiError(err); e != nil {
+		return e.StatusCode == http.StatusTooManyRequests
+	}
+	return false
+}
+
+// IsConflict returns true when err was caused by an API response indicating a conflicting object already exists.
+func IsConflict(err error) bool {
+	if e := AsApiError(err); e != nil {
+		if e.StatusCode == http.StatusConflict {
+			return true
+		}
+		if e.StatusCode == http.StatusBadRequest && e.OData != nil {
+			return e.OData.Match(odata.ErrorConflictingObjectPresentInDirectory) ||
+				e.OData.Match(odata.ErrorAddedObjectReferencesAlreadyExist)
+		}
+	}
+	return false
+}
+
+// IsAuthorizationDenied returns true when err was caused by an API response indicating the caller does not have
+// sufficient privileges to perform the request.
+func IsAuthorizationDenied(err error) bool {
+	if e := AsApiError(err); e != nil {
+		return e.StatusCode == http.StatusForbidden || e.Code() == "Authorization_RequestDenied"
+	}
+	return false
+}
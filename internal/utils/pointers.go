@@ -9,3 +9,8 @@ func BoolPtr(b bool) *bool {
 func StringPtr(s string) *string {
 	return &s
 }
+
+// Int32Ptr returns a pointer to the provided int32 variable.
+func Int32Ptr(i int32) *int32 {
+	return &i
+}
@@ -0,0 +1,236 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/manicminer/hamilton/transport"
+)
+
+// Redacted replaces sensitive values in logged headers and bodies.
+const Redacted = "REDACTED"
+
+// sensitiveHeaders are request and response headers whose values are always redacted.
+var sensitiveHeaders = []string{
+	"Authorization",
+	"Cookie",
+	"Set-Cookie",
+}
+
+// sensitiveFields are JSON properties and form fields whose values are always redacted, compared case-insensitively.
+// This includes the secretText of password credentials, the password of a user's passwordProfile, passwords and
+// Temporary Access Passes set using authentication methods, and the client secrets and assertions sent when requesting
+// tokens.
+var sensitiveFields = []string{
+	"access_token",
+	"client_assertion",
+	"client_secret",
+	"clientSecret",
+	"id_token",
+	"newPassword",
+	"password",
+	"refresh_token",
+	"secretText",
+	"temporaryAccessPass",
+}
+
+// Entry describes a single attempt at sending a request to an API.
+type Entry struct {
+	// Method is the HTTP method of the request.
+	Method string
+
+	// Url is the URL of the request.
+	Url string
+
+	// Attempt is the attempt number for the request, starting at 1.
+	Attempt int
+
+	// StatusCode is the HTTP status of the response, which is zero when no response was received.
+	StatusCode int
+
+	// Latency is the time taken to receive the response.
+	Latency time.Duration
+
+	// RequestId is the request-id returned by the API, which is useful when reporting issues to Microsoft.
+	RequestId string
+
+	// ClientRequestId is the client-request-id returned by the API.
+	ClientRequestId string
+
+	// RequestHeaders are the headers sent with the request, with sensitive values redacted.
+	RequestHeaders http.Header
+
+	// ResponseHeaders are the headers returned with the response, with sensitive values redacted.
+	ResponseHeaders http.Header
+
+	// RequestBody is the body of the request with sensitive values redacted, populated only when bodies are enabled.
+	RequestBody []byte
+
+	// ResponseBody is the body of the response with sensitive values redacted, populated only when bodies are enabled.
+	ResponseBody []byte
+
+	// Err is any error returned when sending the request, usually a network error.
+	Err error
+}
+
+// Logger receives an Entry for each attempt at sending a request. Implement this interface to forward entries to a
+// structured logger of your choice.
+type Logger interface {
+	Log(ctx context.Context, entry Entry)
+}
+
+// LoggerFunc is an adapter to allow the use of ordinary functions as a Logger.
+type LoggerFunc func(ctx context.Context, entry Entry)
+
+// Log calls f(ctx, entry).
+func (f LoggerFunc) Log(ctx context.Context, entry Entry) {
+	f(ctx, entry)
+}
+
+// Middleware returns a transport.Middleware which logs each request sent using logger. This is useful for logging
+// requests made by the auth package, such as token requests, which are otherwise not logged. Since the middleware is
+// not aware of retries, the Attempt of each Entry is always 1.
+func Middleware(logger Logger, includeBodies bool) transport.Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return transport.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.RoundTrip(req)
+			logger.Log(req.Context(), NewEntry(req, resp, 1, time.Since(start), err, includeBodies))
+			return resp, err
+		})
+	}
+}
+
+// NewEntry returns an Entry describing an attempt at sending req, which received resp or failed with err.
+// When includeBodies is true, the request and response bodies are read and included, and the response body is
+// replaced so that it can be read again by the caller.
+func NewEntry(req *http.Request, resp *http.Response, attempt int, latency time.Duration, err error, includeBodies bool) Entry {
+	entry := Entry{
+		Method:         req.Method,
+		Url:            req.URL.String(),
+		Attempt:        attempt,
+		Latency:        latency,
+		RequestHeaders: RedactHeaders(req.Header),
+		Err:            err,
+	}
+
+	if includeBodies && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			if b, err := ioutil.ReadAll(body); err == nil {
+				entry.RequestBody = RedactBody(b)
+			}
+			body.Close()
+		}
+	}
+
+	if resp != nil {
+		entry.StatusCode = resp.StatusCode
+		entry.ResponseHeaders = RedactHeaders(resp.Header)
+		entry.RequestId = resp.Header.Get("request-id")
+		entry.ClientRequestId = resp.Header.Get("client-request-id")
+
+		if includeBodies && resp.Body != nil {
+			b, err := ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+			resp.Body = ioutil.NopCloser(bytes.NewBuffer(b))
+			if err == nil {
+				entry.ResponseBody = RedactBody(b)
+			}
+		}
+	}
+
+	return entry
+}
+
+// RedactHeaders returns a copy of headers with the values of sensitive headers redacted.
+func RedactHeaders(headers http.Header) http.Header {
+	if headers == nil {
+		return nil
+	}
+	ret := headers.Clone()
+	for _, h := range sensitiveHeaders {
+		if _, ok := ret[http.CanonicalHeaderKey(h)]; ok {
+			ret.Set(h, Redacted)
+		}
+	}
+	return ret
+}
+
+// RedactBody returns a JSON or form encoded body with the values of any sensitive fields redacted.
+// Bodies in other formats are returned unchanged.
+func RedactBody(body []byte) []byte {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return body
+	}
+
+	if trimmed[0] == '{' || trimmed[0] == '[' {
+		var v interface{}
+		if err := json.Unmarshal(trimmed, &v); err != nil {
+			return body
+		}
+		if !redactJson(v) {
+			return body
+		}
+		if b, err := json.Marshal(v); err == nil {
+			return b
+		}
+		return body
+	}
+
+	if values, err := url.ParseQuery(string(trimmed)); err == nil {
+		redacted := false
+		for k := range values {
+			if isSensitiveField(k) {
+				values.Set(k, Redacted)
+				redacted = true
+			}
+		}
+		if redacted {
+			return []byte(values.Encode())
+		}
+	}
+
+	return body
+}
+
+// redactJson redacts sensitive fields in a decoded JSON value in place, and reports whether any were found.
+func redactJson(v interface{}) (redacted bool) {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, val := range t {
+			if isSensitiveField(k) {
+				if _, ok := val.(string); ok {
+					t[k] = Redacted
+					redacted = true
+					continue
+				}
+			}
+			if redactJson(val) {
+				redacted = true
+			}
+		}
+	case []interface{}:
+		for _, val := range t {
+			if redactJson(val) {
+				redacted = true
+			}
+		}
+	}
+	return
+}
+
+func isSensitiveField(name string) bool {
+	for _, f := range sensitiveFields {
+		if strings.EqualFold(name, f) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,131 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/manicminer/hamilton/logging"
+	"github.com/manicminer/hamilton/transport"
+)
+
+func TestRedactBody(t *testing.T) {
+	type testCase struct {
+		body     string
+		expected string
+	}
+	testCases := []testCase{
+		{
+			body:     `{"passwordCredential": {"displayName": "test", "secretText": "hunter2"}}`,
+			expected: `{"passwordCredential":{"displayName":"test","secretText":"REDACTED"}}`,
+		},
+		{
+			body:     `{"accountEnabled": true, "passwordProfile": {"forceChangePasswordNextSignIn": false, "password": "hunter2"}}`,
+			expected: `{"accountEnabled":true,"passwordProfile":{"forceChangePasswordNextSignIn":false,"password":"REDACTED"}}`,
+		},
+		{
+			body:     `{"value": [{"id": "1", "passwordCredentials": [{"keyId": "2", "secretText": null}]}]}`,
+			expected: `{"value": [{"id": "1", "passwordCredentials": [{"keyId": "2", "secretText": null}]}]}`,
+		},
+		{
+			body:     `{"newPassword": "hunter2"}`,
+			expected: `{"newPassword":"REDACTED"}`,
+		},
+		{
+			body:     `{"id": "1", "isUsable": true, "temporaryAccessPass": "TAPRocks!"}`,
+			expected: `{"id":"1","isUsable":true,"temporaryAccessPass":"REDACTED"}`,
+		},
+		{
+			body:     "client_id=00000000-0000-0000-0000-000000000000&client_secret=hunter2&grant_type=client_credentials",
+			expected: "client_id=00000000-0000-0000-0000-000000000000&client_secret=REDACTED&grant_type=client_credentials",
+		},
+		{
+			body:     `{"access_token": "eyJ0eXAi", "token_type": "Bearer"}`,
+			expected: `{"access_token":"REDACTED","token_type":"Bearer"}`,
+		},
+		{
+			body:     "not json",
+			expected: "not json",
+		},
+	}
+	for n, c := range testCases {
+		if b := string(logging.RedactBody([]byte(c.body))); b != c.expected {
+			t.Errorf("test case %d: expected %s, got %s", n, c.expected, b)
+		}
+	}
+}
+
+func TestNewEntry(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodPost, "https://graph.microsoft.com/v1.0/applications/1/addPassword", bytes.NewBufferString(`{"secretText": "hunter2"}`))
+	req.Header.Set("Authorization", "Bearer eyJ0eXAi")
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header: http.Header{
+			"Request-Id":        []string{"11111111-1111-1111-1111-111111111111"},
+			"Client-Request-Id": []string{"22222222-2222-2222-2222-222222222222"},
+		},
+		Body: ioutil.NopCloser(strings.NewReader(`{"keyId": "1", "secretText": "s3cr3t"}`)),
+	}
+
+	entry := logging.NewEntry(req, resp, 2, time.Second, nil, false)
+	if entry.Method != http.MethodPost || entry.Attempt != 2 || entry.StatusCode != http.StatusOK || entry.RequestId != "11111111-1111-1111-1111-111111111111" {
+		t.Errorf("unexpected entry: %+v", entry)
+	}
+	if v := entry.RequestHeaders.Get("Authorization"); v != logging.Redacted {
+		t.Errorf("expected Authorization header to be redacted, got %q", v)
+	}
+	if v := req.Header.Get("Authorization"); v != "Bearer eyJ0eXAi" {
+		t.Errorf("expected request Authorization header to be unchanged, got %q", v)
+	}
+	if entry.RequestBody != nil || entry.ResponseBody != nil {
+		t.Errorf("expected bodies to be omitted")
+	}
+
+	entry = logging.NewEntry(req, resp, 2, time.Second, nil, true)
+	if b := string(entry.RequestBody); b != `{"secretText":"REDACTED"}` {
+		t.Errorf("expected redacted request body, got %s", b)
+	}
+	if b := string(entry.ResponseBody); b != `{"keyId":"1","secretText":"REDACTED"}` {
+		t.Errorf("expected redacted response body, got %s", b)
+	}
+	if b, _ := ioutil.ReadAll(resp.Body); !strings.Contains(string(b), "s3cr3t") {
+		t.Errorf("expected response body to remain readable and unredacted, got %s", b)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	var entries []logging.Entry
+	logger := logging.LoggerFunc(func(ctx context.Context, entry logging.Entry) {
+		entries = append(entries, entry)
+	})
+	tokenApi := transport.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       ioutil.NopCloser(strings.NewReader(`{"access_token": "eyJ0eXAi", "token_type": "Bearer"}`)),
+		}, nil
+	})
+	client := transport.NewClient(&http.Client{Transport: tokenApi}, logging.Middleware(logger, true))
+
+	resp, err := client.PostForm("https://login.microsoftonline.com/tenant/oauth2/v2.0/token", url.Values{"client_secret": []string{"hunter2"}})
+	if err != nil {
+		t.Fatalf("PostForm(): %v", err)
+	}
+	if b, _ := ioutil.ReadAll(resp.Body); !strings.Contains(string(b), "eyJ0eXAi") {
+		t.Errorf("expected token in response body, got %s", b)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 log entry, got %d", len(entries))
+	}
+	if b := string(entries[0].RequestBody); b != "client_secret=REDACTED" {
+		t.Errorf("expected redacted request body, got %s", b)
+	}
+	if b := string(entries[0].ResponseBody); strings.Contains(b, "eyJ0eXAi") {
+		t.Errorf("expected redacted response body, got %s", b)
+	}
+}
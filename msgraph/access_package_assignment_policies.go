@@ -0,0 +1,81 @@
+package msgraph
+
+import (
+	"context"
+	"errors"
+
+	"github.com/manicminer/hamilton/odata"
+)
+
+// AccessPackageAssignmentPoliciesClient performs operations on Access Package Assignment Policies.
+type AccessPackageAssignmentPoliciesClient struct {
+	BaseClient Client
+}
+
+// NewAccessPackageAssignmentPoliciesClient returns a new AccessPackageAssignmentPoliciesClient.
+func NewAccessPackageAssignmentPoliciesClient(tenantId string) *AccessPackageAssignmentPoliciesClient {
+	return &AccessPackageAssignmentPoliciesClient{
+		BaseClient: NewClient(Version10, tenantId),
+	}
+}
+
+// resource returns a Resource for performing common operations on Access Package Assignment Policies.
+func (c *AccessPackageAssignmentPoliciesClient) resource() Resource {
+	return Resource{
+		Client: c.BaseClient,
+		Name:   "AccessPackageAssignmentPoliciesClient",
+		Entity: "/identityGovernance/entitlementManagement/assignmentPolicies",
+	}
+}
+
+// List returns a list of Access Package Assignment Policies, optionally queried using OData, e.g. filtering by
+// "accessPackage/id".
+func (c *AccessPackageAssignmentPoliciesClient) List(ctx context.Context, query odata.Query) (*[]AccessPackageAssignmentPolicy, int, error) {
+	var policies []AccessPackageAssignmentPolicy
+	status, err := c.resource().List(ctx, query, &policies)
+	if err != nil {
+		return nil, status, err
+	}
+	return &policies, status, nil
+}
+
+// Create creates a new Access Package Assignment Policy for the access package specified by the ID of its
+// AccessPackage. The policy specifies who can request the access package, whether approval is required, and when
+// assignments expire.
+func (c *AccessPackageAssignmentPoliciesClient) Create(ctx context.Context, policy AccessPackageAssignmentPolicy) (*AccessPackageAssignmentPolicy, int, error) {
+	var status int
+	if policy.AccessPackage == nil || policy.AccessPackage.ID == nil {
+		return nil, status, errors.New("AccessPackageAssignmentPoliciesClient.Create(): cannot create assignment policy with nil AccessPackage ID")
+	}
+	var newPolicy AccessPackageAssignmentPolicy
+	status, err := c.resource().Create(ctx, policy, &newPolicy)
+	if err != nil {
+		return nil, status, err
+	}
+	return &newPolicy, status, nil
+}
+
+// Get retrieves an Access Package Assignment Policy.
+func (c *AccessPackageAssignmentPoliciesClient) Get(ctx context.Context, id string) (*AccessPackageAssignmentPolicy, int, error) {
+	var policy AccessPackageAssignmentPolicy
+	status, err := c.resource().Get(ctx, id, odata.Query{}, &policy)
+	if err != nil {
+		return nil, status, err
+	}
+	return &policy, status, nil
+}
+
+// Update amends an existing Access Package Assignment Policy. The API replaces the policy, so all properties which
+// should be retained must be specified, e.g. by retrieving the policy with Get() and modifying it.
+func (c *AccessPackageAssignmentPoliciesClient) Update(ctx context.Context, policy AccessPackageAssignmentPolicy) (int, error) {
+	var status int
+	if policy.ID == nil {
+		return status, errors.New("AccessPackageAssignmentPoliciesClient.Update(): cannot update assignment policy with nil ID")
+	}
+	return c.resource().Replace(ctx, *policy.ID, policy)
+}
+
+// Delete removes an Access Package Assignment Policy.
+func (c *AccessPackageAssignmentPoliciesClient) Delete(ctx context.Context, id string) (int, error) {
+	return c.resource().Delete(ctx, id)
+}
@@ -0,0 +1,133 @@
+package msgraph_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/manicminer/hamilton/internal/utils"
+	"github.com/manicminer/hamilton/msgraph"
+	"github.com/manicminer/hamilton/msgraph/msgraphtest"
+	"github.com/manicminer/hamilton/odata"
+)
+
+func TestAccessPackageAssignmentPoliciesClient(t *testing.T) {
+	server := msgraphtest.NewServer()
+	defer server.Close()
+	ctx := context.Background()
+
+	client := msgraph.NewAccessPackageAssignmentPoliciesClient("tenant")
+	client.BaseClient.Endpoint = server.Endpoint()
+
+	catalogId := server.Add("identityGovernance/entitlementManagement/catalogs", msgraph.AccessPackageCatalog{
+		DisplayName: utils.StringPtr("test-catalog"),
+	})
+	accessPackageId := server.Add("identityGovernance/entitlementManagement/accessPackages", msgraph.AccessPackage{
+		Catalog:     &msgraph.AccessPackageCatalog{ID: utils.StringPtr(catalogId)},
+		DisplayName: utils.StringPtr("test-access-package"),
+	})
+	approverId := server.Add("users", msgraph.User{
+		DisplayName:       utils.StringPtr("test-approver"),
+		UserPrincipalName: utils.StringPtr("test-approver@example.com"),
+	})
+	policiesPath := "/identityGovernance/entitlementManagement/assignmentPolicies"
+
+	if _, _, err := client.Create(ctx, msgraph.AccessPackageAssignmentPolicy{DisplayName: utils.StringPtr("test-policy")}); err == nil {
+		t.Fatalf("AccessPackageAssignmentPoliciesClient.Create(): expected an error for a policy with nil AccessPackage")
+	}
+
+	targetScope := msgraph.AllowedTargetScopeAllMemberUsers
+	expirationType := msgraph.ExpirationPatternTypeAfterDuration
+	policy, status, err := client.Create(ctx, msgraph.AccessPackageAssignmentPolicy{
+		AccessPackage:      &msgraph.AccessPackage{ID: utils.StringPtr(accessPackageId)},
+		AllowedTargetScope: &targetScope,
+		DisplayName:        utils.StringPtr("test-policy"),
+		Description:        utils.StringPtr("created by the test suite"),
+		Expiration: &msgraph.ExpirationPattern{
+			Duration: utils.StringPtr("P30D"),
+			Type:     &expirationType,
+		},
+		RequestApprovalSettings: &msgraph.AccessPackageAssignmentApprovalSettings{
+			IsApprovalRequiredForAdd: utils.BoolPtr(true),
+			Stages: &[]msgraph.AccessPackageApprovalStage{
+				{
+					DurationBeforeAutomaticDenial: utils.StringPtr("P7D"),
+					PrimaryApprovers: &[]msgraph.SubjectSet{
+						{
+							ODataType: utils.StringPtr(msgraph.SubjectSetSingleUser),
+							UserId:    utils.StringPtr(approverId),
+						},
+					},
+				},
+			},
+		},
+		RequestorSettings: &msgraph.AccessPackageAssignmentRequestorSettings{
+			EnableTargetsToSelfAddAccess: utils.BoolPtr(true),
+		},
+	})
+	if err != nil {
+		t.Fatalf("AccessPackageAssignmentPoliciesClient.Create(): %v", err)
+	}
+	if status != http.StatusCreated || policy.ID == nil {
+		t.Fatalf("AccessPackageAssignmentPoliciesClient.Create(): expected a new policy with status 201, got status %d", status)
+	}
+	expectRequests(t, server, expectedRequest{http.MethodPost, policiesPath, fmt.Sprintf(`{
+		"accessPackage": {"id": %[1]q},
+		"allowedTargetScope": "allMemberUsers",
+		"displayName": "test-policy",
+		"expiration": {"duration": "P30D", "type": "afterDuration"},
+		"requestApprovalSettings": {
+			"isApprovalRequiredForAdd": true,
+			"stages": [{
+				"durationBeforeAutomaticDenial": "P7D",
+				"primaryApprovers": [{"@odata.type": "#microsoft.graph.singleUser", "userId": %[2]q}]
+			}]
+		},
+		"requestorSettings": {"enableTargetsToSelfAddAccess": true}
+	}`, accessPackageId, approverId)})
+	policyPath := policiesPath + "/" + *policy.ID
+
+	policy.Expiration.Duration = utils.StringPtr("P90D")
+	if _, err := client.Update(ctx, *policy); err != nil {
+		t.Fatalf("AccessPackageAssignmentPoliciesClient.Update(): %v", err)
+	}
+	if _, err := client.Update(ctx, msgraph.AccessPackageAssignmentPolicy{}); err == nil {
+		t.Fatalf("AccessPackageAssignmentPoliciesClient.Update(): expected an error for a policy with nil ID")
+	}
+	expectRequests(t, server, expectedRequest{http.MethodPut, policyPath, `{"displayName": "test-policy", "expiration": {"duration": "P90D", "type": "afterDuration"}}`})
+
+	got, _, err := client.Get(ctx, *policy.ID)
+	if err != nil {
+		t.Fatalf("AccessPackageAssignmentPoliciesClient.Get(): %v", err)
+	}
+	if got.Expiration == nil || *got.Expiration.Duration != "P90D" {
+		t.Errorf("AccessPackageAssignmentPoliciesClient.Get(): expected the updated expiration, got %v", got.Expiration)
+	}
+	if got.RequestApprovalSettings == nil || got.RequestApprovalSettings.Stages == nil || len(*got.RequestApprovalSettings.Stages) != 1 {
+		t.Fatalf("AccessPackageAssignmentPoliciesClient.Get(): unexpected approval settings %v", got.RequestApprovalSettings)
+	}
+	if approvers := (*got.RequestApprovalSettings.Stages)[0].PrimaryApprovers; approvers == nil || *(*approvers)[0].UserId != approverId {
+		t.Errorf("AccessPackageAssignmentPoliciesClient.Get(): expected the test approver, got %v", approvers)
+	}
+
+	policies, _, err := client.List(ctx, odata.Query{Filter: fmt.Sprintf("accessPackage/id eq '%s'", accessPackageId)})
+	if err != nil {
+		t.Fatalf("AccessPackageAssignmentPoliciesClient.List(): %v", err)
+	}
+	if policies == nil || len(*policies) != 1 {
+		t.Fatalf("AccessPackageAssignmentPoliciesClient.List(): expected 1 policy, got %v", policies)
+	}
+	expectRequests(t, server,
+		expectedRequest{http.MethodGet, policyPath, ""},
+		expectedRequest{http.MethodGet, policiesPath, ""},
+	)
+
+	if _, err := client.Delete(ctx, *policy.ID); err != nil {
+		t.Fatalf("AccessPackageAssignmentPoliciesClient.Delete(): %v", err)
+	}
+	expectRequests(t, server, expectedRequest{http.MethodDelete, policyPath, ""})
+	if _, status, err := client.Get(ctx, *policy.ID); err == nil || status != http.StatusNotFound {
+		t.Errorf("AccessPackageAssignmentPoliciesClient.Get(): expected status 404 for a deleted policy, got %d", status)
+	}
+}
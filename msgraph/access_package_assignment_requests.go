@@ -0,0 +1,96 @@
+package msgraph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/manicminer/hamilton/odata"
+)
+
+// AccessPackageAssignmentRequestsClient performs operations on Access Package Assignment Requests.
+type AccessPackageAssignmentRequestsClient struct {
+	BaseClient Client
+}
+
+// NewAccessPackageAssignmentRequestsClient returns a new AccessPackageAssignmentRequestsClient.
+func NewAccessPackageAssignmentRequestsClient(tenantId string) *AccessPackageAssignmentRequestsClient {
+	return &AccessPackageAssignmentRequestsClient{
+		BaseClient: NewClient(Version10, tenantId),
+	}
+}
+
+// resource returns a Resource for performing common operations on Access Package Assignment Requests.
+func (c *AccessPackageAssignmentRequestsClient) resource() Resource {
+	return Resource{
+		Client: c.BaseClient,
+		Name:   "AccessPackageAssignmentRequestsClient",
+		Entity: "/identityGovernance/entitlementManagement/assignmentRequests",
+	}
+}
+
+// List returns a list of Access Package Assignment Requests, optionally queried using OData.
+func (c *AccessPackageAssignmentRequestsClient) List(ctx context.Context, query odata.Query) (*[]AccessPackageAssignmentRequest, int, error) {
+	var requests []AccessPackageAssignmentRequest
+	status, err := c.resource().List(ctx, query, &requests)
+	if err != nil {
+		return nil, status, err
+	}
+	return &requests, status, nil
+}
+
+// Create creates a new Access Package Assignment Request. To assign an access package, use the
+// AccessPackageRequestTypeAdminAdd RequestType and specify the AccessPackageId, AssignmentPolicyId and TargetId of the
+// Assignment. To remove an assignment, use the AccessPackageRequestTypeAdminRemove RequestType and specify the ID of
+// the Assignment.
+func (c *AccessPackageAssignmentRequestsClient) Create(ctx context.Context, request AccessPackageAssignmentRequest) (*AccessPackageAssignmentRequest, int, error) {
+	var status int
+	if request.RequestType == nil {
+		return nil, status, errors.New("AccessPackageAssignmentRequestsClient.Create(): cannot create assignment request with nil RequestType")
+	}
+	if request.Assignment == nil {
+		return nil, status, errors.New("AccessPackageAssignmentRequestsClient.Create(): cannot create assignment request with nil Assignment")
+	}
+	var newRequest AccessPackageAssignmentRequest
+	status, err := c.resource().Create(ctx, request, &newRequest)
+	if err != nil {
+		return nil, status, err
+	}
+	return &newRequest, status, nil
+}
+
+// Get retrieves an Access Package Assignment Request.
+func (c *AccessPackageAssignmentRequestsClient) Get(ctx context.Context, id string) (*AccessPackageAssignmentRequest, int, error) {
+	var request AccessPackageAssignmentRequest
+	status, err := c.resource().Get(ctx, id, odata.Query{}, &request)
+	if err != nil {
+		return nil, status, err
+	}
+	return &request, status, nil
+}
+
+// Cancel cancels an Access Package Assignment Request which has not yet been delivered, e.g. one which is pending
+// approval or scheduled to start in the future.
+func (c *AccessPackageAssignmentRequestsClient) Cancel(ctx context.Context, id string) (int, error) {
+	var status int
+	if id == "" {
+		return status, errors.New("AccessPackageAssignmentRequestsClient.Cancel(): cannot cancel assignment request with empty ID")
+	}
+	_, status, _, err := c.BaseClient.Post(ctx, PostHttpRequestInput{
+		ValidStatusCodes: []int{http.StatusOK, http.StatusNoContent},
+		Uri: Uri{
+			Entity:      fmt.Sprintf("/identityGovernance/entitlementManagement/assignmentRequests/%s/cancel", id),
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return status, fmt.Errorf("AccessPackageAssignmentRequestsClient.BaseClient.Post(): %w", err)
+	}
+	return status, nil
+}
+
+// Delete removes an Access Package Assignment Request. This does not remove any assignment resulting from the request.
+func (c *AccessPackageAssignmentRequestsClient) Delete(ctx context.Context, id string) (int, error) {
+	return c.resource().Delete(ctx, id)
+}
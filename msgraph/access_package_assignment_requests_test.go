@@ -0,0 +1,208 @@
+package msgraph_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/manicminer/hamilton/internal/utils"
+	"github.com/manicminer/hamilton/msgraph"
+	"github.com/manicminer/hamilton/msgraph/msgraphtest"
+	"github.com/manicminer/hamilton/odata"
+)
+
+func TestAccessPackageAssignmentRequestsClient(t *testing.T) {
+	server := msgraphtest.NewServer()
+	defer server.Close()
+	ctx := context.Background()
+
+	client := msgraph.NewAccessPackageAssignmentRequestsClient("tenant")
+	client.BaseClient.Endpoint = server.Endpoint()
+	catalogsClient := msgraph.NewAccessPackageCatalogsClient("tenant")
+	catalogsClient.BaseClient.Endpoint = server.Endpoint()
+	accessPackagesClient := msgraph.NewAccessPackagesClient("tenant")
+	accessPackagesClient.BaseClient.Endpoint = server.Endpoint()
+	roleScopesClient := msgraph.NewAccessPackageResourceRoleScopesClient("tenant")
+	roleScopesClient.BaseClient.Endpoint = server.Endpoint()
+	policiesClient := msgraph.NewAccessPackageAssignmentPoliciesClient("tenant")
+	policiesClient.BaseClient.Endpoint = server.Endpoint()
+	groupsClient := msgraph.NewGroupsClient("tenant")
+	groupsClient.BaseClient.Endpoint = server.Endpoint()
+
+	groupId := server.Add("groups", msgraph.Group{
+		DisplayName:  utils.StringPtr("test-group"),
+		MailNickname: utils.StringPtr("test-group"),
+	})
+	userId := server.Add("users", msgraph.User{
+		DisplayName:       utils.StringPtr("test-user"),
+		UserPrincipalName: utils.StringPtr("test-user@example.com"),
+	})
+
+	// grant membership of the test group using an access package
+	catalog, _, err := catalogsClient.Create(ctx, msgraph.AccessPackageCatalog{DisplayName: utils.StringPtr("test-catalog")})
+	if err != nil {
+		t.Fatalf("AccessPackageCatalogsClient.Create(): %v", err)
+	}
+	originSystem := msgraph.AccessPackageResourceOriginSystemAadGroup
+	resourceRequest, _, err := catalogsClient.AddResource(ctx, *catalog.ID, msgraph.AccessPackageResource{
+		OriginId:     utils.StringPtr(groupId),
+		OriginSystem: &originSystem,
+	})
+	if err != nil {
+		t.Fatalf("AccessPackageCatalogsClient.AddResource(): %v", err)
+	}
+	accessPackage, _, err := accessPackagesClient.Create(ctx, msgraph.AccessPackage{
+		Catalog:     &msgraph.AccessPackageCatalog{ID: catalog.ID},
+		DisplayName: utils.StringPtr("test-access-package"),
+	})
+	if err != nil {
+		t.Fatalf("AccessPackagesClient.Create(): %v", err)
+	}
+	if _, _, err := roleScopesClient.Create(ctx, *accessPackage.ID, msgraph.AccessPackageResourceRoleScope{
+		Role: &msgraph.AccessPackageResourceRole{
+			OriginId:     utils.StringPtr("Member_" + groupId),
+			OriginSystem: &originSystem,
+			Resource:     &msgraph.AccessPackageResource{ID: resourceRequest.Resource.ID},
+		},
+		Scope: &msgraph.AccessPackageResourceScope{
+			OriginId:     utils.StringPtr(groupId),
+			OriginSystem: &originSystem,
+		},
+	}); err != nil {
+		t.Fatalf("AccessPackageResourceRoleScopesClient.Create(): %v", err)
+	}
+	expirationType := msgraph.ExpirationPatternTypeAfterDuration
+	policy, _, err := policiesClient.Create(ctx, msgraph.AccessPackageAssignmentPolicy{
+		AccessPackage: &msgraph.AccessPackage{ID: accessPackage.ID},
+		DisplayName:   utils.StringPtr("test-policy"),
+		Expiration: &msgraph.ExpirationPattern{
+			Duration: utils.StringPtr("P30D"),
+			Type:     &expirationType,
+		},
+	})
+	if err != nil {
+		t.Fatalf("AccessPackageAssignmentPoliciesClient.Create(): %v", err)
+	}
+	server.ClearRequests()
+	requestsPath := "/identityGovernance/entitlementManagement/assignmentRequests"
+
+	if _, _, err := client.Create(ctx, msgraph.AccessPackageAssignmentRequest{}); err == nil {
+		t.Fatalf("AccessPackageAssignmentRequestsClient.Create(): expected an error for a request with nil RequestType")
+	}
+
+	// a request scheduled to start in the future can be canceled
+	addType := msgraph.AccessPackageRequestTypeAdminAdd
+	assignment := msgraph.AccessPackageAssignment{
+		AccessPackageId:    accessPackage.ID,
+		AssignmentPolicyId: policy.ID,
+		TargetId:           utils.StringPtr(userId),
+	}
+	startDateTime := time.Now().Add(24 * time.Hour)
+	scheduled, _, err := client.Create(ctx, msgraph.AccessPackageAssignmentRequest{
+		Assignment:  &assignment,
+		RequestType: &addType,
+		Schedule:    &msgraph.EntitlementManagementSchedule{StartDateTime: &startDateTime},
+	})
+	if err != nil {
+		t.Fatalf("AccessPackageAssignmentRequestsClient.Create(): %v", err)
+	}
+	if scheduled.State == nil || *scheduled.State != msgraph.AccessPackageRequestStateScheduled {
+		t.Fatalf("AccessPackageAssignmentRequestsClient.Create(): expected a scheduled request, got %v", scheduled)
+	}
+	expectRequests(t, server, expectedRequest{http.MethodPost, requestsPath, fmt.Sprintf(`{
+		"assignment": {"accessPackageId": %q, "assignmentPolicyId": %q, "targetId": %q},
+		"requestType": "adminAdd",
+		"schedule": {"startDateTime": %q}
+	}`, *accessPackage.ID, *policy.ID, userId, startDateTime.Format(time.RFC3339Nano))})
+	if status, err := client.Cancel(ctx, *scheduled.ID); err != nil {
+		t.Fatalf("AccessPackageAssignmentRequestsClient.Cancel(): %v", err)
+	} else if status != http.StatusOK {
+		t.Errorf("AccessPackageAssignmentRequestsClient.Cancel(): expected status 200, got %d", status)
+	}
+	scheduled, _, err = client.Get(ctx, *scheduled.ID)
+	if err != nil {
+		t.Fatalf("AccessPackageAssignmentRequestsClient.Get(): %v", err)
+	}
+	if *scheduled.State != msgraph.AccessPackageRequestStateCanceled {
+		t.Errorf("AccessPackageAssignmentRequestsClient.Cancel(): expected a canceled request, got state %s", *scheduled.State)
+	}
+	expectRequests(t, server,
+		expectedRequest{http.MethodPost, requestsPath + "/" + *scheduled.ID + "/cancel", ""},
+		expectedRequest{http.MethodGet, requestsPath + "/" + *scheduled.ID, ""},
+	)
+
+	request, status, err := client.Create(ctx, msgraph.AccessPackageAssignmentRequest{
+		Assignment:    &assignment,
+		Justification: utils.StringPtr("requested by the test suite"),
+		RequestType:   &addType,
+	})
+	if err != nil {
+		t.Fatalf("AccessPackageAssignmentRequestsClient.Create(): %v", err)
+	}
+	if status != http.StatusCreated || request.State == nil || *request.State != msgraph.AccessPackageRequestStateDelivered || request.Assignment == nil || request.Assignment.ID == nil {
+		t.Fatalf("AccessPackageAssignmentRequestsClient.Create(): unexpected request %v with status %d", request, status)
+	}
+	if _, err := client.Cancel(ctx, *request.ID); err == nil {
+		t.Fatalf("AccessPackageAssignmentRequestsClient.Cancel(): expected an error for a delivered request")
+	}
+	expectRequests(t, server,
+		expectedRequest{http.MethodPost, requestsPath, `{"justification": "requested by the test suite", "requestType": "adminAdd"}`},
+		expectedRequest{http.MethodPost, requestsPath + "/" + *request.ID + "/cancel", ""},
+	)
+
+	members, _, err := groupsClient.ListMembers(ctx, groupId)
+	if err != nil {
+		t.Fatalf("GroupsClient.ListMembers(): %v", err)
+	}
+	if members == nil || len(*members) != 1 || *(*members)[0].GetID() != userId {
+		t.Fatalf("AccessPackageAssignmentRequestsClient.Create(): expected the test user to be a member of the test group, got %v", members)
+	}
+
+	requests, _, err := client.List(ctx, odata.Query{Filter: "state eq 'delivered'"})
+	if err != nil {
+		t.Fatalf("AccessPackageAssignmentRequestsClient.List(): %v", err)
+	}
+	if requests == nil || len(*requests) != 1 || *(*requests)[0].ID != *request.ID {
+		t.Fatalf("AccessPackageAssignmentRequestsClient.List(): expected the delivered request, got %v", requests)
+	}
+	expectRequests(t, server,
+		expectedRequest{http.MethodGet, "/groups/" + groupId + "/members", ""},
+		expectedRequest{http.MethodGet, requestsPath, ""},
+	)
+
+	if _, err := accessPackagesClient.Delete(ctx, *accessPackage.ID); err == nil {
+		t.Fatalf("AccessPackagesClient.Delete(): expected an error for an access package with assignments")
+	}
+
+	removeType := msgraph.AccessPackageRequestTypeAdminRemove
+	if _, _, err := client.Create(ctx, msgraph.AccessPackageAssignmentRequest{
+		Assignment:  &msgraph.AccessPackageAssignment{ID: request.Assignment.ID},
+		RequestType: &removeType,
+	}); err != nil {
+		t.Fatalf("AccessPackageAssignmentRequestsClient.Create(): %v", err)
+	}
+	expectRequests(t, server,
+		expectedRequest{http.MethodDelete, "/identityGovernance/entitlementManagement/accessPackages/" + *accessPackage.ID, ""},
+		expectedRequest{http.MethodPost, requestsPath, fmt.Sprintf(`{"assignment": {"id": %q}, "requestType": "adminRemove"}`, *request.Assignment.ID)},
+	)
+	members, _, err = groupsClient.ListMembers(ctx, groupId)
+	if err != nil {
+		t.Fatalf("GroupsClient.ListMembers(): %v", err)
+	}
+	if len(*members) != 0 {
+		t.Errorf("AccessPackageAssignmentRequestsClient.Create(): expected the test user to be removed from the test group, got %v", members)
+	}
+
+	if _, err := client.Delete(ctx, *request.ID); err != nil {
+		t.Fatalf("AccessPackageAssignmentRequestsClient.Delete(): %v", err)
+	}
+	expectRequests(t, server,
+		expectedRequest{http.MethodGet, "/groups/" + groupId + "/members", ""},
+		expectedRequest{http.MethodDelete, requestsPath + "/" + *request.ID, ""},
+	)
+	if _, status, err := client.Get(ctx, *request.ID); err == nil || status != http.StatusNotFound {
+		t.Errorf("AccessPackageAssignmentRequestsClient.Get(): expected status 404 for a deleted request, got %d", status)
+	}
+}
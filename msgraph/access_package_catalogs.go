@@ -0,0 +1,137 @@
+package msgraph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/manicminer/hamilton/odata"
+)
+
+// AccessPackageCatalogsClient performs operations on Access Package Catalogs, and the resources they contain.
+type AccessPackageCatalogsClient struct {
+	BaseClient Client
+}
+
+// NewAccessPackageCatalogsClient returns a new AccessPackageCatalogsClient.
+func NewAccessPackageCatalogsClient(tenantId string) *AccessPackageCatalogsClient {
+	return &AccessPackageCatalogsClient{
+		BaseClient: NewClient(Version10, tenantId),
+	}
+}
+
+// resource returns a Resource for performing common operations on Access Package Catalogs.
+func (c *AccessPackageCatalogsClient) resource() Resource {
+	return Resource{
+		Client: c.BaseClient,
+		Name:   "AccessPackageCatalogsClient",
+		Entity: "/identityGovernance/entitlementManagement/catalogs",
+	}
+}
+
+// resourceRequestsResource returns a Resource for performing common operations on Access Package Resource Requests.
+func (c *AccessPackageCatalogsClient) resourceRequestsResource() Resource {
+	return Resource{
+		Client: c.BaseClient,
+		Name:   "AccessPackageCatalogsClient",
+		Entity: "/identityGovernance/entitlementManagement/resourceRequests",
+	}
+}
+
+// List returns a list of Access Package Catalogs, optionally queried using OData.
+func (c *AccessPackageCatalogsClient) List(ctx context.Context, query odata.Query) (*[]AccessPackageCatalog, int, error) {
+	var catalogs []AccessPackageCatalog
+	status, err := c.resource().List(ctx, query, &catalogs)
+	if err != nil {
+		return nil, status, err
+	}
+	return &catalogs, status, nil
+}
+
+// Create creates a new Access Package Catalog.
+func (c *AccessPackageCatalogsClient) Create(ctx context.Context, catalog AccessPackageCatalog) (*AccessPackageCatalog, int, error) {
+	var newCatalog AccessPackageCatalog
+	status, err := c.resource().Create(ctx, catalog, &newCatalog)
+	if err != nil {
+		return nil, status, err
+	}
+	return &newCatalog, status, nil
+}
+
+// Get retrieves an Access Package Catalog.
+func (c *AccessPackageCatalogsClient) Get(ctx context.Context, id string) (*AccessPackageCatalog, int, error) {
+	var catalog AccessPackageCatalog
+	status, err := c.resource().Get(ctx, id, odata.Query{}, &catalog)
+	if err != nil {
+		return nil, status, err
+	}
+	return &catalog, status, nil
+}
+
+// Update amends an existing Access Package Catalog.
+func (c *AccessPackageCatalogsClient) Update(ctx context.Context, catalog AccessPackageCatalog) (int, error) {
+	var status int
+	if catalog.ID == nil {
+		return status, errors.New("AccessPackageCatalogsClient.Update(): cannot update access package catalog with nil ID")
+	}
+	return c.resource().Update(ctx, *catalog.ID, catalog)
+}
+
+// Delete removes an Access Package Catalog.
+func (c *AccessPackageCatalogsClient) Delete(ctx context.Context, id string) (int, error) {
+	return c.resource().Delete(ctx, id)
+}
+
+// ListResources retrieves the resources which have been added to the specified Access Package Catalog, optionally
+// queried using OData.
+func (c *AccessPackageCatalogsClient) ListResources(ctx context.Context, catalogId string, query odata.Query) (*[]AccessPackageResource, int, error) {
+	var resources []AccessPackageResource
+	status, err := c.resource().list(ctx, fmt.Sprintf("/identityGovernance/entitlementManagement/catalogs/%s/resources", catalogId), query, &resources)
+	if err != nil {
+		return nil, status, err
+	}
+	return &resources, status, nil
+}
+
+// ListResourceRoles retrieves the roles of the resources in the specified Access Package Catalog, optionally queried
+// using OData, e.g. filtering by "resource/id".
+func (c *AccessPackageCatalogsClient) ListResourceRoles(ctx context.Context, catalogId string, query odata.Query) (*[]AccessPackageResourceRole, int, error) {
+	var roles []AccessPackageResourceRole
+	status, err := c.resource().list(ctx, fmt.Sprintf("/identityGovernance/entitlementManagement/catalogs/%s/resourceRoles", catalogId), query, &roles)
+	if err != nil {
+		return nil, status, err
+	}
+	return &roles, status, nil
+}
+
+// AddResource adds a resource, such as a group or an application, to the specified Access Package Catalog so that its
+// roles can be granted by access packages in the catalog. The OriginId and OriginSystem of the resource must be set,
+// where the OriginId is the object ID of the group or service principal.
+func (c *AccessPackageCatalogsClient) AddResource(ctx context.Context, catalogId string, resource AccessPackageResource) (*AccessPackageResourceRequest, int, error) {
+	return c.requestResource(ctx, catalogId, resource, AccessPackageRequestTypeAdminAdd)
+}
+
+// RemoveResource removes a resource from the specified Access Package Catalog. The OriginId and OriginSystem of the
+// resource must be set.
+func (c *AccessPackageCatalogsClient) RemoveResource(ctx context.Context, catalogId string, resource AccessPackageResource) (*AccessPackageResourceRequest, int, error) {
+	return c.requestResource(ctx, catalogId, resource, AccessPackageRequestTypeAdminRemove)
+}
+
+// requestResource creates an Access Package Resource Request to add or remove a catalog resource.
+func (c *AccessPackageCatalogsClient) requestResource(ctx context.Context, catalogId string, resource AccessPackageResource, requestType AccessPackageRequestType) (*AccessPackageResourceRequest, int, error) {
+	var status int
+	if resource.OriginId == nil || resource.OriginSystem == nil {
+		return nil, status, errors.New("AccessPackageCatalogsClient: cannot request resource with nil OriginId or OriginSystem")
+	}
+	request := AccessPackageResourceRequest{
+		Catalog:     &AccessPackageCatalog{ID: &catalogId},
+		RequestType: &requestType,
+		Resource:    &resource,
+	}
+	var newRequest AccessPackageResourceRequest
+	status, err := c.resourceRequestsResource().Create(ctx, request, &newRequest)
+	if err != nil {
+		return nil, status, err
+	}
+	return &newRequest, status, nil
+}
@@ -0,0 +1,136 @@
+package msgraph_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/manicminer/hamilton/internal/utils"
+	"github.com/manicminer/hamilton/msgraph"
+	"github.com/manicminer/hamilton/msgraph/msgraphtest"
+	"github.com/manicminer/hamilton/odata"
+)
+
+func TestAccessPackageCatalogsClient(t *testing.T) {
+	server := msgraphtest.NewServer()
+	defer server.Close()
+	ctx := context.Background()
+
+	client := msgraph.NewAccessPackageCatalogsClient("tenant")
+	client.BaseClient.Endpoint = server.Endpoint()
+	catalogsPath := "/identityGovernance/entitlementManagement/catalogs"
+	resourceRequestsPath := "/identityGovernance/entitlementManagement/resourceRequests"
+
+	catalog, status, err := client.Create(ctx, msgraph.AccessPackageCatalog{
+		DisplayName: utils.StringPtr("test-catalog"),
+		Description: utils.StringPtr("created by the test suite"),
+	})
+	if err != nil {
+		t.Fatalf("AccessPackageCatalogsClient.Create(): %v", err)
+	}
+	if status != http.StatusCreated || catalog.ID == nil || catalog.State == nil || *catalog.State != msgraph.AccessPackageCatalogStatePublished {
+		t.Fatalf("AccessPackageCatalogsClient.Create(): unexpected catalog %v with status %d", catalog, status)
+	}
+	expectRequests(t, server, expectedRequest{http.MethodPost, catalogsPath, `{"displayName": "test-catalog", "description": "created by the test suite"}`})
+	catalogPath := catalogsPath + "/" + *catalog.ID
+
+	if _, err := client.Update(ctx, msgraph.AccessPackageCatalog{ID: catalog.ID, IsExternallyVisible: utils.BoolPtr(true)}); err != nil {
+		t.Fatalf("AccessPackageCatalogsClient.Update(): %v", err)
+	}
+	if _, err := client.Update(ctx, msgraph.AccessPackageCatalog{}); err == nil {
+		t.Fatalf("AccessPackageCatalogsClient.Update(): expected an error for a catalog with nil ID")
+	}
+	expectRequests(t, server, expectedRequest{http.MethodPatch, catalogPath, `{"isExternallyVisible": true}`})
+
+	catalog, _, err = client.Get(ctx, *catalog.ID)
+	if err != nil {
+		t.Fatalf("AccessPackageCatalogsClient.Get(): %v", err)
+	}
+	if catalog.IsExternallyVisible == nil || !*catalog.IsExternallyVisible {
+		t.Errorf("AccessPackageCatalogsClient.Get(): expected catalog to be externally visible")
+	}
+
+	catalogs, _, err := client.List(ctx, odata.Query{Filter: "displayName eq 'test-catalog'"})
+	if err != nil {
+		t.Fatalf("AccessPackageCatalogsClient.List(): %v", err)
+	}
+	if catalogs == nil || len(*catalogs) != 1 {
+		t.Fatalf("AccessPackageCatalogsClient.List(): expected 1 catalog, got %v", catalogs)
+	}
+	expectRequests(t, server,
+		expectedRequest{http.MethodGet, catalogPath, ""},
+		expectedRequest{http.MethodGet, catalogsPath, ""},
+	)
+
+	groupId := server.Add("groups", msgraph.Group{
+		DisplayName:  utils.StringPtr("test-group"),
+		MailNickname: utils.StringPtr("test-group"),
+	})
+	originSystem := msgraph.AccessPackageResourceOriginSystemAadGroup
+	resource := msgraph.AccessPackageResource{
+		OriginId:     utils.StringPtr(groupId),
+		OriginSystem: &originSystem,
+	}
+	if _, _, err := client.AddResource(ctx, *catalog.ID, msgraph.AccessPackageResource{OriginId: utils.StringPtr(groupId)}); err == nil {
+		t.Fatalf("AccessPackageCatalogsClient.AddResource(): expected an error for a resource with nil OriginSystem")
+	}
+	request, status, err := client.AddResource(ctx, *catalog.ID, resource)
+	if err != nil {
+		t.Fatalf("AccessPackageCatalogsClient.AddResource(): %v", err)
+	}
+	if status != http.StatusCreated || request.State == nil || *request.State != msgraph.AccessPackageRequestStateDelivered {
+		t.Fatalf("AccessPackageCatalogsClient.AddResource(): unexpected request %v with status %d", request, status)
+	}
+	if _, _, err := client.AddResource(ctx, *catalog.ID, resource); err == nil {
+		t.Fatalf("AccessPackageCatalogsClient.AddResource(): expected an error for a resource which was already added")
+	}
+	addResourceBody := fmt.Sprintf(`{"requestType": "adminAdd", "catalog": {"id": %q}, "resource": {"originId": %q, "originSystem": "AadGroup"}}`, *catalog.ID, groupId)
+	expectRequests(t, server,
+		expectedRequest{http.MethodPost, resourceRequestsPath, addResourceBody},
+		expectedRequest{http.MethodPost, resourceRequestsPath, addResourceBody},
+	)
+
+	resources, _, err := client.ListResources(ctx, *catalog.ID, odata.Query{})
+	if err != nil {
+		t.Fatalf("AccessPackageCatalogsClient.ListResources(): %v", err)
+	}
+	if resources == nil || len(*resources) != 1 || *(*resources)[0].OriginId != groupId || *(*resources)[0].DisplayName != "test-group" {
+		t.Fatalf("AccessPackageCatalogsClient.ListResources(): expected the test group, got %v", resources)
+	}
+
+	roles, _, err := client.ListResourceRoles(ctx, *catalog.ID, odata.Query{
+		Filter: fmt.Sprintf("resource/id eq '%s' and displayName eq 'Member'", *(*resources)[0].ID),
+	})
+	if err != nil {
+		t.Fatalf("AccessPackageCatalogsClient.ListResourceRoles(): %v", err)
+	}
+	if roles == nil || len(*roles) != 1 || *(*roles)[0].OriginId != "Member_"+groupId {
+		t.Errorf("AccessPackageCatalogsClient.ListResourceRoles(): expected the member role of the test group, got %v", roles)
+	}
+	expectRequests(t, server,
+		expectedRequest{http.MethodGet, catalogPath + "/resources", ""},
+		expectedRequest{http.MethodGet, catalogPath + "/resourceRoles", ""},
+	)
+
+	if _, _, err := client.RemoveResource(ctx, *catalog.ID, resource); err != nil {
+		t.Fatalf("AccessPackageCatalogsClient.RemoveResource(): %v", err)
+	}
+	expectRequests(t, server, expectedRequest{http.MethodPost, resourceRequestsPath, fmt.Sprintf(`{"requestType": "adminRemove", "catalog": {"id": %q}, "resource": {"originId": %q, "originSystem": "AadGroup"}}`, *catalog.ID, groupId)})
+	resources, _, err = client.ListResources(ctx, *catalog.ID, odata.Query{})
+	if err != nil {
+		t.Fatalf("AccessPackageCatalogsClient.ListResources(): %v", err)
+	}
+	if len(*resources) != 0 {
+		t.Errorf("AccessPackageCatalogsClient.RemoveResource(): expected no resources to remain, got %v", resources)
+	}
+	expectRequests(t, server, expectedRequest{http.MethodGet, catalogPath + "/resources", ""})
+
+	if _, err := client.Delete(ctx, *catalog.ID); err != nil {
+		t.Fatalf("AccessPackageCatalogsClient.Delete(): %v", err)
+	}
+	expectRequests(t, server, expectedRequest{http.MethodDelete, catalogPath, ""})
+	if _, status, err := client.Get(ctx, *catalog.ID); err == nil || status != http.StatusNotFound {
+		t.Errorf("AccessPackageCatalogsClient.Get(): expected status 404 for a deleted catalog, got %d", status)
+	}
+}
@@ -0,0 +1,84 @@
+package msgraph
+
+import (
+	"context"
+	"errors"
+
+	"github.com/manicminer/hamilton/odata"
+)
+
+// AccessPackagesClient performs operations on Access Packages.
+type AccessPackagesClient struct {
+	BaseClient Client
+}
+
+// NewAccessPackagesClient returns a new AccessPackagesClient.
+func NewAccessPackagesClient(tenantId string) *AccessPackagesClient {
+	return &AccessPackagesClient{
+		BaseClient: NewClient(Version10, tenantId),
+	}
+}
+
+// resource returns a Resource for performing common operations on Access Packages.
+func (c *AccessPackagesClient) resource() Resource {
+	return Resource{
+		Client: c.BaseClient,
+		Name:   "AccessPackagesClient",
+		Entity: "/identityGovernance/entitlementManagement/accessPackages",
+	}
+}
+
+// List returns a list of Access Packages, optionally queried using OData, e.g. filtering by "catalog/id".
+func (c *AccessPackagesClient) List(ctx context.Context, query odata.Query) (*[]AccessPackage, int, error) {
+	var accessPackages []AccessPackage
+	status, err := c.resource().List(ctx, query, &accessPackages)
+	if err != nil {
+		return nil, status, err
+	}
+	return &accessPackages, status, nil
+}
+
+// ListPager returns a Pager for retrieving Access Packages one page at a time, optionally queried using OData.
+func (c *AccessPackagesClient) ListPager(query odata.Query) *Pager {
+	return c.resource().ListPager(query)
+}
+
+// Create creates a new Access Package in the catalog specified by the ID of its Catalog.
+func (c *AccessPackagesClient) Create(ctx context.Context, accessPackage AccessPackage) (*AccessPackage, int, error) {
+	var status int
+	if accessPackage.Catalog == nil || accessPackage.Catalog.ID == nil {
+		return nil, status, errors.New("AccessPackagesClient.Create(): cannot create access package with nil Catalog ID")
+	}
+	var newAccessPackage AccessPackage
+	status, err := c.resource().Create(ctx, accessPackage, &newAccessPackage)
+	if err != nil {
+		return nil, status, err
+	}
+	return &newAccessPackage, status, nil
+}
+
+// Get retrieves an Access Package.
+func (c *AccessPackagesClient) Get(ctx context.Context, id string) (*AccessPackage, int, error) {
+	var accessPackage AccessPackage
+	status, err := c.resource().Get(ctx, id, odata.Query{}, &accessPackage)
+	if err != nil {
+		return nil, status, err
+	}
+	return &accessPackage, status, nil
+}
+
+// Update amends an existing Access Package. The catalog of an access package cannot be changed.
+func (c *AccessPackagesClient) Update(ctx context.Context, accessPackage AccessPackage) (int, error) {
+	var status int
+	if accessPackage.ID == nil {
+		return status, errors.New("AccessPackagesClient.Update(): cannot update access package with nil ID")
+	}
+	accessPackage.Catalog = nil
+	accessPackage.ResourceRoleScopes = nil
+	return c.resource().Update(ctx, *accessPackage.ID, accessPackage)
+}
+
+// Delete removes an Access Package. An access package cannot be removed while it has assignments.
+func (c *AccessPackagesClient) Delete(ctx context.Context, id string) (int, error) {
+	return c.resource().Delete(ctx, id)
+}
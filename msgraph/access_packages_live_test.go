@@ -0,0 +1,385 @@
+//go:build live
+// +build live
+
+package msgraph_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/manicminer/hamilton/auth"
+	"github.com/manicminer/hamilton/internal/test"
+	"github.com/manicminer/hamilton/internal/utils"
+	"github.com/manicminer/hamilton/msgraph"
+	"github.com/manicminer/hamilton/odata"
+)
+
+type AccessPackagesClientTest struct {
+	connection       *test.Connection
+	client           *msgraph.AccessPackagesClient
+	catalogsClient   *msgraph.AccessPackageCatalogsClient
+	roleScopesClient *msgraph.AccessPackageResourceRoleScopesClient
+	policiesClient   *msgraph.AccessPackageAssignmentPoliciesClient
+	requestsClient   *msgraph.AccessPackageAssignmentRequestsClient
+	randomString     string
+}
+
+// TestAccessPackagesClient_Live requires a tenant licensed for Identity Governance.
+func TestAccessPackagesClient_Live(t *testing.T) {
+	rs := test.RandomString()
+	c := AccessPackagesClientTest{
+		connection:   test.NewConnection(auth.MsGraph, auth.TokenVersion2),
+		randomString: rs,
+	}
+	c.client = msgraph.NewAccessPackagesClient(c.connection.AuthConfig.TenantID)
+	c.client.BaseClient.Authorizer = c.connection.Authorizer
+	c.catalogsClient = msgraph.NewAccessPackageCatalogsClient(c.connection.AuthConfig.TenantID)
+	c.catalogsClient.BaseClient.Authorizer = c.connection.Authorizer
+	c.roleScopesClient = msgraph.NewAccessPackageResourceRoleScopesClient(c.connection.AuthConfig.TenantID)
+	c.roleScopesClient.BaseClient.Authorizer = c.connection.Authorizer
+	c.policiesClient = msgraph.NewAccessPackageAssignmentPoliciesClient(c.connection.AuthConfig.TenantID)
+	c.policiesClient.BaseClient.Authorizer = c.connection.Authorizer
+	c.requestsClient = msgraph.NewAccessPackageAssignmentRequestsClient(c.connection.AuthConfig.TenantID)
+	c.requestsClient.BaseClient.Authorizer = c.connection.Authorizer
+
+	g := GroupsClientTest{
+		connection:   test.NewConnection(auth.MsGraph, auth.TokenVersion2),
+		randomString: rs,
+	}
+	g.client = msgraph.NewGroupsClient(g.connection.AuthConfig.TenantID)
+	g.client.BaseClient.Authorizer = g.connection.Authorizer
+
+	u := UsersClientTest{
+		connection:   test.NewConnection(auth.MsGraph, auth.TokenVersion2),
+		randomString: rs,
+	}
+	u.client = msgraph.NewUsersClient(u.connection.AuthConfig.TenantID)
+	u.client.BaseClient.Authorizer = u.connection.Authorizer
+
+	group := testGroupsClient_Create(t, g, msgraph.Group{
+		DisplayName:     utils.StringPtr("test-group-access-package"),
+		MailEnabled:     utils.BoolPtr(false),
+		MailNickname:    utils.StringPtr(fmt.Sprintf("test-group-access-package-%s", c.randomString)),
+		SecurityEnabled: utils.BoolPtr(true),
+	})
+	user := testUsersClient_Create(t, u, msgraph.User{
+		AccountEnabled:    utils.BoolPtr(true),
+		DisplayName:       utils.StringPtr("test-user-access-package"),
+		MailNickname:      utils.StringPtr(fmt.Sprintf("test-user-access-package-%s", c.randomString)),
+		UserPrincipalName: utils.StringPtr(fmt.Sprintf("test-user-access-package-%s@%s", c.randomString, c.connection.DomainName)),
+		PasswordProfile: &msgraph.UserPasswordProfile{
+			Password: utils.StringPtr(fmt.Sprintf("IrPa55w0rd%s", c.randomString)),
+		},
+	})
+
+	catalog := testAccessPackageCatalogsClient_Create(t, c, msgraph.AccessPackageCatalog{
+		DisplayName: utils.StringPtr(fmt.Sprintf("test-catalog-%s", c.randomString)),
+		Description: utils.StringPtr("created by the test suite"),
+	})
+	catalog = testAccessPackageCatalogsClient_Get(t, c, *catalog.ID)
+	testAccessPackageCatalogsClient_Update(t, c, msgraph.AccessPackageCatalog{
+		ID:          catalog.ID,
+		Description: utils.StringPtr("updated by the test suite"),
+	})
+
+	originSystem := msgraph.AccessPackageResourceOriginSystemAadGroup
+	testAccessPackageCatalogsClient_AddResource(t, c, *catalog.ID, msgraph.AccessPackageResource{
+		OriginId:     group.ID,
+		OriginSystem: &originSystem,
+	})
+	role := testAccessPackageCatalogsClient_ListMemberRole(t, c, *catalog.ID, *group.ID)
+
+	accessPackage := testAccessPackagesClient_Create(t, c, msgraph.AccessPackage{
+		Catalog:     &msgraph.AccessPackageCatalog{ID: catalog.ID},
+		DisplayName: utils.StringPtr(fmt.Sprintf("test-access-package-%s", c.randomString)),
+	})
+	accessPackage = testAccessPackagesClient_Get(t, c, *accessPackage.ID)
+	testAccessPackagesClient_Update(t, c, msgraph.AccessPackage{
+		ID:          accessPackage.ID,
+		Description: utils.StringPtr("updated by the test suite"),
+	})
+
+	roleScope := testAccessPackageResourceRoleScopesClient_Create(t, c, *accessPackage.ID, msgraph.AccessPackageResourceRoleScope{
+		Role: role,
+		Scope: &msgraph.AccessPackageResourceScope{
+			OriginId:     group.ID,
+			OriginSystem: &originSystem,
+		},
+	})
+
+	expirationType := msgraph.ExpirationPatternTypeAfterDuration
+	policy := testAccessPackageAssignmentPoliciesClient_Create(t, c, msgraph.AccessPackageAssignmentPolicy{
+		AccessPackage: &msgraph.AccessPackage{ID: accessPackage.ID},
+		DisplayName:   utils.StringPtr(fmt.Sprintf("test-policy-%s", c.randomString)),
+		Description:   utils.StringPtr("created by the test suite"),
+		Expiration: &msgraph.ExpirationPattern{
+			Duration: utils.StringPtr("P30D"),
+			Type:     &expirationType,
+		},
+	})
+	policy = testAccessPackageAssignmentPoliciesClient_Get(t, c, *policy.ID)
+
+	addType := msgraph.AccessPackageRequestTypeAdminAdd
+	request := testAccessPackageAssignmentRequestsClient_Create(t, c, msgraph.AccessPackageAssignmentRequest{
+		Assignment: &msgraph.AccessPackageAssignment{
+			AccessPackageId:    accessPackage.ID,
+			AssignmentPolicyId: policy.ID,
+			TargetId:           user.ID,
+		},
+		RequestType: &addType,
+	})
+	testAccessPackageAssignmentRequestsClient_Get(t, c, *request.ID)
+	testAccessPackageAssignmentRequestsClient_List(t, c, odata.Query{Filter: fmt.Sprintf("accessPackage/id eq '%s'", *accessPackage.ID)})
+
+	testUsersClient_Delete(t, u, *user.ID)
+	testAccessPackageAssignmentPoliciesClient_Delete(t, c, *policy.ID)
+	testAccessPackageResourceRoleScopesClient_Delete(t, c, *accessPackage.ID, *roleScope.ID)
+	testAccessPackagesClient_Delete(t, c, *accessPackage.ID)
+	testAccessPackageCatalogsClient_Delete(t, c, *catalog.ID)
+	testGroupsClient_Delete(t, g, *group.ID)
+}
+
+func testAccessPackageCatalogsClient_Create(t *testing.T, c AccessPackagesClientTest, a msgraph.AccessPackageCatalog) (catalog *msgraph.AccessPackageCatalog) {
+	catalog, status, err := c.catalogsClient.Create(c.connection.Context, a)
+	if err != nil {
+		t.Fatalf("AccessPackageCatalogsClient.Create(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("AccessPackageCatalogsClient.Create(): invalid status: %d", status)
+	}
+	if catalog == nil {
+		t.Fatal("AccessPackageCatalogsClient.Create(): catalog was nil")
+	}
+	if catalog.ID == nil {
+		t.Fatal("AccessPackageCatalogsClient.Create(): catalog.ID was nil")
+	}
+	return
+}
+
+func testAccessPackageCatalogsClient_Get(t *testing.T, c AccessPackagesClientTest, id string) (catalog *msgraph.AccessPackageCatalog) {
+	catalog, status, err := c.catalogsClient.Get(c.connection.Context, id)
+	if err != nil {
+		t.Fatalf("AccessPackageCatalogsClient.Get(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("AccessPackageCatalogsClient.Get(): invalid status: %d", status)
+	}
+	if catalog == nil {
+		t.Fatal("AccessPackageCatalogsClient.Get(): catalog was nil")
+	}
+	return
+}
+
+func testAccessPackageCatalogsClient_Update(t *testing.T, c AccessPackagesClientTest, a msgraph.AccessPackageCatalog) {
+	status, err := c.catalogsClient.Update(c.connection.Context, a)
+	if err != nil {
+		t.Fatalf("AccessPackageCatalogsClient.Update(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("AccessPackageCatalogsClient.Update(): invalid status: %d", status)
+	}
+}
+
+func testAccessPackageCatalogsClient_AddResource(t *testing.T, c AccessPackagesClientTest, catalogId string, r msgraph.AccessPackageResource) (request *msgraph.AccessPackageResourceRequest) {
+	request, status, err := c.catalogsClient.AddResource(c.connection.Context, catalogId, r)
+	if err != nil {
+		t.Fatalf("AccessPackageCatalogsClient.AddResource(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("AccessPackageCatalogsClient.AddResource(): invalid status: %d", status)
+	}
+	if request == nil {
+		t.Fatal("AccessPackageCatalogsClient.AddResource(): request was nil")
+	}
+	return
+}
+
+func testAccessPackageCatalogsClient_ListMemberRole(t *testing.T, c AccessPackagesClientTest, catalogId, groupId string) *msgraph.AccessPackageResourceRole {
+	roles, _, err := c.catalogsClient.ListResourceRoles(c.connection.Context, catalogId, odata.Query{
+		Filter: fmt.Sprintf("originSystem eq 'AadGroup' and resource/originId eq '%s'", groupId),
+	})
+	if err != nil {
+		t.Fatalf("AccessPackageCatalogsClient.ListResourceRoles(): %v", err)
+	}
+	if roles == nil {
+		t.Fatal("AccessPackageCatalogsClient.ListResourceRoles(): roles was nil")
+	}
+	for _, role := range *roles {
+		if role.OriginId != nil && *role.OriginId == "Member_"+groupId {
+			return &role
+		}
+	}
+	t.Fatalf("AccessPackageCatalogsClient.ListResourceRoles(): expected the member role of group %q", groupId)
+	return nil
+}
+
+func testAccessPackageCatalogsClient_Delete(t *testing.T, c AccessPackagesClientTest, id string) {
+	status, err := c.catalogsClient.Delete(c.connection.Context, id)
+	if err != nil {
+		t.Fatalf("AccessPackageCatalogsClient.Delete(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("AccessPackageCatalogsClient.Delete(): invalid status: %d", status)
+	}
+}
+
+func testAccessPackagesClient_Create(t *testing.T, c AccessPackagesClientTest, a msgraph.AccessPackage) (accessPackage *msgraph.AccessPackage) {
+	accessPackage, status, err := c.client.Create(c.connection.Context, a)
+	if err != nil {
+		t.Fatalf("AccessPackagesClient.Create(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("AccessPackagesClient.Create(): invalid status: %d", status)
+	}
+	if accessPackage == nil {
+		t.Fatal("AccessPackagesClient.Create(): accessPackage was nil")
+	}
+	if accessPackage.ID == nil {
+		t.Fatal("AccessPackagesClient.Create(): accessPackage.ID was nil")
+	}
+	return
+}
+
+func testAccessPackagesClient_Get(t *testing.T, c AccessPackagesClientTest, id string) (accessPackage *msgraph.AccessPackage) {
+	accessPackage, status, err := c.client.Get(c.connection.Context, id)
+	if err != nil {
+		t.Fatalf("AccessPackagesClient.Get(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("AccessPackagesClient.Get(): invalid status: %d", status)
+	}
+	if accessPackage == nil {
+		t.Fatal("AccessPackagesClient.Get(): accessPackage was nil")
+	}
+	return
+}
+
+func testAccessPackagesClient_Update(t *testing.T, c AccessPackagesClientTest, a msgraph.AccessPackage) {
+	status, err := c.client.Update(c.connection.Context, a)
+	if err != nil {
+		t.Fatalf("AccessPackagesClient.Update(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("AccessPackagesClient.Update(): invalid status: %d", status)
+	}
+}
+
+func testAccessPackagesClient_Delete(t *testing.T, c AccessPackagesClientTest, id string) {
+	status, err := c.client.Delete(c.connection.Context, id)
+	if err != nil {
+		t.Fatalf("AccessPackagesClient.Delete(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("AccessPackagesClient.Delete(): invalid status: %d", status)
+	}
+}
+
+func testAccessPackageResourceRoleScopesClient_Create(t *testing.T, c AccessPackagesClientTest, accessPackageId string, r msgraph.AccessPackageResourceRoleScope) (roleScope *msgraph.AccessPackageResourceRoleScope) {
+	roleScope, status, err := c.roleScopesClient.Create(c.connection.Context, accessPackageId, r)
+	if err != nil {
+		t.Fatalf("AccessPackageResourceRoleScopesClient.Create(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("AccessPackageResourceRoleScopesClient.Create(): invalid status: %d", status)
+	}
+	if roleScope == nil {
+		t.Fatal("AccessPackageResourceRoleScopesClient.Create(): roleScope was nil")
+	}
+	if roleScope.ID == nil {
+		t.Fatal("AccessPackageResourceRoleScopesClient.Create(): roleScope.ID was nil")
+	}
+	return
+}
+
+func testAccessPackageResourceRoleScopesClient_Delete(t *testing.T, c AccessPackagesClientTest, accessPackageId, id string) {
+	status, err := c.roleScopesClient.Delete(c.connection.Context, accessPackageId, id)
+	if err != nil {
+		t.Fatalf("AccessPackageResourceRoleScopesClient.Delete(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("AccessPackageResourceRoleScopesClient.Delete(): invalid status: %d", status)
+	}
+}
+
+func testAccessPackageAssignmentPoliciesClient_Create(t *testing.T, c AccessPackagesClientTest, p msgraph.AccessPackageAssignmentPolicy) (policy *msgraph.AccessPackageAssignmentPolicy) {
+	policy, status, err := c.policiesClient.Create(c.connection.Context, p)
+	if err != nil {
+		t.Fatalf("AccessPackageAssignmentPoliciesClient.Create(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("AccessPackageAssignmentPoliciesClient.Create(): invalid status: %d", status)
+	}
+	if policy == nil {
+		t.Fatal("AccessPackageAssignmentPoliciesClient.Create(): policy was nil")
+	}
+	if policy.ID == nil {
+		t.Fatal("AccessPackageAssignmentPoliciesClient.Create(): policy.ID was nil")
+	}
+	return
+}
+
+func testAccessPackageAssignmentPoliciesClient_Get(t *testing.T, c AccessPackagesClientTest, id string) (policy *msgraph.AccessPackageAssignmentPolicy) {
+	policy, status, err := c.policiesClient.Get(c.connection.Context, id)
+	if err != nil {
+		t.Fatalf("AccessPackageAssignmentPoliciesClient.Get(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("AccessPackageAssignmentPoliciesClient.Get(): invalid status: %d", status)
+	}
+	if policy == nil {
+		t.Fatal("AccessPackageAssignmentPoliciesClient.Get(): policy was nil")
+	}
+	return
+}
+
+func testAccessPackageAssignmentPoliciesClient_Delete(t *testing.T, c AccessPackagesClientTest, id string) {
+	status, err := c.policiesClient.Delete(c.connection.Context, id)
+	if err != nil {
+		t.Fatalf("AccessPackageAssignmentPoliciesClient.Delete(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("AccessPackageAssignmentPoliciesClient.Delete(): invalid status: %d", status)
+	}
+}
+
+func testAccessPackageAssignmentRequestsClient_Create(t *testing.T, c AccessPackagesClientTest, r msgraph.AccessPackageAssignmentRequest) (request *msgraph.AccessPackageAssignmentRequest) {
+	request, status, err := c.requestsClient.Create(c.connection.Context, r)
+	if err != nil {
+		t.Fatalf("AccessPackageAssignmentRequestsClient.Create(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("AccessPackageAssignmentRequestsClient.Create(): invalid status: %d", status)
+	}
+	if request == nil {
+		t.Fatal("AccessPackageAssignmentRequestsClient.Create(): request was nil")
+	}
+	if request.ID == nil {
+		t.Fatal("AccessPackageAssignmentRequestsClient.Create(): request.ID was nil")
+	}
+	return
+}
+
+func testAccessPackageAssignmentRequestsClient_Get(t *testing.T, c AccessPackagesClientTest, id string) (request *msgraph.AccessPackageAssignmentRequest) {
+	request, status, err := c.requestsClient.Get(c.connection.Context, id)
+	if err != nil {
+		t.Fatalf("AccessPackageAssignmentRequestsClient.Get(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("AccessPackageAssignmentRequestsClient.Get(): invalid status: %d", status)
+	}
+	if request == nil {
+		t.Fatal("AccessPackageAssignmentRequestsClient.Get(): request was nil")
+	}
+	return
+}
+
+func testAccessPackageAssignmentRequestsClient_List(t *testing.T, c AccessPackagesClientTest, query odata.Query) (requests *[]msgraph.AccessPackageAssignmentRequest) {
+	requests, _, err := c.requestsClient.List(c.connection.Context, query)
+	if err != nil {
+		t.Fatalf("AccessPackageAssignmentRequestsClient.List(): %v", err)
+	}
+	if requests == nil {
+		t.Fatal("AccessPackageAssignmentRequestsClient.List(): requests was nil")
+	}
+	return
+}
@@ -0,0 +1,167 @@
+package msgraph_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/manicminer/hamilton/internal/utils"
+	"github.com/manicminer/hamilton/msgraph"
+	"github.com/manicminer/hamilton/msgraph/msgraphtest"
+	"github.com/manicminer/hamilton/odata"
+)
+
+func TestAccessPackagesClient(t *testing.T) {
+	server := msgraphtest.NewServer()
+	defer server.Close()
+	ctx := context.Background()
+
+	client := msgraph.NewAccessPackagesClient("tenant")
+	client.BaseClient.Endpoint = server.Endpoint()
+	catalogsClient := msgraph.NewAccessPackageCatalogsClient("tenant")
+	catalogsClient.BaseClient.Endpoint = server.Endpoint()
+	roleScopesClient := msgraph.NewAccessPackageResourceRoleScopesClient("tenant")
+	roleScopesClient.BaseClient.Endpoint = server.Endpoint()
+
+	catalog, _, err := catalogsClient.Create(ctx, msgraph.AccessPackageCatalog{DisplayName: utils.StringPtr("test-catalog")})
+	if err != nil {
+		t.Fatalf("AccessPackageCatalogsClient.Create(): %v", err)
+	}
+	server.ClearRequests()
+	accessPackagesPath := "/identityGovernance/entitlementManagement/accessPackages"
+
+	if _, _, err := client.Create(ctx, msgraph.AccessPackage{DisplayName: utils.StringPtr("test-access-package")}); err == nil {
+		t.Fatalf("AccessPackagesClient.Create(): expected an error for an access package with nil Catalog")
+	}
+	accessPackage, status, err := client.Create(ctx, msgraph.AccessPackage{
+		Catalog:     &msgraph.AccessPackageCatalog{ID: catalog.ID},
+		DisplayName: utils.StringPtr("test-access-package"),
+		Description: utils.StringPtr("created by the test suite"),
+	})
+	if err != nil {
+		t.Fatalf("AccessPackagesClient.Create(): %v", err)
+	}
+	if status != http.StatusCreated || accessPackage.ID == nil {
+		t.Fatalf("AccessPackagesClient.Create(): expected a new access package with status 201, got status %d", status)
+	}
+	expectRequests(t, server, expectedRequest{http.MethodPost, accessPackagesPath, fmt.Sprintf(`{"catalog": {"id": %q}, "displayName": "test-access-package", "description": "created by the test suite"}`, *catalog.ID)})
+	accessPackagePath := accessPackagesPath + "/" + *accessPackage.ID
+
+	accessPackage.IsHidden = utils.BoolPtr(true)
+	if _, err := client.Update(ctx, *accessPackage); err != nil {
+		t.Fatalf("AccessPackagesClient.Update(): %v", err)
+	}
+	if _, err := client.Update(ctx, msgraph.AccessPackage{}); err == nil {
+		t.Fatalf("AccessPackagesClient.Update(): expected an error for an access package with nil ID")
+	}
+	expectRequests(t, server, expectedRequest{http.MethodPatch, accessPackagePath, `{"isHidden": true}`})
+
+	got, _, err := client.Get(ctx, *accessPackage.ID)
+	if err != nil {
+		t.Fatalf("AccessPackagesClient.Get(): %v", err)
+	}
+	if got.IsHidden == nil || !*got.IsHidden {
+		t.Errorf("AccessPackagesClient.Get(): expected access package to be hidden")
+	}
+
+	accessPackages, _, err := client.List(ctx, odata.Query{Filter: fmt.Sprintf("catalog/id eq '%s'", *catalog.ID)})
+	if err != nil {
+		t.Fatalf("AccessPackagesClient.List(): %v", err)
+	}
+	if accessPackages == nil || len(*accessPackages) != 1 {
+		t.Fatalf("AccessPackagesClient.List(): expected 1 access package, got %v", accessPackages)
+	}
+	expectRequests(t, server,
+		expectedRequest{http.MethodGet, accessPackagePath, ""},
+		expectedRequest{http.MethodGet, accessPackagesPath, ""},
+	)
+
+	groupId := server.Add("groups", msgraph.Group{
+		DisplayName:  utils.StringPtr("test-group"),
+		MailNickname: utils.StringPtr("test-group"),
+	})
+	originSystem := msgraph.AccessPackageResourceOriginSystemAadGroup
+	request, _, err := catalogsClient.AddResource(ctx, *catalog.ID, msgraph.AccessPackageResource{
+		OriginId:     utils.StringPtr(groupId),
+		OriginSystem: &originSystem,
+	})
+	if err != nil {
+		t.Fatalf("AccessPackageCatalogsClient.AddResource(): %v", err)
+	}
+	roles, _, err := catalogsClient.ListResourceRoles(ctx, *catalog.ID, odata.Query{
+		Filter: fmt.Sprintf("originId eq 'Member_%s'", groupId),
+	})
+	if err != nil || len(*roles) != 1 {
+		t.Fatalf("AccessPackageCatalogsClient.ListResourceRoles(): expected the member role of the test group, got %v (%v)", roles, err)
+	}
+	server.ClearRequests()
+	roleScopesPath := accessPackagePath + "/resourceRoleScopes"
+
+	if _, _, err := roleScopesClient.Create(ctx, *accessPackage.ID, msgraph.AccessPackageResourceRoleScope{Role: &(*roles)[0]}); err == nil {
+		t.Fatalf("AccessPackageResourceRoleScopesClient.Create(): expected an error for a resource role scope with nil Scope")
+	}
+	roleScope, status, err := roleScopesClient.Create(ctx, *accessPackage.ID, msgraph.AccessPackageResourceRoleScope{
+		Role: &msgraph.AccessPackageResourceRole{
+			OriginId:     (*roles)[0].OriginId,
+			OriginSystem: &originSystem,
+			Resource:     &msgraph.AccessPackageResource{ID: request.Resource.ID},
+		},
+		Scope: &msgraph.AccessPackageResourceScope{
+			OriginId:     utils.StringPtr(groupId),
+			OriginSystem: &originSystem,
+		},
+	})
+	if err != nil {
+		t.Fatalf("AccessPackageResourceRoleScopesClient.Create(): %v", err)
+	}
+	if status != http.StatusCreated || roleScope.ID == nil {
+		t.Fatalf("AccessPackageResourceRoleScopesClient.Create(): expected a new resource role scope with status 201, got status %d", status)
+	}
+	expectRequests(t, server, expectedRequest{http.MethodPost, roleScopesPath, fmt.Sprintf(`{
+		"role": {"originId": "Member_%[1]s", "originSystem": "AadGroup", "resource": {"id": %[2]q}},
+		"scope": {"originId": %[1]q, "originSystem": "AadGroup"}
+	}`, groupId, *request.Resource.ID)})
+
+	roleScopes, _, err := roleScopesClient.List(ctx, *accessPackage.ID, odata.Query{})
+	if err != nil {
+		t.Fatalf("AccessPackageResourceRoleScopesClient.List(): %v", err)
+	}
+	if roleScopes == nil || len(*roleScopes) != 1 {
+		t.Fatalf("AccessPackageResourceRoleScopesClient.List(): expected 1 resource role scope, got %v", roleScopes)
+	}
+	roleScope, _, err = roleScopesClient.Get(ctx, *accessPackage.ID, *roleScope.ID)
+	if err != nil {
+		t.Fatalf("AccessPackageResourceRoleScopesClient.Get(): %v", err)
+	}
+	if roleScope.Role == nil || *roleScope.Role.DisplayName != "Member" || roleScope.Scope == nil || !*roleScope.Scope.IsRootScope {
+		t.Errorf("AccessPackageResourceRoleScopesClient.Get(): unexpected resource role scope %v", roleScope)
+	}
+	expectRequests(t, server,
+		expectedRequest{http.MethodGet, roleScopesPath, ""},
+		expectedRequest{http.MethodGet, roleScopesPath + "/" + *roleScope.ID, ""},
+	)
+
+	if _, err := roleScopesClient.Delete(ctx, *accessPackage.ID, *roleScope.ID); err != nil {
+		t.Fatalf("AccessPackageResourceRoleScopesClient.Delete(): %v", err)
+	}
+	expectRequests(t, server, expectedRequest{http.MethodDelete, roleScopesPath + "/" + *roleScope.ID, ""})
+	if _, status, err := roleScopesClient.Get(ctx, *accessPackage.ID, *roleScope.ID); err == nil || status != http.StatusNotFound {
+		t.Errorf("AccessPackageResourceRoleScopesClient.Get(): expected status 404 for a deleted resource role scope, got %d", status)
+	}
+
+	if _, err := catalogsClient.Delete(ctx, *catalog.ID); err == nil {
+		t.Fatalf("AccessPackageCatalogsClient.Delete(): expected an error for a catalog containing an access package")
+	}
+	if _, err := client.Delete(ctx, *accessPackage.ID); err != nil {
+		t.Fatalf("AccessPackagesClient.Delete(): %v", err)
+	}
+	expectRequests(t, server,
+		expectedRequest{http.MethodGet, roleScopesPath + "/" + *roleScope.ID, ""},
+		expectedRequest{http.MethodDelete, "/identityGovernance/entitlementManagement/catalogs/" + *catalog.ID, ""},
+		expectedRequest{http.MethodDelete, accessPackagePath, ""},
+	)
+	if _, status, err := client.Get(ctx, *accessPackage.ID); err == nil || status != http.StatusNotFound {
+		t.Errorf("AccessPackagesClient.Get(): expected status 404 for a deleted access package, got %d", status)
+	}
+}
@@ -0,0 +1,157 @@
+package msgraph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/manicminer/hamilton/odata"
+)
+
+// AccessReviewsClient performs operations on Access Reviews.
+type AccessReviewsClient struct {
+	BaseClient Client
+}
+
+// NewAccessReviewsClient returns a new AccessReviewsClient.
+func NewAccessReviewsClient(tenantId string) *AccessReviewsClient {
+	return &AccessReviewsClient{
+		BaseClient: NewClient(Version10, tenantId),
+	}
+}
+
+// resource returns a Resource for performing common operations on Access Review definitions.
+func (c *AccessReviewsClient) resource() Resource {
+	return Resource{
+		Client: c.BaseClient,
+		Name:   "AccessReviewsClient",
+		Entity: "/identityGovernance/accessReviews/definitions",
+	}
+}
+
+// instancesResource returns a Resource for performing common operations on the instances of the specified Access
+// Review definition.
+func (c *AccessReviewsClient) instancesResource(definitionId string) Resource {
+	return Resource{
+		Client: c.BaseClient,
+		Name:   "AccessReviewsClient",
+		Entity: fmt.Sprintf("/identityGovernance/accessReviews/definitions/%s/instances", definitionId),
+	}
+}
+
+// decisionsResource returns a Resource for performing common operations on the decisions of the specified Access
+// Review instance.
+func (c *AccessReviewsClient) decisionsResource(definitionId, instanceId string) Resource {
+	return Resource{
+		Client: c.BaseClient,
+		Name:   "AccessReviewsClient",
+		Entity: fmt.Sprintf("/identityGovernance/accessReviews/definitions/%s/instances/%s/decisions", definitionId, instanceId),
+	}
+}
+
+// List returns a list of Access Review definitions, optionally queried using OData.
+func (c *AccessReviewsClient) List(ctx context.Context, query odata.Query) (*[]AccessReviewScheduleDefinition, int, error) {
+	var definitions []AccessReviewScheduleDefinition
+	status, err := c.resource().List(ctx, query, &definitions)
+	if err != nil {
+		return nil, status, err
+	}
+	return &definitions, status, nil
+}
+
+// Create creates a new Access Review definition. The Scope of the definition specifies what is reviewed, e.g. the
+// members of a group or the app role assignments of an application, and its Settings specify how often the review
+// recurs and how decisions are applied.
+func (c *AccessReviewsClient) Create(ctx context.Context, definition AccessReviewScheduleDefinition) (*AccessReviewScheduleDefinition, int, error) {
+	var newDefinition AccessReviewScheduleDefinition
+	status, err := c.resource().Create(ctx, definition, &newDefinition)
+	if err != nil {
+		return nil, status, err
+	}
+	return &newDefinition, status, nil
+}
+
+// Get retrieves an Access Review definition.
+func (c *AccessReviewsClient) Get(ctx context.Context, id string) (*AccessReviewScheduleDefinition, int, error) {
+	var definition AccessReviewScheduleDefinition
+	status, err := c.resource().Get(ctx, id, odata.Query{}, &definition)
+	if err != nil {
+		return nil, status, err
+	}
+	return &definition, status, nil
+}
+
+// Update amends an existing Access Review definition. The API replaces the definition, so all properties which should
+// be retained must be specified, e.g. by retrieving the definition with Get() and modifying it.
+func (c *AccessReviewsClient) Update(ctx context.Context, definition AccessReviewScheduleDefinition) (int, error) {
+	var status int
+	if definition.ID == nil {
+		return status, errors.New("AccessReviewsClient.Update(): cannot update access review definition with nil ID")
+	}
+	return c.resource().Replace(ctx, *definition.ID, definition)
+}
+
+// Delete removes an Access Review definition, along with its instances and decisions.
+func (c *AccessReviewsClient) Delete(ctx context.Context, id string) (int, error) {
+	return c.resource().Delete(ctx, id)
+}
+
+// ListInstances retrieves the instances of the specified Access Review definition, optionally queried using OData.
+// A recurring review has an instance for each recurrence.
+func (c *AccessReviewsClient) ListInstances(ctx context.Context, definitionId string, query odata.Query) (*[]AccessReviewInstance, int, error) {
+	var instances []AccessReviewInstance
+	status, err := c.instancesResource(definitionId).List(ctx, query, &instances)
+	if err != nil {
+		return nil, status, err
+	}
+	return &instances, status, nil
+}
+
+// GetInstance retrieves an instance of the specified Access Review definition.
+func (c *AccessReviewsClient) GetInstance(ctx context.Context, definitionId, instanceId string) (*AccessReviewInstance, int, error) {
+	var instance AccessReviewInstance
+	status, err := c.instancesResource(definitionId).Get(ctx, instanceId, odata.Query{}, &instance)
+	if err != nil {
+		return nil, status, err
+	}
+	return &instance, status, nil
+}
+
+// StopInstance ends an Access Review instance before its scheduled end date. Decisions can no longer be recorded once
+// the instance has stopped.
+func (c *AccessReviewsClient) StopInstance(ctx context.Context, definitionId, instanceId string) (int, error) {
+	return c.instancesResource(definitionId).Action(ctx, instanceId, "stop")
+}
+
+// ApplyInstanceDecisions applies the decisions of a completed Access Review instance, e.g. removing the group
+// memberships of principals whose access was denied.
+func (c *AccessReviewsClient) ApplyInstanceDecisions(ctx context.Context, definitionId, instanceId string) (int, error) {
+	return c.instancesResource(definitionId).Action(ctx, instanceId, "applyDecisions")
+}
+
+// ListDecisions retrieves the decisions of an Access Review instance, optionally queried using OData. There is a
+// decision for each principal whose access is reviewed.
+func (c *AccessReviewsClient) ListDecisions(ctx context.Context, definitionId, instanceId string, query odata.Query) (*[]AccessReviewInstanceDecisionItem, int, error) {
+	var decisions []AccessReviewInstanceDecisionItem
+	status, err := c.decisionsResource(definitionId, instanceId).List(ctx, query, &decisions)
+	if err != nil {
+		return nil, status, err
+	}
+	return &decisions, status, nil
+}
+
+// RecordDecision records the Decision and Justification of an Access Review decision item, which must have its ID set.
+func (c *AccessReviewsClient) RecordDecision(ctx context.Context, definitionId, instanceId string, decision AccessReviewInstanceDecisionItem) (int, error) {
+	var status int
+	if decision.ID == nil {
+		return status, errors.New("AccessReviewsClient.RecordDecision(): cannot record decision with nil ID")
+	}
+	if decision.Decision == nil {
+		return status, errors.New("AccessReviewsClient.RecordDecision(): cannot record decision with nil Decision")
+	}
+	data := AccessReviewInstanceDecisionItem{
+		Decision:      decision.Decision,
+		Justification: decision.Justification,
+	}
+	return c.decisionsResource(definitionId, instanceId).Update(ctx, *decision.ID, data)
+}
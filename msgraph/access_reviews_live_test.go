@@ -0,0 +1,146 @@
+//go:build live
+// +build live
+
+package msgraph_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/manicminer/hamilton/auth"
+	"github.com/manicminer/hamilton/internal/test"
+	"github.com/manicminer/hamilton/internal/utils"
+	"github.com/manicminer/hamilton/msgraph"
+	"github.com/manicminer/hamilton/odata"
+)
+
+type AccessReviewsClientTest struct {
+	connection   *test.Connection
+	client       *msgraph.AccessReviewsClient
+	randomString string
+}
+
+// TestAccessReviewsClient_Live requires a tenant licensed for Identity Governance.
+func TestAccessReviewsClient_Live(t *testing.T) {
+	rs := test.RandomString()
+	c := AccessReviewsClientTest{
+		connection:   test.NewConnection(auth.MsGraph, auth.TokenVersion2),
+		randomString: rs,
+	}
+	c.client = msgraph.NewAccessReviewsClient(c.connection.AuthConfig.TenantID)
+	c.client.BaseClient.Authorizer = c.connection.Authorizer
+
+	g := GroupsClientTest{
+		connection:   test.NewConnection(auth.MsGraph, auth.TokenVersion2),
+		randomString: rs,
+	}
+	g.client = msgraph.NewGroupsClient(g.connection.AuthConfig.TenantID)
+	g.client.BaseClient.Authorizer = g.connection.Authorizer
+
+	group := testGroupsClient_Create(t, g, msgraph.Group{
+		DisplayName:     utils.StringPtr("test-group-access-review"),
+		MailEnabled:     utils.BoolPtr(false),
+		MailNickname:    utils.StringPtr(fmt.Sprintf("test-group-access-review-%s", c.randomString)),
+		SecurityEnabled: utils.BoolPtr(true),
+	})
+
+	patternType := msgraph.RecurrencePatternTypeWeekly
+	rangeType := msgraph.RecurrenceRangeTypeNoEnd
+	definition := testAccessReviewsClient_Create(t, c, msgraph.AccessReviewScheduleDefinition{
+		DisplayName: utils.StringPtr(fmt.Sprintf("test-access-review-%s", c.randomString)),
+		Scope: &msgraph.AccessReviewScope{
+			ODataType: utils.StringPtr(msgraph.AccessReviewScopeQuery),
+			Query:     utils.StringPtr(fmt.Sprintf("/groups/%s/transitiveMembers", *group.ID)),
+			QueryType: utils.StringPtr("MicrosoftGraph"),
+		},
+		Reviewers: &[]msgraph.AccessReviewReviewerScope{
+			{
+				Query:     utils.StringPtr(fmt.Sprintf("/groups/%s/owners", *group.ID)),
+				QueryType: utils.StringPtr("MicrosoftGraph"),
+			},
+		},
+		Settings: &msgraph.AccessReviewScheduleSettings{
+			InstanceDurationInDays: utils.Int32Ptr(3),
+			Recurrence: &msgraph.PatternedRecurrence{
+				Pattern: &msgraph.RecurrencePattern{
+					Type:     &patternType,
+					Interval: utils.Int32Ptr(1),
+				},
+				Range: &msgraph.RecurrenceRange{
+					Type:      &rangeType,
+					StartDate: utils.StringPtr(time.Now().Format("2006-01-02")),
+				},
+			},
+		},
+	})
+	definition = testAccessReviewsClient_Get(t, c, *definition.ID)
+	definition.DescriptionForReviewers = utils.StringPtr("please review the members of this group")
+	testAccessReviewsClient_Update(t, c, *definition)
+	testAccessReviewsClient_ListInstances(t, c, *definition.ID)
+
+	testAccessReviewsClient_Delete(t, c, *definition.ID)
+	testGroupsClient_Delete(t, g, *group.ID)
+}
+
+func testAccessReviewsClient_Create(t *testing.T, c AccessReviewsClientTest, d msgraph.AccessReviewScheduleDefinition) (definition *msgraph.AccessReviewScheduleDefinition) {
+	definition, status, err := c.client.Create(c.connection.Context, d)
+	if err != nil {
+		t.Fatalf("AccessReviewsClient.Create(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("AccessReviewsClient.Create(): invalid status: %d", status)
+	}
+	if definition == nil {
+		t.Fatal("AccessReviewsClient.Create(): definition was nil")
+	}
+	if definition.ID == nil {
+		t.Fatal("AccessReviewsClient.Create(): definition.ID was nil")
+	}
+	return
+}
+
+func testAccessReviewsClient_Get(t *testing.T, c AccessReviewsClientTest, id string) (definition *msgraph.AccessReviewScheduleDefinition) {
+	definition, status, err := c.client.Get(c.connection.Context, id)
+	if err != nil {
+		t.Fatalf("AccessReviewsClient.Get(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("AccessReviewsClient.Get(): invalid status: %d", status)
+	}
+	if definition == nil {
+		t.Fatal("AccessReviewsClient.Get(): definition was nil")
+	}
+	return
+}
+
+func testAccessReviewsClient_Update(t *testing.T, c AccessReviewsClientTest, d msgraph.AccessReviewScheduleDefinition) {
+	status, err := c.client.Update(c.connection.Context, d)
+	if err != nil {
+		t.Fatalf("AccessReviewsClient.Update(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("AccessReviewsClient.Update(): invalid status: %d", status)
+	}
+}
+
+func testAccessReviewsClient_ListInstances(t *testing.T, c AccessReviewsClientTest, id string) (instances *[]msgraph.AccessReviewInstance) {
+	instances, _, err := c.client.ListInstances(c.connection.Context, id, odata.Query{})
+	if err != nil {
+		t.Fatalf("AccessReviewsClient.ListInstances(): %v", err)
+	}
+	if instances == nil {
+		t.Fatal("AccessReviewsClient.ListInstances(): instances was nil")
+	}
+	return
+}
+
+func testAccessReviewsClient_Delete(t *testing.T, c AccessReviewsClientTest, id string) {
+	status, err := c.client.Delete(c.connection.Context, id)
+	if err != nil {
+		t.Fatalf("AccessReviewsClient.Delete(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("AccessReviewsClient.Delete(): invalid status: %d", status)
+	}
+}
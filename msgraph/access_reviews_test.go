@@ -0,0 +1,225 @@
+package msgraph_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/manicminer/hamilton/internal/utils"
+	"github.com/manicminer/hamilton/msgraph"
+	"github.com/manicminer/hamilton/msgraph/msgraphtest"
+	"github.com/manicminer/hamilton/odata"
+)
+
+func TestAccessReviewsClient(t *testing.T) {
+	server := msgraphtest.NewServer()
+	defer server.Close()
+	ctx := context.Background()
+
+	client := msgraph.NewAccessReviewsClient("tenant")
+	client.BaseClient.Endpoint = server.Endpoint()
+	groupsClient := msgraph.NewGroupsClient("tenant")
+	groupsClient.BaseClient.Endpoint = server.Endpoint()
+
+	groupId := server.Add("groups", msgraph.Group{
+		DisplayName:     utils.StringPtr("test-group"),
+		MailNickname:    utils.StringPtr("test-group"),
+		SecurityEnabled: utils.BoolPtr(true),
+	})
+	group := msgraph.Group{ID: utils.StringPtr(groupId)}
+	for i := 0; i < 2; i++ {
+		userId := server.Add("users", msgraph.User{
+			DisplayName:       utils.StringPtr(fmt.Sprintf("test-user-%d", i)),
+			UserPrincipalName: utils.StringPtr(fmt.Sprintf("test-user-%d@example.com", i)),
+		})
+		group.AppendMember(server.Endpoint(), groupsClient.BaseClient.ApiVersion, userId)
+	}
+	if _, err := groupsClient.AddMembers(ctx, &group); err != nil {
+		t.Fatalf("GroupsClient.AddMembers(): %v", err)
+	}
+	server.ClearRequests()
+	definitionsPath := "/identityGovernance/accessReviews/definitions"
+
+	if _, _, err := client.Create(ctx, msgraph.AccessReviewScheduleDefinition{
+		DisplayName: utils.StringPtr("test-access-review"),
+	}); err == nil {
+		t.Fatalf("AccessReviewsClient.Create(): expected an error for a definition with nil Scope")
+	}
+
+	patternType := msgraph.RecurrencePatternTypeWeekly
+	rangeType := msgraph.RecurrenceRangeTypeNoEnd
+	definition, status, err := client.Create(ctx, msgraph.AccessReviewScheduleDefinition{
+		DisplayName: utils.StringPtr("test-access-review"),
+		Scope: &msgraph.AccessReviewScope{
+			ODataType: utils.StringPtr(msgraph.AccessReviewScopeQuery),
+			Query:     utils.StringPtr(fmt.Sprintf("/groups/%s/transitiveMembers", groupId)),
+			QueryType: utils.StringPtr("MicrosoftGraph"),
+		},
+		Reviewers: &[]msgraph.AccessReviewReviewerScope{
+			{
+				Query:     utils.StringPtr(fmt.Sprintf("/groups/%s/owners", groupId)),
+				QueryType: utils.StringPtr("MicrosoftGraph"),
+			},
+		},
+		Settings: &msgraph.AccessReviewScheduleSettings{
+			ApplyActions: &[]msgraph.AccessReviewApplyAction{
+				{ODataType: utils.StringPtr(msgraph.AccessReviewApplyActionRemoveAccess)},
+			},
+			InstanceDurationInDays: utils.Int32Ptr(3),
+			Recurrence: &msgraph.PatternedRecurrence{
+				Pattern: &msgraph.RecurrencePattern{
+					Type:     &patternType,
+					Interval: utils.Int32Ptr(1),
+				},
+				Range: &msgraph.RecurrenceRange{
+					Type:      &rangeType,
+					StartDate: utils.StringPtr("2022-01-01"),
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("AccessReviewsClient.Create(): %v", err)
+	}
+	if status != http.StatusCreated || definition.ID == nil || definition.Status == nil || *definition.Status != "InProgress" {
+		t.Fatalf("AccessReviewsClient.Create(): unexpected definition %v with status %d", definition, status)
+	}
+	expectRequests(t, server,
+		expectedRequest{http.MethodPost, definitionsPath, `{"displayName": "test-access-review"}`},
+		expectedRequest{http.MethodPost, definitionsPath, fmt.Sprintf(`{
+			"displayName": "test-access-review",
+			"scope": {"@odata.type": "#microsoft.graph.accessReviewQueryScope", "query": "/groups/%[1]s/transitiveMembers", "queryType": "MicrosoftGraph"},
+			"reviewers": [{"query": "/groups/%[1]s/owners", "queryType": "MicrosoftGraph"}],
+			"settings": {
+				"applyActions": [{"@odata.type": "#microsoft.graph.removeAccessApplyAction"}],
+				"instanceDurationInDays": 3,
+				"recurrence": {"pattern": {"type": "weekly", "interval": 1}, "range": {"type": "noEnd", "startDate": "2022-01-01"}}
+			}
+		}`, groupId)},
+	)
+	definitionPath := definitionsPath + "/" + *definition.ID
+
+	definition.DescriptionForReviewers = utils.StringPtr("please review the members of test-group")
+	if _, err := client.Update(ctx, *definition); err != nil {
+		t.Fatalf("AccessReviewsClient.Update(): %v", err)
+	}
+	if _, err := client.Update(ctx, msgraph.AccessReviewScheduleDefinition{}); err == nil {
+		t.Fatalf("AccessReviewsClient.Update(): expected an error for a definition with nil ID")
+	}
+	expectRequests(t, server, expectedRequest{http.MethodPut, definitionPath, `{"displayName": "test-access-review", "descriptionForReviewers": "please review the members of test-group"}`})
+
+	got, _, err := client.Get(ctx, *definition.ID)
+	if err != nil {
+		t.Fatalf("AccessReviewsClient.Get(): %v", err)
+	}
+	if got.DescriptionForReviewers == nil || *got.DescriptionForReviewers != *definition.DescriptionForReviewers {
+		t.Errorf("AccessReviewsClient.Get(): expected the updated description, got %v", got.DescriptionForReviewers)
+	}
+	if got.Settings == nil || got.Settings.Recurrence == nil || *got.Settings.Recurrence.Pattern.Type != patternType {
+		t.Errorf("AccessReviewsClient.Get(): unexpected settings %v", got.Settings)
+	}
+
+	definitions, _, err := client.List(ctx, odata.Query{})
+	if err != nil {
+		t.Fatalf("AccessReviewsClient.List(): %v", err)
+	}
+	if definitions == nil || len(*definitions) != 1 {
+		t.Fatalf("AccessReviewsClient.List(): expected 1 definition, got %v", definitions)
+	}
+
+	instances, _, err := client.ListInstances(ctx, *definition.ID, odata.Query{})
+	if err != nil {
+		t.Fatalf("AccessReviewsClient.ListInstances(): %v", err)
+	}
+	if instances == nil || len(*instances) != 1 {
+		t.Fatalf("AccessReviewsClient.ListInstances(): expected 1 instance, got %v", instances)
+	}
+	instance, _, err := client.GetInstance(ctx, *definition.ID, *(*instances)[0].ID)
+	if err != nil {
+		t.Fatalf("AccessReviewsClient.GetInstance(): %v", err)
+	}
+	if instance.Status == nil || *instance.Status != "InProgress" {
+		t.Fatalf("AccessReviewsClient.GetInstance(): unexpected instance %v", instance)
+	}
+	instancePath := fmt.Sprintf("%s/instances/%s", definitionPath, *instance.ID)
+	expectRequests(t, server,
+		expectedRequest{http.MethodGet, definitionPath, ""},
+		expectedRequest{http.MethodGet, definitionsPath, ""},
+		expectedRequest{http.MethodGet, definitionPath + "/instances", ""},
+		expectedRequest{http.MethodGet, instancePath, ""},
+	)
+
+	decisions, _, err := client.ListDecisions(ctx, *definition.ID, *instance.ID, odata.Query{})
+	if err != nil {
+		t.Fatalf("AccessReviewsClient.ListDecisions(): %v", err)
+	}
+	if decisions == nil || len(*decisions) != 2 {
+		t.Fatalf("AccessReviewsClient.ListDecisions(): expected 2 decisions, got %v", decisions)
+	}
+
+	if _, err := client.RecordDecision(ctx, *definition.ID, *instance.ID, msgraph.AccessReviewInstanceDecisionItem{ID: (*decisions)[0].ID}); err == nil {
+		t.Fatalf("AccessReviewsClient.RecordDecision(): expected an error for a decision item with nil Decision")
+	}
+	deniedUserId := ""
+	for i, d := range *decisions {
+		decision := msgraph.AccessReviewDecisionApprove
+		if i == 0 {
+			decision = msgraph.AccessReviewDecisionDeny
+			deniedUserId = *d.Principal.ID
+		}
+		if _, err := client.RecordDecision(ctx, *definition.ID, *instance.ID, msgraph.AccessReviewInstanceDecisionItem{
+			ID:            d.ID,
+			Decision:      &decision,
+			Justification: utils.StringPtr("reviewed by the test suite"),
+		}); err != nil {
+			t.Fatalf("AccessReviewsClient.RecordDecision(): %v", err)
+		}
+	}
+	expectRequests(t, server,
+		expectedRequest{http.MethodGet, instancePath + "/decisions", ""},
+		expectedRequest{http.MethodPatch, fmt.Sprintf("%s/decisions/%s", instancePath, *(*decisions)[0].ID), `{"decision": "Deny", "justification": "reviewed by the test suite"}`},
+		expectedRequest{http.MethodPatch, fmt.Sprintf("%s/decisions/%s", instancePath, *(*decisions)[1].ID), `{"decision": "Approve", "justification": "reviewed by the test suite"}`},
+	)
+
+	if _, err := client.ApplyInstanceDecisions(ctx, *definition.ID, *instance.ID); err == nil {
+		t.Fatalf("AccessReviewsClient.ApplyInstanceDecisions(): expected an error for an instance which is in progress")
+	}
+	if _, err := client.StopInstance(ctx, *definition.ID, *instance.ID); err != nil {
+		t.Fatalf("AccessReviewsClient.StopInstance(): %v", err)
+	}
+	if _, err := client.ApplyInstanceDecisions(ctx, *definition.ID, *instance.ID); err != nil {
+		t.Fatalf("AccessReviewsClient.ApplyInstanceDecisions(): %v", err)
+	}
+	expectRequests(t, server,
+		expectedRequest{http.MethodPost, instancePath + "/applyDecisions", ""},
+		expectedRequest{http.MethodPost, instancePath + "/stop", ""},
+		expectedRequest{http.MethodPost, instancePath + "/applyDecisions", ""},
+	)
+
+	decisions, _, err = client.ListDecisions(ctx, *definition.ID, *instance.ID, odata.Query{})
+	if err != nil {
+		t.Fatalf("AccessReviewsClient.ListDecisions(): %v", err)
+	}
+	for _, d := range *decisions {
+		if d.ApplyResult == nil || *d.ApplyResult != "AppliedSuccessfully" {
+			t.Errorf("AccessReviewsClient.ApplyInstanceDecisions(): unexpected result %v for decision %s", d.ApplyResult, *d.ID)
+		}
+	}
+	members, _, err := groupsClient.ListMembers(ctx, groupId)
+	if err != nil {
+		t.Fatalf("GroupsClient.ListMembers(): %v", err)
+	}
+	if members == nil || len(*members) != 1 || *(*members)[0].GetID() == deniedUserId {
+		t.Errorf("AccessReviewsClient.ApplyInstanceDecisions(): expected the denied user to be removed from the group, got %v", members)
+	}
+	server.ClearRequests()
+
+	if _, err := client.Delete(ctx, *definition.ID); err != nil {
+		t.Fatalf("AccessReviewsClient.Delete(): %v", err)
+	}
+	expectRequests(t, server, expectedRequest{http.MethodDelete, definitionPath, ""})
+	if _, status, err := client.Get(ctx, *definition.ID); err == nil || status != http.StatusNotFound {
+		t.Errorf("AccessReviewsClient.Get(): expected status 404 for a deleted definition, got %d", status)
+	}
+}
@@ -0,0 +1,185 @@
+package msgraph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/manicminer/hamilton/odata"
+)
+
+// AdministrativeUnitsClient performs operations on Administrative Units.
+type AdministrativeUnitsClient struct {
+	BaseClient Client
+}
+
+// NewAdministrativeUnitsClient returns a new AdministrativeUnitsClient.
+func NewAdministrativeUnitsClient(tenantId string) *AdministrativeUnitsClient {
+	return &AdministrativeUnitsClient{
+		BaseClient: NewClient(VersionBeta, tenantId),
+	}
+}
+
+// resource returns a Resource for performing common operations on Administrative Units.
+func (c *AdministrativeUnitsClient) resource() Resource {
+	return Resource{
+		Client: c.BaseClient,
+		Name:   "AdministrativeUnitsClient",
+		Entity: "/administrativeUnits",
+	}
+}
+
+// scopedRoleMembersResource returns a Resource for performing common operations on the scoped role members of the
+// specified Administrative Unit.
+func (c *AdministrativeUnitsClient) scopedRoleMembersResource(administrativeUnitId string) Resource {
+	return Resource{
+		Client: c.BaseClient,
+		Name:   "AdministrativeUnitsClient",
+		Entity: fmt.Sprintf("/administrativeUnits/%s/scopedRoleMembers", administrativeUnitId),
+	}
+}
+
+// List returns a list of Administrative Units, optionally queried using OData.
+func (c *AdministrativeUnitsClient) List(ctx context.Context, query odata.Query) (*[]AdministrativeUnit, int, error) {
+	var administrativeUnits []AdministrativeUnit
+	status, err := c.resource().List(ctx, query, &administrativeUnits)
+	if err != nil {
+		return nil, status, err
+	}
+	return &administrativeUnits, status, nil
+}
+
+// ListPager returns a Pager for retrieving Administrative Units one page at a time, optionally queried using OData.
+func (c *AdministrativeUnitsClient) ListPager(query odata.Query) *Pager {
+	return c.resource().ListPager(query)
+}
+
+// Create creates a new Administrative Unit.
+func (c *AdministrativeUnitsClient) Create(ctx context.Context, administrativeUnit AdministrativeUnit) (*AdministrativeUnit, int, error) {
+	var newAdministrativeUnit AdministrativeUnit
+	status, err := c.resource().Create(ctx, administrativeUnit, &newAdministrativeUnit)
+	if err != nil {
+		return nil, status, err
+	}
+	return &newAdministrativeUnit, status, nil
+}
+
+// Get retrieves an Administrative Unit.
+func (c *AdministrativeUnitsClient) Get(ctx context.Context, id string) (*AdministrativeUnit, int, error) {
+	var administrativeUnit AdministrativeUnit
+	status, err := c.resource().Get(ctx, id, odata.Query{}, &administrativeUnit)
+	if err != nil {
+		return nil, status, err
+	}
+	return &administrativeUnit, status, nil
+}
+
+// Update amends an existing Administrative Unit.
+func (c *AdministrativeUnitsClient) Update(ctx context.Context, administrativeUnit AdministrativeUnit) (int, error) {
+	var status int
+	if administrativeUnit.ID == nil {
+		return status, errors.New("AdministrativeUnitsClient.Update(): cannot update administrative unit with nil ID")
+	}
+	return c.resource().Update(ctx, *administrativeUnit.ID, administrativeUnit)
+}
+
+// Delete removes an Administrative Unit.
+func (c *AdministrativeUnitsClient) Delete(ctx context.Context, id string) (int, error) {
+	return c.resource().Delete(ctx, id)
+}
+
+// ListMembers retrieves the members of the specified Administrative Unit. Each member is returned as the model for its
+// type, e.g. *User, *Group or *Device.
+// id is the object ID of the administrative unit.
+func (c *AdministrativeUnitsClient) ListMembers(ctx context.Context, id string) (*[]DirectoryObject, int, error) {
+	var members DirectoryObjects
+	status, err := c.resource().list(ctx, fmt.Sprintf("/administrativeUnits/%s/members", id), odata.Query{}, &members)
+	if err != nil {
+		return nil, status, err
+	}
+	ret := []DirectoryObject(members)
+	return &ret, status, nil
+}
+
+// AddMembers adds new members to an Administrative Unit. Users, groups and devices can be members.
+// First populate the Members field of the AdministrativeUnit using the AppendMember method of the model, then call this method.
+func (c *AdministrativeUnitsClient) AddMembers(ctx context.Context, administrativeUnit *AdministrativeUnit) (int, error) {
+	var status int
+	if administrativeUnit.ID == nil {
+		return status, errors.New("cannot update administrative unit with nil ID")
+	}
+	if administrativeUnit.Members == nil {
+		return status, errors.New("cannot update administrative unit with nil Members")
+	}
+	status, err := c.BaseClient.batchAddRefs(ctx, fmt.Sprintf("/administrativeUnits/%s/members", *administrativeUnit.ID), *administrativeUnit.Members)
+	if err != nil {
+		return status, fmt.Errorf("AdministrativeUnitsClient.BaseClient.Batch(): %w", err)
+	}
+	return status, nil
+}
+
+// RemoveMembers removes members from an Administrative Unit.
+// administrativeUnitId is the object ID of the Administrative Unit.
+// memberIds is a *[]string containing object IDs of members to remove.
+func (c *AdministrativeUnitsClient) RemoveMembers(ctx context.Context, administrativeUnitId string, memberIds *[]string) (int, error) {
+	var status int
+	if memberIds == nil {
+		return status, errors.New("cannot remove, nil memberIds")
+	}
+	status, err := c.BaseClient.batchRemoveRefs(ctx, fmt.Sprintf("/administrativeUnits/%s/members", administrativeUnitId), *memberIds)
+	if err != nil {
+		return status, fmt.Errorf("AdministrativeUnitsClient.BaseClient.Batch(): %w", err)
+	}
+	return status, nil
+}
+
+// ListScopedRoleMembers retrieves the directory roles assigned to principals with the scope of the specified
+// Administrative Unit, optionally queried using OData.
+// id is the object ID of the administrative unit.
+func (c *AdministrativeUnitsClient) ListScopedRoleMembers(ctx context.Context, id string, query odata.Query) (*[]ScopedRoleMembership, int, error) {
+	var memberships []ScopedRoleMembership
+	status, err := c.scopedRoleMembersResource(id).List(ctx, query, &memberships)
+	if err != nil {
+		return nil, status, err
+	}
+	return &memberships, status, nil
+}
+
+// GetScopedRoleMember retrieves a single scoped role membership of the specified Administrative Unit.
+// administrativeUnitId is the object ID of the administrative unit.
+// scopedRoleMembershipId is the ID of the scoped role membership.
+func (c *AdministrativeUnitsClient) GetScopedRoleMember(ctx context.Context, administrativeUnitId, scopedRoleMembershipId string) (*ScopedRoleMembership, int, error) {
+	var membership ScopedRoleMembership
+	status, err := c.scopedRoleMembersResource(administrativeUnitId).Get(ctx, scopedRoleMembershipId, odata.Query{}, &membership)
+	if err != nil {
+		return nil, status, err
+	}
+	return &membership, status, nil
+}
+
+// AddScopedRoleMember assigns a directory role to a principal, with the scope of the specified Administrative Unit.
+// The RoleId of the membership is the object ID of an activated directory role, as returned by
+// DirectoryRolesClient{}.Activate(), and the ID of its RoleMemberInfo is the object ID of the principal.
+// administrativeUnitId is the object ID of the administrative unit.
+func (c *AdministrativeUnitsClient) AddScopedRoleMember(ctx context.Context, administrativeUnitId string, membership ScopedRoleMembership) (*ScopedRoleMembership, int, error) {
+	var status int
+	if membership.RoleId == nil {
+		return nil, status, errors.New("AdministrativeUnitsClient.AddScopedRoleMember(): cannot add scoped role member with nil RoleId")
+	}
+	if membership.RoleMemberInfo == nil || membership.RoleMemberInfo.ID == nil {
+		return nil, status, errors.New("AdministrativeUnitsClient.AddScopedRoleMember(): cannot add scoped role member with nil RoleMemberInfo ID")
+	}
+	var newMembership ScopedRoleMembership
+	status, err := c.scopedRoleMembersResource(administrativeUnitId).Create(ctx, membership, &newMembership)
+	if err != nil {
+		return nil, status, err
+	}
+	return &newMembership, status, nil
+}
+
+// RemoveScopedRoleMember removes a scoped role membership from the specified Administrative Unit.
+// administrativeUnitId is the object ID of the administrative unit.
+// scopedRoleMembershipId is the ID of the scoped role membership.
+func (c *AdministrativeUnitsClient) RemoveScopedRoleMember(ctx context.Context, administrativeUnitId, scopedRoleMembershipId string) (int, error) {
+	return c.scopedRoleMembersResource(administrativeUnitId).Delete(ctx, scopedRoleMembershipId)
+}
@@ -0,0 +1,159 @@
+//go:build live
+// +build live
+
+package msgraph_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/manicminer/hamilton/auth"
+	"github.com/manicminer/hamilton/internal/test"
+	"github.com/manicminer/hamilton/internal/utils"
+	"github.com/manicminer/hamilton/msgraph"
+	"github.com/manicminer/hamilton/odata"
+)
+
+type AdministrativeUnitsClientTest struct {
+	connection   *test.Connection
+	client       *msgraph.AdministrativeUnitsClient
+	randomString string
+}
+
+func TestAdministrativeUnitsClient_Live(t *testing.T) {
+	rs := test.RandomString()
+	c := AdministrativeUnitsClientTest{
+		connection:   test.NewConnection(auth.MsGraph, auth.TokenVersion2),
+		randomString: rs,
+	}
+	c.client = msgraph.NewAdministrativeUnitsClient(c.connection.AuthConfig.TenantID)
+	c.client.BaseClient.Authorizer = c.connection.Authorizer
+
+	g := GroupsClientTest{
+		connection:   test.NewConnection(auth.MsGraph, auth.TokenVersion2),
+		randomString: rs,
+	}
+	g.client = msgraph.NewGroupsClient(g.connection.AuthConfig.TenantID)
+	g.client.BaseClient.Authorizer = g.connection.Authorizer
+
+	au := testAdministrativeUnitsClient_Create(t, c, msgraph.AdministrativeUnit{
+		DisplayName: utils.StringPtr(fmt.Sprintf("test-administrative-unit-%s", c.randomString)),
+	})
+	testAdministrativeUnitsClient_Get(t, c, *au.ID)
+	au.Description = utils.StringPtr("managed by the test suite")
+	testAdministrativeUnitsClient_Update(t, c, msgraph.AdministrativeUnit{ID: au.ID, Description: au.Description})
+	testAdministrativeUnitsClient_List(t, c)
+
+	group := testGroupsClient_Create(t, g, msgraph.Group{
+		DisplayName:     utils.StringPtr("test-group-administrative-unit"),
+		MailEnabled:     utils.BoolPtr(false),
+		MailNickname:    utils.StringPtr(fmt.Sprintf("test-group-administrative-unit-%s", c.randomString)),
+		SecurityEnabled: utils.BoolPtr(true),
+	})
+	au.AppendMember(c.client.BaseClient.Endpoint, c.client.BaseClient.ApiVersion, *group.ID)
+	testAdministrativeUnitsClient_AddMembers(t, c, au)
+	testAdministrativeUnitsClient_ListMembers(t, c, *au.ID, *group.ID)
+	testAdministrativeUnitsClient_RemoveMembers(t, c, *au.ID, []string{*group.ID})
+
+	testGroupsClient_Delete(t, g, *group.ID)
+	testAdministrativeUnitsClient_Delete(t, c, *au.ID)
+}
+
+func testAdministrativeUnitsClient_Create(t *testing.T, c AdministrativeUnitsClientTest, a msgraph.AdministrativeUnit) (administrativeUnit *msgraph.AdministrativeUnit) {
+	administrativeUnit, status, err := c.client.Create(c.connection.Context, a)
+	if err != nil {
+		t.Fatalf("AdministrativeUnitsClient.Create(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("AdministrativeUnitsClient.Create(): invalid status: %d", status)
+	}
+	if administrativeUnit == nil {
+		t.Fatal("AdministrativeUnitsClient.Create(): administrativeUnit was nil")
+	}
+	if administrativeUnit.ID == nil {
+		t.Fatal("AdministrativeUnitsClient.Create(): administrativeUnit.ID was nil")
+	}
+	return
+}
+
+func testAdministrativeUnitsClient_Get(t *testing.T, c AdministrativeUnitsClientTest, id string) (administrativeUnit *msgraph.AdministrativeUnit) {
+	administrativeUnit, status, err := c.client.Get(c.connection.Context, id)
+	if err != nil {
+		t.Fatalf("AdministrativeUnitsClient.Get(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("AdministrativeUnitsClient.Get(): invalid status: %d", status)
+	}
+	if administrativeUnit == nil {
+		t.Fatal("AdministrativeUnitsClient.Get(): administrativeUnit was nil")
+	}
+	return
+}
+
+func testAdministrativeUnitsClient_Update(t *testing.T, c AdministrativeUnitsClientTest, a msgraph.AdministrativeUnit) {
+	status, err := c.client.Update(c.connection.Context, a)
+	if err != nil {
+		t.Fatalf("AdministrativeUnitsClient.Update(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("AdministrativeUnitsClient.Update(): invalid status: %d", status)
+	}
+}
+
+func testAdministrativeUnitsClient_List(t *testing.T, c AdministrativeUnitsClientTest) (administrativeUnits *[]msgraph.AdministrativeUnit) {
+	administrativeUnits, _, err := c.client.List(c.connection.Context, odata.Query{Top: 10})
+	if err != nil {
+		t.Fatalf("AdministrativeUnitsClient.List(): %v", err)
+	}
+	if administrativeUnits == nil {
+		t.Fatal("AdministrativeUnitsClient.List(): administrativeUnits was nil")
+	}
+	return
+}
+
+func testAdministrativeUnitsClient_AddMembers(t *testing.T, c AdministrativeUnitsClientTest, a *msgraph.AdministrativeUnit) {
+	status, err := c.client.AddMembers(c.connection.Context, a)
+	if err != nil {
+		t.Fatalf("AdministrativeUnitsClient.AddMembers(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("AdministrativeUnitsClient.AddMembers(): invalid status: %d", status)
+	}
+}
+
+func testAdministrativeUnitsClient_ListMembers(t *testing.T, c AdministrativeUnitsClientTest, id, expectedId string) (members *[]msgraph.DirectoryObject) {
+	members, _, err := c.client.ListMembers(c.connection.Context, id)
+	if err != nil {
+		t.Fatalf("AdministrativeUnitsClient.ListMembers(): %v", err)
+	}
+	if members == nil {
+		t.Fatal("AdministrativeUnitsClient.ListMembers(): members was nil")
+	}
+	for _, member := range *members {
+		if memberId := member.GetID(); memberId != nil && *memberId == expectedId {
+			return
+		}
+	}
+	t.Fatalf("AdministrativeUnitsClient.ListMembers(): expected member %q in result", expectedId)
+	return
+}
+
+func testAdministrativeUnitsClient_RemoveMembers(t *testing.T, c AdministrativeUnitsClientTest, id string, memberIds []string) {
+	status, err := c.client.RemoveMembers(c.connection.Context, id, &memberIds)
+	if err != nil {
+		t.Fatalf("AdministrativeUnitsClient.RemoveMembers(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("AdministrativeUnitsClient.RemoveMembers(): invalid status: %d", status)
+	}
+}
+
+func testAdministrativeUnitsClient_Delete(t *testing.T, c AdministrativeUnitsClientTest, id string) {
+	status, err := c.client.Delete(c.connection.Context, id)
+	if err != nil {
+		t.Fatalf("AdministrativeUnitsClient.Delete(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("AdministrativeUnitsClient.Delete(): invalid status: %d", status)
+	}
+}
@@ -0,0 +1,185 @@
+package msgraph_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/manicminer/hamilton/internal/utils"
+	"github.com/manicminer/hamilton/msgraph"
+	"github.com/manicminer/hamilton/msgraph/msgraphtest"
+	"github.com/manicminer/hamilton/odata"
+)
+
+func TestAdministrativeUnitsClient(t *testing.T) {
+	server := msgraphtest.NewServer()
+	defer server.Close()
+	ctx := context.Background()
+
+	client := msgraph.NewAdministrativeUnitsClient("tenant")
+	client.BaseClient.Endpoint = server.Endpoint()
+	rolesClient := msgraph.NewDirectoryRolesClient("tenant")
+	rolesClient.BaseClient.Endpoint = server.Endpoint()
+
+	visibility := msgraph.AdministrativeUnitVisibilityHiddenMembership
+	au, status, err := client.Create(ctx, msgraph.AdministrativeUnit{
+		DisplayName: utils.StringPtr("test-administrative-unit"),
+		Visibility:  &visibility,
+	})
+	if err != nil {
+		t.Fatalf("AdministrativeUnitsClient.Create(): %v", err)
+	}
+	if status != http.StatusCreated || au.ID == nil {
+		t.Fatalf("AdministrativeUnitsClient.Create(): expected a new administrative unit with status 201, got status %d", status)
+	}
+	expectRequests(t, server, expectedRequest{http.MethodPost, "/administrativeUnits", `{"displayName": "test-administrative-unit", "visibility": "HiddenMembership"}`})
+
+	au.Description = utils.StringPtr("managed by the test suite")
+	if _, err := client.Update(ctx, msgraph.AdministrativeUnit{ID: au.ID, Description: au.Description}); err != nil {
+		t.Fatalf("AdministrativeUnitsClient.Update(): %v", err)
+	}
+	if _, err := client.Update(ctx, msgraph.AdministrativeUnit{}); err == nil {
+		t.Fatalf("AdministrativeUnitsClient.Update(): expected an error for an administrative unit with nil ID")
+	}
+	expectRequests(t, server, expectedRequest{http.MethodPatch, "/administrativeUnits/" + *au.ID, `{"description": "managed by the test suite"}`})
+
+	got, _, err := client.Get(ctx, *au.ID)
+	if err != nil {
+		t.Fatalf("AdministrativeUnitsClient.Get(): %v", err)
+	}
+	if got.Description == nil || *got.Description != *au.Description || got.Visibility == nil || *got.Visibility != visibility {
+		t.Fatalf("AdministrativeUnitsClient.Get(): unexpected administrative unit %v", got)
+	}
+
+	aus, _, err := client.List(ctx, odata.Query{})
+	if err != nil {
+		t.Fatalf("AdministrativeUnitsClient.List(): %v", err)
+	}
+	if aus == nil || len(*aus) != 1 {
+		t.Fatalf("AdministrativeUnitsClient.List(): expected 1 administrative unit, got %v", aus)
+	}
+	expectRequests(t, server,
+		expectedRequest{http.MethodGet, "/administrativeUnits/" + *au.ID, ""},
+		expectedRequest{http.MethodGet, "/administrativeUnits", ""},
+	)
+
+	userId := server.Add("users", msgraph.User{
+		DisplayName:       utils.StringPtr("test-user"),
+		UserPrincipalName: utils.StringPtr("test-user@example.com"),
+	})
+	groupId := server.Add("groups", msgraph.Group{
+		DisplayName:  utils.StringPtr("test-group"),
+		MailNickname: utils.StringPtr("test-group"),
+	})
+	deviceId := server.Add("devices", msgraph.Device{
+		DeviceId:    utils.StringPtr("00000000-0000-0000-0000-000000000001"),
+		DisplayName: utils.StringPtr("test-device"),
+	})
+	for _, id := range []string{userId, groupId, deviceId} {
+		au.AppendMember(server.Endpoint(), client.BaseClient.ApiVersion, id)
+	}
+	if _, err := client.AddMembers(ctx, au); err != nil {
+		t.Fatalf("AdministrativeUnitsClient.AddMembers(): %v", err)
+	}
+	membersPath := fmt.Sprintf("/administrativeUnits/%s/members", *au.ID)
+	expectRequests(t, server,
+		expectedRequest{http.MethodPost, membersPath + "/$ref", fmt.Sprintf(`{"@odata.id": "%s/beta/directoryObjects/%s"}`, server.Endpoint(), userId)},
+		expectedRequest{http.MethodPost, membersPath + "/$ref", fmt.Sprintf(`{"@odata.id": "%s/beta/directoryObjects/%s"}`, server.Endpoint(), groupId)},
+		expectedRequest{http.MethodPost, membersPath + "/$ref", fmt.Sprintf(`{"@odata.id": "%s/beta/directoryObjects/%s"}`, server.Endpoint(), deviceId)},
+	)
+
+	members, _, err := client.ListMembers(ctx, *au.ID)
+	if err != nil {
+		t.Fatalf("AdministrativeUnitsClient.ListMembers(): %v", err)
+	}
+	if members == nil || len(*members) != 3 {
+		t.Fatalf("AdministrativeUnitsClient.ListMembers(): expected 3 members, got %v", members)
+	}
+	if _, ok := (*members)[0].(*msgraph.User); !ok {
+		t.Errorf("AdministrativeUnitsClient.ListMembers(): expected a *msgraph.User, got %#v", (*members)[0])
+	}
+	if _, ok := (*members)[1].(*msgraph.Group); !ok {
+		t.Errorf("AdministrativeUnitsClient.ListMembers(): expected a *msgraph.Group, got %#v", (*members)[1])
+	}
+	if _, ok := (*members)[2].(*msgraph.Device); !ok {
+		t.Errorf("AdministrativeUnitsClient.ListMembers(): expected a *msgraph.Device, got %#v", (*members)[2])
+	}
+	expectRequests(t, server, expectedRequest{http.MethodGet, membersPath, ""})
+
+	if _, err := client.RemoveMembers(ctx, *au.ID, &[]string{groupId, deviceId}); err != nil {
+		t.Fatalf("AdministrativeUnitsClient.RemoveMembers(): %v", err)
+	}
+	expectRequests(t, server,
+		expectedRequest{http.MethodDelete, fmt.Sprintf("%s/%s/$ref", membersPath, groupId), ""},
+		expectedRequest{http.MethodDelete, fmt.Sprintf("%s/%s/$ref", membersPath, deviceId), ""},
+	)
+	members, _, err = client.ListMembers(ctx, *au.ID)
+	if err != nil {
+		t.Fatalf("AdministrativeUnitsClient.ListMembers(): %v", err)
+	}
+	if len(*members) != 1 || *(*members)[0].GetID() != userId {
+		t.Errorf("AdministrativeUnitsClient.RemoveMembers(): expected only the test user to remain, got %v", members)
+	}
+
+	role, _, err := rolesClient.Activate(ctx, "fe930be7-5e62-47db-91af-98c3a49a38b1")
+	if err != nil {
+		t.Fatalf("DirectoryRolesClient.Activate(): %v", err)
+	}
+	server.ClearRequests()
+
+	if _, _, err := client.AddScopedRoleMember(ctx, *au.ID, msgraph.ScopedRoleMembership{RoleId: role.ID}); err == nil {
+		t.Fatalf("AdministrativeUnitsClient.AddScopedRoleMember(): expected an error for a membership with nil RoleMemberInfo")
+	}
+	membership, status, err := client.AddScopedRoleMember(ctx, *au.ID, msgraph.ScopedRoleMembership{
+		RoleId:         role.ID,
+		RoleMemberInfo: &msgraph.Identity{ID: utils.StringPtr(userId)},
+	})
+	if err != nil {
+		t.Fatalf("AdministrativeUnitsClient.AddScopedRoleMember(): %v", err)
+	}
+	if status != http.StatusCreated || membership.ID == nil || *membership.AdministrativeUnitId != *au.ID {
+		t.Fatalf("AdministrativeUnitsClient.AddScopedRoleMember(): unexpected membership %v with status %d", membership, status)
+	}
+	scopedRoleMembersPath := fmt.Sprintf("/administrativeUnits/%s/scopedRoleMembers", *au.ID)
+	expectRequests(t, server, expectedRequest{http.MethodPost, scopedRoleMembersPath, fmt.Sprintf(`{"roleId": %q, "roleMemberInfo": {"id": %q}}`, *role.ID, userId)})
+
+	memberships, _, err := client.ListScopedRoleMembers(ctx, *au.ID, odata.Query{})
+	if err != nil {
+		t.Fatalf("AdministrativeUnitsClient.ListScopedRoleMembers(): %v", err)
+	}
+	if memberships == nil || len(*memberships) != 1 {
+		t.Fatalf("AdministrativeUnitsClient.ListScopedRoleMembers(): expected 1 membership, got %v", memberships)
+	}
+
+	membership, _, err = client.GetScopedRoleMember(ctx, *au.ID, *membership.ID)
+	if err != nil {
+		t.Fatalf("AdministrativeUnitsClient.GetScopedRoleMember(): %v", err)
+	}
+	if *membership.RoleId != *role.ID || membership.RoleMemberInfo == nil || *membership.RoleMemberInfo.DisplayName != "test-user" {
+		t.Errorf("AdministrativeUnitsClient.GetScopedRoleMember(): unexpected membership %v", membership)
+	}
+	expectRequests(t, server,
+		expectedRequest{http.MethodGet, scopedRoleMembersPath, ""},
+		expectedRequest{http.MethodGet, fmt.Sprintf("%s/%s", scopedRoleMembersPath, *membership.ID), ""},
+	)
+
+	if _, err := client.RemoveScopedRoleMember(ctx, *au.ID, *membership.ID); err != nil {
+		t.Fatalf("AdministrativeUnitsClient.RemoveScopedRoleMember(): %v", err)
+	}
+	expectRequests(t, server, expectedRequest{http.MethodDelete, fmt.Sprintf("%s/%s", scopedRoleMembersPath, *membership.ID), ""})
+	if _, status, err := client.GetScopedRoleMember(ctx, *au.ID, *membership.ID); err == nil || status != http.StatusNotFound {
+		t.Errorf("AdministrativeUnitsClient.GetScopedRoleMember(): expected status 404 for a removed membership, got %d", status)
+	}
+
+	if _, err := client.Delete(ctx, *au.ID); err != nil {
+		t.Fatalf("AdministrativeUnitsClient.Delete(): %v", err)
+	}
+	expectRequests(t, server,
+		expectedRequest{http.MethodGet, fmt.Sprintf("%s/%s", scopedRoleMembersPath, *membership.ID), ""},
+		expectedRequest{http.MethodDelete, "/administrativeUnits/" + *au.ID, ""},
+	)
+	if _, status, err := client.Get(ctx, *au.ID); err == nil || status != http.StatusNotFound {
+		t.Errorf("AdministrativeUnitsClient.Get(): expected status 404 for a deleted administrative unit, got %d", status)
+	}
+}
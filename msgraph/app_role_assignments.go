@@ -48,28 +48,23 @@ func NewServicePrincipalsAppRoleAssignmentsClient(tenantId string) *AppRoleAssig
 
 // List returns a list of app role assignments.
 func (c *AppRoleAssignmentsClient) List(ctx context.Context, id string) (*[]AppRoleAssignment, int, error) {
-	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
+	var appRoleAssignments []AppRoleAssignment
+	status, err := c.ListPager(id).all(ctx, &appRoleAssignments)
+	if err != nil {
+		return nil, status, fmt.Errorf("AppRoleAssignmentsClient.BaseClient.Get(): %w", err)
+	}
+	return &appRoleAssignments, status, nil
+}
+
+// ListPager returns a Pager for retrieving app role assignments one page at a time.
+func (c *AppRoleAssignmentsClient) ListPager(id string) *Pager {
+	return c.BaseClient.NewPager(GetHttpRequestInput{
 		ValidStatusCodes: []int{http.StatusOK},
 		Uri: Uri{
 			Entity:      fmt.Sprintf("/%s/%s/appRoleAssignments", c.resourceType, id),
 			HasTenantId: true,
 		},
 	})
-	if err != nil {
-		return nil, status, fmt.Errorf("AppRoleAssignmentsClient.BaseClient.Get(): %v", err)
-	}
-	defer resp.Body.Close()
-	respBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, status, fmt.Errorf("ioutil.ReadAll(): %v", err)
-	}
-	var data struct {
-		AppRoleAssignments []AppRoleAssignment `json:"value"`
-	}
-	if err := json.Unmarshal(respBody, &data); err != nil {
-		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
-	}
-	return &data.AppRoleAssignments, status, nil
 }
 
 // Remove removes a app role assignment.
@@ -82,7 +77,7 @@ func (c *AppRoleAssignmentsClient) Remove(ctx context.Context, id, appRoleAssign
 		},
 	})
 	if err != nil {
-		return status, fmt.Errorf("AppRoleAssignmentsClient.BaseClient.Delete(): %v", err)
+		return status, fmt.Errorf("AppRoleAssignmentsClient.BaseClient.Delete(): %w", err)
 	}
 	return status, nil
 }
@@ -113,7 +108,7 @@ func (c *AppRoleAssignmentsClient) Assign(ctx context.Context, clientServicePrin
 		},
 	})
 	if err != nil {
-		return nil, status, fmt.Errorf("AppRoleAssignmentsClient.BaseClient.Post(): %v", err)
+		return nil, status, fmt.Errorf("AppRoleAssignmentsClient.BaseClient.Post(): %w", err)
 	}
 	defer resp.Body.Close()
 	respBody, err := ioutil.ReadAll(resp.Body)
@@ -24,112 +24,95 @@ func NewApplicationsClient(tenantId string) *ApplicationsClient {
 	}
 }
 
-// List returns a list of Applications, optionally filtered using OData.
-func (c *ApplicationsClient) List(ctx context.Context, filter string) (*[]Application, int, error) {
-	params := url.Values{}
-	if filter != "" {
-		params.Add("$filter", filter)
+// resource returns a Resource for performing common operations on Applications.
+func (c *ApplicationsClient) resource() Resource {
+	return Resource{
+		Client:      c.BaseClient,
+		Name:        "ApplicationsClient",
+		Entity:      "/applications",
+		DeletedType: "microsoft.graph.application",
 	}
-	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
-		ValidStatusCodes: []int{http.StatusOK},
-		Uri: Uri{
-			Entity:      "/applications",
-			Params:      params,
-			HasTenantId: true,
-		},
-	})
+}
+
+// List returns a list of Applications, optionally queried using OData.
+func (c *ApplicationsClient) List(ctx context.Context, query odata.Query) (*[]Application, int, error) {
+	var applications []Application
+	status, err := c.resource().List(ctx, query, &applications)
 	if err != nil {
-		return nil, status, fmt.Errorf("ApplicationsClient.BaseClient.Get(): %v", err)
+		return nil, status, err
 	}
-	defer resp.Body.Close()
-	respBody, err := ioutil.ReadAll(resp.Body)
+	return &applications, status, nil
+}
+
+// ListPager returns a Pager for retrieving Applications one page at a time, optionally queried using OData.
+func (c *ApplicationsClient) ListPager(query odata.Query) *Pager {
+	return c.resource().ListPager(query)
+}
+
+// Delta retrieves Applications which have been created, updated or removed since a previous delta query, optionally
+// queried using OData. Specify an empty deltaLink to retrieve all Applications, or a deltaLink returned from a previous
+// call to retrieve only changes since that call. Applications which have been removed have their Removed field populated.
+// The returned deltaLink should be persisted and used to retrieve subsequent changes.
+func (c *ApplicationsClient) Delta(ctx context.Context, query odata.Query, deltaLink string) (*[]Application, *string, int, error) {
+	var applications []Application
+	link, status, err := c.resource().Delta(ctx, query, deltaLink, &applications)
 	if err != nil {
-		return nil, status, fmt.Errorf("ioutil.ReadAll(): %v", err)
-	}
-	var data struct {
-		Applications []Application `json:"value"`
+		return nil, nil, status, err
 	}
-	if err := json.Unmarshal(respBody, &data); err != nil {
-		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
-	}
-	return &data.Applications, status, nil
+	return &applications, link, status, nil
 }
 
 // Create creates a new Application.
 func (c *ApplicationsClient) Create(ctx context.Context, application Application) (*Application, int, error) {
-	var status int
-	body, err := json.Marshal(application)
-	if err != nil {
-		return nil, status, fmt.Errorf("json.Marshal(): %v", err)
-	}
-	resp, status, _, err := c.BaseClient.Post(ctx, PostHttpRequestInput{
-		Body:             body,
-		ValidStatusCodes: []int{http.StatusCreated},
-		Uri: Uri{
-			Entity:      "/applications",
-			HasTenantId: true,
-		},
-	})
-	if err != nil {
-		return nil, status, fmt.Errorf("ApplicationsClient.BaseClient.Post(): %v", err)
-	}
-	defer resp.Body.Close()
-	respBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, status, fmt.Errorf("ioutil.ReadAll(): %v", err)
-	}
 	var newApplication Application
-	if err := json.Unmarshal(respBody, &newApplication); err != nil {
-		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	status, err := c.resource().Create(ctx, application, &newApplication)
+	if err != nil {
+		return nil, status, err
 	}
 	return &newApplication, status, nil
 }
 
 // Get retrieves an Application manifest.
 func (c *ApplicationsClient) Get(ctx context.Context, id string) (*Application, int, error) {
-	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
-		ValidStatusCodes: []int{http.StatusOK},
-		Uri: Uri{
-			Entity:      fmt.Sprintf("/applications/%s", id),
-			HasTenantId: true,
-		},
-	})
+	var application Application
+	status, err := c.resource().Get(ctx, id, odata.Query{}, &application)
 	if err != nil {
-		return nil, status, fmt.Errorf("ApplicationsClient.BaseClient.Get(): %v", err)
+		return nil, status, err
 	}
-	defer resp.Body.Close()
-	respBody, err := ioutil.ReadAll(resp.Body)
+	return &application, status, nil
+}
+
+// WaitFor polls the specified Application until predicate returns true, and then returns it.
+// The predicate is called with nil when the Application does not exist, so WaitFor can be used to wait until an Application
+// has been created or deleted, or until it has a particular property value. ctx should have a deadline, as WaitFor
+// will otherwise wait indefinitely for the predicate to be satisfied.
+func (c *ApplicationsClient) WaitFor(ctx context.Context, id string, predicate func(app *Application) bool) (*Application, int, error) {
+	var app *Application
+	var status int
+	err := c.BaseClient.waitFor(ctx, func(ctx context.Context) (bool, error) {
+		var err error
+		app, status, err = c.Get(ctx, id)
+		if err != nil {
+			if status != http.StatusNotFound {
+				return false, err
+			}
+			app = nil
+		}
+		return predicate(app), nil
+	})
 	if err != nil {
-		return nil, status, fmt.Errorf("ioutil.ReadAll(): %v", err)
-	}
-	var application Application
-	if err := json.Unmarshal(respBody, &application); err != nil {
-		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+		return nil, status, fmt.Errorf("ApplicationsClient.BaseClient.waitFor(): %w", err)
 	}
-	return &application, status, nil
+	return app, status, nil
 }
 
 // GetDeleted retrieves a deleted Application manifest.
 // id is the object ID of the application.
 func (c *ApplicationsClient) GetDeleted(ctx context.Context, id string) (*Application, int, error) {
-	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
-		ValidStatusCodes: []int{http.StatusOK},
-		Uri: Uri{
-			Entity:      fmt.Sprintf("/directory/deletedItems/%s", id),
-			HasTenantId: true,
-		},
-	})
-	if err != nil {
-		return nil, status, fmt.Errorf("ApplicationsClient.BaseClient.Get(): %v", err)
-	}
-	defer resp.Body.Close()
-	respBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, status, fmt.Errorf("ioutil.ReadAll(): %v", err)
-	}
 	var application Application
-	if err := json.Unmarshal(respBody, &application); err != nil {
-		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	status, err := c.resource().GetDeleted(ctx, id, odata.Query{}, &application)
+	if err != nil {
+		return nil, status, err
 	}
 	return &application, status, nil
 }
@@ -140,65 +123,27 @@ func (c *ApplicationsClient) Update(ctx context.Context, application Application
 	if application.ID == nil {
 		return status, errors.New("ApplicationsClient.Update(): cannot update application with nil ID")
 	}
-	body, err := json.Marshal(application)
-	if err != nil {
-		return status, fmt.Errorf("json.Marshal(): %v", err)
-	}
-	_, status, _, err = c.BaseClient.Patch(ctx, PatchHttpRequestInput{
-		Body:             body,
-		ValidStatusCodes: []int{http.StatusNoContent},
-		Uri: Uri{
-			Entity:      fmt.Sprintf("/applications/%s", *application.ID),
-			HasTenantId: true,
-		},
-	})
-	if err != nil {
-		return status, fmt.Errorf("ApplicationsClient.BaseClient.Patch(): %v", err)
-	}
-	return status, nil
+	return c.resource().Update(ctx, *application.ID, application)
 }
 
 // Delete removes an Application.
 func (c *ApplicationsClient) Delete(ctx context.Context, id string) (int, error) {
-	_, status, _, err := c.BaseClient.Delete(ctx, DeleteHttpRequestInput{
-		ValidStatusCodes: []int{http.StatusNoContent},
-		Uri: Uri{
-			Entity:      fmt.Sprintf("/applications/%s", id),
-			HasTenantId: true,
-		},
-	})
-	if err != nil {
-		return status, fmt.Errorf("ApplicationsClient.BaseClient.Delete(): %v", err)
-	}
-	return status, nil
+	return c.resource().Delete(ctx, id)
 }
 
-// ListDeleted retrieves a list of recently deleted applications, optionally filtered using OData.
-func (c *ApplicationsClient) ListDeleted(ctx context.Context, filter string) (*[]Application, int, error) {
-	params := url.Values{}
-	if filter != "" {
-		params.Add("$filter", filter)
-	}
-	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
-		ValidStatusCodes: []int{http.StatusOK},
-		Uri: Uri{
-			Entity:      "/directory/deleteditems/microsoft.graph.application",
-			Params:      params,
-			HasTenantId: true,
-		},
-	})
+// ListDeleted retrieves a list of recently deleted applications, optionally queried using OData.
+func (c *ApplicationsClient) ListDeleted(ctx context.Context, query odata.Query) (*[]Application, int, error) {
+	var deletedApplications []Application
+	status, err := c.resource().ListDeleted(ctx, query, &deletedApplications)
 	if err != nil {
 		return nil, status, err
 	}
-	defer resp.Body.Close()
-	respBody, _ := ioutil.ReadAll(resp.Body)
-	var data struct {
-		DeletedApps []Application `json:"value"`
-	}
-	if err = json.Unmarshal(respBody, &data); err != nil {
-		return nil, status, err
-	}
-	return &data.DeletedApps, status, nil
+	return &deletedApplications, status, nil
+}
+
+// ListDeletedPager returns a Pager for retrieving recently deleted applications one page at a time, optionally queried using OData.
+func (c *ApplicationsClient) ListDeletedPager(query odata.Query) *Pager {
+	return c.resource().ListDeletedPager(query)
 }
 
 // AddPassword appends a new password credential to an Application.
@@ -221,7 +166,7 @@ func (c *ApplicationsClient) AddPassword(ctx context.Context, applicationId stri
 		},
 	})
 	if err != nil {
-		return nil, status, fmt.Errorf("ApplicationsClient.BaseClient.Post(): %v", err)
+		return nil, status, fmt.Errorf("ApplicationsClient.BaseClient.Post(): %w", err)
 	}
 	defer resp.Body.Close()
 	respBody, err := ioutil.ReadAll(resp.Body)
@@ -255,44 +200,34 @@ func (c *ApplicationsClient) RemovePassword(ctx context.Context, applicationId s
 		},
 	})
 	if err != nil {
-		return status, fmt.Errorf("ApplicationsClient.BaseClient.Post(): %v", err)
+		return status, fmt.Errorf("ApplicationsClient.BaseClient.Post(): %w", err)
 	}
 	return status, nil
 }
 
-// ListOwners retrieves the owners of the specified Application.
+// ListOwners retrieves the owners of the specified Application. Each owner is returned as the model for its type,
+// e.g. *User or *ServicePrincipal.
 // id is the object ID of the application.
-func (c *ApplicationsClient) ListOwners(ctx context.Context, id string) (*[]string, int, error) {
-	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
+func (c *ApplicationsClient) ListOwners(ctx context.Context, id string) (*[]DirectoryObject, int, error) {
+	var owners DirectoryObjects
+	status, err := c.resource().list(ctx, fmt.Sprintf("/applications/%s/owners", id), odata.Query{}, &owners)
+	if err != nil {
+		return nil, status, err
+	}
+	ret := []DirectoryObject(owners)
+	return &ret, status, nil
+}
+
+// ListOwnersPager returns a Pager for retrieving the owners of the specified Application one page at a time.
+// id is the object ID of the application.
+func (c *ApplicationsClient) ListOwnersPager(id string) *Pager {
+	return c.BaseClient.NewPager(GetHttpRequestInput{
 		ValidStatusCodes: []int{http.StatusOK},
 		Uri: Uri{
 			Entity:      fmt.Sprintf("/applications/%s/owners", id),
-			Params:      url.Values{"$select": []string{"id"}},
 			HasTenantId: true,
 		},
 	})
-	if err != nil {
-		return nil, status, fmt.Errorf("ApplicationsClient.BaseClient.Get(): %v", err)
-	}
-	defer resp.Body.Close()
-	respBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, status, fmt.Errorf("ioutil.ReadAll(): %v", err)
-	}
-	var data struct {
-		Owners []struct {
-			Type string `json:"@odata.type"`
-			Id   string `json:"id"`
-		} `json:"value"`
-	}
-	if err := json.Unmarshal(respBody, &data); err != nil {
-		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
-	}
-	ret := make([]string, len(data.Owners))
-	for i, v := range data.Owners {
-		ret[i] = v.Id
-	}
-	return &ret, status, nil
 }
 
 // GetOwner retrieves a single owner for the specified Application.
@@ -308,7 +243,7 @@ func (c *ApplicationsClient) GetOwner(ctx context.Context, applicationId, ownerI
 		},
 	})
 	if err != nil {
-		return nil, status, fmt.Errorf("ApplicationsClient.BaseClient.Get(): %v", err)
+		return nil, status, fmt.Errorf("ApplicationsClient.BaseClient.Get(): %w", err)
 	}
 	defer resp.Body.Close()
 	respBody, err := ioutil.ReadAll(resp.Body)
@@ -367,7 +302,7 @@ func (c *ApplicationsClient) AddOwners(ctx context.Context, application *Applica
 			},
 		})
 		if err != nil {
-			return status, fmt.Errorf("ApplicationsClient.BaseClient.Post(): %v", err)
+			return status, fmt.Errorf("ApplicationsClient.BaseClient.Post(): %w", err)
 		}
 	}
 	return status, nil
@@ -410,7 +345,7 @@ func (c *ApplicationsClient) RemoveOwners(ctx context.Context, applicationId str
 			},
 		})
 		if err != nil {
-			return status, fmt.Errorf("ApplicationsClient.BaseClient.Delete(): %v", err)
+			return status, fmt.Errorf("ApplicationsClient.BaseClient.Delete(): %w", err)
 		}
 	}
 	return status, nil
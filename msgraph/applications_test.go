@@ -1,3 +1,6 @@
+//go:build live
+// +build live
+
 package msgraph_test
 
 import (
@@ -8,6 +11,7 @@ import (
 	"github.com/manicminer/hamilton/internal/test"
 	"github.com/manicminer/hamilton/internal/utils"
 	"github.com/manicminer/hamilton/msgraph"
+	"github.com/manicminer/hamilton/odata"
 )
 
 type ApplicationsClientTest struct {
@@ -86,7 +90,7 @@ func testApplicationsClient_Update(t *testing.T, c ApplicationsClientTest, a msg
 }
 
 func testApplicationsClient_List(t *testing.T, c ApplicationsClientTest) (applications *[]msgraph.Application) {
-	applications, _, err := c.client.List(c.connection.Context, "")
+	applications, _, err := c.client.List(c.connection.Context, odata.Query{})
 	if err != nil {
 		t.Fatalf("ApplicationsClient.List(): %v", err)
 	}
@@ -135,19 +139,26 @@ func testApplicationsClient_Delete(t *testing.T, c ApplicationsClientTest, id st
 }
 
 func testApplicationsClient_ListOwners(t *testing.T, c ApplicationsClientTest, id string) (owners *[]string) {
-	owners, status, err := c.client.ListOwners(c.connection.Context, id)
+	result, status, err := c.client.ListOwners(c.connection.Context, id)
 	if err != nil {
 		t.Fatalf("ApplicationsClient.ListOwners(): %v", err)
 	}
 	if status < 200 || status >= 300 {
 		t.Fatalf("ApplicationsClient.ListOwners(): invalid status: %d", status)
 	}
-	if owners == nil {
+	if result == nil {
 		t.Fatal("ApplicationsClient.ListOwners(): owners was nil")
 	}
-	if len(*owners) == 0 {
+	if len(*result) == 0 {
 		t.Fatal("ApplicationsClient.ListOwners(): owners was empty")
 	}
+	owners = &[]string{}
+	for _, o := range *result {
+		if o.GetID() == nil {
+			t.Fatal("ApplicationsClient.ListOwners(): owner ID was nil")
+		}
+		*owners = append(*owners, *o.GetID())
+	}
 	return
 }
 
@@ -216,7 +227,7 @@ func testApplicationsClient_RemovePassword(t *testing.T, c ApplicationsClientTes
 }
 
 func testApplicationsClient_ListDeleted(t *testing.T, c ApplicationsClientTest, expectedId string) (deletedApps *[]msgraph.Application) {
-	deletedApps, status, err := c.client.ListDeleted(c.connection.Context, "")
+	deletedApps, status, err := c.client.ListDeleted(c.connection.Context, odata.Query{})
 	if err != nil {
 		t.Fatalf("ApplicationsClient.ListDeleted(): %v", err)
 	}
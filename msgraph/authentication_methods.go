@@ -0,0 +1,460 @@
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/manicminer/hamilton/odata"
+)
+
+// OData types of authentication methods.
+const (
+	ODataTypeEmailAuthenticationMethod                  = "#microsoft.graph.emailAuthenticationMethod"
+	ODataTypeFido2AuthenticationMethod                  = "#microsoft.graph.fido2AuthenticationMethod"
+	ODataTypeMicrosoftAuthenticatorAuthenticationMethod = "#microsoft.graph.microsoftAuthenticatorAuthenticationMethod"
+	ODataTypePasswordAuthenticationMethod               = "#microsoft.graph.passwordAuthenticationMethod"
+	ODataTypePhoneAuthenticationMethod                  = "#microsoft.graph.phoneAuthenticationMethod"
+	ODataTypeSoftwareOathAuthenticationMethod           = "#microsoft.graph.softwareOathAuthenticationMethod"
+	ODataTypeTemporaryAccessPassAuthenticationMethod    = "#microsoft.graph.temporaryAccessPassAuthenticationMethod"
+)
+
+// PasswordAuthenticationMethodId is the well-known ID of the password authentication method of every user.
+const PasswordAuthenticationMethodId = "28c10230-6103-485e-b985-444c60001490"
+
+// AuthenticationMethod is implemented by models of authentication methods, such as phone numbers, FIDO2 security keys
+// and Temporary Access Passes, which are returned together by AuthenticationMethodsClient{}.List(). Use a type switch
+// to access the properties of each method.
+type AuthenticationMethod interface {
+	// GetID returns the ID of the authentication method.
+	GetID() *string
+
+	// GetODataType returns the @odata.type of the authentication method, e.g.
+	// "#microsoft.graph.phoneAuthenticationMethod".
+	GetODataType() string
+}
+
+// authenticationMethodTypes are the authentication method types which are decoded into their own models.
+var authenticationMethodTypes = odataTypes{
+	ODataTypeEmailAuthenticationMethod:                  func() interface{} { return &EmailAuthenticationMethod{} },
+	ODataTypeFido2AuthenticationMethod:                  func() interface{} { return &Fido2AuthenticationMethod{} },
+	ODataTypeMicrosoftAuthenticatorAuthenticationMethod: func() interface{} { return &MicrosoftAuthenticatorAuthenticationMethod{} },
+	ODataTypePasswordAuthenticationMethod:               func() interface{} { return &PasswordAuthenticationMethod{} },
+	ODataTypePhoneAuthenticationMethod:                  func() interface{} { return &PhoneAuthenticationMethod{} },
+	ODataTypeSoftwareOathAuthenticationMethod:           func() interface{} { return &SoftwareOathAuthenticationMethod{} },
+	ODataTypeTemporaryAccessPassAuthenticationMethod:    func() interface{} { return &TemporaryAccessPassAuthenticationMethod{} },
+}
+
+// UnmarshalAuthenticationMethod unmarshals an authentication method into the model for its @odata.type, e.g.
+// *PhoneAuthenticationMethod. Methods of types which are not modelled are returned as a *BaseAuthenticationMethod.
+func UnmarshalAuthenticationMethod(data []byte) (AuthenticationMethod, error) {
+	v, err := authenticationMethodTypes.unmarshal(data)
+	if err != nil {
+		return nil, err
+	}
+	if method, ok := v.(AuthenticationMethod); ok {
+		return method, nil
+	}
+	var method BaseAuthenticationMethod
+	if err := json.Unmarshal(data, &method); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+	return &method, nil
+}
+
+// AuthenticationMethods is a collection of authentication methods of mixed types. Each method is unmarshaled using
+// UnmarshalAuthenticationMethod.
+type AuthenticationMethods []AuthenticationMethod
+
+// UnmarshalJSON unmarshals a JSON array of authentication methods.
+func (m *AuthenticationMethods) UnmarshalJSON(data []byte) error {
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+	ret := make(AuthenticationMethods, 0, len(items))
+	for _, item := range items {
+		method, err := UnmarshalAuthenticationMethod(item)
+		if err != nil {
+			return err
+		}
+		ret = append(ret, method)
+	}
+	*m = ret
+	return nil
+}
+
+// GetID returns the ID of the authentication method.
+func (m BaseAuthenticationMethod) GetID() *string { return m.ID }
+
+// GetODataType returns the @odata.type of the authentication method.
+func (m BaseAuthenticationMethod) GetODataType() string {
+	if m.ODataType == nil {
+		return ""
+	}
+	return *m.ODataType
+}
+
+// GetID returns the ID of the authentication method.
+func (m EmailAuthenticationMethod) GetID() *string { return m.ID }
+
+// GetODataType returns the @odata.type of the authentication method.
+func (m EmailAuthenticationMethod) GetODataType() string { return ODataTypeEmailAuthenticationMethod }
+
+// GetID returns the ID of the authentication method.
+func (m Fido2AuthenticationMethod) GetID() *string { return m.ID }
+
+// GetODataType returns the @odata.type of the authentication method.
+func (m Fido2AuthenticationMethod) GetODataType() string { return ODataTypeFido2AuthenticationMethod }
+
+// GetID returns the ID of the authentication method.
+func (m MicrosoftAuthenticatorAuthenticationMethod) GetID() *string { return m.ID }
+
+// GetODataType returns the @odata.type of the authentication method.
+func (m MicrosoftAuthenticatorAuthenticationMethod) GetODataType() string {
+	return ODataTypeMicrosoftAuthenticatorAuthenticationMethod
+}
+
+// GetID returns the ID of the authentication method.
+func (m PasswordAuthenticationMethod) GetID() *string { return m.ID }
+
+// GetODataType returns the @odata.type of the authentication method.
+func (m PasswordAuthenticationMethod) GetODataType() string {
+	return ODataTypePasswordAuthenticationMethod
+}
+
+// GetID returns the ID of the authentication method.
+func (m PhoneAuthenticationMethod) GetID() *string { return m.ID }
+
+// GetODataType returns the @odata.type of the authentication method.
+func (m PhoneAuthenticationMethod) GetODataType() string { return ODataTypePhoneAuthenticationMethod }
+
+// GetID returns the ID of the authentication method.
+func (m SoftwareOathAuthenticationMethod) GetID() *string { return m.ID }
+
+// GetODataType returns the @odata.type of the authentication method.
+func (m SoftwareOathAuthenticationMethod) GetODataType() string {
+	return ODataTypeSoftwareOathAuthenticationMethod
+}
+
+// GetID returns the ID of the authentication method.
+func (m TemporaryAccessPassAuthenticationMethod) GetID() *string { return m.ID }
+
+// GetODataType returns the @odata.type of the authentication method.
+func (m TemporaryAccessPassAuthenticationMethod) GetODataType() string {
+	return ODataTypeTemporaryAccessPassAuthenticationMethod
+}
+
+// AuthenticationMethodsClient performs operations on the authentication methods registered to Users. Each method accepts
+// the object ID or user principal name of the user.
+type AuthenticationMethodsClient struct {
+	BaseClient Client
+}
+
+// NewAuthenticationMethodsClient returns a new AuthenticationMethodsClient.
+func NewAuthenticationMethodsClient(tenantId string) *AuthenticationMethodsClient {
+	return &AuthenticationMethodsClient{
+		BaseClient: NewClient(Version10, tenantId),
+	}
+}
+
+// resource returns a Resource for performing common operations on the specified collection of authentication methods
+// of a User, e.g. "phoneMethods".
+func (c *AuthenticationMethodsClient) resource(userId, methods string) Resource {
+	return Resource{
+		Client: c.BaseClient,
+		Name:   "AuthenticationMethodsClient",
+		Entity: fmt.Sprintf("/users/%s/authentication/%s", userId, methods),
+	}
+}
+
+// List returns all the authentication methods registered to the specified User. Each method is returned as the model
+// for its type, e.g. *PhoneAuthenticationMethod.
+func (c *AuthenticationMethodsClient) List(ctx context.Context, userId string) (*[]AuthenticationMethod, int, error) {
+	var methods AuthenticationMethods
+	status, err := c.resource(userId, "methods").List(ctx, odata.Query{}, &methods)
+	if err != nil {
+		return nil, status, err
+	}
+	ret := []AuthenticationMethod(methods)
+	return &ret, status, nil
+}
+
+// ListEmailMethods returns the email authentication methods registered to the specified User.
+func (c *AuthenticationMethodsClient) ListEmailMethods(ctx context.Context, userId string) (*[]EmailAuthenticationMethod, int, error) {
+	var methods []EmailAuthenticationMethod
+	status, err := c.resource(userId, "emailMethods").List(ctx, odata.Query{}, &methods)
+	if err != nil {
+		return nil, status, err
+	}
+	return &methods, status, nil
+}
+
+// GetEmailMethod retrieves an email authentication method of the specified User.
+func (c *AuthenticationMethodsClient) GetEmailMethod(ctx context.Context, userId, id string) (*EmailAuthenticationMethod, int, error) {
+	var method EmailAuthenticationMethod
+	status, err := c.resource(userId, "emailMethods").Get(ctx, id, odata.Query{}, &method)
+	if err != nil {
+		return nil, status, err
+	}
+	return &method, status, nil
+}
+
+// CreateEmailMethod registers an email address to the specified User for self-service password reset. A user can
+// have only one email authentication method.
+func (c *AuthenticationMethodsClient) CreateEmailMethod(ctx context.Context, userId string, method EmailAuthenticationMethod) (*EmailAuthenticationMethod, int, error) {
+	var status int
+	if method.EmailAddress == nil {
+		return nil, status, errors.New("AuthenticationMethodsClient.CreateEmailMethod(): cannot create email method with nil EmailAddress")
+	}
+	var newMethod EmailAuthenticationMethod
+	status, err := c.resource(userId, "emailMethods").Create(ctx, method, &newMethod)
+	if err != nil {
+		return nil, status, err
+	}
+	return &newMethod, status, nil
+}
+
+// UpdateEmailMethod amends an email authentication method of the specified User.
+func (c *AuthenticationMethodsClient) UpdateEmailMethod(ctx context.Context, userId string, method EmailAuthenticationMethod) (int, error) {
+	var status int
+	if method.ID == nil {
+		return status, errors.New("AuthenticationMethodsClient.UpdateEmailMethod(): cannot update email method with nil ID")
+	}
+	return c.resource(userId, "emailMethods").Update(ctx, *method.ID, EmailAuthenticationMethod{EmailAddress: method.EmailAddress})
+}
+
+// DeleteEmailMethod removes an email authentication method from the specified User.
+func (c *AuthenticationMethodsClient) DeleteEmailMethod(ctx context.Context, userId, id string) (int, error) {
+	return c.resource(userId, "emailMethods").Delete(ctx, id)
+}
+
+// ListFido2Methods returns the FIDO2 security keys registered to the specified User.
+func (c *AuthenticationMethodsClient) ListFido2Methods(ctx context.Context, userId string) (*[]Fido2AuthenticationMethod, int, error) {
+	var methods []Fido2AuthenticationMethod
+	status, err := c.resource(userId, "fido2Methods").List(ctx, odata.Query{}, &methods)
+	if err != nil {
+		return nil, status, err
+	}
+	return &methods, status, nil
+}
+
+// GetFido2Method retrieves a FIDO2 security key registered to the specified User.
+func (c *AuthenticationMethodsClient) GetFido2Method(ctx context.Context, userId, id string) (*Fido2AuthenticationMethod, int, error) {
+	var method Fido2AuthenticationMethod
+	status, err := c.resource(userId, "fido2Methods").Get(ctx, id, odata.Query{}, &method)
+	if err != nil {
+		return nil, status, err
+	}
+	return &method, status, nil
+}
+
+// DeleteFido2Method removes a FIDO2 security key from the specified User.
+func (c *AuthenticationMethodsClient) DeleteFido2Method(ctx context.Context, userId, id string) (int, error) {
+	return c.resource(userId, "fido2Methods").Delete(ctx, id)
+}
+
+// ListMicrosoftAuthenticatorMethods returns the Microsoft Authenticator apps registered to the specified User.
+func (c *AuthenticationMethodsClient) ListMicrosoftAuthenticatorMethods(ctx context.Context, userId string) (*[]MicrosoftAuthenticatorAuthenticationMethod, int, error) {
+	var methods []MicrosoftAuthenticatorAuthenticationMethod
+	status, err := c.resource(userId, "microsoftAuthenticatorMethods").List(ctx, odata.Query{}, &methods)
+	if err != nil {
+		return nil, status, err
+	}
+	return &methods, status, nil
+}
+
+// GetMicrosoftAuthenticatorMethod retrieves a Microsoft Authenticator app registered to the specified User.
+func (c *AuthenticationMethodsClient) GetMicrosoftAuthenticatorMethod(ctx context.Context, userId, id string) (*MicrosoftAuthenticatorAuthenticationMethod, int, error) {
+	var method MicrosoftAuthenticatorAuthenticationMethod
+	status, err := c.resource(userId, "microsoftAuthenticatorMethods").Get(ctx, id, odata.Query{}, &method)
+	if err != nil {
+		return nil, status, err
+	}
+	return &method, status, nil
+}
+
+// DeleteMicrosoftAuthenticatorMethod removes a Microsoft Authenticator app from the specified User.
+func (c *AuthenticationMethodsClient) DeleteMicrosoftAuthenticatorMethod(ctx context.Context, userId, id string) (int, error) {
+	return c.resource(userId, "microsoftAuthenticatorMethods").Delete(ctx, id)
+}
+
+// ListPasswordMethods returns the password authentication method of the specified User. The password itself is never
+// returned.
+func (c *AuthenticationMethodsClient) ListPasswordMethods(ctx context.Context, userId string) (*[]PasswordAuthenticationMethod, int, error) {
+	var methods []PasswordAuthenticationMethod
+	status, err := c.resource(userId, "passwordMethods").List(ctx, odata.Query{}, &methods)
+	if err != nil {
+		return nil, status, err
+	}
+	return &methods, status, nil
+}
+
+// GetPasswordMethod retrieves the password authentication method of the specified User, whose ID is always
+// PasswordAuthenticationMethodId.
+func (c *AuthenticationMethodsClient) GetPasswordMethod(ctx context.Context, userId, id string) (*PasswordAuthenticationMethod, int, error) {
+	var method PasswordAuthenticationMethod
+	status, err := c.resource(userId, "passwordMethods").Get(ctx, id, odata.Query{}, &method)
+	if err != nil {
+		return nil, status, err
+	}
+	return &method, status, nil
+}
+
+// ResetPassword resets the password of the specified User. When newPassword is empty, a password is generated by the
+// service and returned in the response. The reset completes asynchronously.
+func (c *AuthenticationMethodsClient) ResetPassword(ctx context.Context, userId, newPassword string) (*PasswordResetResponse, int, error) {
+	var status int
+	reset := PasswordResetResponse{}
+	if newPassword != "" {
+		reset.NewPassword = &newPassword
+	}
+	body, err := json.Marshal(reset)
+	if err != nil {
+		return nil, status, fmt.Errorf("json.Marshal(): %v", err)
+	}
+	resp, status, _, err := c.BaseClient.Post(ctx, PostHttpRequestInput{
+		Body:             body,
+		ValidStatusCodes: []int{http.StatusOK, http.StatusAccepted},
+		Uri: Uri{
+			Entity:      fmt.Sprintf("/users/%s/authentication/methods/%s/resetPassword", userId, PasswordAuthenticationMethodId),
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("AuthenticationMethodsClient.BaseClient.Post(): %w", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("ioutil.ReadAll(): %v", err)
+	}
+	var response PasswordResetResponse
+	if len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, &response); err != nil {
+			return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+		}
+	}
+	return &response, status, nil
+}
+
+// ListPhoneMethods returns the phone authentication methods registered to the specified User.
+func (c *AuthenticationMethodsClient) ListPhoneMethods(ctx context.Context, userId string) (*[]PhoneAuthenticationMethod, int, error) {
+	var methods []PhoneAuthenticationMethod
+	status, err := c.resource(userId, "phoneMethods").List(ctx, odata.Query{}, &methods)
+	if err != nil {
+		return nil, status, err
+	}
+	return &methods, status, nil
+}
+
+// GetPhoneMethod retrieves a phone authentication method of the specified User.
+func (c *AuthenticationMethodsClient) GetPhoneMethod(ctx context.Context, userId, id string) (*PhoneAuthenticationMethod, int, error) {
+	var method PhoneAuthenticationMethod
+	status, err := c.resource(userId, "phoneMethods").Get(ctx, id, odata.Query{}, &method)
+	if err != nil {
+		return nil, status, err
+	}
+	return &method, status, nil
+}
+
+// CreatePhoneMethod registers a phone number to the specified User. A user can have one phone number of each
+// PhoneType, and the phone number should be in the format "+1 5555551234".
+func (c *AuthenticationMethodsClient) CreatePhoneMethod(ctx context.Context, userId string, method PhoneAuthenticationMethod) (*PhoneAuthenticationMethod, int, error) {
+	var status int
+	if method.PhoneNumber == nil || method.PhoneType == nil {
+		return nil, status, errors.New("AuthenticationMethodsClient.CreatePhoneMethod(): cannot create phone method with nil PhoneNumber or PhoneType")
+	}
+	var newMethod PhoneAuthenticationMethod
+	status, err := c.resource(userId, "phoneMethods").Create(ctx, method, &newMethod)
+	if err != nil {
+		return nil, status, err
+	}
+	return &newMethod, status, nil
+}
+
+// UpdatePhoneMethod amends a phone authentication method of the specified User. Only the PhoneNumber and PhoneType can
+// be changed.
+func (c *AuthenticationMethodsClient) UpdatePhoneMethod(ctx context.Context, userId string, method PhoneAuthenticationMethod) (int, error) {
+	var status int
+	if method.ID == nil {
+		return status, errors.New("AuthenticationMethodsClient.UpdatePhoneMethod(): cannot update phone method with nil ID")
+	}
+	return c.resource(userId, "phoneMethods").Update(ctx, *method.ID, PhoneAuthenticationMethod{
+		PhoneNumber: method.PhoneNumber,
+		PhoneType:   method.PhoneType,
+	})
+}
+
+// DeletePhoneMethod removes a phone authentication method from the specified User.
+func (c *AuthenticationMethodsClient) DeletePhoneMethod(ctx context.Context, userId, id string) (int, error) {
+	return c.resource(userId, "phoneMethods").Delete(ctx, id)
+}
+
+// ListSoftwareOathMethods returns the software OATH tokens registered to the specified User.
+func (c *AuthenticationMethodsClient) ListSoftwareOathMethods(ctx context.Context, userId string) (*[]SoftwareOathAuthenticationMethod, int, error) {
+	var methods []SoftwareOathAuthenticationMethod
+	status, err := c.resource(userId, "softwareOathMethods").List(ctx, odata.Query{}, &methods)
+	if err != nil {
+		return nil, status, err
+	}
+	return &methods, status, nil
+}
+
+// GetSoftwareOathMethod retrieves a software OATH token registered to the specified User.
+func (c *AuthenticationMethodsClient) GetSoftwareOathMethod(ctx context.Context, userId, id string) (*SoftwareOathAuthenticationMethod, int, error) {
+	var method SoftwareOathAuthenticationMethod
+	status, err := c.resource(userId, "softwareOathMethods").Get(ctx, id, odata.Query{}, &method)
+	if err != nil {
+		return nil, status, err
+	}
+	return &method, status, nil
+}
+
+// DeleteSoftwareOathMethod removes a software OATH token from the specified User.
+func (c *AuthenticationMethodsClient) DeleteSoftwareOathMethod(ctx context.Context, userId, id string) (int, error) {
+	return c.resource(userId, "softwareOathMethods").Delete(ctx, id)
+}
+
+// ListTemporaryAccessPassMethods returns the Temporary Access Passes issued to the specified User. The passcodes are
+// not returned.
+func (c *AuthenticationMethodsClient) ListTemporaryAccessPassMethods(ctx context.Context, userId string) (*[]TemporaryAccessPassAuthenticationMethod, int, error) {
+	var methods []TemporaryAccessPassAuthenticationMethod
+	status, err := c.resource(userId, "temporaryAccessPassMethods").List(ctx, odata.Query{}, &methods)
+	if err != nil {
+		return nil, status, err
+	}
+	return &methods, status, nil
+}
+
+// GetTemporaryAccessPassMethod retrieves a Temporary Access Pass issued to the specified User. The passcode is not
+// returned.
+func (c *AuthenticationMethodsClient) GetTemporaryAccessPassMethod(ctx context.Context, userId, id string) (*TemporaryAccessPassAuthenticationMethod, int, error) {
+	var method TemporaryAccessPassAuthenticationMethod
+	status, err := c.resource(userId, "temporaryAccessPassMethods").Get(ctx, id, odata.Query{}, &method)
+	if err != nil {
+		return nil, status, err
+	}
+	return &method, status, nil
+}
+
+// CreateTemporaryAccessPassMethod issues a Temporary Access Pass to the specified User, which can be used to sign in
+// and register other authentication methods. A user can have only one Temporary Access Pass. The passcode is returned
+// in the TemporaryAccessPass field of the new method, and cannot be retrieved later. Fields which are not set use the
+// defaults of the Temporary Access Pass policy of the tenant.
+func (c *AuthenticationMethodsClient) CreateTemporaryAccessPassMethod(ctx context.Context, userId string, method TemporaryAccessPassAuthenticationMethod) (*TemporaryAccessPassAuthenticationMethod, int, error) {
+	var newMethod TemporaryAccessPassAuthenticationMethod
+	status, err := c.resource(userId, "temporaryAccessPassMethods").Create(ctx, method, &newMethod)
+	if err != nil {
+		return nil, status, err
+	}
+	return &newMethod, status, nil
+}
+
+// DeleteTemporaryAccessPassMethod removes a Temporary Access Pass from the specified User.
+func (c *AuthenticationMethodsClient) DeleteTemporaryAccessPassMethod(ctx context.Context, userId, id string) (int, error) {
+	return c.resource(userId, "temporaryAccessPassMethods").Delete(ctx, id)
+}
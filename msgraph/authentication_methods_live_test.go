@@ -0,0 +1,171 @@
+//go:build live
+// +build live
+
+package msgraph_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/manicminer/hamilton/auth"
+	"github.com/manicminer/hamilton/internal/test"
+	"github.com/manicminer/hamilton/internal/utils"
+	"github.com/manicminer/hamilton/msgraph"
+)
+
+type AuthenticationMethodsClientTest struct {
+	connection   *test.Connection
+	client       *msgraph.AuthenticationMethodsClient
+	randomString string
+}
+
+// TestAuthenticationMethodsClient_Live requires the Temporary Access Pass method to be enabled in the tenant.
+func TestAuthenticationMethodsClient_Live(t *testing.T) {
+	rs := test.RandomString()
+	c := AuthenticationMethodsClientTest{
+		connection:   test.NewConnection(auth.MsGraph, auth.TokenVersion2),
+		randomString: rs,
+	}
+	c.client = msgraph.NewAuthenticationMethodsClient(c.connection.AuthConfig.TenantID)
+	c.client.BaseClient.Authorizer = c.connection.Authorizer
+
+	u := UsersClientTest{
+		connection:   test.NewConnection(auth.MsGraph, auth.TokenVersion2),
+		randomString: rs,
+	}
+	u.client = msgraph.NewUsersClient(u.connection.AuthConfig.TenantID)
+	u.client.BaseClient.Authorizer = u.connection.Authorizer
+
+	user := testUsersClient_Create(t, u, msgraph.User{
+		AccountEnabled:    utils.BoolPtr(true),
+		DisplayName:       utils.StringPtr("test-user-authentication-methods"),
+		MailNickname:      utils.StringPtr(fmt.Sprintf("test-user-authentication-methods-%s", c.randomString)),
+		UserPrincipalName: utils.StringPtr(fmt.Sprintf("test-user-authentication-methods-%s@%s", c.randomString, c.connection.DomainName)),
+		PasswordProfile: &msgraph.UserPasswordProfile{
+			Password: utils.StringPtr(fmt.Sprintf("IrPa55w0rd%s", c.randomString)),
+		},
+	})
+
+	tap := testAuthenticationMethodsClient_CreateTemporaryAccessPassMethod(t, c, *user.ID, msgraph.TemporaryAccessPassAuthenticationMethod{
+		IsUsableOnce:      utils.BoolPtr(true),
+		LifetimeInMinutes: utils.Int32Ptr(60),
+	})
+	testAuthenticationMethodsClient_GetTemporaryAccessPassMethod(t, c, *user.ID, *tap.ID)
+
+	phoneType := msgraph.AuthenticationPhoneTypeMobile
+	phone := testAuthenticationMethodsClient_CreatePhoneMethod(t, c, *user.ID, msgraph.PhoneAuthenticationMethod{
+		PhoneNumber: utils.StringPtr("+1 5555551234"),
+		PhoneType:   &phoneType,
+	})
+	phone.PhoneNumber = utils.StringPtr("+1 5555554321")
+	testAuthenticationMethodsClient_UpdatePhoneMethod(t, c, *user.ID, *phone)
+	testAuthenticationMethodsClient_ListPhoneMethods(t, c, *user.ID)
+	testAuthenticationMethodsClient_List(t, c, *user.ID)
+
+	testAuthenticationMethodsClient_DeletePhoneMethod(t, c, *user.ID, *phone.ID)
+	testAuthenticationMethodsClient_DeleteTemporaryAccessPassMethod(t, c, *user.ID, *tap.ID)
+	testUsersClient_Delete(t, u, *user.ID)
+}
+
+func testAuthenticationMethodsClient_CreateTemporaryAccessPassMethod(t *testing.T, c AuthenticationMethodsClientTest, userId string, m msgraph.TemporaryAccessPassAuthenticationMethod) (method *msgraph.TemporaryAccessPassAuthenticationMethod) {
+	method, status, err := c.client.CreateTemporaryAccessPassMethod(c.connection.Context, userId, m)
+	if err != nil {
+		t.Fatalf("AuthenticationMethodsClient.CreateTemporaryAccessPassMethod(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("AuthenticationMethodsClient.CreateTemporaryAccessPassMethod(): invalid status: %d", status)
+	}
+	if method == nil {
+		t.Fatal("AuthenticationMethodsClient.CreateTemporaryAccessPassMethod(): method was nil")
+	}
+	if method.ID == nil {
+		t.Fatal("AuthenticationMethodsClient.CreateTemporaryAccessPassMethod(): method.ID was nil")
+	}
+	if method.TemporaryAccessPass == nil {
+		t.Fatal("AuthenticationMethodsClient.CreateTemporaryAccessPassMethod(): method.TemporaryAccessPass was nil")
+	}
+	return
+}
+
+func testAuthenticationMethodsClient_GetTemporaryAccessPassMethod(t *testing.T, c AuthenticationMethodsClientTest, userId, id string) (method *msgraph.TemporaryAccessPassAuthenticationMethod) {
+	method, status, err := c.client.GetTemporaryAccessPassMethod(c.connection.Context, userId, id)
+	if err != nil {
+		t.Fatalf("AuthenticationMethodsClient.GetTemporaryAccessPassMethod(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("AuthenticationMethodsClient.GetTemporaryAccessPassMethod(): invalid status: %d", status)
+	}
+	if method == nil {
+		t.Fatal("AuthenticationMethodsClient.GetTemporaryAccessPassMethod(): method was nil")
+	}
+	return
+}
+
+func testAuthenticationMethodsClient_DeleteTemporaryAccessPassMethod(t *testing.T, c AuthenticationMethodsClientTest, userId, id string) {
+	status, err := c.client.DeleteTemporaryAccessPassMethod(c.connection.Context, userId, id)
+	if err != nil {
+		t.Fatalf("AuthenticationMethodsClient.DeleteTemporaryAccessPassMethod(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("AuthenticationMethodsClient.DeleteTemporaryAccessPassMethod(): invalid status: %d", status)
+	}
+}
+
+func testAuthenticationMethodsClient_CreatePhoneMethod(t *testing.T, c AuthenticationMethodsClientTest, userId string, m msgraph.PhoneAuthenticationMethod) (method *msgraph.PhoneAuthenticationMethod) {
+	method, status, err := c.client.CreatePhoneMethod(c.connection.Context, userId, m)
+	if err != nil {
+		t.Fatalf("AuthenticationMethodsClient.CreatePhoneMethod(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("AuthenticationMethodsClient.CreatePhoneMethod(): invalid status: %d", status)
+	}
+	if method == nil {
+		t.Fatal("AuthenticationMethodsClient.CreatePhoneMethod(): method was nil")
+	}
+	if method.ID == nil {
+		t.Fatal("AuthenticationMethodsClient.CreatePhoneMethod(): method.ID was nil")
+	}
+	return
+}
+
+func testAuthenticationMethodsClient_UpdatePhoneMethod(t *testing.T, c AuthenticationMethodsClientTest, userId string, m msgraph.PhoneAuthenticationMethod) {
+	status, err := c.client.UpdatePhoneMethod(c.connection.Context, userId, m)
+	if err != nil {
+		t.Fatalf("AuthenticationMethodsClient.UpdatePhoneMethod(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("AuthenticationMethodsClient.UpdatePhoneMethod(): invalid status: %d", status)
+	}
+}
+
+func testAuthenticationMethodsClient_ListPhoneMethods(t *testing.T, c AuthenticationMethodsClientTest, userId string) (methods *[]msgraph.PhoneAuthenticationMethod) {
+	methods, _, err := c.client.ListPhoneMethods(c.connection.Context, userId)
+	if err != nil {
+		t.Fatalf("AuthenticationMethodsClient.ListPhoneMethods(): %v", err)
+	}
+	if methods == nil || len(*methods) != 1 {
+		t.Fatalf("AuthenticationMethodsClient.ListPhoneMethods(): expected 1 phone method, got %v", methods)
+	}
+	return
+}
+
+func testAuthenticationMethodsClient_List(t *testing.T, c AuthenticationMethodsClientTest, userId string) (methods *[]msgraph.AuthenticationMethod) {
+	methods, _, err := c.client.List(c.connection.Context, userId)
+	if err != nil {
+		t.Fatalf("AuthenticationMethodsClient.List(): %v", err)
+	}
+	if methods == nil {
+		t.Fatal("AuthenticationMethodsClient.List(): methods was nil")
+	}
+	return
+}
+
+func testAuthenticationMethodsClient_DeletePhoneMethod(t *testing.T, c AuthenticationMethodsClientTest, userId, id string) {
+	status, err := c.client.DeletePhoneMethod(c.connection.Context, userId, id)
+	if err != nil {
+		t.Fatalf("AuthenticationMethodsClient.DeletePhoneMethod(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("AuthenticationMethodsClient.DeletePhoneMethod(): invalid status: %d", status)
+	}
+}
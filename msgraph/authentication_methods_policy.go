@@ -0,0 +1,82 @@
+package msgraph
+
+import (
+	"context"
+	"errors"
+
+	"github.com/manicminer/hamilton/odata"
+)
+
+// AuthenticationMethodsPolicyClient performs operations on the Authentication Methods Policy of the tenant, and the
+// configurations of each authentication method.
+type AuthenticationMethodsPolicyClient struct {
+	BaseClient Client
+}
+
+// NewAuthenticationMethodsPolicyClient returns a new AuthenticationMethodsPolicyClient.
+func NewAuthenticationMethodsPolicyClient(tenantId string) *AuthenticationMethodsPolicyClient {
+	return &AuthenticationMethodsPolicyClient{
+		BaseClient: NewClient(Version10, tenantId),
+	}
+}
+
+// resource returns a Resource for performing common operations on the Authentication Methods Policy, which is a
+// singleton.
+func (c *AuthenticationMethodsPolicyClient) resource() Resource {
+	return Resource{
+		Client: c.BaseClient,
+		Name:   "AuthenticationMethodsPolicyClient",
+		Entity: "/policies/authenticationMethodsPolicy",
+	}
+}
+
+// configurationsResource returns a Resource for performing common operations on authentication method configurations.
+func (c *AuthenticationMethodsPolicyClient) configurationsResource() Resource {
+	return Resource{
+		Client: c.BaseClient,
+		Name:   "AuthenticationMethodsPolicyClient",
+		Entity: "/policies/authenticationMethodsPolicy/authenticationMethodConfigurations",
+	}
+}
+
+// Get retrieves the Authentication Methods Policy, including the configuration of each authentication method.
+func (c *AuthenticationMethodsPolicyClient) Get(ctx context.Context) (*AuthenticationMethodsPolicy, int, error) {
+	var policy AuthenticationMethodsPolicy
+	status, err := c.resource().GetSingleton(ctx, odata.Query{}, &policy)
+	if err != nil {
+		return nil, status, err
+	}
+	return &policy, status, nil
+}
+
+// Update amends the Authentication Methods Policy. The configurations of authentication methods cannot be changed
+// using this method, use UpdateConfiguration() instead.
+func (c *AuthenticationMethodsPolicyClient) Update(ctx context.Context, policy AuthenticationMethodsPolicy) (int, error) {
+	policy.ID = nil
+	policy.AuthenticationMethodConfigurations = nil
+	return c.resource().UpdateSingleton(ctx, policy)
+}
+
+// GetConfiguration retrieves the configuration of an authentication method, e.g.
+// AuthenticationMethodConfigurationIdFido2.
+func (c *AuthenticationMethodsPolicyClient) GetConfiguration(ctx context.Context, id string) (*AuthenticationMethodConfiguration, int, error) {
+	var configuration AuthenticationMethodConfiguration
+	status, err := c.configurationsResource().Get(ctx, id, odata.Query{}, &configuration)
+	if err != nil {
+		return nil, status, err
+	}
+	return &configuration, status, nil
+}
+
+// UpdateConfiguration amends the configuration of an authentication method, such as whether it is enabled and which
+// users and groups it targets. The ODataType of the configuration must be specified.
+func (c *AuthenticationMethodsPolicyClient) UpdateConfiguration(ctx context.Context, configuration AuthenticationMethodConfiguration) (int, error) {
+	var status int
+	if configuration.ID == nil {
+		return status, errors.New("AuthenticationMethodsPolicyClient.UpdateConfiguration(): cannot update authentication method configuration with nil ID")
+	}
+	if configuration.ODataType == nil {
+		return status, errors.New("AuthenticationMethodsPolicyClient.UpdateConfiguration(): cannot update authentication method configuration with nil ODataType")
+	}
+	return c.configurationsResource().Update(ctx, *configuration.ID, configuration)
+}
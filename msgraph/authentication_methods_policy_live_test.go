@@ -0,0 +1,90 @@
+//go:build live
+// +build live
+
+package msgraph_test
+
+import (
+	"testing"
+
+	"github.com/manicminer/hamilton/auth"
+	"github.com/manicminer/hamilton/internal/test"
+	"github.com/manicminer/hamilton/msgraph"
+)
+
+type AuthenticationMethodsPolicyClientTest struct {
+	connection *test.Connection
+	client     *msgraph.AuthenticationMethodsPolicyClient
+}
+
+// TestAuthenticationMethodsPolicyClient_Live writes back the existing settings of the tenant, so that running it does
+// not change which authentication methods are enabled.
+func TestAuthenticationMethodsPolicyClient_Live(t *testing.T) {
+	c := AuthenticationMethodsPolicyClientTest{
+		connection: test.NewConnection(auth.MsGraph, auth.TokenVersion2),
+	}
+	c.client = msgraph.NewAuthenticationMethodsPolicyClient(c.connection.AuthConfig.TenantID)
+	c.client.BaseClient.Authorizer = c.connection.Authorizer
+
+	policy := testAuthenticationMethodsPolicyClient_Get(t, c)
+	testAuthenticationMethodsPolicyClient_Update(t, c, msgraph.AuthenticationMethodsPolicy{
+		ReconfirmationInDays: policy.ReconfirmationInDays,
+	})
+
+	configuration := testAuthenticationMethodsPolicyClient_GetConfiguration(t, c, msgraph.AuthenticationMethodConfigurationIdFido2)
+	testAuthenticationMethodsPolicyClient_UpdateConfiguration(t, c, msgraph.AuthenticationMethodConfiguration{
+		ODataType: configuration.ODataType,
+		ID:        configuration.ID,
+		State:     configuration.State,
+	})
+}
+
+func testAuthenticationMethodsPolicyClient_Get(t *testing.T, c AuthenticationMethodsPolicyClientTest) (policy *msgraph.AuthenticationMethodsPolicy) {
+	policy, status, err := c.client.Get(c.connection.Context)
+	if err != nil {
+		t.Fatalf("AuthenticationMethodsPolicyClient.Get(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("AuthenticationMethodsPolicyClient.Get(): invalid status: %d", status)
+	}
+	if policy == nil {
+		t.Fatal("AuthenticationMethodsPolicyClient.Get(): policy was nil")
+	}
+	return
+}
+
+func testAuthenticationMethodsPolicyClient_Update(t *testing.T, c AuthenticationMethodsPolicyClientTest, p msgraph.AuthenticationMethodsPolicy) {
+	status, err := c.client.Update(c.connection.Context, p)
+	if err != nil {
+		t.Fatalf("AuthenticationMethodsPolicyClient.Update(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("AuthenticationMethodsPolicyClient.Update(): invalid status: %d", status)
+	}
+}
+
+func testAuthenticationMethodsPolicyClient_GetConfiguration(t *testing.T, c AuthenticationMethodsPolicyClientTest, id string) (configuration *msgraph.AuthenticationMethodConfiguration) {
+	configuration, status, err := c.client.GetConfiguration(c.connection.Context, id)
+	if err != nil {
+		t.Fatalf("AuthenticationMethodsPolicyClient.GetConfiguration(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("AuthenticationMethodsPolicyClient.GetConfiguration(): invalid status: %d", status)
+	}
+	if configuration == nil {
+		t.Fatal("AuthenticationMethodsPolicyClient.GetConfiguration(): configuration was nil")
+	}
+	if configuration.ODataType == nil {
+		t.Fatal("AuthenticationMethodsPolicyClient.GetConfiguration(): configuration.ODataType was nil")
+	}
+	return
+}
+
+func testAuthenticationMethodsPolicyClient_UpdateConfiguration(t *testing.T, c AuthenticationMethodsPolicyClientTest, m msgraph.AuthenticationMethodConfiguration) {
+	status, err := c.client.UpdateConfiguration(c.connection.Context, m)
+	if err != nil {
+		t.Fatalf("AuthenticationMethodsPolicyClient.UpdateConfiguration(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("AuthenticationMethodsPolicyClient.UpdateConfiguration(): invalid status: %d", status)
+	}
+}
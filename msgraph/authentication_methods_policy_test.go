@@ -0,0 +1,109 @@
+package msgraph_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/manicminer/hamilton/internal/utils"
+	"github.com/manicminer/hamilton/msgraph"
+	"github.com/manicminer/hamilton/msgraph/msgraphtest"
+)
+
+func TestAuthenticationMethodsPolicyClient(t *testing.T) {
+	server := msgraphtest.NewServer()
+	defer server.Close()
+	ctx := context.Background()
+
+	client := msgraph.NewAuthenticationMethodsPolicyClient("tenant")
+	client.BaseClient.Endpoint = server.Endpoint()
+
+	groupId := server.Add("groups", msgraph.Group{
+		DisplayName:  utils.StringPtr("test-group"),
+		MailNickname: utils.StringPtr("test-group"),
+	})
+	policyPath := "/policies/authenticationMethodsPolicy"
+	fido2Path := policyPath + "/authenticationMethodConfigurations/" + msgraph.AuthenticationMethodConfigurationIdFido2
+
+	policy, _, err := client.Get(ctx)
+	if err != nil {
+		t.Fatalf("AuthenticationMethodsPolicyClient.Get(): %v", err)
+	}
+	if policy.AuthenticationMethodConfigurations == nil || len(*policy.AuthenticationMethodConfigurations) == 0 {
+		t.Fatalf("AuthenticationMethodsPolicyClient.Get(): expected the policy to include method configurations, got %v", policy)
+	}
+	for _, c := range *policy.AuthenticationMethodConfigurations {
+		if c.ODataType == nil || c.State == nil {
+			t.Errorf("AuthenticationMethodsPolicyClient.Get(): expected configuration %v to have a type and state", c.ID)
+		}
+	}
+
+	policy.ReconfirmationInDays = utils.Int32Ptr(180)
+	if _, err := client.Update(ctx, *policy); err != nil {
+		t.Fatalf("AuthenticationMethodsPolicyClient.Update(): %v", err)
+	}
+	policy, _, err = client.Get(ctx)
+	if err != nil {
+		t.Fatalf("AuthenticationMethodsPolicyClient.Get(): %v", err)
+	}
+	if policy.ReconfirmationInDays == nil || *policy.ReconfirmationInDays != 180 {
+		t.Errorf("AuthenticationMethodsPolicyClient.Update(): expected ReconfirmationInDays to be updated, got %v", policy.ReconfirmationInDays)
+	}
+	expectRequests(t, server,
+		expectedRequest{http.MethodGet, policyPath, ""},
+		expectedRequest{http.MethodPatch, policyPath, `{"reconfirmationInDays": 180}`},
+		expectedRequest{http.MethodGet, policyPath, ""},
+	)
+
+	// enable FIDO2 security keys for members of the test group, with attestation enforced
+	if _, err := client.UpdateConfiguration(ctx, msgraph.AuthenticationMethodConfiguration{ID: utils.StringPtr(msgraph.AuthenticationMethodConfigurationIdFido2)}); err == nil {
+		t.Fatalf("AuthenticationMethodsPolicyClient.UpdateConfiguration(): expected an error for a configuration with nil ODataType")
+	}
+	enabled := msgraph.AuthenticationMethodStateEnabled
+	targetType := msgraph.AuthenticationMethodTargetTypeGroup
+	if _, err := client.UpdateConfiguration(ctx, msgraph.AuthenticationMethodConfiguration{
+		ODataType: utils.StringPtr(msgraph.AuthenticationMethodConfigurationFido2),
+		ID:        utils.StringPtr(msgraph.AuthenticationMethodConfigurationIdFido2),
+		IncludeTargets: &[]msgraph.AuthenticationMethodTarget{
+			{ID: utils.StringPtr("not-a-group"), TargetType: &targetType},
+		},
+	}); err == nil {
+		t.Fatalf("AuthenticationMethodsPolicyClient.UpdateConfiguration(): expected an error for a configuration targeting a nonexistent group")
+	}
+	if _, err := client.UpdateConfiguration(ctx, msgraph.AuthenticationMethodConfiguration{
+		ODataType: utils.StringPtr(msgraph.AuthenticationMethodConfigurationFido2),
+		ID:        utils.StringPtr(msgraph.AuthenticationMethodConfigurationIdFido2),
+		IncludeTargets: &[]msgraph.AuthenticationMethodTarget{
+			{ID: utils.StringPtr(groupId), IsRegistrationRequired: utils.BoolPtr(false), TargetType: &targetType},
+		},
+		IsAttestationEnforced: utils.BoolPtr(true),
+		State:                 &enabled,
+	}); err != nil {
+		t.Fatalf("AuthenticationMethodsPolicyClient.UpdateConfiguration(): %v", err)
+	}
+	expectRequests(t, server,
+		expectedRequest{http.MethodPatch, fido2Path, `{"@odata.type": "#microsoft.graph.fido2AuthenticationMethodConfiguration", "includeTargets": [{"id": "not-a-group", "targetType": "group"}]}`},
+		expectedRequest{http.MethodPatch, fido2Path, fmt.Sprintf(`{
+			"@odata.type": "#microsoft.graph.fido2AuthenticationMethodConfiguration",
+			"includeTargets": [{"id": %q, "isRegistrationRequired": false, "targetType": "group"}],
+			"isAttestationEnforced": true,
+			"state": "enabled"
+		}`, groupId)},
+	)
+
+	configuration, _, err := client.GetConfiguration(ctx, msgraph.AuthenticationMethodConfigurationIdFido2)
+	if err != nil {
+		t.Fatalf("AuthenticationMethodsPolicyClient.GetConfiguration(): %v", err)
+	}
+	if *configuration.ODataType != msgraph.AuthenticationMethodConfigurationFido2 || *configuration.State != enabled {
+		t.Errorf("AuthenticationMethodsPolicyClient.GetConfiguration(): expected an enabled FIDO2 configuration, got %v", configuration)
+	}
+	if configuration.IsAttestationEnforced == nil || !*configuration.IsAttestationEnforced {
+		t.Errorf("AuthenticationMethodsPolicyClient.GetConfiguration(): expected attestation to be enforced")
+	}
+	if configuration.IncludeTargets == nil || len(*configuration.IncludeTargets) != 1 || *(*configuration.IncludeTargets)[0].ID != groupId {
+		t.Errorf("AuthenticationMethodsPolicyClient.GetConfiguration(): expected the configuration to target the test group, got %v", configuration.IncludeTargets)
+	}
+	expectRequests(t, server, expectedRequest{http.MethodGet, fido2Path, ""})
+}
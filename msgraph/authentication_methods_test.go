@@ -0,0 +1,203 @@
+package msgraph_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/manicminer/hamilton/internal/utils"
+	"github.com/manicminer/hamilton/msgraph"
+	"github.com/manicminer/hamilton/msgraph/msgraphtest"
+)
+
+func TestAuthenticationMethodsClient(t *testing.T) {
+	server := msgraphtest.NewServer()
+	defer server.Close()
+	ctx := context.Background()
+
+	client := msgraph.NewAuthenticationMethodsClient("tenant")
+	client.BaseClient.Endpoint = server.Endpoint()
+	usersClient := msgraph.NewUsersClient("tenant")
+	usersClient.BaseClient.Endpoint = server.Endpoint()
+
+	user, _, err := usersClient.Create(ctx, msgraph.User{
+		AccountEnabled:    utils.BoolPtr(true),
+		DisplayName:       utils.StringPtr("test-user"),
+		MailNickname:      utils.StringPtr("test-user"),
+		UserPrincipalName: utils.StringPtr("test-user@example.com"),
+		PasswordProfile: &msgraph.UserPasswordProfile{
+			Password: utils.StringPtr("Super$ecret123"),
+		},
+	})
+	if err != nil {
+		t.Fatalf("UsersClient.Create(): %v", err)
+	}
+	fido2Id := server.AddAuthenticationMethod(*user.ID, msgraph.ODataTypeFido2AuthenticationMethod, msgraph.Fido2AuthenticationMethod{
+		DisplayName: utils.StringPtr("test-security-key"),
+		Model:       utils.StringPtr("test-model"),
+	})
+	server.ClearRequests()
+	methodsPath := "/users/" + *user.ID + "/authentication/"
+
+	// issue a Temporary Access Pass to the new user
+	tap, status, err := client.CreateTemporaryAccessPassMethod(ctx, *user.ID, msgraph.TemporaryAccessPassAuthenticationMethod{
+		IsUsableOnce:      utils.BoolPtr(true),
+		LifetimeInMinutes: utils.Int32Ptr(120),
+	})
+	if err != nil {
+		t.Fatalf("AuthenticationMethodsClient.CreateTemporaryAccessPassMethod(): %v", err)
+	}
+	if status != http.StatusCreated || tap.ID == nil || tap.TemporaryAccessPass == nil || *tap.TemporaryAccessPass == "" {
+		t.Fatalf("AuthenticationMethodsClient.CreateTemporaryAccessPassMethod(): expected a new passcode with status 201, got %v with status %d", tap, status)
+	}
+	if tap.IsUsable == nil || !*tap.IsUsable || *tap.LifetimeInMinutes != 120 {
+		t.Errorf("AuthenticationMethodsClient.CreateTemporaryAccessPassMethod(): unexpected method %v", tap)
+	}
+	if _, _, err := client.CreateTemporaryAccessPassMethod(ctx, *user.ID, msgraph.TemporaryAccessPassAuthenticationMethod{}); err == nil {
+		t.Fatalf("AuthenticationMethodsClient.CreateTemporaryAccessPassMethod(): expected an error for a user which already has a Temporary Access Pass")
+	}
+	got, _, err := client.GetTemporaryAccessPassMethod(ctx, *user.UserPrincipalName, *tap.ID)
+	if err != nil {
+		t.Fatalf("AuthenticationMethodsClient.GetTemporaryAccessPassMethod(): %v", err)
+	}
+	if got.TemporaryAccessPass != nil {
+		t.Errorf("AuthenticationMethodsClient.GetTemporaryAccessPassMethod(): expected the passcode not to be returned")
+	}
+	expectRequests(t, server,
+		expectedRequest{http.MethodPost, methodsPath + "temporaryAccessPassMethods", `{"isUsableOnce": true, "lifetimeInMinutes": 120}`},
+		expectedRequest{http.MethodPost, methodsPath + "temporaryAccessPassMethods", `{}`},
+		expectedRequest{http.MethodGet, "/users/" + *user.UserPrincipalName + "/authentication/temporaryAccessPassMethods/" + *tap.ID, ""},
+	)
+
+	if _, _, err := client.CreatePhoneMethod(ctx, *user.ID, msgraph.PhoneAuthenticationMethod{PhoneNumber: utils.StringPtr("+1 5555551234")}); err == nil {
+		t.Fatalf("AuthenticationMethodsClient.CreatePhoneMethod(): expected an error for a phone method with nil PhoneType")
+	}
+	phoneType := msgraph.AuthenticationPhoneTypeMobile
+	phone, _, err := client.CreatePhoneMethod(ctx, *user.ID, msgraph.PhoneAuthenticationMethod{
+		PhoneNumber: utils.StringPtr("+1 5555551234"),
+		PhoneType:   &phoneType,
+	})
+	if err != nil {
+		t.Fatalf("AuthenticationMethodsClient.CreatePhoneMethod(): %v", err)
+	}
+	phone.PhoneNumber = utils.StringPtr("+1 5555554321")
+	if _, err := client.UpdatePhoneMethod(ctx, *user.ID, *phone); err != nil {
+		t.Fatalf("AuthenticationMethodsClient.UpdatePhoneMethod(): %v", err)
+	}
+	phones, _, err := client.ListPhoneMethods(ctx, *user.ID)
+	if err != nil {
+		t.Fatalf("AuthenticationMethodsClient.ListPhoneMethods(): %v", err)
+	}
+	if phones == nil || len(*phones) != 1 || *(*phones)[0].PhoneNumber != "+1 5555554321" {
+		t.Fatalf("AuthenticationMethodsClient.ListPhoneMethods(): expected the updated phone method, got %v", phones)
+	}
+	expectRequests(t, server,
+		expectedRequest{http.MethodPost, methodsPath + "phoneMethods", `{"phoneNumber": "+1 5555551234", "phoneType": "mobile"}`},
+		expectedRequest{http.MethodPatch, methodsPath + "phoneMethods/" + *phone.ID, `{"phoneNumber": "+1 5555554321", "phoneType": "mobile"}`},
+		expectedRequest{http.MethodGet, methodsPath + "phoneMethods", ""},
+	)
+
+	email, _, err := client.CreateEmailMethod(ctx, *user.ID, msgraph.EmailAuthenticationMethod{EmailAddress: utils.StringPtr("test-user@example.net")})
+	if err != nil {
+		t.Fatalf("AuthenticationMethodsClient.CreateEmailMethod(): %v", err)
+	}
+	if _, err := client.UpdateEmailMethod(ctx, *user.ID, msgraph.EmailAuthenticationMethod{}); err == nil {
+		t.Fatalf("AuthenticationMethodsClient.UpdateEmailMethod(): expected an error for an email method with nil ID")
+	}
+	expectRequests(t, server, expectedRequest{http.MethodPost, methodsPath + "emailMethods", `{"emailAddress": "test-user@example.net"}`})
+
+	methods, _, err := client.List(ctx, *user.ID)
+	if err != nil {
+		t.Fatalf("AuthenticationMethodsClient.List(): %v", err)
+	}
+	if methods == nil || len(*methods) != 5 {
+		t.Fatalf("AuthenticationMethodsClient.List(): expected 5 methods, got %v", methods)
+	}
+	for _, method := range *methods {
+		switch m := method.(type) {
+		case *msgraph.PasswordAuthenticationMethod:
+			if *m.ID != msgraph.PasswordAuthenticationMethodId {
+				t.Errorf("AuthenticationMethodsClient.List(): unexpected password method ID %q", *m.ID)
+			}
+		case *msgraph.Fido2AuthenticationMethod:
+			if *m.ID != fido2Id || *m.Model != "test-model" {
+				t.Errorf("AuthenticationMethodsClient.List(): unexpected FIDO2 method %v", m)
+			}
+		case *msgraph.TemporaryAccessPassAuthenticationMethod, *msgraph.PhoneAuthenticationMethod, *msgraph.EmailAuthenticationMethod:
+		default:
+			t.Errorf("AuthenticationMethodsClient.List(): unexpected method of type %T", m)
+		}
+	}
+
+	fido2, _, err := client.GetFido2Method(ctx, *user.ID, fido2Id)
+	if err != nil {
+		t.Fatalf("AuthenticationMethodsClient.GetFido2Method(): %v", err)
+	}
+	if *fido2.DisplayName != "test-security-key" {
+		t.Errorf("AuthenticationMethodsClient.GetFido2Method(): unexpected method %v", fido2)
+	}
+	if _, err := client.DeleteFido2Method(ctx, *user.ID, fido2Id); err != nil {
+		t.Fatalf("AuthenticationMethodsClient.DeleteFido2Method(): %v", err)
+	}
+	if _, status, err := client.GetFido2Method(ctx, *user.ID, fido2Id); err == nil || status != http.StatusNotFound {
+		t.Errorf("AuthenticationMethodsClient.GetFido2Method(): expected status 404 for a deleted method, got %d", status)
+	}
+	expectRequests(t, server,
+		expectedRequest{http.MethodGet, methodsPath + "methods", ""},
+		expectedRequest{http.MethodGet, methodsPath + "fido2Methods/" + fido2Id, ""},
+		expectedRequest{http.MethodDelete, methodsPath + "fido2Methods/" + fido2Id, ""},
+		expectedRequest{http.MethodGet, methodsPath + "fido2Methods/" + fido2Id, ""},
+	)
+
+	for _, del := range []func() (int, error){
+		func() (int, error) { return client.DeleteTemporaryAccessPassMethod(ctx, *user.ID, *tap.ID) },
+		func() (int, error) { return client.DeletePhoneMethod(ctx, *user.ID, *phone.ID) },
+		func() (int, error) { return client.DeleteEmailMethod(ctx, *user.ID, *email.ID) },
+	} {
+		if _, err := del(); err != nil {
+			t.Fatalf("AuthenticationMethodsClient: deleting method: %v", err)
+		}
+	}
+	methods, _, err = client.List(ctx, *user.ID)
+	if err != nil {
+		t.Fatalf("AuthenticationMethodsClient.List(): %v", err)
+	}
+	if len(*methods) != 1 {
+		t.Errorf("AuthenticationMethodsClient.List(): expected only the password method to remain, got %v", methods)
+	}
+	expectRequests(t, server,
+		expectedRequest{http.MethodDelete, methodsPath + "temporaryAccessPassMethods/" + *tap.ID, ""},
+		expectedRequest{http.MethodDelete, methodsPath + "phoneMethods/" + *phone.ID, ""},
+		expectedRequest{http.MethodDelete, methodsPath + "emailMethods/" + *email.ID, ""},
+		expectedRequest{http.MethodGet, methodsPath + "methods", ""},
+	)
+
+	passwords, _, err := client.ListPasswordMethods(ctx, *user.ID)
+	if err != nil {
+		t.Fatalf("AuthenticationMethodsClient.ListPasswordMethods(): %v", err)
+	}
+	if len(*passwords) != 1 || *(*passwords)[0].ID != msgraph.PasswordAuthenticationMethodId {
+		t.Fatalf("AuthenticationMethodsClient.ListPasswordMethods(): expected the password method, got %v", passwords)
+	}
+	expectRequests(t, server, expectedRequest{http.MethodGet, methodsPath + "passwordMethods", ""})
+	resetPath := methodsPath + "methods/" + msgraph.PasswordAuthenticationMethodId + "/resetPassword"
+	reset, status, err := client.ResetPassword(ctx, *user.ID, "")
+	if err != nil {
+		t.Fatalf("AuthenticationMethodsClient.ResetPassword(): %v", err)
+	}
+	if status != http.StatusAccepted || reset.NewPassword == nil || *reset.NewPassword == "" {
+		t.Errorf("AuthenticationMethodsClient.ResetPassword(): expected a generated password with status 202, got %v with status %d", reset, status)
+	}
+	if requests := server.Requests(); len(requests) != 1 || requests[0].Method != http.MethodPost || requests[0].Path != resetPath || string(requests[0].Body) != "{}" {
+		t.Errorf("AuthenticationMethodsClient.ResetPassword(): expected POST %s with an empty object, got %v", resetPath, requests)
+	}
+	server.ClearRequests()
+	reset, _, err = client.ResetPassword(ctx, *user.ID, "An0ther$ecret456")
+	if err != nil {
+		t.Fatalf("AuthenticationMethodsClient.ResetPassword(): %v", err)
+	}
+	if reset.NewPassword != nil {
+		t.Errorf("AuthenticationMethodsClient.ResetPassword(): expected no password to be returned when one was specified")
+	}
+	expectRequests(t, server, expectedRequest{http.MethodPost, resetPath, `{"newPassword": "An0ther$ecret456"}`})
+}
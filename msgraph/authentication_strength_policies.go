@@ -0,0 +1,112 @@
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/manicminer/hamilton/odata"
+)
+
+// AuthenticationStrengthPoliciesClient performs operations on Authentication Strength Policies.
+type AuthenticationStrengthPoliciesClient struct {
+	BaseClient Client
+}
+
+// NewAuthenticationStrengthPoliciesClient returns a new AuthenticationStrengthPoliciesClient.
+func NewAuthenticationStrengthPoliciesClient(tenantId string) *AuthenticationStrengthPoliciesClient {
+	return &AuthenticationStrengthPoliciesClient{
+		BaseClient: NewClient(Version10, tenantId),
+	}
+}
+
+// resource returns a Resource for performing common operations on Authentication Strength Policies.
+func (c *AuthenticationStrengthPoliciesClient) resource() Resource {
+	return Resource{
+		Client: c.BaseClient,
+		Name:   "AuthenticationStrengthPoliciesClient",
+		Entity: "/policies/authenticationStrengthPolicies",
+	}
+}
+
+// List returns a list of Authentication Strength Policies, including the built-in policies, optionally queried using
+// OData.
+func (c *AuthenticationStrengthPoliciesClient) List(ctx context.Context, query odata.Query) (*[]AuthenticationStrengthPolicy, int, error) {
+	var policies []AuthenticationStrengthPolicy
+	status, err := c.resource().List(ctx, query, &policies)
+	if err != nil {
+		return nil, status, err
+	}
+	return &policies, status, nil
+}
+
+// Create creates a new custom Authentication Strength Policy.
+func (c *AuthenticationStrengthPoliciesClient) Create(ctx context.Context, policy AuthenticationStrengthPolicy) (*AuthenticationStrengthPolicy, int, error) {
+	var status int
+	if policy.AllowedCombinations == nil || len(*policy.AllowedCombinations) == 0 {
+		return nil, status, errors.New("AuthenticationStrengthPoliciesClient.Create(): cannot create authentication strength policy without AllowedCombinations")
+	}
+	var newPolicy AuthenticationStrengthPolicy
+	status, err := c.resource().Create(ctx, policy, &newPolicy)
+	if err != nil {
+		return nil, status, err
+	}
+	return &newPolicy, status, nil
+}
+
+// Get retrieves an Authentication Strength Policy, e.g. AuthenticationStrengthPolicyPhishingResistant.
+func (c *AuthenticationStrengthPoliciesClient) Get(ctx context.Context, id string) (*AuthenticationStrengthPolicy, int, error) {
+	var policy AuthenticationStrengthPolicy
+	status, err := c.resource().Get(ctx, id, odata.Query{}, &policy)
+	if err != nil {
+		return nil, status, err
+	}
+	return &policy, status, nil
+}
+
+// Update amends the name and description of an existing custom Authentication Strength Policy. The allowed
+// combinations cannot be changed using this method, use UpdateAllowedCombinations() instead.
+func (c *AuthenticationStrengthPoliciesClient) Update(ctx context.Context, policy AuthenticationStrengthPolicy) (int, error) {
+	var status int
+	if policy.ID == nil {
+		return status, errors.New("AuthenticationStrengthPoliciesClient.Update(): cannot update authentication strength policy with nil ID")
+	}
+	return c.resource().Update(ctx, *policy.ID, AuthenticationStrengthPolicy{
+		Description: policy.Description,
+		DisplayName: policy.DisplayName,
+	})
+}
+
+// UpdateAllowedCombinations replaces the combinations of authentication methods which satisfy a custom Authentication
+// Strength Policy.
+func (c *AuthenticationStrengthPoliciesClient) UpdateAllowedCombinations(ctx context.Context, id string, allowedCombinations []AuthenticationMethodModes) (int, error) {
+	var status int
+	body, err := json.Marshal(struct {
+		AllowedCombinations []AuthenticationMethodModes `json:"allowedCombinations"`
+	}{
+		AllowedCombinations: allowedCombinations,
+	})
+	if err != nil {
+		return status, fmt.Errorf("json.Marshal(): %v", err)
+	}
+	_, status, _, err = c.BaseClient.Post(ctx, PostHttpRequestInput{
+		Body:             body,
+		ValidStatusCodes: []int{http.StatusOK, http.StatusNoContent},
+		Uri: Uri{
+			Entity:      fmt.Sprintf("/policies/authenticationStrengthPolicies/%s/updateAllowedCombinations", id),
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return status, fmt.Errorf("AuthenticationStrengthPoliciesClient.BaseClient.Post(): %w", err)
+	}
+	return status, nil
+}
+
+// Delete removes a custom Authentication Strength Policy. Policies which are required by a conditional access policy
+// cannot be deleted.
+func (c *AuthenticationStrengthPoliciesClient) Delete(ctx context.Context, id string) (int, error) {
+	return c.resource().Delete(ctx, id)
+}
@@ -0,0 +1,122 @@
+//go:build live
+// +build live
+
+package msgraph_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/manicminer/hamilton/auth"
+	"github.com/manicminer/hamilton/internal/test"
+	"github.com/manicminer/hamilton/internal/utils"
+	"github.com/manicminer/hamilton/msgraph"
+	"github.com/manicminer/hamilton/odata"
+)
+
+type AuthenticationStrengthPoliciesClientTest struct {
+	connection   *test.Connection
+	client       *msgraph.AuthenticationStrengthPoliciesClient
+	randomString string
+}
+
+func TestAuthenticationStrengthPoliciesClient_Live(t *testing.T) {
+	c := AuthenticationStrengthPoliciesClientTest{
+		connection:   test.NewConnection(auth.MsGraph, auth.TokenVersion2),
+		randomString: test.RandomString(),
+	}
+	c.client = msgraph.NewAuthenticationStrengthPoliciesClient(c.connection.AuthConfig.TenantID)
+	c.client.BaseClient.Authorizer = c.connection.Authorizer
+
+	testAuthenticationStrengthPoliciesClient_List(t, c, odata.Query{Filter: "policyType eq 'builtIn'"})
+
+	policy := testAuthenticationStrengthPoliciesClient_Create(t, c, msgraph.AuthenticationStrengthPolicy{
+		DisplayName: utils.StringPtr(fmt.Sprintf("test-authentication-strength-%s", c.randomString)),
+		AllowedCombinations: &[]msgraph.AuthenticationMethodModes{
+			msgraph.AuthenticationMethodModesFido2,
+		},
+	})
+	testAuthenticationStrengthPoliciesClient_Update(t, c, msgraph.AuthenticationStrengthPolicy{
+		ID:          policy.ID,
+		Description: utils.StringPtr("updated by the test suite"),
+		DisplayName: policy.DisplayName,
+	})
+	testAuthenticationStrengthPoliciesClient_UpdateAllowedCombinations(t, c, *policy.ID, []msgraph.AuthenticationMethodModes{
+		msgraph.AuthenticationMethodModesFido2,
+		msgraph.AuthenticationMethodModesPassword + "," + msgraph.AuthenticationMethodModesMicrosoftAuthenticatorPush,
+	})
+	testAuthenticationStrengthPoliciesClient_Get(t, c, *policy.ID)
+	testAuthenticationStrengthPoliciesClient_Delete(t, c, *policy.ID)
+}
+
+func testAuthenticationStrengthPoliciesClient_List(t *testing.T, c AuthenticationStrengthPoliciesClientTest, query odata.Query) (policies *[]msgraph.AuthenticationStrengthPolicy) {
+	policies, _, err := c.client.List(c.connection.Context, query)
+	if err != nil {
+		t.Fatalf("AuthenticationStrengthPoliciesClient.List(): %v", err)
+	}
+	if policies == nil || len(*policies) == 0 {
+		t.Fatal("AuthenticationStrengthPoliciesClient.List(): policies was nil or empty")
+	}
+	return
+}
+
+func testAuthenticationStrengthPoliciesClient_Create(t *testing.T, c AuthenticationStrengthPoliciesClientTest, p msgraph.AuthenticationStrengthPolicy) (policy *msgraph.AuthenticationStrengthPolicy) {
+	policy, status, err := c.client.Create(c.connection.Context, p)
+	if err != nil {
+		t.Fatalf("AuthenticationStrengthPoliciesClient.Create(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("AuthenticationStrengthPoliciesClient.Create(): invalid status: %d", status)
+	}
+	if policy == nil {
+		t.Fatal("AuthenticationStrengthPoliciesClient.Create(): policy was nil")
+	}
+	if policy.ID == nil {
+		t.Fatal("AuthenticationStrengthPoliciesClient.Create(): policy.ID was nil")
+	}
+	return
+}
+
+func testAuthenticationStrengthPoliciesClient_Get(t *testing.T, c AuthenticationStrengthPoliciesClientTest, id string) (policy *msgraph.AuthenticationStrengthPolicy) {
+	policy, status, err := c.client.Get(c.connection.Context, id)
+	if err != nil {
+		t.Fatalf("AuthenticationStrengthPoliciesClient.Get(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("AuthenticationStrengthPoliciesClient.Get(): invalid status: %d", status)
+	}
+	if policy == nil {
+		t.Fatal("AuthenticationStrengthPoliciesClient.Get(): policy was nil")
+	}
+	return
+}
+
+func testAuthenticationStrengthPoliciesClient_Update(t *testing.T, c AuthenticationStrengthPoliciesClientTest, p msgraph.AuthenticationStrengthPolicy) {
+	status, err := c.client.Update(c.connection.Context, p)
+	if err != nil {
+		t.Fatalf("AuthenticationStrengthPoliciesClient.Update(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("AuthenticationStrengthPoliciesClient.Update(): invalid status: %d", status)
+	}
+}
+
+func testAuthenticationStrengthPoliciesClient_UpdateAllowedCombinations(t *testing.T, c AuthenticationStrengthPoliciesClientTest, id string, allowedCombinations []msgraph.AuthenticationMethodModes) {
+	status, err := c.client.UpdateAllowedCombinations(c.connection.Context, id, allowedCombinations)
+	if err != nil {
+		t.Fatalf("AuthenticationStrengthPoliciesClient.UpdateAllowedCombinations(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("AuthenticationStrengthPoliciesClient.UpdateAllowedCombinations(): invalid status: %d", status)
+	}
+}
+
+func testAuthenticationStrengthPoliciesClient_Delete(t *testing.T, c AuthenticationStrengthPoliciesClientTest, id string) {
+	status, err := c.client.Delete(c.connection.Context, id)
+	if err != nil {
+		t.Fatalf("AuthenticationStrengthPoliciesClient.Delete(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("AuthenticationStrengthPoliciesClient.Delete(): invalid status: %d", status)
+	}
+}
@@ -0,0 +1,134 @@
+package msgraph_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/manicminer/hamilton/internal/utils"
+	"github.com/manicminer/hamilton/msgraph"
+	"github.com/manicminer/hamilton/msgraph/msgraphtest"
+	"github.com/manicminer/hamilton/odata"
+)
+
+func TestAuthenticationStrengthPoliciesClient(t *testing.T) {
+	server := msgraphtest.NewServer()
+	defer server.Close()
+	ctx := context.Background()
+
+	client := msgraph.NewAuthenticationStrengthPoliciesClient("tenant")
+	client.BaseClient.Endpoint = server.Endpoint()
+	policiesClient := msgraph.NewConditionalAccessPolicyClient("tenant")
+	policiesClient.BaseClient.Endpoint = server.Endpoint()
+	strengthPoliciesPath := "/policies/authenticationStrengthPolicies"
+
+	builtIn, _, err := client.List(ctx, odata.Query{Filter: "policyType eq 'builtIn'"})
+	if err != nil {
+		t.Fatalf("AuthenticationStrengthPoliciesClient.List(): %v", err)
+	}
+	if builtIn == nil || len(*builtIn) != 3 {
+		t.Fatalf("AuthenticationStrengthPoliciesClient.List(): expected 3 built-in policies, got %v", builtIn)
+	}
+	phishingResistant, _, err := client.Get(ctx, msgraph.AuthenticationStrengthPolicyPhishingResistant)
+	if err != nil {
+		t.Fatalf("AuthenticationStrengthPoliciesClient.Get(): %v", err)
+	}
+	if _, err := client.Update(ctx, *phishingResistant); err == nil {
+		t.Fatalf("AuthenticationStrengthPoliciesClient.Update(): expected an error for a built-in policy")
+	}
+	expectRequests(t, server,
+		expectedRequest{http.MethodGet, strengthPoliciesPath, ""},
+		expectedRequest{http.MethodGet, strengthPoliciesPath + "/" + msgraph.AuthenticationStrengthPolicyPhishingResistant, ""},
+		expectedRequest{http.MethodPatch, strengthPoliciesPath + "/" + msgraph.AuthenticationStrengthPolicyPhishingResistant, `{"displayName": "Phishing-resistant MFA"}`},
+	)
+
+	if _, _, err := client.Create(ctx, msgraph.AuthenticationStrengthPolicy{DisplayName: utils.StringPtr("test-strength")}); err == nil {
+		t.Fatalf("AuthenticationStrengthPoliciesClient.Create(): expected an error for a policy without AllowedCombinations")
+	}
+	policy, status, err := client.Create(ctx, msgraph.AuthenticationStrengthPolicy{
+		DisplayName: utils.StringPtr("test-strength"),
+		AllowedCombinations: &[]msgraph.AuthenticationMethodModes{
+			msgraph.AuthenticationMethodModesFido2,
+			msgraph.AuthenticationMethodModesPassword + "," + msgraph.AuthenticationMethodModesMicrosoftAuthenticatorPush,
+		},
+	})
+	if err != nil {
+		t.Fatalf("AuthenticationStrengthPoliciesClient.Create(): %v", err)
+	}
+	if status != http.StatusCreated || policy.PolicyType == nil || *policy.PolicyType != msgraph.AuthenticationStrengthPolicyTypeCustom {
+		t.Fatalf("AuthenticationStrengthPoliciesClient.Create(): unexpected policy %v with status %d", policy, status)
+	}
+	expectRequests(t, server, expectedRequest{http.MethodPost, strengthPoliciesPath, `{"displayName": "test-strength", "allowedCombinations": ["fido2", "password,microsoftAuthenticatorPush"]}`})
+	policyPath := strengthPoliciesPath + "/" + *policy.ID
+
+	policy.Description = utils.StringPtr("updated by the test suite")
+	if _, err := client.Update(ctx, *policy); err != nil {
+		t.Fatalf("AuthenticationStrengthPoliciesClient.Update(): %v", err)
+	}
+	if requests := server.Requests(); len(requests) == 1 && strings.Contains(string(requests[0].Body), "allowedCombinations") {
+		t.Errorf("AuthenticationStrengthPoliciesClient.Update(): expected allowedCombinations not to be sent, got %s", requests[0].Body)
+	}
+	expectRequests(t, server, expectedRequest{http.MethodPatch, policyPath, `{"displayName": "test-strength", "description": "updated by the test suite"}`})
+	if _, err := client.UpdateAllowedCombinations(ctx, *policy.ID, []msgraph.AuthenticationMethodModes{"notAMethod"}); err == nil {
+		t.Fatalf("AuthenticationStrengthPoliciesClient.UpdateAllowedCombinations(): expected an error for an unknown method")
+	}
+	if _, err := client.UpdateAllowedCombinations(ctx, *policy.ID, []msgraph.AuthenticationMethodModes{msgraph.AuthenticationMethodModesFido2}); err != nil {
+		t.Fatalf("AuthenticationStrengthPoliciesClient.UpdateAllowedCombinations(): %v", err)
+	}
+	policy, _, err = client.Get(ctx, *policy.ID)
+	if err != nil {
+		t.Fatalf("AuthenticationStrengthPoliciesClient.Get(): %v", err)
+	}
+	if *policy.Description != "updated by the test suite" || len(*policy.AllowedCombinations) != 1 {
+		t.Errorf("AuthenticationStrengthPoliciesClient.Get(): unexpected policy %v", policy)
+	}
+	expectRequests(t, server,
+		expectedRequest{http.MethodPost, policyPath + "/updateAllowedCombinations", `{"allowedCombinations": ["notAMethod"]}`},
+		expectedRequest{http.MethodPost, policyPath + "/updateAllowedCombinations", `{"allowedCombinations": ["fido2"]}`},
+		expectedRequest{http.MethodGet, policyPath, ""},
+	)
+
+	// require the custom authentication strength using a conditional access policy
+	state := msgraph.ConditionalAccessPolicyStateEnabledForReportingButNotEnforced
+	caPolicy, _, err := policiesClient.Create(ctx, msgraph.ConditionalAccessPolicy{
+		DisplayName: utils.StringPtr("test-require-strength"),
+		State:       &state,
+		Conditions: &msgraph.ConditionalAccessConditionSet{
+			Applications: &msgraph.ConditionalAccessApplications{IncludeApplications: &[]string{"All"}},
+			Users:        &msgraph.ConditionalAccessUsers{IncludeUsers: &[]string{"All"}},
+		},
+		GrantControls: &msgraph.ConditionalAccessGrantControls{
+			Operator:               utils.StringPtr("OR"),
+			AuthenticationStrength: &msgraph.AuthenticationStrengthPolicy{ID: policy.ID},
+		},
+	})
+	if err != nil {
+		t.Fatalf("ConditionalAccessPolicyClient.Create(): %v", err)
+	}
+	if caPolicy.GrantControls == nil || caPolicy.GrantControls.AuthenticationStrength == nil || *caPolicy.GrantControls.AuthenticationStrength.ID != *policy.ID {
+		t.Fatalf("ConditionalAccessPolicyClient.Create(): expected the policy to require the test authentication strength, got %v", caPolicy.GrantControls)
+	}
+	if _, err := client.Delete(ctx, *policy.ID); err == nil {
+		t.Fatalf("AuthenticationStrengthPoliciesClient.Delete(): expected an error for a policy required by a conditional access policy")
+	}
+	expectRequests(t, server,
+		expectedRequest{http.MethodPost, "/identity/conditionalAccess/policies", fmt.Sprintf(`{"grantControls": {"operator": "OR", "authenticationStrength": {"id": %q}}}`, *policy.ID)},
+		expectedRequest{http.MethodDelete, policyPath, ""},
+	)
+
+	if _, err := policiesClient.Delete(ctx, *caPolicy.ID); err != nil {
+		t.Fatalf("ConditionalAccessPolicyClient.Delete(): %v", err)
+	}
+	if _, err := client.Delete(ctx, *policy.ID); err != nil {
+		t.Fatalf("AuthenticationStrengthPoliciesClient.Delete(): %v", err)
+	}
+	expectRequests(t, server,
+		expectedRequest{http.MethodDelete, "/identity/conditionalAccess/policies/" + *caPolicy.ID, ""},
+		expectedRequest{http.MethodDelete, policyPath, ""},
+	)
+	if _, status, err := client.Get(ctx, *policy.ID); err == nil || status != http.StatusNotFound {
+		t.Errorf("AuthenticationStrengthPoliciesClient.Get(): expected status 404 for a deleted policy, got %d", status)
+	}
+}
@@ -0,0 +1,467 @@
+package msgraph
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/manicminer/hamilton/errors"
+	"github.com/manicminer/hamilton/odata"
+	"github.com/manicminer/hamilton/retry"
+)
+
+// batchMaxRequests is the maximum number of requests supported by Microsoft Graph in a single JSON batch.
+const batchMaxRequests = 20
+
+// BatchRequest describes a single request to be sent as part of a JSON batch.
+type BatchRequest struct {
+	// ID uniquely identifies the request within the batch. When empty, the position of the request is used.
+	ID string
+
+	// Method is the HTTP method for the request.
+	Method string
+
+	// Uri is the endpoint for the request. The API version and endpoint are inherited from the Client.
+	Uri Uri
+
+	// Body is an optional JSON request body.
+	Body []byte
+
+	// Headers are optional headers to send with the request.
+	Headers http.Header
+
+	// DependsOn optionally lists the IDs of requests which must complete before this request is performed. When any of
+	// these requests does not receive a valid response, this request is not performed and its response has the status
+	// 424 Failed Dependency.
+	DependsOn []string
+
+	// ValidStatusCodes are the status codes considered valid for this request. When empty, any 2xx status is valid.
+	ValidStatusCodes []int
+
+	// ValidStatusFunc is an optional function for evaluating whether a response is valid.
+	ValidStatusFunc ValidStatusFunc
+}
+
+// BatchResponse describes the response to a single request sent as part of a JSON batch.
+type BatchResponse struct {
+	ID      string
+	Status  int
+	Headers http.Header
+	Body    json.RawMessage
+	OData   *odata.OData
+}
+
+type batchRequestItem struct {
+	ID        string            `json:"id"`
+	Method    string            `json:"method"`
+	Url       string            `json:"url"`
+	Headers   map[string]string `json:"headers,omitempty"`
+	Body      json.RawMessage   `json:"body,omitempty"`
+	DependsOn []string          `json:"dependsOn,omitempty"`
+}
+
+type batchResponseItem struct {
+	ID      string            `json:"id"`
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers"`
+	Body    json.RawMessage   `json:"body"`
+}
+
+// Batch sends the provided requests using JSON batching. Requests are sent in batches of up to 20, in the order
+// provided, so any request listed in DependsOn must appear earlier in the slice. Requests that are throttled are
+// retried individually, honoring any Retry-After header returned for them.
+//
+// A BatchResponse is returned for every request. If any request does not receive a valid response, an error is
+// also returned describing each failure.
+//
+// The returned status is that of the last $batch request sent, which is 200 when the batch was accepted even if some
+// of the requests it contained failed. Check the Status of each BatchResponse, or inspect the returned error using
+// errors.As(), to determine the outcome of individual requests.
+func (c Client) Batch(ctx context.Context, requests []BatchRequest) (*[]BatchResponse, int, error) {
+	var status int
+
+	ids := make(map[string]bool, len(requests))
+	reqs := make([]BatchRequest, len(requests))
+	for i, r := range requests {
+		if r.ID == "" {
+			r.ID = strconv.Itoa(i + 1)
+		}
+		if ids[r.ID] {
+			return nil, status, fmt.Errorf("duplicate batch request ID %q", r.ID)
+		}
+		for _, d := range r.DependsOn {
+			if !ids[d] {
+				return nil, status, fmt.Errorf("batch request %q depends on %q, which must be specified earlier", r.ID, d)
+			}
+		}
+		ids[r.ID] = true
+		reqs[i] = r
+	}
+
+	results := make(map[string]BatchResponse, len(reqs))
+	for i := 0; i < len(reqs); i += batchMaxRequests {
+		end := i + batchMaxRequests
+		if end > len(reqs) {
+			end = len(reqs)
+		}
+
+		// Requests which depend on a failed request from an earlier batch are not sent
+		failed := make(map[string]bool)
+		for _, r := range reqs[:i] {
+			if !results[r.ID].valid(r) {
+				failed[r.ID] = true
+			}
+		}
+		pending := make([]BatchRequest, 0, end-i)
+		for _, r := range reqs[i:end] {
+			if d := r.failedDependency(failed); d != "" {
+				failed[r.ID] = true
+				results[r.ID] = failedDependencyResponse(r.ID, d)
+				continue
+			}
+			pending = append(pending, r)
+		}
+		if len(pending) == 0 {
+			continue
+		}
+
+		var err error
+		status, err = c.sendBatch(ctx, pending, results)
+		if err != nil {
+			return nil, status, err
+		}
+	}
+
+	ret := make([]BatchResponse, 0, len(reqs))
+	var failed []string
+	var firstErr error
+	for _, r := range reqs {
+		resp := results[r.ID]
+		ret = append(ret, resp)
+		if !resp.valid(r) {
+			failed = append(failed, r.ID)
+			if firstErr == nil {
+				e := &errors.ApiError{
+					Method:     r.Method,
+					Url:        c.batchUrl(r.Uri),
+					StatusCode: resp.Status,
+				}
+				if resp.OData != nil && resp.OData.Error != nil {
+					e.OData = resp.OData.Error
+				}
+				if e.OData == nil || e.OData.String() == "" {
+					e.ResponseBody = resp.Body
+				}
+				firstErr = fmt.Errorf("request %q: %w", r.ID, e)
+			}
+		}
+	}
+	if firstErr != nil {
+		return &ret, status, fmt.Errorf("batch requests failed with IDs [%s], first failure: %w", strings.Join(failed, ", "), firstErr)
+	}
+
+	return &ret, status, nil
+}
+
+// sendBatch is used by the package to send a single JSON batch, retrying any throttled requests.
+func (c Client) sendBatch(ctx context.Context, requests []BatchRequest, results map[string]BatchResponse) (int, error) {
+	var status int
+	policy := c.retryPolicy(ctx)
+	pending := requests
+
+	start := time.Now()
+	for attempt := 1; len(pending) > 0; attempt++ {
+		inBatch := make(map[string]bool, len(pending))
+		for _, r := range pending {
+			inBatch[r.ID] = true
+		}
+
+		items := make([]batchRequestItem, 0, len(pending))
+		for _, r := range pending {
+			item, err := c.batchItem(r, inBatch)
+			if err != nil {
+				return status, err
+			}
+			items = append(items, item)
+		}
+
+		body, err := json.Marshal(struct {
+			Requests []batchRequestItem `json:"requests"`
+		}{
+			Requests: items,
+		})
+		if err != nil {
+			return status, fmt.Errorf("json.Marshal(): %v", err)
+		}
+
+		var resp *http.Response
+		resp, status, _, err = c.Post(ctx, PostHttpRequestInput{
+			Body:             body,
+			ValidStatusCodes: []int{http.StatusOK},
+			Uri: Uri{
+				Entity: "/$batch",
+			},
+		})
+		if err != nil {
+			return status, fmt.Errorf("Client.Post(): %w", err)
+		}
+		respBody, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return status, fmt.Errorf("ioutil.ReadAll(): %v", err)
+		}
+
+		var data struct {
+			Responses []batchResponseItem `json:"responses"`
+		}
+		if err := json.Unmarshal(respBody, &data); err != nil {
+			return status, fmt.Errorf("json.Unmarshal(): %v", err)
+		}
+
+		throttled := make(map[string]bool)
+		var delay time.Duration
+		retryable := true
+		for _, item := range data.Responses {
+			r := BatchResponse{
+				ID:      item.ID,
+				Status:  item.Status,
+				Headers: http.Header{},
+				Body:    item.Body,
+			}
+			for k, v := range item.Headers {
+				r.Headers.Set(k, v)
+			}
+			if len(item.Body) > 0 && item.Body[0] == '{' {
+				var o odata.OData
+				if err := json.Unmarshal(item.Body, &o); err == nil {
+					r.OData = &o
+				}
+			}
+			results[item.ID] = r
+
+			if item.Status == http.StatusTooManyRequests || item.Status == http.StatusServiceUnavailable {
+				throttled[item.ID] = true
+				d, ok := policy.Retry(retry.Attempt{
+					Number:   attempt,
+					Start:    start,
+					Response: &http.Response{StatusCode: r.Status, Header: r.Headers},
+					OData:    r.OData,
+				})
+				if !ok {
+					retryable = false
+				}
+				if d > delay {
+					delay = d
+				}
+			}
+		}
+		if !retryable {
+			break
+		}
+
+		// Retry throttled requests, along with any requests that failed because they depend on a throttled request
+		var retrying []BatchRequest
+		for _, r := range pending {
+			if throttled[r.ID] {
+				retrying = append(retrying, r)
+				continue
+			}
+			if results[r.ID].Status == http.StatusFailedDependency {
+				for _, d := range r.DependsOn {
+					if throttled[d] {
+						throttled[r.ID] = true
+						retrying = append(retrying, r)
+						break
+					}
+				}
+			}
+		}
+		pending = retrying
+
+		if len(pending) > 0 {
+			if err := retry.Sleep(ctx, delay); err != nil {
+				return status, err
+			}
+		}
+	}
+
+	return status, nil
+}
+
+// batchItem is used by the package to build the JSON representation of a request within a batch.
+// Dependencies on requests that are not part of the current batch have already completed and are omitted.
+func (c Client) batchItem(r BatchRequest, inBatch map[string]bool) (batchRequestItem, error) {
+	if r.Method == "" {
+		return batchRequestItem{}, fmt.Errorf("batch request %q has no method", r.ID)
+	}
+
+	item := batchRequestItem{
+		ID:     r.ID,
+		Method: r.Method,
+		Url:    c.batchUrl(r.Uri),
+	}
+
+	if len(r.Headers) > 0 || len(r.Body) > 0 {
+		item.Headers = make(map[string]string)
+		for k := range r.Headers {
+			item.Headers[k] = r.Headers.Get(k)
+		}
+	}
+	if len(r.Body) > 0 {
+		item.Body = r.Body
+		if _, ok := item.Headers["Content-Type"]; !ok {
+			item.Headers["Content-Type"] = "application/json"
+		}
+	}
+
+	for _, d := range r.DependsOn {
+		if inBatch[d] {
+			item.DependsOn = append(item.DependsOn, d)
+		}
+	}
+
+	return item, nil
+}
+
+// batchUrl is used by the package to build the URL for a request within a batch, which is relative to the API version.
+func (c Client) batchUrl(uri Uri) string {
+	path := "/" + strings.TrimLeft(uri.Entity, "/")
+	if uri.HasTenantId {
+		path = fmt.Sprintf("/%s%s", c.TenantId, path)
+	}
+	if uri.Params != nil {
+		path = fmt.Sprintf("%s?%s", path, uri.Params.Encode())
+	}
+	return path
+}
+
+// failedDependency returns the ID of the first request listed in DependsOn which has failed, or an empty string.
+func (r BatchRequest) failedDependency(failed map[string]bool) string {
+	for _, d := range r.DependsOn {
+		if failed[d] {
+			return d
+		}
+	}
+	return ""
+}
+
+// failedDependencyResponse returns the response for a request which was not sent because a request it depends on
+// failed, in the same form as the response returned by Microsoft Graph for such requests within a single batch.
+func failedDependencyResponse(id, dependency string) BatchResponse {
+	message, _ := json.Marshal(fmt.Sprintf("Request %q failed, so dependent request %q was not performed.", dependency, id))
+	body := []byte(fmt.Sprintf(`{"error":{"code":"FailedDependency","message":%s}}`, message))
+	var o odata.OData
+	_ = json.Unmarshal(body, &o)
+	return BatchResponse{
+		ID:      id,
+		Status:  http.StatusFailedDependency,
+		Headers: http.Header{},
+		Body:    body,
+		OData:   &o,
+	}
+}
+
+// valid determines whether the response is considered valid for the provided request.
+func (r BatchResponse) valid(req BatchRequest) bool {
+	if len(req.ValidStatusCodes) == 0 {
+		if r.Status >= 200 && r.Status < 300 {
+			return true
+		}
+	} else if containsStatusCode(req.ValidStatusCodes, r.Status) {
+		return true
+	}
+
+	if f := req.ValidStatusFunc; f != nil {
+		resp := &http.Response{
+			StatusCode: r.Status,
+			Header:     r.Headers,
+			Body:       ioutil.NopCloser(bytes.NewBuffer(r.Body)),
+		}
+		o := r.OData
+		if o == nil {
+			o = &odata.OData{}
+		}
+		return f(resp, o)
+	}
+
+	return false
+}
+
+// batchAddRefs adds references to the directory objects in refs, which are URLs in the form used for @odata.id, to the
+// collection at path, e.g. "/directoryRoles/{id}/members", using one batched request per reference. References which already
+// exist are not considered an error.
+func (c Client) batchAddRefs(ctx context.Context, path string, refs []string) (int, error) {
+	// don't fail if a reference already exists
+	checkRefAlreadyExists := func(resp *http.Response, o *odata.OData) bool {
+		if resp.StatusCode == http.StatusBadRequest {
+			if o.Error != nil {
+				return o.Error.Match(odata.ErrorAddedObjectReferencesAlreadyExist)
+			}
+		}
+		return false
+	}
+
+	requests := make([]BatchRequest, 0, len(refs))
+	for _, ref := range refs {
+		data := struct {
+			Ref string `json:"@odata.id"`
+		}{
+			Ref: ref,
+		}
+		body, err := json.Marshal(data)
+		if err != nil {
+			return 0, fmt.Errorf("json.Marshal(): %v", err)
+		}
+		requests = append(requests, BatchRequest{
+			Method:           http.MethodPost,
+			Body:             body,
+			ValidStatusCodes: []int{http.StatusNoContent},
+			ValidStatusFunc:  checkRefAlreadyExists,
+			Uri: Uri{
+				Entity:      fmt.Sprintf("%s/$ref", path),
+				HasTenantId: true,
+			},
+		})
+	}
+	_, status, err := c.Batch(ctx, requests)
+	return status, err
+}
+
+// batchRemoveRefs removes references to the directory objects with the specified IDs from the collection at path, e.g.
+// "/directoryRoles/{id}/members", using one batched request per reference. References which do not exist are not considered an
+// error.
+func (c Client) batchRemoveRefs(ctx context.Context, path string, ids []string) (int, error) {
+	// don't fail if a reference has already been removed
+	checkRefGone := func(resp *http.Response, o *odata.OData) bool {
+		switch resp.StatusCode {
+		case http.StatusNotFound:
+			return true
+		case http.StatusBadRequest:
+			if o.Error != nil {
+				return o.Error.Match(odata.ErrorRemovedObjectReferencesDoNotExist)
+			}
+		}
+		return false
+	}
+
+	requests := make([]BatchRequest, 0, len(ids))
+	for _, id := range ids {
+		requests = append(requests, BatchRequest{
+			Method:           http.MethodDelete,
+			ValidStatusCodes: []int{http.StatusNoContent},
+			ValidStatusFunc:  checkRefGone,
+			Uri: Uri{
+				Entity:      fmt.Sprintf("%s/%s/$ref", path, id),
+				HasTenantId: true,
+			},
+		})
+	}
+	_, status, err := c.Batch(ctx, requests)
+	return status, err
+}
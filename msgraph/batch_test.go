@@ -0,0 +1,230 @@
+package msgraph_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/manicminer/hamilton/environments"
+	"github.com/manicminer/hamilton/errors"
+	"github.com/manicminer/hamilton/msgraph"
+)
+
+type batchServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	calls    int
+	requests []map[string]interface{}
+	respond  func(call int, req map[string]interface{}) (int, map[string]string, interface{})
+}
+
+func newBatchServer(respond func(call int, req map[string]interface{}) (int, map[string]string, interface{})) *batchServer {
+	s := &batchServer{respond: respond}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/beta/$batch" {
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+		var in struct {
+			Requests []map[string]interface{} `json:"requests"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if len(in.Requests) > 20 {
+			http.Error(w, "too many requests in batch", http.StatusBadRequest)
+			return
+		}
+
+		s.mu.Lock()
+		s.calls++
+		call := s.calls
+		s.requests = append(s.requests, in.Requests...)
+		s.mu.Unlock()
+
+		responses := make([]map[string]interface{}, 0, len(in.Requests))
+		// respond in reverse order, as the API does not guarantee ordering
+		for i := len(in.Requests) - 1; i >= 0; i-- {
+			status, headers, body := s.respond(call, in.Requests[i])
+			responses = append(responses, map[string]interface{}{
+				"id":      in.Requests[i]["id"],
+				"status":  status,
+				"headers": headers,
+				"body":    body,
+			})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"responses": responses})
+	}))
+	return s
+}
+
+func TestBatch(t *testing.T) {
+	server := newBatchServer(func(call int, req map[string]interface{}) (int, map[string]string, interface{}) {
+		if call == 1 {
+			switch req["id"] {
+			case "2":
+				return http.StatusTooManyRequests, map[string]string{"Retry-After": "0.01"}, nil
+			case "3":
+				return http.StatusFailedDependency, nil, nil
+			}
+		}
+		return http.StatusNoContent, nil, nil
+	})
+	defer server.Close()
+
+	client := msgraph.NewClient(msgraph.VersionBeta, "tenant")
+	client.Endpoint = environments.ApiEndpoint(server.URL)
+
+	var requests []msgraph.BatchRequest
+	for i := 1; i <= 25; i++ {
+		r := msgraph.BatchRequest{
+			Method: http.MethodPatch,
+			Body:   []byte(`{"displayName":"test"}`),
+			Uri: msgraph.Uri{
+				Entity:      fmt.Sprintf("/groups/group-%d", i),
+				HasTenantId: true,
+			},
+		}
+		switch i {
+		case 3:
+			r.DependsOn = []string{"2"}
+		case 22:
+			r.DependsOn = []string{"1"}
+		}
+		requests = append(requests, r)
+	}
+
+	responses, _, err := client.Batch(context.Background(), requests)
+	if err != nil {
+		t.Fatalf("Client.Batch(): %v", err)
+	}
+	if len(*responses) != 25 {
+		t.Fatalf("expected 25 responses, got %d", len(*responses))
+	}
+	for i, r := range *responses {
+		if expected := fmt.Sprintf("%d", i+1); r.ID != expected {
+			t.Errorf("expected response %d to have ID %q, got %q", i, expected, r.ID)
+		}
+		if r.Status != http.StatusNoContent {
+			t.Errorf("expected response %q to have status 204, got %d", r.ID, r.Status)
+		}
+	}
+
+	// first chunk, retry of throttled requests, second chunk
+	if server.calls != 3 {
+		t.Errorf("expected 3 batch calls, got %d", server.calls)
+	}
+	for _, r := range server.requests {
+		switch r["id"] {
+		case "1":
+			if url := r["url"]; url != "/tenant/groups/group-1" {
+				t.Errorf("unexpected url for request 1: %v", url)
+			}
+			if h, ok := r["headers"].(map[string]interface{}); !ok || h["Content-Type"] != "application/json" {
+				t.Errorf("expected Content-Type header for request 1, got %v", r["headers"])
+			}
+		case "22":
+			if _, ok := r["dependsOn"]; ok {
+				t.Errorf("expected dependency on completed request to be omitted for request 22")
+			}
+		}
+	}
+}
+
+func TestBatchErrors(t *testing.T) {
+	server := newBatchServer(func(call int, req map[string]interface{}) (int, map[string]string, interface{}) {
+		url, _ := req["url"].(string)
+		switch {
+		case strings.HasSuffix(url, "/gone/$ref"):
+			return http.StatusNotFound, nil, map[string]interface{}{
+				"error": map[string]interface{}{"code": "Request_ResourceNotFound", "message": "Resource 'gone' does not exist."},
+			}
+		case strings.HasSuffix(url, "/forbidden/$ref"):
+			return http.StatusForbidden, nil, map[string]interface{}{
+				"error": map[string]interface{}{"code": "Authorization_RequestDenied", "message": "Insufficient privileges to complete the operation."},
+			}
+		}
+		return http.StatusNoContent, nil, nil
+	})
+	defer server.Close()
+
+	client := msgraph.NewGroupsClient("tenant")
+	client.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+	ctx := context.Background()
+
+	if _, err := client.RemoveMembers(ctx, "group", &[]string{"member", "gone"}); err != nil {
+		t.Fatalf("GroupsClient.RemoveMembers(): expected members that are already gone to be ignored, got: %v", err)
+	}
+
+	_, err := client.RemoveMembers(ctx, "group", &[]string{"member", "forbidden"})
+	if err == nil {
+		t.Fatalf("GroupsClient.RemoveMembers(): expected an error")
+	}
+	if !strings.Contains(err.Error(), "Insufficient privileges") {
+		t.Fatalf("GroupsClient.RemoveMembers(): expected OData error in message, got: %v", err)
+	}
+	if !errors.IsAuthorizationDenied(err) {
+		t.Fatalf("GroupsClient.RemoveMembers(): expected error to be IsAuthorizationDenied, got: %v", err)
+	}
+}
+
+func TestBatchDependsOnEarlierBatch(t *testing.T) {
+	server := newBatchServer(func(call int, req map[string]interface{}) (int, map[string]string, interface{}) {
+		if req["id"] == "1" {
+			return http.StatusForbidden, nil, map[string]interface{}{
+				"error": map[string]interface{}{"code": "Authorization_RequestDenied", "message": "Insufficient privileges to complete the operation."},
+			}
+		}
+		return http.StatusNoContent, nil, nil
+	})
+	defer server.Close()
+
+	client := msgraph.NewClient(msgraph.VersionBeta, "tenant")
+	client.Endpoint = environments.ApiEndpoint(server.URL)
+
+	// request 1 fails in the first batch, so request 21 and request 22 which depends on it must not be sent
+	requests := make([]msgraph.BatchRequest, 0, 22)
+	for i := 1; i <= 22; i++ {
+		r := msgraph.BatchRequest{
+			Method: http.MethodDelete,
+			Uri:    msgraph.Uri{Entity: fmt.Sprintf("/groups/group-%d", i)},
+		}
+		switch i {
+		case 21:
+			r.DependsOn = []string{"1"}
+		case 22:
+			r.DependsOn = []string{"21"}
+		}
+		requests = append(requests, r)
+	}
+
+	responses, status, err := client.Batch(context.Background(), requests)
+	if err == nil {
+		t.Fatal("Client.Batch(): expected an error")
+	}
+	if status != http.StatusOK {
+		t.Errorf("Client.Batch(): expected the status of the $batch request, got %d", status)
+	}
+	if len(server.requests) != 20 {
+		t.Errorf("Client.Batch(): expected 20 requests to be sent, got %d", len(server.requests))
+	}
+	for _, r := range (*responses)[20:] {
+		if r.Status != http.StatusFailedDependency {
+			t.Errorf("Client.Batch(): expected status 424 for request %q, got %d", r.ID, r.Status)
+		}
+		if r.OData == nil || r.OData.Error == nil || r.OData.Error.Code == nil || *r.OData.Error.Code != "FailedDependency" {
+			t.Errorf("Client.Batch(): expected a FailedDependency error for request %q", r.ID)
+		}
+	}
+	if !strings.Contains(err.Error(), "1, 21, 22") {
+		t.Errorf("Client.Batch(): expected error to list the failed requests, got: %v", err)
+	}
+}
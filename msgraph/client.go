@@ -3,18 +3,20 @@ package msgraph
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/url"
-	"strconv"
 	"strings"
 	"time"
 
 	"github.com/manicminer/hamilton/auth"
 	"github.com/manicminer/hamilton/environments"
+	"github.com/manicminer/hamilton/errors"
+	"github.com/manicminer/hamilton/logging"
 	"github.com/manicminer/hamilton/odata"
+	"github.com/manicminer/hamilton/retry"
+	"github.com/manicminer/hamilton/telemetry"
 )
 
 type ApiVersion string
@@ -24,11 +26,8 @@ const (
 	VersionBeta ApiVersion = "beta"
 )
 
-const (
-	defaultInitialBackoff = 1 * time.Second
-	defaultBackoffCap     = 64 * time.Second
-	requestAttempts       = 10
-)
+// RetryPolicy determines whether a failed request should be retried, and how long to wait beforehand.
+type RetryPolicy = retry.Policy
 
 // ValidStatusFunc is a function that tests whether an HTTP response is considered valid for the particular request.
 type ValidStatusFunc func(response *http.Response, o *odata.OData) bool
@@ -64,17 +63,37 @@ type Client struct {
 	// Authorizer is anything that can provide an access token with which to authorize requests.
 	Authorizer auth.Authorizer
 
-	httpClient *http.Client
+	// RetryPolicy determines whether and when failed requests are retried. Set to retry.Disabled to disable retries.
+	RetryPolicy RetryPolicy
+
+	// HttpClient is the HTTP client used to send requests, which defaults to http.DefaultClient.
+	// Use transport.NewClient to build a client with a chain of middleware.
+	HttpClient *http.Client
+
+	// Logger optionally receives details of each attempt at sending a request, with sensitive values redacted.
+	Logger logging.Logger
+
+	// LogBodies enables logging of request and response bodies. Although known sensitive values are redacted, bodies
+	// may still contain personal or otherwise confidential information.
+	LogBodies bool
+
+	// Tracer optionally creates spans for each request, and for each attempt at sending a request.
+	Tracer telemetry.Tracer
+
+	// Meter optionally records the latency of each attempt at sending a request, and counts of attempts and throttled
+	// attempts.
+	Meter telemetry.Meter
 }
 
 // NewClient returns a new Client configured with the specified API version and tenant ID.
 func NewClient(apiVersion ApiVersion, tenantId string) Client {
 	return Client{
-		Endpoint:   environments.MsGraphGlobal.Endpoint,
-		ApiVersion: apiVersion,
-		TenantId:   tenantId,
-		UserAgent:  "Hamilton (Go-http-client/1.1)",
-		httpClient: http.DefaultClient,
+		Endpoint:    environments.MsGraphGlobal.Endpoint,
+		ApiVersion:  apiVersion,
+		TenantId:    tenantId,
+		UserAgent:   "Hamilton (Go-http-client/1.1)",
+		RetryPolicy: retry.DefaultPolicy(),
+		HttpClient:  http.DefaultClient,
 	}
 }
 
@@ -96,8 +115,19 @@ func (c Client) buildUri(uri Uri) (string, error) {
 }
 
 // performRequest is used by the package to send an HTTP request to the API.
-func (c Client) performRequest(req *http.Request, input HttpRequestInput) (*http.Response, int, *odata.OData, error) {
-	var status int
+func (c Client) performRequest(req *http.Request, input HttpRequestInput) (resp *http.Response, status int, o *odata.OData, err error) {
+	entity := telemetry.Entity(req.URL.Path, string(c.ApiVersion), c.TenantId)
+	if c.Tracer != nil {
+		ctx, span := c.Tracer.Start(req.Context(), "msgraph.request",
+			telemetry.String(telemetry.AttributeServiceName, "msgraph"),
+			telemetry.String(telemetry.AttributeHttpMethod, req.Method),
+			telemetry.String(telemetry.AttributeHttpUrl, req.URL.String()),
+			telemetry.String(telemetry.AttributeEntity, entity))
+		req = req.WithContext(ctx)
+		defer func() {
+			telemetry.EndSpan(span, err, telemetry.Int(telemetry.AttributeHttpStatus, status))
+		}()
+	}
 
 	if c.Authorizer != nil {
 		token, err := c.Authorizer.Token()
@@ -114,34 +144,43 @@ func (c Client) performRequest(req *http.Request, input HttpRequestInput) (*http
 		req.Header.Add("User-Agent", c.UserAgent)
 	}
 
-	var resp *http.Response
-	var o *odata.OData
-	var err error
+	policy := c.retryPolicy(req.Context())
 
-	var backoffPower func(int64, int64) int64
-	backoffPower = func(base, exp int64) int64 {
-		if exp <= 1 {
-			return base
-		}
-		return base * backoffPower(base, exp-1)
+	httpClient := c.HttpClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
 	}
 
-	var attempts, backoff, multiplier int64
-	for attempts = 0; attempts < requestAttempts; attempts++ {
-		// sleep after the previous failed attempt
-		if attempts > 0 {
-			time.Sleep(time.Duration(backoff))
+	start := time.Now()
+	for attempt := 1; ; attempt++ {
+		// rewind the request body after a previous failed attempt
+		if attempt > 1 && req.GetBody != nil {
+			if req.Body, err = req.GetBody(); err != nil {
+				return nil, status, nil, err
+			}
 		}
 
-		// default exponential backoff
-		multiplier++
-		backoff = int64(defaultInitialBackoff) * backoffPower(2, multiplier)
-		if cap := int64(defaultBackoffCap); backoff > cap {
-			backoff = cap
+		attemptReq := req
+		attemptCtx, attemptSpan := telemetry.StartSpan(req.Context(), c.Tracer, "msgraph.attempt")
+		if c.Tracer != nil {
+			attemptReq = req.WithContext(attemptCtx)
+		}
+		attemptStart := time.Now()
+		resp, err = httpClient.Do(attemptReq)
+		latency := time.Since(attemptStart)
+		telemetry.RecordAttempt(req.Context(), attemptSpan, c.Meter, entity, attempt, req, resp, latency, err)
+		if c.Logger != nil {
+			c.Logger.Log(req.Context(), logging.NewEntry(req, resp, attempt, latency, err, c.LogBodies))
 		}
-
-		resp, err = c.httpClient.Do(req)
 		if err != nil {
+			if req.Context().Err() == nil {
+				if delay, ok := policy.Retry(retry.Attempt{Number: attempt, Start: start, Request: req, Err: err}); ok {
+					if err := retry.Sleep(req.Context(), delay); err != nil {
+						return nil, status, nil, err
+					}
+					continue
+				}
+			}
 			return nil, status, nil, err
 		}
 
@@ -151,43 +190,54 @@ func (c Client) performRequest(req *http.Request, input HttpRequestInput) (*http
 		}
 
 		status = resp.StatusCode
-		if !containsStatusCode(input.GetValidStatusCodes(), status) {
-			f := input.GetValidStatusFunc()
-			if f != nil && f(resp, o) {
-				return resp, status, o, nil
-			}
-
-			// rate limiting
-			if containsStatusCode([]int{424, 429, 503}, status) {
-				if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
-					if r, err := strconv.ParseFloat(retryAfter, 64); err == nil && r > 0 {
-						// Retry-After header detected, use that instead of default backoff
-						backoff = int64(r * float64(time.Second))
-						multiplier = 0
-					}
-				}
-				continue
-			}
+		if containsStatusCode(input.GetValidStatusCodes(), status) {
+			return resp, status, o, nil
+		}
+		if f := input.GetValidStatusFunc(); f != nil && f(resp, o) {
+			return resp, status, o, nil
+		}
 
-			var errText string
-			switch {
-			case o != nil && o.Error != nil && o.Error.String() != "":
-				errText = fmt.Sprintf("OData error: %s", o.Error)
-			default:
-				defer resp.Body.Close()
-				respBody, err := ioutil.ReadAll(resp.Body)
-				if err != nil {
-					return nil, status, o, fmt.Errorf("unexpected status %d, could not read response body", resp.StatusCode)
-				}
-				errText = fmt.Sprintf("response: %s", respBody)
+		// rate limiting and transient errors
+		if delay, ok := policy.Retry(retry.Attempt{Number: attempt, Start: start, Request: req, Response: resp, OData: o}); ok {
+			resp.Body.Close()
+			if err := retry.Sleep(req.Context(), delay); err != nil {
+				return nil, status, o, err
 			}
-			return nil, status, o, fmt.Errorf("unexpected status %d with %s", resp.StatusCode, errText)
+			continue
 		}
 
-		break
+		return nil, status, o, newApiError(req, resp, o, attempt-1)
 	}
+}
 
-	return resp, status, o, nil
+// retryPolicy is used by the package to determine the retry policy for a request, which can be overridden by the
+// request context using retry.WithPolicy.
+func (c Client) retryPolicy(ctx context.Context) RetryPolicy {
+	if p, ok := retry.FromContext(ctx); ok {
+		return p
+	}
+	if c.RetryPolicy == nil {
+		return retry.DefaultPolicy()
+	}
+	return c.RetryPolicy
+}
+
+// newApiError is used by the package to build an error describing an unexpected response.
+func newApiError(req *http.Request, resp *http.Response, o *odata.OData, retries int) error {
+	e := &errors.ApiError{
+		Method:     req.Method,
+		Url:        req.URL.String(),
+		StatusCode: resp.StatusCode,
+		Retries:    retries,
+	}
+	if o != nil && o.Error != nil {
+		e.OData = o.Error
+	}
+	if e.OData == nil || e.OData.String() == "" {
+		defer resp.Body.Close()
+		e.ResponseBody, _ = ioutil.ReadAll(resp.Body)
+	}
+	return e
 }
 
 // containsStatusCode determines whether the returned status code is in the []int of expected status codes.
@@ -238,6 +288,7 @@ func (c Client) Delete(ctx context.Context, input DeleteHttpRequestInput) (*http
 
 // GetHttpRequestInput configures a GET request.
 type GetHttpRequestInput struct {
+	OData            odata.Query
 	ValidStatusCodes []int
 	ValidStatusFunc  ValidStatusFunc
 	Uri              Uri
@@ -254,15 +305,25 @@ func (i GetHttpRequestInput) GetValidStatusFunc() ValidStatusFunc {
 	return i.ValidStatusFunc
 }
 
-// Get performs a GET request.
+// Get performs a GET request. When the response is a collection, only the first page is returned and any
+// @odata.nextLink is not followed. Use a Pager to retrieve subsequent pages.
 func (c Client) Get(ctx context.Context, input GetHttpRequestInput) (*http.Response, int, *odata.OData, error) {
 	var status int
 
-	// Check for a raw uri, else build one from the Uri field
+	// Check for a raw uri, else build one from the Uri field and any OData query options
 	url := input.rawUri
 	if url == "" {
+		uri := input.Uri
+		if query := input.OData.Values(); len(query) > 0 {
+			for k, v := range uri.Params {
+				if _, ok := query[k]; !ok {
+					query[k] = v
+				}
+			}
+			uri.Params = query
+		}
 		var err error
-		url, err = c.buildUri(input.Uri)
+		url, err = c.buildUri(uri)
 		if err != nil {
 			return nil, status, nil, fmt.Errorf("unable to make request: %v", err)
 		}
@@ -273,6 +334,9 @@ func (c Client) Get(ctx context.Context, input GetHttpRequestInput) (*http.Respo
 	if err != nil {
 		return nil, status, nil, err
 	}
+	for k, v := range input.OData.Headers() {
+		req.Header[k] = v
+	}
 
 	// Perform the request
 	resp, status, o, err := c.performRequest(req, input)
@@ -280,65 +344,6 @@ func (c Client) Get(ctx context.Context, input GetHttpRequestInput) (*http.Respo
 		return nil, status, o, err
 	}
 
-	// Check for json content before handling pagination
-	contentType := strings.ToLower(resp.Header.Get("Content-Type"))
-	if strings.HasPrefix(contentType, "application/json") {
-		// Read the response body and close it
-		respBody, err := ioutil.ReadAll(resp.Body)
-		if err != nil {
-			return nil, status, o, fmt.Errorf("could not parse response body")
-		}
-		resp.Body.Close()
-
-		// Unmarshall firstOdata
-		var firstOdata odata.OData
-		if err := json.Unmarshal(respBody, &firstOdata); err != nil {
-			return nil, status, o, err
-		}
-
-		if firstOdata.NextLink == nil || firstOdata.Value == nil {
-			// No more pages, reassign response body and return
-			resp.Body = ioutil.NopCloser(bytes.NewBuffer(respBody))
-			return resp, status, o, nil
-		}
-
-		// Get the next page, recursively
-		nextInput := input
-		nextInput.rawUri = *firstOdata.NextLink
-		nextResp, status, o, err := c.Get(ctx, nextInput)
-		if err != nil {
-			return resp, status, o, err
-		}
-
-		// Read the next page response body and close it
-		nextRespBody, err := ioutil.ReadAll(nextResp.Body)
-		if err != nil {
-			return nil, status, o, fmt.Errorf("could not parse response body")
-		}
-		nextResp.Body.Close()
-
-		// Unmarshall firstOdata from the next page
-		var nextOdata odata.OData
-		if err := json.Unmarshal(nextRespBody, &nextOdata); err != nil {
-			return resp, status, o, err
-		}
-
-		if nextOdata.Value != nil {
-			// Next page has results, append to current page
-			value := append(*firstOdata.Value, *nextOdata.Value...)
-			nextOdata.Value = &value
-		}
-
-		// Marshal the entire result, along with fields from the final page
-		newJson, err := json.Marshal(nextOdata)
-		if err != nil {
-			return resp, status, o, err
-		}
-
-		// Reassign the response body
-		resp.Body = ioutil.NopCloser(bytes.NewBuffer(newJson))
-	}
-
 	return resp, status, o, nil
 }
 
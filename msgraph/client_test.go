@@ -0,0 +1,270 @@
+package msgraph_test
+
+import (
+	"context"
+	goerrors "errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/manicminer/hamilton/environments"
+	"github.com/manicminer/hamilton/errors"
+	"github.com/manicminer/hamilton/internal/utils"
+	"github.com/manicminer/hamilton/logging"
+	"github.com/manicminer/hamilton/msgraph"
+	"github.com/manicminer/hamilton/retry"
+	"github.com/manicminer/hamilton/telemetry"
+)
+
+func TestClientApiError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/beta/tenant/users/missing":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error": {"code": "Request_ResourceNotFound", "message": "Resource 'missing' does not exist.", "innerError": {"date": "2021-06-01T12:00:00", "request-id": "11111111-1111-1111-1111-111111111111", "client-request-id": "22222222-2222-2222-2222-222222222222"}}}`))
+		case "/beta/tenant/users":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"error": {"code": "Authorization_RequestDenied", "message": "Insufficient privileges to complete the operation."}}`))
+		default:
+			w.Header().Set("Content-Type", "text/plain")
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`oops`))
+		}
+	}))
+	defer server.Close()
+
+	client := msgraph.NewUsersClient("tenant")
+	client.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+	ctx := context.Background()
+
+	_, status, err := client.Get(ctx, "missing")
+	if err == nil {
+		t.Fatalf("UsersClient.Get(): expected an error")
+	}
+	if status != http.StatusNotFound {
+		t.Errorf("UsersClient.Get(): expected status 404, got %d", status)
+	}
+	var apiErr *errors.ApiError
+	if !goerrors.As(err, &apiErr) {
+		t.Fatalf("UsersClient.Get(): expected an *errors.ApiError, got %T", err)
+	}
+	if apiErr.Method != http.MethodGet || apiErr.Url != server.URL+"/beta/tenant/users/missing" {
+		t.Errorf("UsersClient.Get(): unexpected request in error: %s %s", apiErr.Method, apiErr.Url)
+	}
+	if apiErr.Code() != "Request_ResourceNotFound" || apiErr.RequestId() != "11111111-1111-1111-1111-111111111111" {
+		t.Errorf("UsersClient.Get(): unexpected OData error: %s (request-id %q)", apiErr.OData, apiErr.RequestId())
+	}
+	if !errors.IsNotFound(err) || errors.IsAuthorizationDenied(err) || errors.IsConflict(err) || errors.IsThrottled(err) {
+		t.Errorf("UsersClient.Get(): expected error to be only IsNotFound: %v", err)
+	}
+
+	_, _, err = client.Create(ctx, msgraph.User{})
+	if !errors.IsAuthorizationDenied(err) || errors.IsNotFound(err) {
+		t.Errorf("UsersClient.Create(): expected error to be IsAuthorizationDenied: %v", err)
+	}
+
+	_, status, err = client.Get(ctx, "other/broken")
+	if apiErr := errors.AsApiError(err); apiErr == nil || string(apiErr.ResponseBody) != "oops" || status != http.StatusInternalServerError {
+		t.Errorf("UsersClient.Get(): expected response body in error: %v", err)
+	}
+}
+
+func TestClientRetry(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		calls++
+		body, _ := ioutil.ReadAll(r.Body)
+		if string(body) == "" {
+			http.Error(w, "request body was not sent", http.StatusBadRequest)
+			return
+		}
+		if calls%3 != 0 {
+			w.Header().Set("Retry-After", "0.01")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id": "user"}`))
+	}))
+	defer server.Close()
+
+	client := msgraph.NewUsersClient("tenant")
+	client.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+	ctx := context.Background()
+
+	if _, _, err := client.Create(ctx, msgraph.User{ID: utils.StringPtr("user")}); err != nil {
+		t.Fatalf("UsersClient.Create(): %v", err)
+	}
+	if calls != 3 {
+		t.Fatalf("UsersClient.Create(): expected 3 attempts, got %d", calls)
+	}
+
+	calls = 0
+	_, status, err := client.Create(retry.WithPolicy(ctx, retry.Disabled), msgraph.User{ID: utils.StringPtr("user")})
+	if !errors.IsThrottled(err) || status != http.StatusTooManyRequests {
+		t.Fatalf("UsersClient.Create(): expected throttling error with retries disabled, got: %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("UsersClient.Create(): expected 1 attempt with retries disabled, got %d", calls)
+	}
+
+	cancelCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	policy := retry.DefaultPolicy()
+	policy.InitialBackoff = time.Hour
+	client.BaseClient.RetryPolicy = policy
+	start := time.Now()
+	if _, _, err := client.Get(cancelCtx, "user"); !goerrors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("UsersClient.Get(): expected context deadline to be exceeded, got: %v", err)
+	}
+	if time.Since(start) > 10*time.Second {
+		t.Fatalf("UsersClient.Get(): expected backoff to be interrupted by context cancellation")
+	}
+}
+
+func TestClientLogger(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("request-id", fmt.Sprintf("request-%d", calls))
+		if calls == 1 {
+			w.Header().Set("Retry-After", "0.01")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id": "user"}`))
+	}))
+	defer server.Close()
+
+	var entries []logging.Entry
+	client := msgraph.NewUsersClient("tenant")
+	client.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+	client.BaseClient.LogBodies = true
+	client.BaseClient.Logger = logging.LoggerFunc(func(ctx context.Context, entry logging.Entry) {
+		entries = append(entries, entry)
+	})
+
+	user := msgraph.User{
+		DisplayName:     utils.StringPtr("test-user"),
+		PasswordProfile: &msgraph.UserPasswordProfile{Password: utils.StringPtr("hunter2")},
+	}
+	if _, _, err := client.Create(context.Background(), user); err != nil {
+		t.Fatalf("UsersClient.Create(): %v", err)
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 log entries, got %d", len(entries))
+	}
+	for i, e := range entries {
+		if e.Attempt != i+1 || e.RequestId != fmt.Sprintf("request-%d", i+1) || e.Method != http.MethodPost {
+			t.Errorf("unexpected log entry: %+v", e)
+		}
+		if strings.Contains(string(e.RequestBody), "hunter2") || !strings.Contains(string(e.RequestBody), "test-user") {
+			t.Errorf("expected password to be redacted from request body, got: %s", e.RequestBody)
+		}
+	}
+	if entries[0].StatusCode != http.StatusTooManyRequests || entries[1].StatusCode != http.StatusCreated {
+		t.Errorf("unexpected status codes: %d, %d", entries[0].StatusCode, entries[1].StatusCode)
+	}
+}
+
+type testSpan struct {
+	name  string
+	attrs map[string]interface{}
+	err   error
+	ended bool
+}
+
+func (s *testSpan) SetAttributes(attrs ...telemetry.Attribute) {
+	for _, a := range attrs {
+		s.attrs[a.Key] = a.Value
+	}
+}
+
+func (s *testSpan) RecordError(err error) { s.err = err }
+func (s *testSpan) End()                  { s.ended = true }
+
+type testTracer struct {
+	spans []*testSpan
+}
+
+func (t *testTracer) Start(ctx context.Context, name string, attrs ...telemetry.Attribute) (context.Context, telemetry.Span) {
+	span := &testSpan{name: name, attrs: map[string]interface{}{}}
+	span.SetAttributes(attrs...)
+	t.spans = append(t.spans, span)
+	return ctx, span
+}
+
+type testMeter struct {
+	counters   map[string]int64
+	histograms map[string]int
+}
+
+func (m *testMeter) AddCounter(_ context.Context, name string, value int64, _ ...telemetry.Attribute) {
+	m.counters[name] += value
+}
+
+func (m *testMeter) RecordHistogram(_ context.Context, name string, _ float64, _ ...telemetry.Attribute) {
+	m.histograms[name]++
+}
+
+func TestClientTelemetry(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("request-id", fmt.Sprintf("request-%d", calls))
+		if calls == 1 {
+			w.Header().Set("Retry-After", "0.01")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id": "user"}`))
+	}))
+	defer server.Close()
+
+	tracer := &testTracer{}
+	meter := &testMeter{counters: map[string]int64{}, histograms: map[string]int{}}
+	client := msgraph.NewUsersClient("tenant")
+	client.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+	client.BaseClient.Tracer = tracer
+	client.BaseClient.Meter = meter
+
+	if _, _, err := client.Get(context.Background(), "user"); err != nil {
+		t.Fatalf("UsersClient.Get(): %v", err)
+	}
+
+	if len(tracer.spans) != 3 {
+		t.Fatalf("expected 3 spans, got %d", len(tracer.spans))
+	}
+	request := tracer.spans[0]
+	if request.name != "msgraph.request" || !request.ended || request.attrs[telemetry.AttributeEntity] != "users" || request.attrs[telemetry.AttributeHttpStatus] != http.StatusOK {
+		t.Errorf("unexpected request span: %+v", request)
+	}
+	for i, attempt := range tracer.spans[1:] {
+		if attempt.name != "msgraph.attempt" || !attempt.ended || attempt.attrs[telemetry.AttributeAttempt] != i+1 || attempt.attrs[telemetry.AttributeRequestId] != fmt.Sprintf("request-%d", i+1) {
+			t.Errorf("unexpected attempt span: %+v", attempt)
+		}
+	}
+	if tracer.spans[1].attrs[telemetry.AttributeThrottled] != true || tracer.spans[2].attrs[telemetry.AttributeThrottled] != false {
+		t.Errorf("expected only the first attempt to be throttled")
+	}
+
+	if meter.counters[telemetry.MetricRequests] != 2 || meter.counters[telemetry.MetricThrottledRequests] != 1 || meter.histograms[telemetry.MetricRequestDuration] != 2 {
+		t.Errorf("unexpected measurements: %v, %v", meter.counters, meter.histograms)
+	}
+}
@@ -2,12 +2,9 @@ package msgraph
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
-	"fmt"
-	"io/ioutil"
-	"net/http"
-	"net/url"
+
+	"github.com/manicminer/hamilton/odata"
 )
 
 // ConditionalAccessPolicyClient performs operations on ConditionalAccessPolicy.
@@ -22,87 +19,46 @@ func NewConditionalAccessPolicyClient(tenantId string) *ConditionalAccessPolicyC
 	}
 }
 
-// List returns a list of ConditionalAccessPolicys, optionally filtered using OData.
-func (c *ConditionalAccessPolicyClient) List(ctx context.Context, filter string) (*[]ConditionalAccessPolicy, int, error) {
-	params := url.Values{}
-	if filter != "" {
-		params.Add("$filter", filter)
-	}
-	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
-		ValidStatusCodes: []int{http.StatusOK},
-		Uri: Uri{
-			Entity:      "/identity/conditionalAccess/policies",
-			Params:      params,
-			HasTenantId: true,
-		},
-	})
-	if err != nil {
-		return nil, status, fmt.Errorf("ConditionalAccessPolicyClient.BaseClient.Get(): %v", err)
+// resource returns a Resource for performing common operations on Conditional Access Policies.
+func (c *ConditionalAccessPolicyClient) resource() Resource {
+	return Resource{
+		Client: c.BaseClient,
+		Name:   "ConditionalAccessPolicyClient",
+		Entity: "/identity/conditionalAccess/policies",
 	}
-	defer resp.Body.Close()
-	respBody, err := ioutil.ReadAll(resp.Body)
+}
+
+// List returns a list of ConditionalAccessPolicys, optionally queried using OData.
+func (c *ConditionalAccessPolicyClient) List(ctx context.Context, query odata.Query) (*[]ConditionalAccessPolicy, int, error) {
+	var conditionalAccessPolicies []ConditionalAccessPolicy
+	status, err := c.resource().List(ctx, query, &conditionalAccessPolicies)
 	if err != nil {
-		return nil, status, fmt.Errorf("ioutil.ReadAll(): %v", err)
-	}
-	var data struct {
-		ConditionalAccessPolicys []ConditionalAccessPolicy `json:"value"`
+		return nil, status, err
 	}
-	if err := json.Unmarshal(respBody, &data); err != nil {
-		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
-	}
-	return &data.ConditionalAccessPolicys, status, nil
+	return &conditionalAccessPolicies, status, nil
+}
+
+// ListPager returns a Pager for retrieving ConditionalAccessPolicys one page at a time, optionally queried using OData.
+func (c *ConditionalAccessPolicyClient) ListPager(query odata.Query) *Pager {
+	return c.resource().ListPager(query)
 }
 
 // Create creates a new ConditionalAccessPolicy.
 func (c *ConditionalAccessPolicyClient) Create(ctx context.Context, conditionalAccessPolicy ConditionalAccessPolicy) (*ConditionalAccessPolicy, int, error) {
-	var status int
-	body, err := json.Marshal(conditionalAccessPolicy)
-	if err != nil {
-		return nil, status, fmt.Errorf("json.Marshal(): %v", err)
-	}
-	resp, status, _, err := c.BaseClient.Post(ctx, PostHttpRequestInput{
-		Body:             body,
-		ValidStatusCodes: []int{http.StatusCreated},
-		Uri: Uri{
-			Entity:      "/identity/conditionalAccess/policies",
-			HasTenantId: true,
-		},
-	})
-	if err != nil {
-		return nil, status, fmt.Errorf("ConditionalAccessPolicyClient.BaseClient.Post(): %v", err)
-	}
-	defer resp.Body.Close()
-	respBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, status, fmt.Errorf("ioutil.ReadAll(): %v", err)
-	}
 	var newConditionalAccessPolicy ConditionalAccessPolicy
-	if err := json.Unmarshal(respBody, &newConditionalAccessPolicy); err != nil {
-		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	status, err := c.resource().Create(ctx, conditionalAccessPolicy, &newConditionalAccessPolicy)
+	if err != nil {
+		return nil, status, err
 	}
 	return &newConditionalAccessPolicy, status, nil
 }
 
 // Get retrieves an ConditionalAccessPolicy.
 func (c *ConditionalAccessPolicyClient) Get(ctx context.Context, id string) (*ConditionalAccessPolicy, int, error) {
-	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
-		ValidStatusCodes: []int{http.StatusOK},
-		Uri: Uri{
-			Entity:      fmt.Sprintf("/identity/conditionalAccess/policies/%s", id),
-			HasTenantId: true,
-		},
-	})
-	if err != nil {
-		return nil, status, fmt.Errorf("ConditionalAccessPolicyClient.BaseClient.Get(): %v", err)
-	}
-	defer resp.Body.Close()
-	respBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, status, fmt.Errorf("ioutil.ReadAll(): %v", err)
-	}
 	var conditionalAccessPolicy ConditionalAccessPolicy
-	if err := json.Unmarshal(respBody, &conditionalAccessPolicy); err != nil {
-		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	status, err := c.resource().Get(ctx, id, odata.Query{}, &conditionalAccessPolicy)
+	if err != nil {
+		return nil, status, err
 	}
 	return &conditionalAccessPolicy, status, nil
 }
@@ -113,36 +69,10 @@ func (c *ConditionalAccessPolicyClient) Update(ctx context.Context, conditionalA
 	if conditionalAccessPolicy.ID == nil {
 		return status, errors.New("cannot update conditionalAccessPolicy with nil ID")
 	}
-
-	body, err := json.Marshal(conditionalAccessPolicy)
-	if err != nil {
-		return status, fmt.Errorf("json.Marshal(): %v", err)
-	}
-	_, status, _, err = c.BaseClient.Patch(ctx, PatchHttpRequestInput{
-		Body:             body,
-		ValidStatusCodes: []int{http.StatusNoContent},
-		Uri: Uri{
-			Entity:      fmt.Sprintf("/identity/conditionalAccess/policies/%s", *conditionalAccessPolicy.ID),
-			HasTenantId: true,
-		},
-	})
-	if err != nil {
-		return status, fmt.Errorf("ConditionalAccessPolicyClient.BaseClient.Patch(): %v", err)
-	}
-	return status, nil
+	return c.resource().Update(ctx, *conditionalAccessPolicy.ID, conditionalAccessPolicy)
 }
 
 // Delete removes a ConditionalAccessPolicy.
 func (c *ConditionalAccessPolicyClient) Delete(ctx context.Context, id string) (int, error) {
-	_, status, _, err := c.BaseClient.Delete(ctx, DeleteHttpRequestInput{
-		ValidStatusCodes: []int{http.StatusNoContent},
-		Uri: Uri{
-			Entity:      fmt.Sprintf("/identity/conditionalAccess/policies/%s", id),
-			HasTenantId: true,
-		},
-	})
-	if err != nil {
-		return status, fmt.Errorf("ConditionalAccessPolicyClient.BaseClient.Delete(): %v", err)
-	}
-	return status, nil
+	return c.resource().Delete(ctx, id)
 }
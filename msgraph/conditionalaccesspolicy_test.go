@@ -1,3 +1,6 @@
+//go:build live
+// +build live
+
 package msgraph_test
 
 import (
@@ -9,6 +12,7 @@ import (
 	"github.com/manicminer/hamilton/internal/test"
 	"github.com/manicminer/hamilton/internal/utils"
 	"github.com/manicminer/hamilton/msgraph"
+	"github.com/manicminer/hamilton/odata"
 )
 
 type ConditionalAccessPolicyTest struct {
@@ -39,12 +43,14 @@ func TestConditionalAccessPolicyClient(t *testing.T) {
 	testExcGroup := testGroup_Create(t, c, "exc")
 	testUser := testUser_Create(t, c)
 
+	state := msgraph.ConditionalAccessPolicyStateEnabled
+
 	// act
 	policy := testConditionalAccessPolicysClient_Create(t, c, msgraph.ConditionalAccessPolicy{
 		DisplayName: utils.StringPtr(fmt.Sprintf("test-policy-%s", c.randomString)),
-		State:       utils.StringPtr("enabled"),
+		State:       &state,
 		Conditions: &msgraph.ConditionalAccessConditionSet{
-			ClientAppTypes: &[]string{"mobileAppsAndDesktopClients", "browser"},
+			ClientAppTypes: &[]msgraph.ConditionalAccessClientAppType{msgraph.ConditionalAccessClientAppTypeMobileAppsAndDesktopClients, msgraph.ConditionalAccessClientAppTypeBrowser},
 			Applications: &msgraph.ConditionalAccessApplications{
 				IncludeApplications: &[]string{testAppId},
 			},
@@ -61,7 +67,7 @@ func TestConditionalAccessPolicyClient(t *testing.T) {
 		},
 		GrantControls: &msgraph.ConditionalAccessGrantControls{
 			Operator:        utils.StringPtr("OR"),
-			BuiltInControls: &[]string{"block"},
+			BuiltInControls: &[]msgraph.ConditionalAccessGrantControl{msgraph.ConditionalAccessGrantControlBlock},
 		},
 	})
 
@@ -123,7 +129,7 @@ func testConditionalAccessPolicysClient_Update(t *testing.T, c ConditionalAccess
 }
 
 func testConditionalAccessPolicysClient_List(t *testing.T, c ConditionalAccessPolicyTest) (policies *[]msgraph.ConditionalAccessPolicy) {
-	policies, _, err := c.policyClient.List(c.connection.Context, "")
+	policies, _, err := c.policyClient.List(c.connection.Context, odata.Query{})
 	if err != nil {
 		t.Fatalf("ConditionalAccessPolicyClient.List(): %v", err)
 	}
@@ -0,0 +1,72 @@
+package msgraph_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/manicminer/hamilton/environments"
+	"github.com/manicminer/hamilton/msgraph"
+	"github.com/manicminer/hamilton/odata"
+)
+
+func TestUsersClient_Delta(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/beta/tenant/users/delta" {
+			http.Error(w, "unexpected path", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		q := r.URL.Query()
+		switch {
+		case q.Get("$deltatoken") == "token1":
+			fmt.Fprintf(w, `{"@odata.deltaLink": "%s/beta/tenant/users/delta?$deltatoken=token2", "value": [
+				{"id": "user-2", "displayName": "renamed"},
+				{"id": "user-1", "@removed": {"reason": "deleted"}}
+			]}`, server.URL)
+		case q.Get("$skiptoken") == "page2":
+			fmt.Fprintf(w, `{"@odata.deltaLink": "%s/beta/tenant/users/delta?$deltatoken=token1", "value": [{"id": "user-2"}]}`, server.URL)
+		case q.Get("$select") == "id,displayName":
+			fmt.Fprintf(w, `{"@odata.nextLink": "%s/beta/tenant/users/delta?$skiptoken=page2", "value": [{"id": "user-1"}]}`, server.URL)
+		default:
+			http.Error(w, "unexpected query", http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	client := msgraph.NewUsersClient("tenant")
+	client.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+	ctx := context.Background()
+	query := odata.Query{Select: []string{"id", "displayName"}}
+
+	users, deltaLink, _, err := client.Delta(ctx, query, "")
+	if err != nil {
+		t.Fatalf("UsersClient.Delta(): %v", err)
+	}
+	if len(*users) != 2 {
+		t.Fatalf("UsersClient.Delta(): expected 2 users, got %d", len(*users))
+	}
+	if deltaLink == nil {
+		t.Fatalf("UsersClient.Delta(): expected deltaLink, got nil")
+	}
+
+	users, deltaLink, _, err = client.Delta(ctx, query, *deltaLink)
+	if err != nil {
+		t.Fatalf("UsersClient.Delta(): %v", err)
+	}
+	if len(*users) != 2 {
+		t.Fatalf("UsersClient.Delta(): expected 2 changed users, got %d", len(*users))
+	}
+	if u := (*users)[0]; u.Removed != nil || u.DisplayName == nil || *u.DisplayName != "renamed" {
+		t.Errorf("UsersClient.Delta(): expected user-2 to be updated, got %+v", u)
+	}
+	if u := (*users)[1]; u.Removed == nil || u.Removed.Reason == nil || *u.Removed.Reason != odata.RemovedReasonDeleted {
+		t.Errorf("UsersClient.Delta(): expected user-1 to be removed, got %+v", u)
+	}
+	if expected := fmt.Sprintf("%s/beta/tenant/users/delta?$deltatoken=token2", server.URL); deltaLink == nil || *deltaLink != expected {
+		t.Errorf("UsersClient.Delta(): expected deltaLink %q, got %v", expected, deltaLink)
+	}
+}
@@ -0,0 +1,197 @@
+package msgraph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/manicminer/hamilton/odata"
+)
+
+// DevicesClient performs operations on Devices.
+type DevicesClient struct {
+	BaseClient Client
+}
+
+// NewDevicesClient returns a new DevicesClient.
+func NewDevicesClient(tenantId string) *DevicesClient {
+	return &DevicesClient{
+		BaseClient: NewClient(VersionBeta, tenantId),
+	}
+}
+
+// resource returns a Resource for performing common operations on Devices.
+func (c *DevicesClient) resource() Resource {
+	return Resource{
+		Client: c.BaseClient,
+		Name:   "DevicesClient",
+		Entity: "/devices",
+	}
+}
+
+// List returns a list of Devices, optionally queried using OData.
+func (c *DevicesClient) List(ctx context.Context, query odata.Query) (*[]Device, int, error) {
+	var devices []Device
+	status, err := c.resource().List(ctx, query, &devices)
+	if err != nil {
+		return nil, status, err
+	}
+	return &devices, status, nil
+}
+
+// ListPager returns a Pager for retrieving Devices one page at a time, optionally queried using OData.
+func (c *DevicesClient) ListPager(query odata.Query) *Pager {
+	return c.resource().ListPager(query)
+}
+
+// Get retrieves a Device.
+// id is the object ID of the device, which is distinct from its DeviceId.
+func (c *DevicesClient) Get(ctx context.Context, id string) (*Device, int, error) {
+	var device Device
+	status, err := c.resource().Get(ctx, id, odata.Query{}, &device)
+	if err != nil {
+		return nil, status, err
+	}
+	return &device, status, nil
+}
+
+// Update amends an existing Device.
+func (c *DevicesClient) Update(ctx context.Context, device Device) (int, error) {
+	var status int
+	if device.ID == nil {
+		return status, errors.New("DevicesClient.Update(): cannot update device with nil ID")
+	}
+	return c.resource().Update(ctx, *device.ID, device)
+}
+
+// UpdateExtensionAttributes sets the extension attributes of the specified Device. Attributes which are nil are left
+// unchanged; use ClearExtensionAttributes to clear them.
+// id is the object ID of the device.
+func (c *DevicesClient) UpdateExtensionAttributes(ctx context.Context, id string, attributes OnPremisesExtensionAttributes) (int, error) {
+	return c.resource().Update(ctx, id, Device{ExtensionAttributes: &attributes})
+}
+
+// ClearExtensionAttributes clears the named extension attributes of the specified Device.
+// id is the object ID of the device.
+// names contains the names of the attributes to clear, from extensionAttribute1 to extensionAttribute15.
+func (c *DevicesClient) ClearExtensionAttributes(ctx context.Context, id string, names []string) (int, error) {
+	var status int
+	if len(names) == 0 {
+		return status, errors.New("DevicesClient.ClearExtensionAttributes(): no extension attributes specified")
+	}
+	attributes := make(map[string]*string, len(names))
+	for _, name := range names {
+		if !isExtensionAttributeName(name) {
+			return status, fmt.Errorf("DevicesClient.ClearExtensionAttributes(): invalid extension attribute %q, expected extensionAttribute1 to extensionAttribute15", name)
+		}
+		attributes[name] = nil
+	}
+	return c.resource().Update(ctx, id, map[string]interface{}{"extensionAttributes": attributes})
+}
+
+// isExtensionAttributeName returns whether name is one of extensionAttribute1 to extensionAttribute15.
+func isExtensionAttributeName(name string) bool {
+	for i := 1; i <= 15; i++ {
+		if name == fmt.Sprintf("extensionAttribute%d", i) {
+			return true
+		}
+	}
+	return false
+}
+
+// Delete removes a Device.
+func (c *DevicesClient) Delete(ctx context.Context, id string) (int, error) {
+	return c.resource().Delete(ctx, id)
+}
+
+// ListRegisteredOwners retrieves the registered owners of the specified Device. Each owner is returned as the model
+// for its type, usually *User.
+// id is the object ID of the device.
+func (c *DevicesClient) ListRegisteredOwners(ctx context.Context, id string) (*[]DirectoryObject, int, error) {
+	var owners DirectoryObjects
+	status, err := c.resource().list(ctx, fmt.Sprintf("/devices/%s/registeredOwners", id), odata.Query{}, &owners)
+	if err != nil {
+		return nil, status, err
+	}
+	ret := []DirectoryObject(owners)
+	return &ret, status, nil
+}
+
+// AddRegisteredOwners adds registered owners to the specified Device.
+// id is the object ID of the device.
+// ownerIds contains the object IDs of the users to add.
+func (c *DevicesClient) AddRegisteredOwners(ctx context.Context, id string, ownerIds []string) (int, error) {
+	status, err := c.BaseClient.batchAddRefs(ctx, fmt.Sprintf("/devices/%s/registeredOwners", id), c.directoryObjectRefs(ownerIds))
+	if err != nil {
+		return status, fmt.Errorf("DevicesClient.BaseClient.Batch(): %w", err)
+	}
+	return status, nil
+}
+
+// RemoveRegisteredOwners removes registered owners from the specified Device.
+// id is the object ID of the device.
+// ownerIds contains the object IDs of the users to remove.
+func (c *DevicesClient) RemoveRegisteredOwners(ctx context.Context, id string, ownerIds []string) (int, error) {
+	status, err := c.BaseClient.batchRemoveRefs(ctx, fmt.Sprintf("/devices/%s/registeredOwners", id), ownerIds)
+	if err != nil {
+		return status, fmt.Errorf("DevicesClient.BaseClient.Batch(): %w", err)
+	}
+	return status, nil
+}
+
+// ListRegisteredUsers retrieves the registered users of the specified Device. Each user is returned as the model for
+// its type, usually *User.
+// id is the object ID of the device.
+func (c *DevicesClient) ListRegisteredUsers(ctx context.Context, id string) (*[]DirectoryObject, int, error) {
+	var users DirectoryObjects
+	status, err := c.resource().list(ctx, fmt.Sprintf("/devices/%s/registeredUsers", id), odata.Query{}, &users)
+	if err != nil {
+		return nil, status, err
+	}
+	ret := []DirectoryObject(users)
+	return &ret, status, nil
+}
+
+// AddRegisteredUsers adds registered users to the specified Device.
+// id is the object ID of the device.
+// userIds contains the object IDs of the users to add.
+func (c *DevicesClient) AddRegisteredUsers(ctx context.Context, id string, userIds []string) (int, error) {
+	status, err := c.BaseClient.batchAddRefs(ctx, fmt.Sprintf("/devices/%s/registeredUsers", id), c.directoryObjectRefs(userIds))
+	if err != nil {
+		return status, fmt.Errorf("DevicesClient.BaseClient.Batch(): %w", err)
+	}
+	return status, nil
+}
+
+// RemoveRegisteredUsers removes registered users from the specified Device.
+// id is the object ID of the device.
+// userIds contains the object IDs of the users to remove.
+func (c *DevicesClient) RemoveRegisteredUsers(ctx context.Context, id string, userIds []string) (int, error) {
+	status, err := c.BaseClient.batchRemoveRefs(ctx, fmt.Sprintf("/devices/%s/registeredUsers", id), userIds)
+	if err != nil {
+		return status, fmt.Errorf("DevicesClient.BaseClient.Batch(): %w", err)
+	}
+	return status, nil
+}
+
+// ListMemberOf retrieves the groups and administrative units which the specified Device is a direct member of,
+// optionally queried using OData. Each object is returned as the model for its type, e.g. *Group.
+// id is the object ID of the device.
+func (c *DevicesClient) ListMemberOf(ctx context.Context, id string, query odata.Query) (*[]DirectoryObject, int, error) {
+	var memberOf DirectoryObjects
+	status, err := c.resource().list(ctx, fmt.Sprintf("/devices/%s/memberOf", id), query, &memberOf)
+	if err != nil {
+		return nil, status, err
+	}
+	ret := []DirectoryObject(memberOf)
+	return &ret, status, nil
+}
+
+// directoryObjectRefs returns the @odata.id references for the directory objects with the specified IDs.
+func (c *DevicesClient) directoryObjectRefs(ids []string) []string {
+	refs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		refs = append(refs, fmt.Sprintf("%s/%s/directoryObjects/%s", c.BaseClient.Endpoint, c.BaseClient.ApiVersion, id))
+	}
+	return refs
+}
@@ -0,0 +1,94 @@
+//go:build live
+// +build live
+
+package msgraph_test
+
+import (
+	"testing"
+
+	"github.com/manicminer/hamilton/auth"
+	"github.com/manicminer/hamilton/internal/test"
+	"github.com/manicminer/hamilton/msgraph"
+	"github.com/manicminer/hamilton/odata"
+)
+
+type DevicesClientTest struct {
+	connection *test.Connection
+	client     *msgraph.DevicesClient
+}
+
+// TestDevicesClient_Live reads an existing device, since devices cannot be registered without a real device identity.
+func TestDevicesClient_Live(t *testing.T) {
+	c := DevicesClientTest{
+		connection: test.NewConnection(auth.MsGraph, auth.TokenVersion2),
+	}
+	c.client = msgraph.NewDevicesClient(c.connection.AuthConfig.TenantID)
+	c.client.BaseClient.Authorizer = c.connection.Authorizer
+
+	devices := testDevicesClient_List(t, c)
+	if len(*devices) == 0 {
+		t.Skip("no devices are registered in the tenant")
+	}
+	device := testDevicesClient_Get(t, c, *(*devices)[0].ID)
+	testDevicesClient_ListRegisteredOwners(t, c, *device.ID)
+	testDevicesClient_ListRegisteredUsers(t, c, *device.ID)
+	testDevicesClient_ListMemberOf(t, c, *device.ID)
+}
+
+func testDevicesClient_List(t *testing.T, c DevicesClientTest) (devices *[]msgraph.Device) {
+	devices, _, err := c.client.List(c.connection.Context, odata.Query{Top: 10})
+	if err != nil {
+		t.Fatalf("DevicesClient.List(): %v", err)
+	}
+	if devices == nil {
+		t.Fatal("DevicesClient.List(): devices was nil")
+	}
+	return
+}
+
+func testDevicesClient_Get(t *testing.T, c DevicesClientTest, id string) (device *msgraph.Device) {
+	device, status, err := c.client.Get(c.connection.Context, id)
+	if err != nil {
+		t.Fatalf("DevicesClient.Get(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("DevicesClient.Get(): invalid status: %d", status)
+	}
+	if device == nil {
+		t.Fatal("DevicesClient.Get(): device was nil")
+	}
+	return
+}
+
+func testDevicesClient_ListRegisteredOwners(t *testing.T, c DevicesClientTest, id string) (owners *[]msgraph.DirectoryObject) {
+	owners, _, err := c.client.ListRegisteredOwners(c.connection.Context, id)
+	if err != nil {
+		t.Fatalf("DevicesClient.ListRegisteredOwners(): %v", err)
+	}
+	if owners == nil {
+		t.Fatal("DevicesClient.ListRegisteredOwners(): owners was nil")
+	}
+	return
+}
+
+func testDevicesClient_ListRegisteredUsers(t *testing.T, c DevicesClientTest, id string) (users *[]msgraph.DirectoryObject) {
+	users, _, err := c.client.ListRegisteredUsers(c.connection.Context, id)
+	if err != nil {
+		t.Fatalf("DevicesClient.ListRegisteredUsers(): %v", err)
+	}
+	if users == nil {
+		t.Fatal("DevicesClient.ListRegisteredUsers(): users was nil")
+	}
+	return
+}
+
+func testDevicesClient_ListMemberOf(t *testing.T, c DevicesClientTest, id string) (memberOf *[]msgraph.DirectoryObject) {
+	memberOf, _, err := c.client.ListMemberOf(c.connection.Context, id, odata.Query{})
+	if err != nil {
+		t.Fatalf("DevicesClient.ListMemberOf(): %v", err)
+	}
+	if memberOf == nil {
+		t.Fatal("DevicesClient.ListMemberOf(): memberOf was nil")
+	}
+	return
+}
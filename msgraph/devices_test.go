@@ -0,0 +1,193 @@
+package msgraph_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/manicminer/hamilton/internal/utils"
+	"github.com/manicminer/hamilton/msgraph"
+	"github.com/manicminer/hamilton/msgraph/msgraphtest"
+	"github.com/manicminer/hamilton/odata"
+)
+
+func TestDevicesClient(t *testing.T) {
+	server := msgraphtest.NewServer()
+	defer server.Close()
+	ctx := context.Background()
+
+	client := msgraph.NewDevicesClient("tenant")
+	client.BaseClient.Endpoint = server.Endpoint()
+
+	deviceId := server.Add("devices", msgraph.Device{
+		AccountEnabled:         utils.BoolPtr(true),
+		DeviceId:               utils.StringPtr("00000000-0000-0000-0000-000000000001"),
+		DisplayName:            utils.StringPtr("test-device"),
+		OperatingSystem:        utils.StringPtr("Windows"),
+		OperatingSystemVersion: utils.StringPtr("10.0.19044"),
+		ExtensionAttributes: &msgraph.OnPremisesExtensionAttributes{
+			ExtensionAttribute1: utils.StringPtr("one"),
+		},
+	})
+	userId := server.Add("users", msgraph.User{
+		DisplayName:       utils.StringPtr("test-user"),
+		UserPrincipalName: utils.StringPtr("test-user@example.com"),
+	})
+	groupId := server.Add("groups", msgraph.Group{
+		DisplayName:     utils.StringPtr("test-group"),
+		MailNickname:    utils.StringPtr("test-group"),
+		SecurityEnabled: utils.BoolPtr(true),
+	})
+
+	devices, _, err := client.List(ctx, odata.Query{})
+	if err != nil {
+		t.Fatalf("DevicesClient.List(): %v", err)
+	}
+	if devices == nil || len(*devices) != 1 || *(*devices)[0].ID != deviceId {
+		t.Fatalf("DevicesClient.List(): expected the test device, got %v", devices)
+	}
+	expectRequests(t, server, expectedRequest{http.MethodGet, "/devices", ""})
+
+	device, _, err := client.Get(ctx, deviceId)
+	if err != nil {
+		t.Fatalf("DevicesClient.Get(): %v", err)
+	}
+	if device.DisplayName == nil || *device.DisplayName != "test-device" {
+		t.Fatalf("DevicesClient.Get(): unexpected device %v", device)
+	}
+	expectRequests(t, server, expectedRequest{http.MethodGet, "/devices/" + deviceId, ""})
+
+	if _, err := client.Update(ctx, msgraph.Device{ID: device.ID, AccountEnabled: utils.BoolPtr(false)}); err != nil {
+		t.Fatalf("DevicesClient.Update(): %v", err)
+	}
+	if _, err := client.Update(ctx, msgraph.Device{}); err == nil {
+		t.Fatalf("DevicesClient.Update(): expected an error for a device with nil ID")
+	}
+	expectRequests(t, server, expectedRequest{http.MethodPatch, "/devices/" + deviceId, `{"accountEnabled": false}`})
+
+	if _, err := client.UpdateExtensionAttributes(ctx, deviceId, msgraph.OnPremisesExtensionAttributes{
+		ExtensionAttribute2: utils.StringPtr("two"),
+	}); err != nil {
+		t.Fatalf("DevicesClient.UpdateExtensionAttributes(): %v", err)
+	}
+	expectRequests(t, server, expectedRequest{http.MethodPatch, "/devices/" + deviceId, `{"extensionAttributes": {"extensionAttribute2": "two"}}`})
+	device, _, err = client.Get(ctx, deviceId)
+	if err != nil {
+		t.Fatalf("DevicesClient.Get(): %v", err)
+	}
+	if *device.AccountEnabled {
+		t.Errorf("DevicesClient.Update(): expected device to be disabled")
+	}
+	if attrs := device.ExtensionAttributes; attrs == nil || attrs.ExtensionAttribute1 == nil || *attrs.ExtensionAttribute1 != "one" || attrs.ExtensionAttribute2 == nil || *attrs.ExtensionAttribute2 != "two" {
+		t.Errorf("DevicesClient.UpdateExtensionAttributes(): unexpected extension attributes %v", attrs)
+	}
+	expectRequests(t, server, expectedRequest{http.MethodGet, "/devices/" + deviceId, ""})
+
+	if _, err := client.ClearExtensionAttributes(ctx, deviceId, []string{"extensionAttribute1"}); err != nil {
+		t.Fatalf("DevicesClient.ClearExtensionAttributes(): %v", err)
+	}
+	if _, err := client.ClearExtensionAttributes(ctx, deviceId, []string{"extensionAttribute16"}); err == nil {
+		t.Fatalf("DevicesClient.ClearExtensionAttributes(): expected an error for an invalid extension attribute")
+	}
+	expectRequests(t, server, expectedRequest{http.MethodPatch, "/devices/" + deviceId, `{"extensionAttributes": {"extensionAttribute1": null}}`})
+	device, _, err = client.Get(ctx, deviceId)
+	if err != nil {
+		t.Fatalf("DevicesClient.Get(): %v", err)
+	}
+	if attrs := device.ExtensionAttributes; attrs == nil || attrs.ExtensionAttribute1 != nil || attrs.ExtensionAttribute2 == nil || *attrs.ExtensionAttribute2 != "two" {
+		t.Errorf("DevicesClient.ClearExtensionAttributes(): expected extensionAttribute1 to be cleared, got %v", attrs)
+	}
+	expectRequests(t, server, expectedRequest{http.MethodGet, "/devices/" + deviceId, ""})
+
+	if _, err := client.AddRegisteredOwners(ctx, deviceId, []string{userId}); err != nil {
+		t.Fatalf("DevicesClient.AddRegisteredOwners(): %v", err)
+	}
+	if _, err := client.AddRegisteredUsers(ctx, deviceId, []string{userId}); err != nil {
+		t.Fatalf("DevicesClient.AddRegisteredUsers(): %v", err)
+	}
+	userRef := fmt.Sprintf(`{"@odata.id": "%s/beta/directoryObjects/%s"}`, server.Endpoint(), userId)
+	expectRequests(t, server,
+		expectedRequest{http.MethodPost, fmt.Sprintf("/devices/%s/registeredOwners/$ref", deviceId), userRef},
+		expectedRequest{http.MethodPost, fmt.Sprintf("/devices/%s/registeredUsers/$ref", deviceId), userRef},
+	)
+
+	owners, _, err := client.ListRegisteredOwners(ctx, deviceId)
+	if err != nil {
+		t.Fatalf("DevicesClient.ListRegisteredOwners(): %v", err)
+	}
+	if owners == nil || len(*owners) != 1 {
+		t.Fatalf("DevicesClient.ListRegisteredOwners(): expected 1 owner, got %v", owners)
+	}
+	if owner, ok := (*owners)[0].(*msgraph.User); !ok || *owner.ID != userId {
+		t.Errorf("DevicesClient.ListRegisteredOwners(): expected the test user, got %#v", (*owners)[0])
+	}
+
+	users, _, err := client.ListRegisteredUsers(ctx, deviceId)
+	if err != nil {
+		t.Fatalf("DevicesClient.ListRegisteredUsers(): %v", err)
+	}
+	if users == nil || len(*users) != 1 || *(*users)[0].GetID() != userId {
+		t.Errorf("DevicesClient.ListRegisteredUsers(): expected the test user, got %v", users)
+	}
+	expectRequests(t, server,
+		expectedRequest{http.MethodGet, fmt.Sprintf("/devices/%s/registeredOwners", deviceId), ""},
+		expectedRequest{http.MethodGet, fmt.Sprintf("/devices/%s/registeredUsers", deviceId), ""},
+	)
+
+	if _, err := client.RemoveRegisteredOwners(ctx, deviceId, []string{userId}); err != nil {
+		t.Fatalf("DevicesClient.RemoveRegisteredOwners(): %v", err)
+	}
+	if _, err := client.RemoveRegisteredUsers(ctx, deviceId, []string{userId}); err != nil {
+		t.Fatalf("DevicesClient.RemoveRegisteredUsers(): %v", err)
+	}
+	expectRequests(t, server,
+		expectedRequest{http.MethodDelete, fmt.Sprintf("/devices/%s/registeredOwners/%s/$ref", deviceId, userId), ""},
+		expectedRequest{http.MethodDelete, fmt.Sprintf("/devices/%s/registeredUsers/%s/$ref", deviceId, userId), ""},
+	)
+	if owners, _, err = client.ListRegisteredOwners(ctx, deviceId); err != nil {
+		t.Fatalf("DevicesClient.ListRegisteredOwners(): %v", err)
+	} else if len(*owners) != 0 {
+		t.Errorf("DevicesClient.RemoveRegisteredOwners(): expected no owners, got %v", owners)
+	}
+	if users, _, err = client.ListRegisteredUsers(ctx, deviceId); err != nil {
+		t.Fatalf("DevicesClient.ListRegisteredUsers(): %v", err)
+	} else if len(*users) != 0 {
+		t.Errorf("DevicesClient.RemoveRegisteredUsers(): expected no users, got %v", users)
+	}
+	expectRequests(t, server,
+		expectedRequest{http.MethodGet, fmt.Sprintf("/devices/%s/registeredOwners", deviceId), ""},
+		expectedRequest{http.MethodGet, fmt.Sprintf("/devices/%s/registeredUsers", deviceId), ""},
+	)
+
+	groupsClient := msgraph.NewGroupsClient("tenant")
+	groupsClient.BaseClient.Endpoint = server.Endpoint()
+	group := msgraph.Group{ID: utils.StringPtr(groupId)}
+	group.AppendMember(server.Endpoint(), groupsClient.BaseClient.ApiVersion, deviceId)
+	if _, err := groupsClient.AddMembers(ctx, &group); err != nil {
+		t.Fatalf("GroupsClient.AddMembers(): %v", err)
+	}
+
+	memberOf, _, err := client.ListMemberOf(ctx, deviceId, odata.Query{})
+	if err != nil {
+		t.Fatalf("DevicesClient.ListMemberOf(): %v", err)
+	}
+	if memberOf == nil || len(*memberOf) != 1 {
+		t.Fatalf("DevicesClient.ListMemberOf(): expected 1 group, got %v", memberOf)
+	}
+	if g, ok := (*memberOf)[0].(*msgraph.Group); !ok || *g.ID != groupId {
+		t.Errorf("DevicesClient.ListMemberOf(): expected the test group, got %#v", (*memberOf)[0])
+	}
+	expectRequests(t, server,
+		expectedRequest{http.MethodPatch, "/groups/" + groupId, fmt.Sprintf(`{"members@odata.bind": ["%s/beta/directoryObjects/%s"]}`, server.Endpoint(), deviceId)},
+		expectedRequest{http.MethodGet, fmt.Sprintf("/devices/%s/memberOf", deviceId), ""},
+	)
+
+	if _, err := client.Delete(ctx, deviceId); err != nil {
+		t.Fatalf("DevicesClient.Delete(): %v", err)
+	}
+	expectRequests(t, server, expectedRequest{http.MethodDelete, "/devices/" + deviceId, ""})
+	if _, status, err := client.Get(ctx, deviceId); err == nil || status != http.StatusNotFound {
+		t.Errorf("DevicesClient.Get(): expected status 404 for a deleted device, got %d", status)
+	}
+}
@@ -0,0 +1,174 @@
+package msgraph
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/manicminer/hamilton/odata"
+)
+
+// OData types of directory objects.
+const (
+	ODataTypeAdministrativeUnit = "#microsoft.graph.administrativeUnit"
+	ODataTypeApplication        = "#microsoft.graph.application"
+	ODataTypeDevice             = "#microsoft.graph.device"
+	ODataTypeDirectoryRole      = "#microsoft.graph.directoryRole"
+	ODataTypeGroup              = "#microsoft.graph.group"
+	ODataTypeOrgContact         = "#microsoft.graph.orgContact"
+	ODataTypeServicePrincipal   = "#microsoft.graph.servicePrincipal"
+	ODataTypeUser               = "#microsoft.graph.user"
+)
+
+// DirectoryObject is implemented by models of directory objects, such as users, groups, service principals and
+// organizational contacts, which can be returned together in collections such as the members of a group. Use a type
+// switch to access the properties of each object:
+//
+//	for _, member := range *members {
+//		switch m := member.(type) {
+//		case *msgraph.User:
+//			fmt.Println(*m.UserPrincipalName)
+//		case *msgraph.Group:
+//			fmt.Println(*m.DisplayName)
+//		}
+//	}
+type DirectoryObject interface {
+	// GetID returns the object ID.
+	GetID() *string
+
+	// GetODataType returns the @odata.type of the object, e.g. "#microsoft.graph.user".
+	GetODataType() string
+}
+
+// odataTypes maps @odata.type values to functions returning a pointer to a new instance of the corresponding model.
+type odataTypes map[string]func() interface{}
+
+// unmarshal unmarshals data into a new instance of the model for its @odata.type. It returns nil when data has no
+// @odata.type or the type is not known.
+func (t odataTypes) unmarshal(data []byte) (interface{}, error) {
+	var o odata.OData
+	if err := json.Unmarshal(data, &o); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+	if o.Type == nil {
+		return nil, nil
+	}
+	newModel, ok := t[*o.Type]
+	if !ok {
+		return nil, nil
+	}
+	v := newModel()
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+	return v, nil
+}
+
+// directoryObjectTypes are the directory object types which are decoded into their own models.
+var directoryObjectTypes = odataTypes{
+	ODataTypeAdministrativeUnit: func() interface{} { return &AdministrativeUnit{} },
+	ODataTypeApplication:        func() interface{} { return &Application{} },
+	ODataTypeDevice:             func() interface{} { return &Device{} },
+	ODataTypeDirectoryRole:      func() interface{} { return &DirectoryRole{} },
+	ODataTypeGroup:              func() interface{} { return &Group{} },
+	ODataTypeOrgContact:         func() interface{} { return &OrgContact{} },
+	ODataTypeServicePrincipal:   func() interface{} { return &ServicePrincipal{} },
+	ODataTypeUser:               func() interface{} { return &User{} },
+}
+
+// UnmarshalDirectoryObject unmarshals a directory object into the model for its @odata.type, e.g. *User or *Group.
+// Objects of types which are not modelled are returned as a *BaseDirectoryObject.
+func UnmarshalDirectoryObject(data []byte) (DirectoryObject, error) {
+	v, err := directoryObjectTypes.unmarshal(data)
+	if err != nil {
+		return nil, err
+	}
+	if obj, ok := v.(DirectoryObject); ok {
+		return obj, nil
+	}
+	var obj BaseDirectoryObject
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+	return &obj, nil
+}
+
+// DirectoryObjects is a collection of directory objects of mixed types. Each object is unmarshaled using
+// UnmarshalDirectoryObject, so a *DirectoryObjects can be passed to Pager{}.Next() to retrieve typed objects.
+type DirectoryObjects []DirectoryObject
+
+// UnmarshalJSON unmarshals a JSON array of directory objects.
+func (o *DirectoryObjects) UnmarshalJSON(data []byte) error {
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+	ret := make(DirectoryObjects, 0, len(items))
+	for _, item := range items {
+		obj, err := UnmarshalDirectoryObject(item)
+		if err != nil {
+			return err
+		}
+		ret = append(ret, obj)
+	}
+	*o = ret
+	return nil
+}
+
+// GetID returns the object ID.
+func (o AdministrativeUnit) GetID() *string { return o.ID }
+
+// GetODataType returns the @odata.type of the object.
+func (o AdministrativeUnit) GetODataType() string { return ODataTypeAdministrativeUnit }
+
+// GetID returns the object ID.
+func (o Application) GetID() *string { return o.ID }
+
+// GetODataType returns the @odata.type of the object.
+func (o Application) GetODataType() string { return ODataTypeApplication }
+
+// GetID returns the object ID.
+func (o BaseDirectoryObject) GetID() *string { return o.ID }
+
+// GetODataType returns the @odata.type of the object.
+func (o BaseDirectoryObject) GetODataType() string {
+	if o.ODataType == nil {
+		return ""
+	}
+	return *o.ODataType
+}
+
+// GetID returns the object ID.
+func (o Device) GetID() *string { return o.ID }
+
+// GetODataType returns the @odata.type of the object.
+func (o Device) GetODataType() string { return ODataTypeDevice }
+
+// GetID returns the object ID.
+func (o DirectoryRole) GetID() *string { return o.ID }
+
+// GetODataType returns the @odata.type of the object.
+func (o DirectoryRole) GetODataType() string { return ODataTypeDirectoryRole }
+
+// GetID returns the object ID.
+func (o Group) GetID() *string { return o.ID }
+
+// GetODataType returns the @odata.type of the object.
+func (o Group) GetODataType() string { return ODataTypeGroup }
+
+// GetID returns the object ID.
+func (o OrgContact) GetID() *string { return o.ID }
+
+// GetODataType returns the @odata.type of the object.
+func (o OrgContact) GetODataType() string { return ODataTypeOrgContact }
+
+// GetID returns the object ID.
+func (o ServicePrincipal) GetID() *string { return o.ID }
+
+// GetODataType returns the @odata.type of the object.
+func (o ServicePrincipal) GetODataType() string { return ODataTypeServicePrincipal }
+
+// GetID returns the object ID.
+func (o User) GetID() *string { return o.ID }
+
+// GetODataType returns the @odata.type of the object.
+func (o User) GetODataType() string { return ODataTypeUser }
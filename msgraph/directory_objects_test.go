@@ -0,0 +1,64 @@
+package msgraph_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/manicminer/hamilton/msgraph"
+)
+
+func TestDirectoryObjects(t *testing.T) {
+	data := []byte(`[
+		{"@odata.type": "#microsoft.graph.user", "id": "11111111-1111-1111-1111-111111111111", "userPrincipalName": "user@example.com"},
+		{"@odata.type": "#microsoft.graph.group", "id": "22222222-2222-2222-2222-222222222222", "displayName": "group"},
+		{"@odata.type": "#microsoft.graph.servicePrincipal", "id": "33333333-3333-3333-3333-333333333333", "appId": "app"},
+		{"@odata.type": "#microsoft.graph.orgContact", "id": "44444444-4444-4444-4444-444444444444", "mail": "contact@example.com"},
+		{"@odata.type": "#microsoft.graph.someFutureType", "id": "55555555-5555-5555-5555-555555555555"},
+		{"id": "66666666-6666-6666-6666-666666666666"}
+	]`)
+
+	var objects msgraph.DirectoryObjects
+	if err := json.Unmarshal(data, &objects); err != nil {
+		t.Fatalf("json.Unmarshal(): %v", err)
+	}
+	if len(objects) != 6 {
+		t.Fatalf("expected 6 objects, got %d", len(objects))
+	}
+
+	if user, ok := objects[0].(*msgraph.User); !ok || *user.UserPrincipalName != "user@example.com" {
+		t.Errorf("expected a *msgraph.User with its properties, got %#v", objects[0])
+	}
+	if group, ok := objects[1].(*msgraph.Group); !ok || *group.DisplayName != "group" {
+		t.Errorf("expected a *msgraph.Group with its properties, got %#v", objects[1])
+	}
+	if sp, ok := objects[2].(*msgraph.ServicePrincipal); !ok || *sp.AppId != "app" {
+		t.Errorf("expected a *msgraph.ServicePrincipal with its properties, got %#v", objects[2])
+	}
+	if contact, ok := objects[3].(*msgraph.OrgContact); !ok || *contact.Mail != "contact@example.com" {
+		t.Errorf("expected a *msgraph.OrgContact with its properties, got %#v", objects[3])
+	}
+	if _, ok := objects[4].(*msgraph.BaseDirectoryObject); !ok || objects[4].GetODataType() != "#microsoft.graph.someFutureType" {
+		t.Errorf("expected a *msgraph.BaseDirectoryObject for an unknown type, got %#v", objects[4])
+	}
+	if _, ok := objects[5].(*msgraph.BaseDirectoryObject); !ok || objects[5].GetODataType() != "" {
+		t.Errorf("expected a *msgraph.BaseDirectoryObject for an object without a type, got %#v", objects[5])
+	}
+
+	for i, want := range []string{
+		msgraph.ODataTypeUser,
+		msgraph.ODataTypeGroup,
+		msgraph.ODataTypeServicePrincipal,
+		msgraph.ODataTypeOrgContact,
+	} {
+		if got := objects[i].GetODataType(); got != want {
+			t.Errorf("object %d: expected type %q, got %q", i, want, got)
+		}
+		if objects[i].GetID() == nil || (*objects[i].GetID())[0] != byte('1'+i) {
+			t.Errorf("object %d: unexpected ID %v", i, objects[i].GetID())
+		}
+	}
+
+	if _, err := msgraph.UnmarshalDirectoryObject([]byte(`{"@odata.type": "#microsoft.graph.user", "accountEnabled": "yes"}`)); err == nil {
+		t.Errorf("UnmarshalDirectoryObject(): expected an error for an invalid property value")
+	}
+}
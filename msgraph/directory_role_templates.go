@@ -2,10 +2,8 @@ package msgraph
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
-	"io/ioutil"
-	"net/http"
+
+	"github.com/manicminer/hamilton/odata"
 )
 
 // DirectoryRoleTemplatesClient performs operations on DirectoryRoleTemplates.
@@ -20,52 +18,31 @@ func NewDirectoryRoleTemplatesClient(tenantId string) *DirectoryRoleTemplatesCli
 	}
 }
 
+// resource returns a Resource for performing common operations on Directory Role Templates.
+func (c *DirectoryRoleTemplatesClient) resource() Resource {
+	return Resource{
+		Client: c.BaseClient,
+		Name:   "DirectoryRoleTemplatesClient",
+		Entity: "/directoryRoleTemplates",
+	}
+}
+
 // List returns a list of DirectoryRoleTemplates.
 func (c *DirectoryRoleTemplatesClient) List(ctx context.Context) (*[]DirectoryRoleTemplate, int, error) {
-	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
-		ValidStatusCodes: []int{http.StatusOK},
-		Uri: Uri{
-			Entity:      "/directoryRoleTemplates",
-			HasTenantId: true,
-		},
-	})
+	var dirRoleTemplates []DirectoryRoleTemplate
+	status, err := c.resource().List(ctx, odata.Query{}, &dirRoleTemplates)
 	if err != nil {
-		return nil, status, fmt.Errorf("DirectoryRoleTemplatesClient.BaseClient.Get(): %v", err)
-	}
-	defer resp.Body.Close()
-	respBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, status, fmt.Errorf("ioutil.ReadAll(): %v", err)
-	}
-	var data struct {
-		DirectoryRoleTemplates []DirectoryRoleTemplate `json:"value"`
+		return nil, status, err
 	}
-	if err := json.Unmarshal(respBody, &data); err != nil {
-		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
-	}
-	return &data.DirectoryRoleTemplates, status, nil
+	return &dirRoleTemplates, status, nil
 }
 
 // Get retrieves an DirectoryRoleTemplates manifest.
 func (c *DirectoryRoleTemplatesClient) Get(ctx context.Context, id string) (*DirectoryRoleTemplate, int, error) {
-	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
-		ValidStatusCodes: []int{http.StatusOK},
-		Uri: Uri{
-			Entity:      fmt.Sprintf("/directoryRoleTemplates/%s", id),
-			HasTenantId: true,
-		},
-	})
-	if err != nil {
-		return nil, status, fmt.Errorf("DirectoryRoleTemplatesClient.BaseClient.Get(): %v", err)
-	}
-	defer resp.Body.Close()
-	respBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, status, fmt.Errorf("ioutil.ReadAll(): %v", err)
-	}
 	var dirRoleTemplate DirectoryRoleTemplate
-	if err := json.Unmarshal(respBody, &dirRoleTemplate); err != nil {
-		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	status, err := c.resource().Get(ctx, id, odata.Query{}, &dirRoleTemplate)
+	if err != nil {
+		return nil, status, err
 	}
 	return &dirRoleTemplate, status, nil
 }
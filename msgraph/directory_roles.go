@@ -24,89 +24,58 @@ func NewDirectoryRolesClient(tenantId string) *DirectoryRolesClient {
 	}
 }
 
+// resource returns a Resource for performing common operations on Directory Roles.
+func (c *DirectoryRolesClient) resource() Resource {
+	return Resource{
+		Client: c.BaseClient,
+		Name:   "DirectoryRolesClient",
+		Entity: "/directoryRoles",
+	}
+}
+
 // List returns a list of DirectoryRoles activated in the tenant.
 func (c *DirectoryRolesClient) List(ctx context.Context) (*[]DirectoryRole, int, error) {
-	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
-		ValidStatusCodes: []int{http.StatusOK},
-		Uri: Uri{
-			Entity:      "/directoryRoles",
-			HasTenantId: true,
-		},
-	})
-	if err != nil {
-		return nil, status, fmt.Errorf("DirectoryRolesClient.BaseClient.Get(): %v", err)
-	}
-	defer resp.Body.Close()
-	respBody, err := ioutil.ReadAll(resp.Body)
+	var dirRoles []DirectoryRole
+	status, err := c.resource().List(ctx, odata.Query{}, &dirRoles)
 	if err != nil {
-		return nil, status, fmt.Errorf("ioutil.ReadAll(): %v", err)
-	}
-	var data struct {
-		DirectoryRoles []DirectoryRole `json:"value"`
+		return nil, status, err
 	}
-	if err := json.Unmarshal(respBody, &data); err != nil {
-		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
-	}
-	return &data.DirectoryRoles, status, nil
+	return &dirRoles, status, nil
 }
 
 // Get retrieves an DirectoryRoles manifest.
 func (c *DirectoryRolesClient) Get(ctx context.Context, id string) (*DirectoryRole, int, error) {
-	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
-		ValidStatusCodes: []int{http.StatusOK},
-		Uri: Uri{
-			Entity:      fmt.Sprintf("/directoryRoles/%s", id),
-			HasTenantId: true,
-		},
-	})
+	var dirRole DirectoryRole
+	status, err := c.resource().Get(ctx, id, odata.Query{}, &dirRole)
 	if err != nil {
-		return nil, status, fmt.Errorf("DirectoryRolesClient.BaseClient.Get(): %v", err)
+		return nil, status, err
 	}
-	defer resp.Body.Close()
-	respBody, err := ioutil.ReadAll(resp.Body)
+	return &dirRole, status, nil
+}
+
+// ListMembers retrieves the members of the specified directory role. Each member is returned as the model for its
+// type, e.g. *User or *ServicePrincipal.
+// id is the object ID of the directory role.
+func (c *DirectoryRolesClient) ListMembers(ctx context.Context, id string) (*[]DirectoryObject, int, error) {
+	var members DirectoryObjects
+	status, err := c.resource().list(ctx, fmt.Sprintf("/directoryRoles/%s/members", id), odata.Query{}, &members)
 	if err != nil {
-		return nil, status, fmt.Errorf("ioutil.ReadAll(): %v", err)
-	}
-	var dirRole DirectoryRole
-	if err := json.Unmarshal(respBody, &dirRole); err != nil {
-		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+		return nil, status, err
 	}
-	return &dirRole, status, nil
+	ret := []DirectoryObject(members)
+	return &ret, status, nil
 }
 
-// ListMembers retrieves the members of the specified directory role.
+// ListMembersPager returns a Pager for retrieving the members of the specified directory role one page at a time.
 // id is the object ID of the directory role.
-func (c *DirectoryRolesClient) ListMembers(ctx context.Context, id string) (*[]string, int, error) {
-	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
+func (c *DirectoryRolesClient) ListMembersPager(id string) *Pager {
+	return c.BaseClient.NewPager(GetHttpRequestInput{
 		ValidStatusCodes: []int{http.StatusOK},
 		Uri: Uri{
 			Entity:      fmt.Sprintf("/directoryRoles/%s/members", id),
-			Params:      url.Values{"$select": []string{"id"}},
 			HasTenantId: true,
 		},
 	})
-	if err != nil {
-		return nil, status, fmt.Errorf("DirectoryRolesClient.BaseClient.Get(): %v", err)
-	}
-	defer resp.Body.Close()
-	respBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, status, fmt.Errorf("ioutil.ReadAll(): %v", err)
-	}
-	var data struct {
-		Members []struct {
-			Type string `json:"@odata.type"`
-			Id   string `json:"id"`
-		} `json:"value"`
-	}
-	if err := json.Unmarshal(respBody, &data); err != nil {
-		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
-	}
-	ret := make([]string, len(data.Members))
-	for i, v := range data.Members {
-		ret[i] = v.Id
-	}
-	return &ret, status, nil
 }
 
 // AddMembers adds a new member to a Directory Role.
@@ -119,38 +88,9 @@ func (c *DirectoryRolesClient) AddMembers(ctx context.Context, directoryRole *Di
 	if directoryRole.Members == nil {
 		return status, errors.New("cannot update directory role with nil Owners")
 	}
-	for _, member := range *directoryRole.Members {
-		// don't fail if a member already exists
-		checkMemberAlreadyExists := func(resp *http.Response, o *odata.OData) bool {
-			if resp.StatusCode == http.StatusBadRequest {
-				if o.Error != nil {
-					return o.Error.Match(odata.ErrorAddedObjectReferencesAlreadyExist)
-				}
-			}
-			return false
-		}
-
-		data := struct {
-			Member string `json:"@odata.id"`
-		}{
-			Member: member,
-		}
-		body, err := json.Marshal(data)
-		if err != nil {
-			return status, fmt.Errorf("json.Marshal(): %v", err)
-		}
-		_, status, _, err = c.BaseClient.Post(ctx, PostHttpRequestInput{
-			Body:             body,
-			ValidStatusCodes: []int{http.StatusNoContent},
-			ValidStatusFunc:  checkMemberAlreadyExists,
-			Uri: Uri{
-				Entity:      fmt.Sprintf("/directoryRoles/%s/members/$ref", *directoryRole.ID),
-				HasTenantId: true,
-			},
-		})
-		if err != nil {
-			return status, fmt.Errorf("DirectoryRolesClient.BaseClient.Post(): %v", err)
-		}
+	status, err := c.BaseClient.batchAddRefs(ctx, fmt.Sprintf("/directoryRoles/%s/members", *directoryRole.ID), *directoryRole.Members)
+	if err != nil {
+		return status, fmt.Errorf("DirectoryRolesClient.BaseClient.Batch(): %w", err)
 	}
 	return status, nil
 }
@@ -163,25 +103,9 @@ func (c *DirectoryRolesClient) RemoveMembers(ctx context.Context, directoryRoleI
 	if memberIds == nil {
 		return status, errors.New("cannot remove, nil memberIds")
 	}
-	for _, memberId := range *memberIds {
-		// check for membership before attempting deletion
-		if _, status, err := c.GetMember(ctx, directoryRoleId, memberId); err != nil {
-			if status == http.StatusNotFound {
-				continue
-			}
-			return status, err
-		}
-		var err error
-		_, status, _, err = c.BaseClient.Delete(ctx, DeleteHttpRequestInput{
-			ValidStatusCodes: []int{http.StatusNoContent},
-			Uri: Uri{
-				Entity:      fmt.Sprintf("/directoryRoles/%s/members/%s/$ref", directoryRoleId, memberId),
-				HasTenantId: true,
-			},
-		})
-		if err != nil {
-			return status, fmt.Errorf("DirectoryRolesClient.BaseClient.Delete(): %v", err)
-		}
+	status, err := c.BaseClient.batchRemoveRefs(ctx, fmt.Sprintf("/directoryRoles/%s/members", directoryRoleId), *memberIds)
+	if err != nil {
+		return status, fmt.Errorf("DirectoryRolesClient.BaseClient.Batch(): %w", err)
 	}
 	return status, nil
 }
@@ -199,7 +123,7 @@ func (c *DirectoryRolesClient) GetMember(ctx context.Context, directoryRoleId, m
 		},
 	})
 	if err != nil {
-		return nil, status, fmt.Errorf("DirectoryRolesClient.BaseClient.Get(): %v", err)
+		return nil, status, fmt.Errorf("DirectoryRolesClient.BaseClient.Get(): %w", err)
 	}
 	defer resp.Body.Close()
 	respBody, err := ioutil.ReadAll(resp.Body)
@@ -253,7 +177,7 @@ func (c *DirectoryRolesClient) Activate(ctx context.Context, roleTemplateID stri
 		},
 	})
 	if err != nil {
-		return nil, status, fmt.Errorf("DirectoryRolesClient.BaseClient.Post(): %v", err)
+		return nil, status, fmt.Errorf("DirectoryRolesClient.BaseClient.Post(): %w", err)
 	}
 	defer resp.Body.Close()
 	respBody, err := ioutil.ReadAll(resp.Body)
@@ -1,3 +1,6 @@
+//go:build live
+// +build live
+
 package msgraph_test
 
 import (
@@ -90,19 +93,26 @@ func testDirectoryRolesClient_Get(t *testing.T, c DirectoryRolesClientTest, id s
 }
 
 func testDirectoryRolesClient_ListMembers(t *testing.T, c DirectoryRolesClientTest, id string) (members *[]string) {
-	members, status, err := c.client.ListMembers(c.connection.Context, id)
+	result, status, err := c.client.ListMembers(c.connection.Context, id)
 	if err != nil {
 		t.Fatalf("DirectoryRolesClient.ListMembers(): %v", err)
 	}
 	if status < 200 || status >= 300 {
 		t.Fatalf("DirectoryRolesClient.ListMembers(): invalid status: %d", status)
 	}
-	if members == nil {
+	if result == nil {
 		t.Fatal("DirectoryRolesClient.ListMembers(): members was nil")
 	}
-	if len(*members) == 0 {
+	if len(*result) == 0 {
 		t.Fatal("DirectoryRolesClient.ListMembers(): members was empty")
 	}
+	members = &[]string{}
+	for _, o := range *result {
+		if o.GetID() == nil {
+			t.Fatal("DirectoryRolesClient.ListMembers(): member ID was nil")
+		}
+		*members = append(*members, *o.GetID())
+	}
 	return
 }
 
@@ -2,10 +2,8 @@ package msgraph
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
-	"io/ioutil"
-	"net/http"
+
+	"github.com/manicminer/hamilton/odata"
 )
 
 // DomainsClient performs operations on Domains.
@@ -20,59 +18,31 @@ func NewDomainsClient(tenantId string) *DomainsClient {
 	}
 }
 
-// List returns a list of Domains.
-func (c *DomainsClient) List(ctx context.Context) (*[]Domain, int, error) {
-	var status int
-	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
-		ValidStatusCodes: []int{http.StatusOK},
-		Uri: Uri{
-			Entity:      "/domains",
-			HasTenantId: true,
-		},
-	})
-
-	if err != nil {
-		return nil, status, fmt.Errorf("DomainsClient.BaseClient.Get(): %v", err)
+// resource returns a Resource for performing common operations on Domains.
+func (c *DomainsClient) resource() Resource {
+	return Resource{
+		Client: c.BaseClient,
+		Name:   "DomainsClient",
+		Entity: "/domains",
 	}
+}
 
-	defer resp.Body.Close()
-	respBody, err := ioutil.ReadAll(resp.Body)
+// List returns a list of Domains.
+func (c *DomainsClient) List(ctx context.Context) (*[]Domain, int, error) {
+	var domains []Domain
+	status, err := c.resource().List(ctx, odata.Query{}, &domains)
 	if err != nil {
-		return nil, status, fmt.Errorf("ioutil.ReadAll(): %v", err)
-	}
-
-	var data struct {
-		Domains []Domain `json:"value"`
-	}
-
-	if err := json.Unmarshal(respBody, &data); err != nil {
-		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+		return nil, status, err
 	}
-
-	return &data.Domains, status, nil
+	return &domains, status, nil
 }
 
 // Get retrieves a Domain.
 func (c *DomainsClient) Get(ctx context.Context, id string) (*Domain, int, error) {
-	var status int
-	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
-		ValidStatusCodes: []int{http.StatusOK},
-		Uri: Uri{
-			Entity:      fmt.Sprintf("/domains/%s", id),
-			HasTenantId: true,
-		},
-	})
-	if err != nil {
-		return nil, status, fmt.Errorf("DomainsClient.BaseClient.Get(): %v", err)
-	}
-	defer resp.Body.Close()
-	respBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, status, fmt.Errorf("ioutil.ReadAll(): %v", err)
-	}
 	var domain Domain
-	if err := json.Unmarshal(respBody, &domain); err != nil {
-		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	status, err := c.resource().Get(ctx, id, odata.Query{}, &domain)
+	if err != nil {
+		return nil, status, err
 	}
 	return &domain, status, nil
 }
@@ -3,6 +3,7 @@ package msgraph
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -23,111 +24,94 @@ func NewGroupsClient(tenantId string) *GroupsClient {
 	}
 }
 
-// List returns a list of Groups, optionally filtered using OData.
-func (c *GroupsClient) List(ctx context.Context, filter string) (*[]Group, int, error) {
-	params := url.Values{}
-	if filter != "" {
-		params.Add("$filter", filter)
+// resource returns a Resource for performing common operations on Groups.
+func (c *GroupsClient) resource() Resource {
+	return Resource{
+		Client:      c.BaseClient,
+		Name:        "GroupsClient",
+		Entity:      "/groups",
+		DeletedType: "microsoft.graph.group",
 	}
-	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
-		ValidStatusCodes: []int{http.StatusOK},
-		Uri: Uri{
-			Entity:      "/groups",
-			Params:      params,
-			HasTenantId: true,
-		},
-	})
+}
+
+// List returns a list of Groups, optionally queried using OData.
+func (c *GroupsClient) List(ctx context.Context, query odata.Query) (*[]Group, int, error) {
+	var groups []Group
+	status, err := c.resource().List(ctx, query, &groups)
 	if err != nil {
-		return nil, status, fmt.Errorf("GroupsClient.BaseClient.Get(): %v", err)
+		return nil, status, err
 	}
-	defer resp.Body.Close()
-	respBody, err := ioutil.ReadAll(resp.Body)
+	return &groups, status, nil
+}
+
+// ListPager returns a Pager for retrieving Groups one page at a time, optionally queried using OData.
+func (c *GroupsClient) ListPager(query odata.Query) *Pager {
+	return c.resource().ListPager(query)
+}
+
+// Delta retrieves Groups which have been created, updated or removed since a previous delta query, optionally
+// queried using OData. Specify an empty deltaLink to retrieve all Groups, or a deltaLink returned from a previous
+// call to retrieve only changes since that call. Groups which have been removed have their Removed field populated.
+// The returned deltaLink should be persisted and used to retrieve subsequent changes.
+func (c *GroupsClient) Delta(ctx context.Context, query odata.Query, deltaLink string) (*[]Group, *string, int, error) {
+	var groups []Group
+	link, status, err := c.resource().Delta(ctx, query, deltaLink, &groups)
 	if err != nil {
-		return nil, status, fmt.Errorf("ioutil.ReadAll(): %v", err)
-	}
-	var data struct {
-		Groups []Group `json:"value"`
+		return nil, nil, status, err
 	}
-	if err := json.Unmarshal(respBody, &data); err != nil {
-		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
-	}
-	return &data.Groups, status, nil
+	return &groups, link, status, nil
 }
 
 // Create creates a new Group.
 func (c *GroupsClient) Create(ctx context.Context, group Group) (*Group, int, error) {
-	var status int
-	body, err := json.Marshal(group)
-	if err != nil {
-		return nil, status, fmt.Errorf("json.Marshal(): %v", err)
-	}
-	resp, status, _, err := c.BaseClient.Post(ctx, PostHttpRequestInput{
-		Body:             body,
-		ValidStatusCodes: []int{http.StatusCreated},
-		Uri: Uri{
-			Entity:      "/groups",
-			HasTenantId: true,
-		},
-	})
-	if err != nil {
-		return nil, status, fmt.Errorf("GroupsClient.BaseClient.Post(): %v", err)
-	}
-	defer resp.Body.Close()
-	respBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, status, fmt.Errorf("ioutil.ReadAll(): %v", err)
-	}
 	var newGroup Group
-	if err := json.Unmarshal(respBody, &newGroup); err != nil {
-		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	status, err := c.resource().Create(ctx, group, &newGroup)
+	if err != nil {
+		return nil, status, err
 	}
 	return &newGroup, status, nil
 }
 
 // Get retrieves a Group.
 func (c *GroupsClient) Get(ctx context.Context, id string) (*Group, int, error) {
-	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
-		ValidStatusCodes: []int{http.StatusOK},
-		Uri: Uri{
-			Entity:      fmt.Sprintf("/groups/%s", id),
-			HasTenantId: true,
-		},
-	})
-	if err != nil {
-		return nil, status, fmt.Errorf("GroupsClient.BaseClient.Get(): %v", err)
-	}
-	defer resp.Body.Close()
-	respBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, status, fmt.Errorf("ioutil.ReadAll(): %v", err)
-	}
 	var group Group
-	if err := json.Unmarshal(respBody, &group); err != nil {
-		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	status, err := c.resource().Get(ctx, id, odata.Query{}, &group)
+	if err != nil {
+		return nil, status, err
 	}
 	return &group, status, nil
 }
 
-// GetDeleted retrieves a deleted O365 Group.
-func (c *GroupsClient) GetDeleted(ctx context.Context, id string) (*Group, int, error) {
-	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
-		ValidStatusCodes: []int{http.StatusOK},
-		Uri: Uri{
-			Entity:      fmt.Sprintf("/directory/deletedItems/%s", id),
-			HasTenantId: true,
-		},
+// WaitFor polls the specified Group until predicate returns true, and then returns it.
+// The predicate is called with nil when the Group does not exist, so WaitFor can be used to wait until a Group
+// has been created or deleted, or until it has a particular property value. ctx should have a deadline, as WaitFor
+// will otherwise wait indefinitely for the predicate to be satisfied.
+func (c *GroupsClient) WaitFor(ctx context.Context, id string, predicate func(group *Group) bool) (*Group, int, error) {
+	var group *Group
+	var status int
+	err := c.BaseClient.waitFor(ctx, func(ctx context.Context) (bool, error) {
+		var err error
+		group, status, err = c.Get(ctx, id)
+		if err != nil {
+			if status != http.StatusNotFound {
+				return false, err
+			}
+			group = nil
+		}
+		return predicate(group), nil
 	})
 	if err != nil {
-		return nil, status, fmt.Errorf("GroupsClient.BaseClient.Get(): %v", err)
-	}
-	defer resp.Body.Close()
-	respBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, status, fmt.Errorf("ioutil.ReadAll(): %v", err)
+		return nil, status, fmt.Errorf("GroupsClient.BaseClient.waitFor(): %w", err)
 	}
+	return group, status, nil
+}
+
+// GetDeleted retrieves a deleted O365 Group.
+func (c *GroupsClient) GetDeleted(ctx context.Context, id string) (*Group, int, error) {
 	var group Group
-	if err := json.Unmarshal(respBody, &group); err != nil {
-		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	status, err := c.resource().GetDeleted(ctx, id, odata.Query{}, &group)
+	if err != nil {
+		return nil, status, err
 	}
 	return &group, status, nil
 }
@@ -135,101 +119,56 @@ func (c *GroupsClient) GetDeleted(ctx context.Context, id string) (*Group, int,
 // Update amends an existing Group.
 func (c *GroupsClient) Update(ctx context.Context, group Group) (int, error) {
 	var status int
-	body, err := json.Marshal(group)
-	if err != nil {
-		return status, fmt.Errorf("json.Marshal(): %v", err)
+	if group.ID == nil {
+		return status, errors.New("GroupsClient.Update(): cannot update group with nil ID")
 	}
-	_, status, _, err = c.BaseClient.Patch(ctx, PatchHttpRequestInput{
-		Body:             body,
-		ValidStatusCodes: []int{http.StatusNoContent},
-		Uri: Uri{
-			Entity:      fmt.Sprintf("/groups/%s", *group.ID),
-			HasTenantId: true,
-		},
-	})
-	if err != nil {
-		return status, fmt.Errorf("GroupsClient.BaseClient.Patch(): %v", err)
-	}
-	return status, nil
+	return c.resource().Update(ctx, *group.ID, group)
 }
 
 // Delete removes a Group.
 func (c *GroupsClient) Delete(ctx context.Context, id string) (int, error) {
-	_, status, _, err := c.BaseClient.Delete(ctx, DeleteHttpRequestInput{
-		ValidStatusCodes: []int{http.StatusNoContent},
-		Uri: Uri{
-			Entity:      fmt.Sprintf("/groups/%s", id),
-			HasTenantId: true,
-		},
-	})
-	if err != nil {
-		return status, fmt.Errorf("GroupsClient.BaseClient.Delete(): %v", err)
-	}
-	return status, nil
+	return c.resource().Delete(ctx, id)
 }
 
-// ListDeleted retrieves a list of recently deleted O365 groups, optionally filtered using OData.
+// ListDeleted retrieves a list of recently deleted O365 groups, optionally queried using OData.
 // TODO: add test coverage once API supports creating O365 groups
-func (c *GroupsClient) ListDeleted(ctx context.Context, filter string) (*[]Group, int, error) {
-	params := url.Values{}
-	if filter != "" {
-		params.Add("$filter", filter)
-	}
-	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
-		ValidStatusCodes: []int{http.StatusOK},
-		Uri: Uri{
-			Entity:      "/directory/deleteditems/microsoft.graph.group",
-			Params:      params,
-			HasTenantId: true,
-		},
-	})
+func (c *GroupsClient) ListDeleted(ctx context.Context, query odata.Query) (*[]Group, int, error) {
+	var deletedGroups []Group
+	status, err := c.resource().ListDeleted(ctx, query, &deletedGroups)
 	if err != nil {
 		return nil, status, err
 	}
-	defer resp.Body.Close()
-	respBody, _ := ioutil.ReadAll(resp.Body)
-	var data struct {
-		DeletedGroups []Group `json:"value"`
-	}
-	if err = json.Unmarshal(respBody, &data); err != nil {
+	return &deletedGroups, status, nil
+}
+
+// ListDeletedPager returns a Pager for retrieving recently deleted O365 groups one page at a time, optionally queried using OData.
+func (c *GroupsClient) ListDeletedPager(query odata.Query) *Pager {
+	return c.resource().ListDeletedPager(query)
+}
+
+// ListMembers retrieves the members of the specified Group. Each member is returned as the model for its type,
+// e.g. *User, *Group or *ServicePrincipal.
+// id is the object ID of the group.
+func (c *GroupsClient) ListMembers(ctx context.Context, id string) (*[]DirectoryObject, int, error) {
+	var members DirectoryObjects
+	status, err := c.resource().list(ctx, fmt.Sprintf("/groups/%s/members", id), odata.Query{}, &members)
+	if err != nil {
 		return nil, status, err
 	}
-	return &data.DeletedGroups, status, nil
+	ret := []DirectoryObject(members)
+	return &ret, status, nil
 }
 
-// ListMembers retrieves the members of the specified Group.
+// ListMembersPager returns a Pager for retrieving the members of the specified Group one page at a time.
 // id is the object ID of the group.
-func (c *GroupsClient) ListMembers(ctx context.Context, id string) (*[]string, int, error) {
-	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
+func (c *GroupsClient) ListMembersPager(id string) *Pager {
+	return c.BaseClient.NewPager(GetHttpRequestInput{
 		ValidStatusCodes: []int{http.StatusOK},
 		Uri: Uri{
 			Entity:      fmt.Sprintf("/groups/%s/members", id),
-			Params:      url.Values{"$select": []string{"id"}},
 			HasTenantId: true,
 		},
 	})
-	if err != nil {
-		return nil, status, fmt.Errorf("GroupsClient.BaseClient.Get(): %v", err)
-	}
-	defer resp.Body.Close()
-	respBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, status, fmt.Errorf("ioutil.ReadAll(): %v", err)
-	}
-	var data struct {
-		Members []struct {
-			Type string `json:"@odata.type"`
-			Id   string `json:"id"`
-		} `json:"value"`
-	}
-	if err := json.Unmarshal(respBody, &data); err != nil {
-		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
-	}
-	ret := make([]string, len(data.Members))
-	for i, v := range data.Members {
-		ret[i] = v.Id
-	}
-	return &ret, status, nil
 }
 
 // GetMember retrieves a single member of the specified Group.
@@ -245,7 +184,7 @@ func (c *GroupsClient) GetMember(ctx context.Context, groupId, memberId string)
 		},
 	})
 	if err != nil {
-		return nil, status, fmt.Errorf("GroupsClient.BaseClient.Get(): %v", err)
+		return nil, status, fmt.Errorf("GroupsClient.BaseClient.Get(): %w", err)
 	}
 	defer resp.Body.Close()
 	respBody, err := ioutil.ReadAll(resp.Body)
@@ -283,17 +222,19 @@ func (c *GroupsClient) AddMembers(ctx context.Context, group *Group) (int, error
 		}
 		memberChunks = append(memberChunks, members[i:end])
 	}
-	for _, members := range memberChunks {
-		// don't fail if a member already exists
-		checkMemberAlreadyExists := func(resp *http.Response, o *odata.OData) bool {
-			if resp.StatusCode == http.StatusBadRequest {
-				if o.Error != nil {
-					return o.Error.Match(odata.ErrorAddedObjectReferencesAlreadyExist)
-				}
+	// don't fail if a member already exists
+	checkMemberAlreadyExists := func(resp *http.Response, o *odata.OData) bool {
+		if resp.StatusCode == http.StatusBadRequest {
+			if o.Error != nil {
+				return o.Error.Match(odata.ErrorAddedObjectReferencesAlreadyExist)
 			}
-			return false
 		}
+		return false
+	}
 
+	requests := make([]BatchRequest, 0, len(memberChunks))
+	for _, members := range memberChunks {
+		members := members
 		data := Group{
 			Members: &members,
 		}
@@ -301,7 +242,8 @@ func (c *GroupsClient) AddMembers(ctx context.Context, group *Group) (int, error
 		if err != nil {
 			return status, fmt.Errorf("json.Marshal(): %v", err)
 		}
-		_, status, _, err = c.BaseClient.Patch(ctx, PatchHttpRequestInput{
+		requests = append(requests, BatchRequest{
+			Method:           http.MethodPatch,
 			Body:             body,
 			ValidStatusCodes: []int{http.StatusNoContent},
 			ValidStatusFunc:  checkMemberAlreadyExists,
@@ -310,9 +252,10 @@ func (c *GroupsClient) AddMembers(ctx context.Context, group *Group) (int, error
 				HasTenantId: true,
 			},
 		})
-		if err != nil {
-			return status, fmt.Errorf("GroupsClient.BaseClient.Patch(): %v", err)
-		}
+	}
+	_, status, err := c.BaseClient.Batch(ctx, requests)
+	if err != nil {
+		return status, fmt.Errorf("GroupsClient.BaseClient.Batch(): %w", err)
 	}
 	return status, nil
 }
@@ -325,27 +268,23 @@ func (c *GroupsClient) RemoveMembers(ctx context.Context, id string, memberIds *
 	if memberIds == nil || len(*memberIds) == 0 {
 		return status, fmt.Errorf("no members specified")
 	}
-	for _, memberId := range *memberIds {
-		// check for membership before attempting deletion
-		if _, status, err := c.GetMember(ctx, id, memberId); err != nil {
-			if status == http.StatusNotFound {
-				continue
-			}
-			return status, err
-		}
-
-		// despite the above check, sometimes members are just gone
-		checkMemberGone := func(resp *http.Response, o *odata.OData) bool {
-			if resp.StatusCode == http.StatusBadRequest {
-				if o.Error != nil {
-					return o.Error.Match(odata.ErrorRemovedObjectReferencesDoNotExist)
-				}
+	// don't fail if a member has already been removed
+	checkMemberGone := func(resp *http.Response, o *odata.OData) bool {
+		switch resp.StatusCode {
+		case http.StatusNotFound:
+			return true
+		case http.StatusBadRequest:
+			if o.Error != nil {
+				return o.Error.Match(odata.ErrorRemovedObjectReferencesDoNotExist)
 			}
-			return false
 		}
+		return false
+	}
 
-		var err error
-		_, status, _, err = c.BaseClient.Delete(ctx, DeleteHttpRequestInput{
+	requests := make([]BatchRequest, 0, len(*memberIds))
+	for _, memberId := range *memberIds {
+		requests = append(requests, BatchRequest{
+			Method:           http.MethodDelete,
 			ValidStatusCodes: []int{http.StatusNoContent},
 			ValidStatusFunc:  checkMemberGone,
 			Uri: Uri{
@@ -353,46 +292,37 @@ func (c *GroupsClient) RemoveMembers(ctx context.Context, id string, memberIds *
 				HasTenantId: true,
 			},
 		})
-		if err != nil {
-			return status, fmt.Errorf("GroupsClient.BaseClient.Delete(): %v", err)
-		}
+	}
+	_, status, err := c.BaseClient.Batch(ctx, requests)
+	if err != nil {
+		return status, fmt.Errorf("GroupsClient.BaseClient.Batch(): %w", err)
 	}
 	return status, nil
 }
 
-// ListOwners retrieves the owners of the specified Group.
+// ListOwners retrieves the owners of the specified Group. Each owner is returned as the model for its type,
+// e.g. *User or *ServicePrincipal.
 // id is the object ID of the group.
-func (c *GroupsClient) ListOwners(ctx context.Context, id string) (*[]string, int, error) {
-	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
+func (c *GroupsClient) ListOwners(ctx context.Context, id string) (*[]DirectoryObject, int, error) {
+	var owners DirectoryObjects
+	status, err := c.resource().list(ctx, fmt.Sprintf("/groups/%s/owners", id), odata.Query{}, &owners)
+	if err != nil {
+		return nil, status, err
+	}
+	ret := []DirectoryObject(owners)
+	return &ret, status, nil
+}
+
+// ListOwnersPager returns a Pager for retrieving the owners of the specified Group one page at a time.
+// id is the object ID of the group.
+func (c *GroupsClient) ListOwnersPager(id string) *Pager {
+	return c.BaseClient.NewPager(GetHttpRequestInput{
 		ValidStatusCodes: []int{http.StatusOK},
 		Uri: Uri{
 			Entity:      fmt.Sprintf("/groups/%s/owners", id),
-			Params:      url.Values{"$select": []string{"id"}},
 			HasTenantId: true,
 		},
 	})
-	if err != nil {
-		return nil, status, fmt.Errorf("GroupsClient.BaseClient.Get(): %v", err)
-	}
-	defer resp.Body.Close()
-	respBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, status, fmt.Errorf("ioutil.ReadAll(): %v", err)
-	}
-	var data struct {
-		Owners []struct {
-			Type string `json:"@odata.type"`
-			Id   string `json:"id"`
-		} `json:"value"`
-	}
-	if err := json.Unmarshal(respBody, &data); err != nil {
-		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
-	}
-	ret := make([]string, len(data.Owners))
-	for i, v := range data.Owners {
-		ret[i] = v.Id
-	}
-	return &ret, status, nil
 }
 
 // GetOwner retrieves a single owner for the specified Group.
@@ -408,7 +338,7 @@ func (c *GroupsClient) GetOwner(ctx context.Context, groupId, ownerId string) (*
 		},
 	})
 	if err != nil {
-		return nil, status, fmt.Errorf("GroupsClient.BaseClient.Get(): %v", err)
+		return nil, status, fmt.Errorf("GroupsClient.BaseClient.Get(): %w", err)
 	}
 	defer resp.Body.Close()
 	respBody, err := ioutil.ReadAll(resp.Body)
@@ -464,7 +394,7 @@ func (c *GroupsClient) AddOwners(ctx context.Context, group *Group) (int, error)
 			},
 		})
 		if err != nil {
-			return status, fmt.Errorf("GroupsClient.BaseClient.Post(): %v", err)
+			return status, fmt.Errorf("GroupsClient.BaseClient.Post(): %w", err)
 		}
 	}
 	return status, nil
@@ -507,7 +437,7 @@ func (c *GroupsClient) RemoveOwners(ctx context.Context, id string, ownerIds *[]
 			},
 		})
 		if err != nil {
-			return status, fmt.Errorf("GroupsClient.BaseClient.Delete(): %v", err)
+			return status, fmt.Errorf("GroupsClient.BaseClient.Delete(): %w", err)
 		}
 	}
 	return status, nil
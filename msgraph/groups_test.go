@@ -1,3 +1,6 @@
+//go:build live
+// +build live
+
 package msgraph_test
 
 import (
@@ -8,6 +11,7 @@ import (
 	"github.com/manicminer/hamilton/internal/test"
 	"github.com/manicminer/hamilton/internal/utils"
 	"github.com/manicminer/hamilton/msgraph"
+	"github.com/manicminer/hamilton/odata"
 )
 
 type GroupsClientTest struct {
@@ -112,7 +116,7 @@ func testGroupsClient_Update(t *testing.T, c GroupsClientTest, g msgraph.Group)
 }
 
 func testGroupsClient_List(t *testing.T, c GroupsClientTest) (groups *[]msgraph.Group) {
-	groups, _, err := c.client.List(c.connection.Context, "")
+	groups, _, err := c.client.List(c.connection.Context, odata.Query{})
 	if err != nil {
 		t.Fatalf("GroupsClient.List(): %v", err)
 	}
@@ -147,19 +151,26 @@ func testGroupsClient_Delete(t *testing.T, c GroupsClientTest, id string) {
 }
 
 func testGroupsClient_ListOwners(t *testing.T, c GroupsClientTest, id string) (owners *[]string) {
-	owners, status, err := c.client.ListOwners(c.connection.Context, id)
+	result, status, err := c.client.ListOwners(c.connection.Context, id)
 	if err != nil {
 		t.Fatalf("GroupsClient.ListOwners(): %v", err)
 	}
 	if status < 200 || status >= 300 {
 		t.Fatalf("GroupsClient.ListOwners(): invalid status: %d", status)
 	}
-	if owners == nil {
+	if result == nil {
 		t.Fatal("GroupsClient.ListOwners(): owners was nil")
 	}
-	if len(*owners) == 0 {
+	if len(*result) == 0 {
 		t.Fatal("GroupsClient.ListOwners(): owners was empty")
 	}
+	owners = &[]string{}
+	for _, o := range *result {
+		if o.GetID() == nil {
+			t.Fatal("GroupsClient.ListOwners(): owner ID was nil")
+		}
+		*owners = append(*owners, *o.GetID())
+	}
 	return
 }
 
@@ -198,19 +209,26 @@ func testGroupsClient_RemoveOwners(t *testing.T, c GroupsClientTest, groupId str
 }
 
 func testGroupsClient_ListMembers(t *testing.T, c GroupsClientTest, id string) (members *[]string) {
-	members, status, err := c.client.ListMembers(c.connection.Context, id)
+	result, status, err := c.client.ListMembers(c.connection.Context, id)
 	if err != nil {
 		t.Fatalf("GroupsClient.ListMembers(): %v", err)
 	}
 	if status < 200 || status >= 300 {
 		t.Fatalf("GroupsClient.ListMembers(): invalid status: %d", status)
 	}
-	if members == nil {
+	if result == nil {
 		t.Fatal("GroupsClient.ListMembers(): members was nil")
 	}
-	if len(*members) == 0 {
+	if len(*result) == 0 {
 		t.Fatal("GroupsClient.ListMembers(): members was empty")
 	}
+	members = &[]string{}
+	for _, o := range *result {
+		if o.GetID() == nil {
+			t.Fatal("GroupsClient.ListMembers(): member ID was nil")
+		}
+		*members = append(*members, *o.GetID())
+	}
 	return
 }
 
@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+
+	"github.com/manicminer/hamilton/odata"
 )
 
 // IdentityProvidersClient performs operations on IdentityProviders.
@@ -21,82 +23,41 @@ func NewIdentityProvidersClient(tenantId string) *IdentityProvidersClient {
 	}
 }
 
+// resource returns a Resource for performing common operations on Identity Providers.
+func (c *IdentityProvidersClient) resource() Resource {
+	return Resource{
+		Client: c.BaseClient,
+		Name:   "IdentityProvidersClient",
+		Entity: "/identity/identityProviders",
+	}
+}
+
 // List returns a list of IdentityProviders.
 func (c *IdentityProvidersClient) List(ctx context.Context) (*[]IdentityProvider, int, error) {
-	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
-		ValidStatusCodes: []int{http.StatusOK},
-		Uri: Uri{
-			Entity:      "/identity/identityProviders",
-			HasTenantId: true,
-		},
-	})
-	if err != nil {
-		return nil, status, fmt.Errorf("IdentityProvidersClient.BaseClient.Get(): %v", err)
-	}
-	defer resp.Body.Close()
-	respBody, err := ioutil.ReadAll(resp.Body)
+	var providers []IdentityProvider
+	status, err := c.resource().List(ctx, odata.Query{}, &providers)
 	if err != nil {
-		return nil, status, fmt.Errorf("ioutil.ReadAll(): %v", err)
-	}
-	var data struct {
-		IdentityProviders []IdentityProvider `json:"value"`
-	}
-	if err := json.Unmarshal(respBody, &data); err != nil {
-		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+		return nil, status, err
 	}
-	return &data.IdentityProviders, status, nil
+	return &providers, status, nil
 }
 
 // Create creates a new IdentityProvider.
 func (c *IdentityProvidersClient) Create(ctx context.Context, provider IdentityProvider) (*IdentityProvider, int, error) {
-	var status int
-	body, err := json.Marshal(provider)
-	if err != nil {
-		return nil, status, fmt.Errorf("json.Marshal(): %v", err)
-	}
-	resp, status, _, err := c.BaseClient.Post(ctx, PostHttpRequestInput{
-		Body:             body,
-		ValidStatusCodes: []int{http.StatusCreated},
-		Uri: Uri{
-			Entity:      "/identity/identityProviders",
-			HasTenantId: true,
-		},
-	})
-	if err != nil {
-		return nil, status, fmt.Errorf("IdentityProvidersClient.BaseClient.Post(): %v", err)
-	}
-	defer resp.Body.Close()
-	respBody, err := ioutil.ReadAll(resp.Body)
+	var newIdentityProvider IdentityProvider
+	status, err := c.resource().Create(ctx, provider, &newIdentityProvider)
 	if err != nil {
-		return nil, status, fmt.Errorf("ioutil.ReadAll(): %v", err)
+		return nil, status, err
 	}
-	var newProvider IdentityProvider
-	if err := json.Unmarshal(respBody, &newProvider); err != nil {
-		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
-	}
-	return &newProvider, status, nil
+	return &newIdentityProvider, status, nil
 }
 
 // Get retrieves an IdentityProvider.
 func (c *IdentityProvidersClient) Get(ctx context.Context, id string) (*IdentityProvider, int, error) {
-	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
-		ValidStatusCodes: []int{http.StatusOK},
-		Uri: Uri{
-			Entity:      fmt.Sprintf("/identity/identityProviders/%s", id),
-			HasTenantId: true,
-		},
-	})
-	if err != nil {
-		return nil, status, fmt.Errorf("IdentityProvidersClient.BaseClient.Get(): %v", err)
-	}
-	defer resp.Body.Close()
-	respBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, status, fmt.Errorf("ioutil.ReadAll(): %v", err)
-	}
 	var provider IdentityProvider
-	if err := json.Unmarshal(respBody, &provider); err != nil {
-		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	status, err := c.resource().Get(ctx, id, odata.Query{}, &provider)
+	if err != nil {
+		return nil, status, err
 	}
 	return &provider, status, nil
 }
@@ -107,37 +68,12 @@ func (c *IdentityProvidersClient) Update(ctx context.Context, provider IdentityP
 	if provider.ID == nil {
 		return status, errors.New("IdentityProvidersClient.Update(): cannot update identity provider with nil ID")
 	}
-	body, err := json.Marshal(provider)
-	if err != nil {
-		return status, fmt.Errorf("json.Marshal(): %v", err)
-	}
-	_, status, _, err = c.BaseClient.Patch(ctx, PatchHttpRequestInput{
-		Body:             body,
-		ValidStatusCodes: []int{http.StatusNoContent},
-		Uri: Uri{
-			Entity:      fmt.Sprintf("/identity/identityProviders/%s", *provider.ID),
-			HasTenantId: true,
-		},
-	})
-	if err != nil {
-		return status, fmt.Errorf("IdentityProvidersClient.BaseClient.Patch(): %v", err)
-	}
-	return status, nil
+	return c.resource().Update(ctx, *provider.ID, provider)
 }
 
 // Delete removes a IdentityProvider.
 func (c *IdentityProvidersClient) Delete(ctx context.Context, id string) (int, error) {
-	_, status, _, err := c.BaseClient.Delete(ctx, DeleteHttpRequestInput{
-		ValidStatusCodes: []int{http.StatusNoContent},
-		Uri: Uri{
-			Entity:      fmt.Sprintf("/identity/identityProviders/%s", id),
-			HasTenantId: true,
-		},
-	})
-	if err != nil {
-		return status, fmt.Errorf("IdentityProvidersClient.BaseClient.Delete(): %v", err)
-	}
-	return status, nil
+	return c.resource().Delete(ctx, id)
 }
 
 // List returns a list of all available identity provider types.
@@ -150,7 +86,7 @@ func (c *IdentityProvidersClient) ListAvailableProviderTypes(ctx context.Context
 		},
 	})
 	if err != nil {
-		return nil, status, fmt.Errorf("IdentityProvidersClient.BaseClient.Get(): %v", err)
+		return nil, status, fmt.Errorf("IdentityProvidersClient.BaseClient.Get(): %w", err)
 	}
 	defer resp.Body.Close()
 	respBody, err := ioutil.ReadAll(resp.Body)
@@ -36,7 +36,7 @@ func (c *InvitationsClient) Create(ctx context.Context, invitation Invitation) (
 		},
 	})
 	if err != nil {
-		return nil, status, fmt.Errorf("InvitationsClient.BaseClient.Post(): %v", err)
+		return nil, status, fmt.Errorf("InvitationsClient.BaseClient.Post(): %w", err)
 	}
 	defer resp.Body.Close()
 	respBody, err := ioutil.ReadAll(resp.Body)
@@ -31,7 +31,7 @@ func (c *MeClient) Get(ctx context.Context) (*Me, int, error) {
 		},
 	})
 	if err != nil {
-		return nil, status, fmt.Errorf("MeClient.BaseClient.Get(): %v", err)
+		return nil, status, fmt.Errorf("MeClient.BaseClient.Get(): %w", err)
 	}
 	defer resp.Body.Close()
 	respBody, err := ioutil.ReadAll(resp.Body)
@@ -56,7 +56,7 @@ func (c *MeClient) GetProfile(ctx context.Context) (*Me, int, error) {
 		},
 	})
 	if err != nil {
-		return nil, status, fmt.Errorf("MeClient.BaseClient.Get(): %v", err)
+		return nil, status, fmt.Errorf("MeClient.BaseClient.Get(): %w", err)
 	}
 	defer resp.Body.Close()
 	respBody, err := ioutil.ReadAll(resp.Body)
@@ -87,7 +87,7 @@ func (c *MeClient) Sendmail(ctx context.Context, message MailMessage) (int, erro
 		},
 	})
 	if err != nil {
-		return status, fmt.Errorf("MeClient.BaseClient.Post(): %v", err)
+		return status, fmt.Errorf("MeClient.BaseClient.Post(): %w", err)
 	}
 	return status, nil
 }
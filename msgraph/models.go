@@ -9,8 +9,341 @@ import (
 
 	"github.com/manicminer/hamilton/environments"
 	"github.com/manicminer/hamilton/errors"
+	"github.com/manicminer/hamilton/odata"
 )
 
+// AccessPackage describes a bundle of resource roles which users can request or be assigned, such as membership of a
+// group, along with the policies governing who can request it.
+type AccessPackage struct {
+	ID                 *string                           `json:"id,omitempty"`
+	Catalog            *AccessPackageCatalog             `json:"catalog,omitempty"`
+	CreatedDateTime    *time.Time                        `json:"createdDateTime,omitempty"`
+	Description        *string                           `json:"description,omitempty"`
+	DisplayName        *string                           `json:"displayName,omitempty"`
+	IsHidden           *bool                             `json:"isHidden,omitempty"`
+	ModifiedDateTime   *time.Time                        `json:"modifiedDateTime,omitempty"`
+	ResourceRoleScopes *[]AccessPackageResourceRoleScope `json:"resourceRoleScopes,omitempty"`
+}
+
+// AccessPackageApprovalStage describes a stage of approval for requests to be assigned an access package.
+// Durations are specified in ISO 8601 format, e.g. "P14D".
+type AccessPackageApprovalStage struct {
+	DurationBeforeAutomaticDenial   *string       `json:"durationBeforeAutomaticDenial,omitempty"`
+	DurationBeforeEscalation        *string       `json:"durationBeforeEscalation,omitempty"`
+	EscalationApprovers             *[]SubjectSet `json:"escalationApprovers,omitempty"`
+	FallbackEscalationApprovers     *[]SubjectSet `json:"fallbackEscalationApprovers,omitempty"`
+	FallbackPrimaryApprovers        *[]SubjectSet `json:"fallbackPrimaryApprovers,omitempty"`
+	IsApproverJustificationRequired *bool         `json:"isApproverJustificationRequired,omitempty"`
+	IsEscalationEnabled             *bool         `json:"isEscalationEnabled,omitempty"`
+	PrimaryApprovers                *[]SubjectSet `json:"primaryApprovers,omitempty"`
+}
+
+// AccessPackageAssignment describes the assignment of an access package to a subject. When requesting a new
+// assignment, the AccessPackageId, AssignmentPolicyId and TargetId are specified. When removing an assignment, only
+// the ID is specified.
+type AccessPackageAssignment struct {
+	ID                 *string                        `json:"id,omitempty"`
+	AccessPackage      *AccessPackage                 `json:"accessPackage,omitempty"`
+	AccessPackageId    *string                        `json:"accessPackageId,omitempty"`
+	AssignmentPolicy   *AccessPackageAssignmentPolicy `json:"assignmentPolicy,omitempty"`
+	AssignmentPolicyId *string                        `json:"assignmentPolicyId,omitempty"`
+	ExpiredDateTime    *time.Time                     `json:"expiredDateTime,omitempty"`
+	Schedule           *EntitlementManagementSchedule `json:"schedule,omitempty"`
+	State              *AccessPackageAssignmentState  `json:"state,omitempty"`
+	Status             *string                        `json:"status,omitempty"`
+	Target             *AccessPackageSubject          `json:"target,omitempty"`
+	TargetId           *string                        `json:"targetId,omitempty"`
+}
+
+// AccessPackageAssignmentApprovalSettings describes whether approval is required for requests to be assigned an
+// access package, and the stages of approval.
+type AccessPackageAssignmentApprovalSettings struct {
+	IsApprovalRequiredForAdd    *bool                         `json:"isApprovalRequiredForAdd,omitempty"`
+	IsApprovalRequiredForUpdate *bool                         `json:"isApprovalRequiredForUpdate,omitempty"`
+	Stages                      *[]AccessPackageApprovalStage `json:"stages,omitempty"`
+}
+
+// AccessPackageAssignmentPolicy describes who can request or be assigned an access package, whether approval is
+// required, and when assignments expire.
+type AccessPackageAssignmentPolicy struct {
+	ID                      *string                                   `json:"id,omitempty"`
+	AccessPackage           *AccessPackage                            `json:"accessPackage,omitempty"`
+	AllowedTargetScope      *AllowedTargetScope                       `json:"allowedTargetScope,omitempty"`
+	CreatedDateTime         *time.Time                                `json:"createdDateTime,omitempty"`
+	Description             *string                                   `json:"description,omitempty"`
+	DisplayName             *string                                   `json:"displayName,omitempty"`
+	Expiration              *ExpirationPattern                        `json:"expiration,omitempty"`
+	ModifiedDateTime        *time.Time                                `json:"modifiedDateTime,omitempty"`
+	RequestApprovalSettings *AccessPackageAssignmentApprovalSettings  `json:"requestApprovalSettings,omitempty"`
+	RequestorSettings       *AccessPackageAssignmentRequestorSettings `json:"requestorSettings,omitempty"`
+	SpecificAllowedTargets  *[]SubjectSet                             `json:"specificAllowedTargets,omitempty"`
+}
+
+// AccessPackageAssignmentRequest describes a request to add, update or remove an access package assignment.
+type AccessPackageAssignmentRequest struct {
+	ID                *string                        `json:"id,omitempty"`
+	AccessPackage     *AccessPackage                 `json:"accessPackage,omitempty"`
+	Assignment        *AccessPackageAssignment       `json:"assignment,omitempty"`
+	CompletedDateTime *time.Time                     `json:"completedDateTime,omitempty"`
+	CreatedDateTime   *time.Time                     `json:"createdDateTime,omitempty"`
+	Justification     *string                        `json:"justification,omitempty"`
+	RequestType       *AccessPackageRequestType      `json:"requestType,omitempty"`
+	Schedule          *EntitlementManagementSchedule `json:"schedule,omitempty"`
+	State             *AccessPackageRequestState     `json:"state,omitempty"`
+	Status            *string                        `json:"status,omitempty"`
+}
+
+// AccessPackageAssignmentRequestorSettings describes who can request an access package on behalf of themselves or
+// others.
+type AccessPackageAssignmentRequestorSettings struct {
+	AllowCustomAssignmentSchedule          *bool         `json:"allowCustomAssignmentSchedule,omitempty"`
+	EnableOnBehalfRequestorsToAddAccess    *bool         `json:"enableOnBehalfRequestorsToAddAccess,omitempty"`
+	EnableOnBehalfRequestorsToRemoveAccess *bool         `json:"enableOnBehalfRequestorsToRemoveAccess,omitempty"`
+	EnableOnBehalfRequestorsToUpdateAccess *bool         `json:"enableOnBehalfRequestorsToUpdateAccess,omitempty"`
+	EnableTargetsToSelfAddAccess           *bool         `json:"enableTargetsToSelfAddAccess,omitempty"`
+	EnableTargetsToSelfRemoveAccess        *bool         `json:"enableTargetsToSelfRemoveAccess,omitempty"`
+	EnableTargetsToSelfUpdateAccess        *bool         `json:"enableTargetsToSelfUpdateAccess,omitempty"`
+	OnBehalfRequestors                     *[]SubjectSet `json:"onBehalfRequestors,omitempty"`
+}
+
+type AccessPackageAssignmentState string
+
+const (
+	AccessPackageAssignmentStateDelivered          AccessPackageAssignmentState = "delivered"
+	AccessPackageAssignmentStateDelivering         AccessPackageAssignmentState = "delivering"
+	AccessPackageAssignmentStateDeliveryFailed     AccessPackageAssignmentState = "deliveryFailed"
+	AccessPackageAssignmentStateExpired            AccessPackageAssignmentState = "expired"
+	AccessPackageAssignmentStatePartiallyDelivered AccessPackageAssignmentState = "partiallyDelivered"
+)
+
+// AccessPackageCatalog describes a container of access packages and the resources they grant access to.
+type AccessPackageCatalog struct {
+	ID                  *string                    `json:"id,omitempty"`
+	CatalogType         *AccessPackageCatalogType  `json:"catalogType,omitempty"`
+	CreatedDateTime     *time.Time                 `json:"createdDateTime,omitempty"`
+	Description         *string                    `json:"description,omitempty"`
+	DisplayName         *string                    `json:"displayName,omitempty"`
+	IsExternallyVisible *bool                      `json:"isExternallyVisible,omitempty"`
+	ModifiedDateTime    *time.Time                 `json:"modifiedDateTime,omitempty"`
+	State               *AccessPackageCatalogState `json:"state,omitempty"`
+}
+
+type AccessPackageCatalogState string
+
+const (
+	AccessPackageCatalogStatePublished   AccessPackageCatalogState = "published"
+	AccessPackageCatalogStateUnpublished AccessPackageCatalogState = "unpublished"
+)
+
+type AccessPackageCatalogType string
+
+const (
+	AccessPackageCatalogTypeServiceDefault AccessPackageCatalogType = "serviceDefault"
+	AccessPackageCatalogTypeServiceManaged AccessPackageCatalogType = "serviceManaged"
+	AccessPackageCatalogTypeUserManaged    AccessPackageCatalogType = "userManaged"
+)
+
+type AccessPackageRequestState string
+
+const (
+	AccessPackageRequestStateCanceled           AccessPackageRequestState = "canceled"
+	AccessPackageRequestStateDelivered          AccessPackageRequestState = "delivered"
+	AccessPackageRequestStateDelivering         AccessPackageRequestState = "delivering"
+	AccessPackageRequestStateDeliveryFailed     AccessPackageRequestState = "deliveryFailed"
+	AccessPackageRequestStateDenied             AccessPackageRequestState = "denied"
+	AccessPackageRequestStatePartiallyDelivered AccessPackageRequestState = "partiallyDelivered"
+	AccessPackageRequestStatePendingApproval    AccessPackageRequestState = "pendingApproval"
+	AccessPackageRequestStateScheduled          AccessPackageRequestState = "scheduled"
+	AccessPackageRequestStateSubmitted          AccessPackageRequestState = "submitted"
+)
+
+type AccessPackageRequestType string
+
+const (
+	AccessPackageRequestTypeAdminAdd    AccessPackageRequestType = "adminAdd"
+	AccessPackageRequestTypeAdminRemove AccessPackageRequestType = "adminRemove"
+	AccessPackageRequestTypeAdminUpdate AccessPackageRequestType = "adminUpdate"
+	AccessPackageRequestTypeUserAdd     AccessPackageRequestType = "userAdd"
+	AccessPackageRequestTypeUserRemove  AccessPackageRequestType = "userRemove"
+	AccessPackageRequestTypeUserUpdate  AccessPackageRequestType = "userUpdate"
+)
+
+// AccessPackageResource describes a resource which has been added to a catalog, such as a group or an application.
+type AccessPackageResource struct {
+	ID               *string                            `json:"id,omitempty"`
+	CreatedDateTime  *time.Time                         `json:"createdDateTime,omitempty"`
+	Description      *string                            `json:"description,omitempty"`
+	DisplayName      *string                            `json:"displayName,omitempty"`
+	ModifiedDateTime *time.Time                         `json:"modifiedDateTime,omitempty"`
+	OriginId         *string                            `json:"originId,omitempty"`
+	OriginSystem     *AccessPackageResourceOriginSystem `json:"originSystem,omitempty"`
+}
+
+type AccessPackageResourceOriginSystem string
+
+const (
+	AccessPackageResourceOriginSystemAadApplication   AccessPackageResourceOriginSystem = "AadApplication"
+	AccessPackageResourceOriginSystemAadGroup         AccessPackageResourceOriginSystem = "AadGroup"
+	AccessPackageResourceOriginSystemSharePointOnline AccessPackageResourceOriginSystem = "SharePointOnline"
+)
+
+// AccessPackageResourceRequest describes a request to add a resource to, or remove a resource from, a catalog.
+type AccessPackageResourceRequest struct {
+	ID              *string                    `json:"id,omitempty"`
+	Catalog         *AccessPackageCatalog      `json:"catalog,omitempty"`
+	CreatedDateTime *time.Time                 `json:"createdDateTime,omitempty"`
+	RequestType     *AccessPackageRequestType  `json:"requestType,omitempty"`
+	Resource        *AccessPackageResource     `json:"resource,omitempty"`
+	State           *AccessPackageRequestState `json:"state,omitempty"`
+}
+
+// AccessPackageResourceRole describes a role of a catalog resource, such as membership of a group. The OriginId of a
+// group role is "Member_{groupId}" or "Owner_{groupId}", and that of an application role is the ID of the app role.
+type AccessPackageResourceRole struct {
+	ID           *string                            `json:"id,omitempty"`
+	Description  *string                            `json:"description,omitempty"`
+	DisplayName  *string                            `json:"displayName,omitempty"`
+	OriginId     *string                            `json:"originId,omitempty"`
+	OriginSystem *AccessPackageResourceOriginSystem `json:"originSystem,omitempty"`
+	Resource     *AccessPackageResource             `json:"resource,omitempty"`
+}
+
+// AccessPackageResourceRoleScope describes a resource role granted by an access package.
+type AccessPackageResourceRoleScope struct {
+	ID              *string                     `json:"id,omitempty"`
+	CreatedDateTime *time.Time                  `json:"createdDateTime,omitempty"`
+	Role            *AccessPackageResourceRole  `json:"role,omitempty"`
+	Scope           *AccessPackageResourceScope `json:"scope,omitempty"`
+}
+
+// AccessPackageResourceScope describes the scope of a resource role granted by an access package. For groups and
+// applications, the scope is the whole resource and has the same OriginId as the resource.
+type AccessPackageResourceScope struct {
+	ID           *string                            `json:"id,omitempty"`
+	Description  *string                            `json:"description,omitempty"`
+	DisplayName  *string                            `json:"displayName,omitempty"`
+	IsRootScope  *bool                              `json:"isRootScope,omitempty"`
+	OriginId     *string                            `json:"originId,omitempty"`
+	OriginSystem *AccessPackageResourceOriginSystem `json:"originSystem,omitempty"`
+}
+
+// AccessPackageSubject describes the subject of an access package assignment.
+type AccessPackageSubject struct {
+	ID            *string `json:"id,omitempty"`
+	DisplayName   *string `json:"displayName,omitempty"`
+	Email         *string `json:"email,omitempty"`
+	ObjectId      *string `json:"objectId,omitempty"`
+	PrincipalName *string `json:"principalName,omitempty"`
+	SubjectType   *string `json:"subjectType,omitempty"`
+}
+
+// AccessReviewApplyAction describes an action taken when the decisions of an access review are applied. Currently the
+// only supported action is to remove access, see AccessReviewApplyActionRemoveAccess.
+type AccessReviewApplyAction struct {
+	ODataType *string `json:"@odata.type,omitempty"`
+}
+
+// AccessReviewApplyActionRemoveAccess is the @odata.type of the action which removes access for denied principals.
+const AccessReviewApplyActionRemoveAccess = "#microsoft.graph.removeAccessApplyAction"
+
+type AccessReviewDecision string
+
+const (
+	AccessReviewDecisionApprove     AccessReviewDecision = "Approve"
+	AccessReviewDecisionDeny        AccessReviewDecision = "Deny"
+	AccessReviewDecisionDontKnow    AccessReviewDecision = "DontKnow"
+	AccessReviewDecisionNotReviewed AccessReviewDecision = "NotReviewed"
+)
+
+// AccessReviewInstance describes a single recurrence of an access review.
+type AccessReviewInstance struct {
+	ID                *string                      `json:"id,omitempty"`
+	EndDateTime       *time.Time                   `json:"endDateTime,omitempty"`
+	FallbackReviewers *[]AccessReviewReviewerScope `json:"fallbackReviewers,omitempty"`
+	Reviewers         *[]AccessReviewReviewerScope `json:"reviewers,omitempty"`
+	Scope             *AccessReviewScope           `json:"scope,omitempty"`
+	StartDateTime     *time.Time                   `json:"startDateTime,omitempty"`
+	Status            *string                      `json:"status,omitempty"`
+}
+
+// AccessReviewInstanceDecisionItem describes a decision on whether a principal should retain access to a resource.
+type AccessReviewInstanceDecisionItem struct {
+	ID               *string                                   `json:"id,omitempty"`
+	AccessReviewId   *string                                   `json:"accessReviewId,omitempty"`
+	AppliedBy        *UserIdentity                             `json:"appliedBy,omitempty"`
+	AppliedDateTime  *time.Time                                `json:"appliedDateTime,omitempty"`
+	ApplyResult      *string                                   `json:"applyResult,omitempty"`
+	Decision         *AccessReviewDecision                     `json:"decision,omitempty"`
+	Justification    *string                                   `json:"justification,omitempty"`
+	Principal        *Identity                                 `json:"principal,omitempty"`
+	PrincipalLink    *string                                   `json:"principalLink,omitempty"`
+	Recommendation   *string                                   `json:"recommendation,omitempty"`
+	Resource         *AccessReviewInstanceDecisionItemResource `json:"resource,omitempty"`
+	ResourceLink     *string                                   `json:"resourceLink,omitempty"`
+	ReviewedBy       *UserIdentity                             `json:"reviewedBy,omitempty"`
+	ReviewedDateTime *time.Time                                `json:"reviewedDateTime,omitempty"`
+}
+
+// AccessReviewInstanceDecisionItemResource describes the resource to which access is being reviewed.
+type AccessReviewInstanceDecisionItemResource struct {
+	ID          *string `json:"id,omitempty"`
+	DisplayName *string `json:"displayName,omitempty"`
+	Type        *string `json:"type,omitempty"`
+}
+
+// AccessReviewReviewerScope describes who reviews access, using a query such as "/users/{id}" or
+// "/groups/{id}/owners".
+type AccessReviewReviewerScope struct {
+	Query     *string `json:"query,omitempty"`
+	QueryRoot *string `json:"queryRoot,omitempty"`
+	QueryType *string `json:"queryType,omitempty"`
+}
+
+// AccessReviewScheduleDefinition describes an access review series, including its scope, reviewers and settings.
+type AccessReviewScheduleDefinition struct {
+	ID                       *string                       `json:"id,omitempty"`
+	CreatedBy                *UserIdentity                 `json:"createdBy,omitempty"`
+	CreatedDateTime          *time.Time                    `json:"createdDateTime,omitempty"`
+	DescriptionForAdmins     *string                       `json:"descriptionForAdmins,omitempty"`
+	DescriptionForReviewers  *string                       `json:"descriptionForReviewers,omitempty"`
+	DisplayName              *string                       `json:"displayName,omitempty"`
+	FallbackReviewers        *[]AccessReviewReviewerScope  `json:"fallbackReviewers,omitempty"`
+	InstanceEnumerationScope *AccessReviewScope            `json:"instanceEnumerationScope,omitempty"`
+	LastModifiedDateTime     *time.Time                    `json:"lastModifiedDateTime,omitempty"`
+	Reviewers                *[]AccessReviewReviewerScope  `json:"reviewers,omitempty"`
+	Scope                    *AccessReviewScope            `json:"scope,omitempty"`
+	Settings                 *AccessReviewScheduleSettings `json:"settings,omitempty"`
+	Status                   *string                       `json:"status,omitempty"`
+}
+
+// AccessReviewScheduleSettings describes the settings of an access review series.
+type AccessReviewScheduleSettings struct {
+	ApplyActions                         *[]AccessReviewApplyAction `json:"applyActions,omitempty"`
+	AutoApplyDecisionsEnabled            *bool                      `json:"autoApplyDecisionsEnabled,omitempty"`
+	DecisionHistoriesForReviewersEnabled *bool                      `json:"decisionHistoriesForReviewersEnabled,omitempty"`
+	DefaultDecision                      *string                    `json:"defaultDecision,omitempty"`
+	DefaultDecisionEnabled               *bool                      `json:"defaultDecisionEnabled,omitempty"`
+	InstanceDurationInDays               *int32                     `json:"instanceDurationInDays,omitempty"`
+	JustificationRequiredOnApproval      *bool                      `json:"justificationRequiredOnApproval,omitempty"`
+	MailNotificationsEnabled             *bool                      `json:"mailNotificationsEnabled,omitempty"`
+	RecommendationsEnabled               *bool                      `json:"recommendationsEnabled,omitempty"`
+	Recurrence                           *PatternedRecurrence       `json:"recurrence,omitempty"`
+	ReminderNotificationsEnabled         *bool                      `json:"reminderNotificationsEnabled,omitempty"`
+}
+
+// AccessReviewScope describes what is reviewed, using a query such as "/groups/{id}/transitiveMembers" for the
+// members of a group, or "/servicePrincipals/{id}/appRoleAssignedTo" for the app role assignments of an application.
+// Set ODataType to AccessReviewScopeQuery.
+type AccessReviewScope struct {
+	ODataType *string `json:"@odata.type,omitempty"`
+	Query     *string `json:"query,omitempty"`
+	QueryRoot *string `json:"queryRoot,omitempty"`
+	QueryType *string `json:"queryType,omitempty"`
+}
+
+// AccessReviewScopeQuery is the @odata.type of an AccessReviewScope specified using a query.
+const AccessReviewScopeQuery = "#microsoft.graph.accessReviewQueryScope"
+
 type AddIn struct {
 	ID         *string          `json:"id,omitempty"`
 	Properties *[]AddInKeyValue `json:"properties,omitempty"`
@@ -22,6 +355,56 @@ type AddInKeyValue struct {
 	Value *string `json:"value,omitempty"`
 }
 
+// AdministrativeUnit describes an Administrative Unit object.
+type AdministrativeUnit struct {
+	ID              *string                       `json:"id,omitempty"`
+	DeletedDateTime *time.Time                    `json:"deletedDateTime,omitempty"`
+	Description     *string                       `json:"description,omitempty"`
+	DisplayName     *string                       `json:"displayName,omitempty"`
+	Visibility      *AdministrativeUnitVisibility `json:"visibility,omitempty"`
+
+	Members *[]string `json:"-"`
+}
+
+// AppendMember appends a new member object URI to the Members slice.
+func (a *AdministrativeUnit) AppendMember(endpoint environments.ApiEndpoint, apiVersion ApiVersion, id string) {
+	val := fmt.Sprintf("%s/%s/directoryObjects/%s", endpoint, apiVersion, id)
+	var members []string
+	if a.Members != nil {
+		members = *a.Members
+	}
+	members = append(members, val)
+	a.Members = &members
+}
+
+type AdministrativeUnitVisibility string
+
+const (
+	AdministrativeUnitVisibilityHiddenMembership AdministrativeUnitVisibility = "HiddenMembership"
+	AdministrativeUnitVisibilityPublic           AdministrativeUnitVisibility = "Public"
+)
+
+type AllowedTargetScope string
+
+const (
+	AllowedTargetScopeAllConfiguredConnectedOrganizationUsers AllowedTargetScope = "allConfiguredConnectedOrganizationUsers"
+	AllowedTargetScopeAllDirectoryServicePrincipals           AllowedTargetScope = "allDirectoryServicePrincipals"
+	AllowedTargetScopeAllDirectoryUsers                       AllowedTargetScope = "allDirectoryUsers"
+	AllowedTargetScopeAllExternalUsers                        AllowedTargetScope = "allExternalUsers"
+	AllowedTargetScopeAllMemberUsers                          AllowedTargetScope = "allMemberUsers"
+	AllowedTargetScopeNotSpecified                            AllowedTargetScope = "notSpecified"
+	AllowedTargetScopeSpecificConnectedOrganizationUsers      AllowedTargetScope = "specificConnectedOrganizationUsers"
+	AllowedTargetScopeSpecificDirectoryServicePrincipals      AllowedTargetScope = "specificDirectoryServicePrincipals"
+	AllowedTargetScopeSpecificDirectoryUsers                  AllowedTargetScope = "specificDirectoryUsers"
+)
+
+// AlternativeSecurityId describes an alternative security identifier of a Device.
+type AlternativeSecurityId struct {
+	IdentityProvider *string `json:"identityProvider,omitempty"`
+	Key              *string `json:"key,omitempty"`
+	Type             *int32  `json:"type,omitempty"`
+}
+
 type ApiPreAuthorizedApplication struct {
 	AppId         *string   `json:"appId,omitempty"`
 	PermissionIds *[]string `json:"permissionIds,omitempty"`
@@ -63,6 +446,8 @@ type Application struct {
 	Web                           *ApplicationWeb           `json:"web,omitempty"`
 
 	Owners *[]string `json:"owners@odata.bind,omitempty"`
+
+	Removed *odata.Removed `json:"@removed,omitempty"`
 }
 
 func (a Application) MarshalJSON() ([]byte, error) {
@@ -321,6 +706,200 @@ const (
 	AppRoleAllowedMemberTypeUser        AppRoleAllowedMemberType = "User"
 )
 
+type AttestationLevel string
+
+const (
+	AttestationLevelAttested    AttestationLevel = "attested"
+	AttestationLevelNotAttested AttestationLevel = "notAttested"
+)
+
+// AuthenticationMethodConfiguration describes the settings of an authentication method in the authentication methods
+// policy of the tenant, such as whether it is enabled and which users can use it. Set ODataType to one of the
+// AuthenticationMethodConfiguration* constants when updating a configuration. Fields which only apply to some methods
+// are ignored for other methods.
+type AuthenticationMethodConfiguration struct {
+	ODataType      *string                       `json:"@odata.type,omitempty"`
+	ID             *string                       `json:"id,omitempty"`
+	ExcludeTargets *[]ExcludeTarget              `json:"excludeTargets,omitempty"`
+	IncludeTargets *[]AuthenticationMethodTarget `json:"includeTargets,omitempty"`
+	State          *AuthenticationMethodState    `json:"state,omitempty"`
+
+	// Email
+	AllowExternalIdToUseEmailOtp *ExternalEmailOtpState `json:"allowExternalIdToUseEmailOtp,omitempty"`
+
+	// FIDO2
+	IsAttestationEnforced            *bool                 `json:"isAttestationEnforced,omitempty"`
+	IsSelfServiceRegistrationAllowed *bool                 `json:"isSelfServiceRegistrationAllowed,omitempty"`
+	KeyRestrictions                  *Fido2KeyRestrictions `json:"keyRestrictions,omitempty"`
+
+	// Microsoft Authenticator
+	IsSoftwareOathEnabled *bool `json:"isSoftwareOathEnabled,omitempty"`
+
+	// Temporary Access Pass
+	DefaultLength            *int32 `json:"defaultLength,omitempty"`
+	DefaultLifetimeInMinutes *int32 `json:"defaultLifetimeInMinutes,omitempty"`
+	IsUsableOnce             *bool  `json:"isUsableOnce,omitempty"`
+	MaximumLifetimeInMinutes *int32 `json:"maximumLifetimeInMinutes,omitempty"`
+	MinimumLifetimeInMinutes *int32 `json:"minimumLifetimeInMinutes,omitempty"`
+
+	// Voice
+	IsOfficePhoneAllowed *bool `json:"isOfficePhoneAllowed,omitempty"`
+}
+
+// The @odata.type and ID of each AuthenticationMethodConfiguration.
+const (
+	AuthenticationMethodConfigurationEmail                  = "#microsoft.graph.emailAuthenticationMethodConfiguration"
+	AuthenticationMethodConfigurationFido2                  = "#microsoft.graph.fido2AuthenticationMethodConfiguration"
+	AuthenticationMethodConfigurationMicrosoftAuthenticator = "#microsoft.graph.microsoftAuthenticatorAuthenticationMethodConfiguration"
+	AuthenticationMethodConfigurationSms                    = "#microsoft.graph.smsAuthenticationMethodConfiguration"
+	AuthenticationMethodConfigurationSoftwareOath           = "#microsoft.graph.softwareOathAuthenticationMethodConfiguration"
+	AuthenticationMethodConfigurationTemporaryAccessPass    = "#microsoft.graph.temporaryAccessPassAuthenticationMethodConfiguration"
+	AuthenticationMethodConfigurationVoice                  = "#microsoft.graph.voiceAuthenticationMethodConfiguration"
+
+	AuthenticationMethodConfigurationIdEmail                  = "Email"
+	AuthenticationMethodConfigurationIdFido2                  = "Fido2"
+	AuthenticationMethodConfigurationIdMicrosoftAuthenticator = "MicrosoftAuthenticator"
+	AuthenticationMethodConfigurationIdSms                    = "Sms"
+	AuthenticationMethodConfigurationIdSoftwareOath           = "SoftwareOath"
+	AuthenticationMethodConfigurationIdTemporaryAccessPass    = "TemporaryAccessPass"
+	AuthenticationMethodConfigurationIdVoice                  = "Voice"
+)
+
+type AuthenticationMethodModes string
+
+const (
+	AuthenticationMethodModesDeviceBasedPush             AuthenticationMethodModes = "deviceBasedPush"
+	AuthenticationMethodModesEmail                       AuthenticationMethodModes = "email"
+	AuthenticationMethodModesFederatedMultiFactor        AuthenticationMethodModes = "federatedMultiFactor"
+	AuthenticationMethodModesFederatedSingleFactor       AuthenticationMethodModes = "federatedSingleFactor"
+	AuthenticationMethodModesFido2                       AuthenticationMethodModes = "fido2"
+	AuthenticationMethodModesHardwareOath                AuthenticationMethodModes = "hardwareOath"
+	AuthenticationMethodModesMicrosoftAuthenticatorPush  AuthenticationMethodModes = "microsoftAuthenticatorPush"
+	AuthenticationMethodModesPassword                    AuthenticationMethodModes = "password"
+	AuthenticationMethodModesSms                         AuthenticationMethodModes = "sms"
+	AuthenticationMethodModesSoftwareOath                AuthenticationMethodModes = "softwareOath"
+	AuthenticationMethodModesTemporaryAccessPassMultiUse AuthenticationMethodModes = "temporaryAccessPassMultiUse"
+	AuthenticationMethodModesTemporaryAccessPassOneTime  AuthenticationMethodModes = "temporaryAccessPassOneTime"
+	AuthenticationMethodModesVoice                       AuthenticationMethodModes = "voice"
+	AuthenticationMethodModesWindowsHelloForBusiness     AuthenticationMethodModes = "windowsHelloForBusiness"
+	AuthenticationMethodModesX509CertificateMultiFactor  AuthenticationMethodModes = "x509CertificateMultiFactor"
+	AuthenticationMethodModesX509CertificateSingleFactor AuthenticationMethodModes = "x509CertificateSingleFactor"
+)
+
+type AuthenticationMethodSignInState string
+
+const (
+	AuthenticationMethodSignInStateNotSupported         AuthenticationMethodSignInState = "notSupported"
+	AuthenticationMethodSignInStateNotAllowedByPolicy   AuthenticationMethodSignInState = "notAllowedByPolicy"
+	AuthenticationMethodSignInStateNotEnabled           AuthenticationMethodSignInState = "notEnabled"
+	AuthenticationMethodSignInStatePhoneNumberNotUnique AuthenticationMethodSignInState = "phoneNumberNotUnique"
+	AuthenticationMethodSignInStateReady                AuthenticationMethodSignInState = "ready"
+	AuthenticationMethodSignInStateNotConfigured        AuthenticationMethodSignInState = "notConfigured"
+)
+
+type AuthenticationMethodState string
+
+const (
+	AuthenticationMethodStateDisabled AuthenticationMethodState = "disabled"
+	AuthenticationMethodStateEnabled  AuthenticationMethodState = "enabled"
+)
+
+// AuthenticationMethodTarget describes a user or group which can use an authentication method. Specify the ID
+// "all_users" with the TargetType group to target all users.
+type AuthenticationMethodTarget struct {
+	ID                     *string                         `json:"id,omitempty"`
+	IsRegistrationRequired *bool                           `json:"isRegistrationRequired,omitempty"`
+	TargetType             *AuthenticationMethodTargetType `json:"targetType,omitempty"`
+
+	// Microsoft Authenticator
+	AuthenticationMode *MicrosoftAuthenticatorAuthenticationMode `json:"authenticationMode,omitempty"`
+
+	// SMS
+	IsUsableForSignIn *bool `json:"isUsableForSignIn,omitempty"`
+}
+
+type AuthenticationMethodTargetType string
+
+const (
+	AuthenticationMethodTargetTypeGroup AuthenticationMethodTargetType = "group"
+	AuthenticationMethodTargetTypeUser  AuthenticationMethodTargetType = "user"
+)
+
+// AuthenticationMethodsPolicy describes the authentication methods which users in the tenant can register and use.
+type AuthenticationMethodsPolicy struct {
+	ID                                 *string                                    `json:"id,omitempty"`
+	AuthenticationMethodConfigurations *[]AuthenticationMethodConfiguration       `json:"authenticationMethodConfigurations,omitempty"`
+	Description                        *string                                    `json:"description,omitempty"`
+	DisplayName                        *string                                    `json:"displayName,omitempty"`
+	LastModifiedDateTime               *time.Time                                 `json:"lastModifiedDateTime,omitempty"`
+	PolicyMigrationState               *AuthenticationMethodsPolicyMigrationState `json:"policyMigrationState,omitempty"`
+	PolicyVersion                      *string                                    `json:"policyVersion,omitempty"`
+	ReconfirmationInDays               *int32                                     `json:"reconfirmationInDays,omitempty"`
+}
+
+type AuthenticationMethodsPolicyMigrationState string
+
+const (
+	AuthenticationMethodsPolicyMigrationStateMigrationComplete   AuthenticationMethodsPolicyMigrationState = "migrationComplete"
+	AuthenticationMethodsPolicyMigrationStateMigrationInProgress AuthenticationMethodsPolicyMigrationState = "migrationInProgress"
+	AuthenticationMethodsPolicyMigrationStatePreMigration        AuthenticationMethodsPolicyMigrationState = "preMigration"
+)
+
+type AuthenticationPhoneType string
+
+const (
+	AuthenticationPhoneTypeAlternateMobile AuthenticationPhoneType = "alternateMobile"
+	AuthenticationPhoneTypeMobile          AuthenticationPhoneType = "mobile"
+	AuthenticationPhoneTypeOffice          AuthenticationPhoneType = "office"
+)
+
+// AuthenticationStrengthPolicy describes the combinations of authentication methods which satisfy a conditional access
+// policy requiring the authentication strength.
+type AuthenticationStrengthPolicy struct {
+	ID                    *string                             `json:"id,omitempty"`
+	AllowedCombinations   *[]AuthenticationMethodModes        `json:"allowedCombinations,omitempty"`
+	CreatedDateTime       *time.Time                          `json:"createdDateTime,omitempty"`
+	Description           *string                             `json:"description,omitempty"`
+	DisplayName           *string                             `json:"displayName,omitempty"`
+	ModifiedDateTime      *time.Time                          `json:"modifiedDateTime,omitempty"`
+	PolicyType            *AuthenticationStrengthPolicyType   `json:"policyType,omitempty"`
+	RequirementsSatisfied *AuthenticationStrengthRequirements `json:"requirementsSatisfied,omitempty"`
+}
+
+// The IDs of the built-in AuthenticationStrengthPolicy objects.
+const (
+	AuthenticationStrengthPolicyMultifactor       = "00000000-0000-0000-0000-000000000002"
+	AuthenticationStrengthPolicyPasswordless      = "00000000-0000-0000-0000-000000000003"
+	AuthenticationStrengthPolicyPhishingResistant = "00000000-0000-0000-0000-000000000004"
+)
+
+type AuthenticationStrengthPolicyType string
+
+const (
+	AuthenticationStrengthPolicyTypeBuiltIn AuthenticationStrengthPolicyType = "builtIn"
+	AuthenticationStrengthPolicyTypeCustom  AuthenticationStrengthPolicyType = "custom"
+)
+
+type AuthenticationStrengthRequirements string
+
+const (
+	AuthenticationStrengthRequirementsMfa  AuthenticationStrengthRequirements = "mfa"
+	AuthenticationStrengthRequirementsNone AuthenticationStrengthRequirements = "none"
+)
+
+// BaseAuthenticationMethod describes an authentication method of a type which is not otherwise modelled.
+type BaseAuthenticationMethod struct {
+	ODataType *string `json:"@odata.type,omitempty"`
+	ID        *string `json:"id,omitempty"`
+}
+
+// BaseDirectoryObject describes a directory object of a type which is not otherwise modelled.
+type BaseDirectoryObject struct {
+	ODataType       *string    `json:"@odata.type,omitempty"`
+	ID              *string    `json:"id,omitempty"`
+	DeletedDateTime *time.Time `json:"deletedDateTime,omitempty"`
+}
+
 type BaseNamedLocation struct {
 	ODataType        *string    `json:"@odata.type,omitempty"`
 	ID               *string    `json:"id,omitempty"`
@@ -343,32 +922,150 @@ type ConditionalAccessPolicy struct {
 	ID               *string                           `json:"id,omitempty"`
 	ModifiedDateTime *time.Time                        `json:"modifiedDateTime,omitempty"`
 	SessionControls  *ConditionalAccessSessionControls `json:"sessionControls,omitempty"`
-	State            *string                           `json:"state,omitempty"`
+	State            *ConditionalAccessPolicyState     `json:"state,omitempty"`
+	TemplateId       *string                           `json:"templateId,omitempty"`
 }
 
+type ConditionalAccessPolicyState string
+
+const (
+	ConditionalAccessPolicyStateEnabled                           ConditionalAccessPolicyState = "enabled"
+	ConditionalAccessPolicyStateDisabled                          ConditionalAccessPolicyState = "disabled"
+	ConditionalAccessPolicyStateEnabledForReportingButNotEnforced ConditionalAccessPolicyState = "enabledForReportingButNotEnforced"
+)
+
 type ConditionalAccessConditionSet struct {
-	Applications     *ConditionalAccessApplications `json:"applications,omitempty"`
-	Users            *ConditionalAccessUsers        `json:"users,omitempty"`
-	ClientAppTypes   *[]string                      `json:"clientAppTypes,omitempty"`
-	Locations        *ConditionalAccessLocations    `json:"locations,omitempty"`
-	Platforms        *ConditionalAccessPlatforms    `json:"platforms,omitempty"`
-	SignInRiskLevels *[]string                      `json:"signInRiskLevels,omitempty"`
-	UserRiskLevels   *[]string                      `json:"userRiskLevels,omitempty"`
+	Applications               *ConditionalAccessApplications        `json:"applications,omitempty"`
+	AuthenticationFlows        *ConditionalAccessAuthenticationFlows `json:"authenticationFlows,omitempty"`
+	ClientApplications         *ConditionalAccessClientApplications  `json:"clientApplications,omitempty"`
+	ClientAppTypes             *[]ConditionalAccessClientAppType     `json:"clientAppTypes,omitempty"`
+	Devices                    *ConditionalAccessDevices             `json:"devices,omitempty"`
+	Locations                  *ConditionalAccessLocations           `json:"locations,omitempty"`
+	Platforms                  *ConditionalAccessPlatforms           `json:"platforms,omitempty"`
+	ServicePrincipalRiskLevels *[]ConditionalAccessRiskLevel         `json:"servicePrincipalRiskLevels,omitempty"`
+	SignInRiskLevels           *[]ConditionalAccessRiskLevel         `json:"signInRiskLevels,omitempty"`
+	UserRiskLevels             *[]ConditionalAccessRiskLevel         `json:"userRiskLevels,omitempty"`
+	Users                      *ConditionalAccessUsers               `json:"users,omitempty"`
 }
 
 type ConditionalAccessApplications struct {
-	IncludeApplications *[]string `json:"includeApplications,omitempty"`
-	ExcludeApplications *[]string `json:"excludeApplications,omitempty"`
-	IncludeUserActions  *[]string `json:"includeUserActions,omitempty"`
+	ApplicationFilter                           *ConditionalAccessFilter `json:"applicationFilter,omitempty"`
+	IncludeApplications                         *[]string                `json:"includeApplications,omitempty"`
+	ExcludeApplications                         *[]string                `json:"excludeApplications,omitempty"`
+	IncludeAuthenticationContextClassReferences *[]string                `json:"includeAuthenticationContextClassReferences,omitempty"`
+	IncludeUserActions                          *[]string                `json:"includeUserActions,omitempty"`
+}
+
+type ConditionalAccessAuthenticationFlows struct {
+	TransferMethods *ConditionalAccessTransferMethods `json:"transferMethods,omitempty"`
+}
+
+// ConditionalAccessClientApplications describes the workload identities targeted by a conditional access policy.
+type ConditionalAccessClientApplications struct {
+	IncludeServicePrincipals *[]string                `json:"includeServicePrincipals,omitempty"`
+	ExcludeServicePrincipals *[]string                `json:"excludeServicePrincipals,omitempty"`
+	ServicePrincipalFilter   *ConditionalAccessFilter `json:"servicePrincipalFilter,omitempty"`
+}
+
+type ConditionalAccessClientAppType string
+
+const (
+	ConditionalAccessClientAppTypeAll                         ConditionalAccessClientAppType = "all"
+	ConditionalAccessClientAppTypeBrowser                     ConditionalAccessClientAppType = "browser"
+	ConditionalAccessClientAppTypeEasSupported                ConditionalAccessClientAppType = "easSupported"
+	ConditionalAccessClientAppTypeExchangeActiveSync          ConditionalAccessClientAppType = "exchangeActiveSync"
+	ConditionalAccessClientAppTypeMobileAppsAndDesktopClients ConditionalAccessClientAppType = "mobileAppsAndDesktopClients"
+	ConditionalAccessClientAppTypeOther                       ConditionalAccessClientAppType = "other"
+)
+
+type ConditionalAccessDevices struct {
+	DeviceFilter *ConditionalAccessFilter `json:"deviceFilter,omitempty"`
+}
+
+// ConditionalAccessExternalTenants describes the external tenants of guest or external users targeted by a conditional
+// access policy. Members should only be specified when the ODataType is ConditionalAccessExternalTenantsEnumerated.
+type ConditionalAccessExternalTenants struct {
+	ODataType      *string                                         `json:"@odata.type,omitempty"`
+	MembershipKind *ConditionalAccessExternalTenantsMembershipKind `json:"membershipKind,omitempty"`
+	Members        *[]string                                       `json:"members,omitempty"`
+}
+
+const (
+	ConditionalAccessExternalTenantsAll        = "#microsoft.graph.conditionalAccessAllExternalTenants"
+	ConditionalAccessExternalTenantsEnumerated = "#microsoft.graph.conditionalAccessEnumeratedExternalTenants"
+)
+
+type ConditionalAccessExternalTenantsMembershipKind string
+
+const (
+	ConditionalAccessExternalTenantsMembershipKindAll        ConditionalAccessExternalTenantsMembershipKind = "all"
+	ConditionalAccessExternalTenantsMembershipKindEnumerated ConditionalAccessExternalTenantsMembershipKind = "enumerated"
+)
+
+// ConditionalAccessFilter describes a filter rule for devices, applications or service principals, e.g.
+// `device.trustType -eq "ServerAD"`.
+type ConditionalAccessFilter struct {
+	Mode *ConditionalAccessFilterMode `json:"mode,omitempty"`
+	Rule *string                      `json:"rule,omitempty"`
+}
+
+type ConditionalAccessFilterMode string
+
+const (
+	ConditionalAccessFilterModeInclude ConditionalAccessFilterMode = "include"
+	ConditionalAccessFilterModeExclude ConditionalAccessFilterMode = "exclude"
+)
+
+type ConditionalAccessGuestsOrExternalUsers struct {
+	ExternalTenants          *ConditionalAccessExternalTenants          `json:"externalTenants,omitempty"`
+	GuestOrExternalUserTypes *ConditionalAccessGuestOrExternalUserTypes `json:"guestOrExternalUserTypes,omitempty"`
 }
 
+// ConditionalAccessGuestOrExternalUserTypes is a set of guest or external user types. Multiple types are specified as a
+// comma-separated list, e.g. "internalGuest,b2bCollaborationGuest".
+type ConditionalAccessGuestOrExternalUserTypes string
+
+const (
+	ConditionalAccessGuestOrExternalUserTypesNone                   ConditionalAccessGuestOrExternalUserTypes = "none"
+	ConditionalAccessGuestOrExternalUserTypesInternalGuest          ConditionalAccessGuestOrExternalUserTypes = "internalGuest"
+	ConditionalAccessGuestOrExternalUserTypesB2bCollaborationGuest  ConditionalAccessGuestOrExternalUserTypes = "b2bCollaborationGuest"
+	ConditionalAccessGuestOrExternalUserTypesB2bCollaborationMember ConditionalAccessGuestOrExternalUserTypes = "b2bCollaborationMember"
+	ConditionalAccessGuestOrExternalUserTypesB2bDirectConnectUser   ConditionalAccessGuestOrExternalUserTypes = "b2bDirectConnectUser"
+	ConditionalAccessGuestOrExternalUserTypesOtherExternalUser      ConditionalAccessGuestOrExternalUserTypes = "otherExternalUser"
+	ConditionalAccessGuestOrExternalUserTypesServiceProvider        ConditionalAccessGuestOrExternalUserTypes = "serviceProvider"
+)
+
+type ConditionalAccessRiskLevel string
+
+const (
+	ConditionalAccessRiskLevelHigh   ConditionalAccessRiskLevel = "high"
+	ConditionalAccessRiskLevelMedium ConditionalAccessRiskLevel = "medium"
+	ConditionalAccessRiskLevelLow    ConditionalAccessRiskLevel = "low"
+	ConditionalAccessRiskLevelHidden ConditionalAccessRiskLevel = "hidden"
+	ConditionalAccessRiskLevelNone   ConditionalAccessRiskLevel = "none"
+
+	ConditionalAccessRiskLevelUnknownFutureValue ConditionalAccessRiskLevel = "unknownFutureValue"
+)
+
+// ConditionalAccessTransferMethods is a set of authentication transfer methods. Multiple methods are specified as a
+// comma-separated list, e.g. "deviceCodeFlow,authenticationTransfer".
+type ConditionalAccessTransferMethods string
+
+const (
+	ConditionalAccessTransferMethodsNone                   ConditionalAccessTransferMethods = "none"
+	ConditionalAccessTransferMethodsDeviceCodeFlow         ConditionalAccessTransferMethods = "deviceCodeFlow"
+	ConditionalAccessTransferMethodsAuthenticationTransfer ConditionalAccessTransferMethods = "authenticationTransfer"
+)
+
 type ConditionalAccessUsers struct {
-	IncludeUsers  *[]string `json:"includeUsers,omitempty"`
-	ExcludeUsers  *[]string `json:"excludeUsers,omitempty"`
-	IncludeGroups *[]string `json:"includeGroups,omitempty"`
-	ExcludeGroups *[]string `json:"excludeGroups,omitempty"`
-	IncludeRoles  *[]string `json:"includeRoles,omitempty"`
-	ExcludeRoles  *[]string `json:"excludeRoles,omitempty"`
+	IncludeUsers                 *[]string                               `json:"includeUsers,omitempty"`
+	ExcludeUsers                 *[]string                               `json:"excludeUsers,omitempty"`
+	IncludeGroups                *[]string                               `json:"includeGroups,omitempty"`
+	ExcludeGroups                *[]string                               `json:"excludeGroups,omitempty"`
+	IncludeGuestsOrExternalUsers *ConditionalAccessGuestsOrExternalUsers `json:"includeGuestsOrExternalUsers,omitempty"`
+	ExcludeGuestsOrExternalUsers *ConditionalAccessGuestsOrExternalUsers `json:"excludeGuestsOrExternalUsers,omitempty"`
+	IncludeRoles                 *[]string                               `json:"includeRoles,omitempty"`
+	ExcludeRoles                 *[]string                               `json:"excludeRoles,omitempty"`
 }
 
 type ConditionalAccessLocations struct {
@@ -382,19 +1079,47 @@ type ConditionalAccessPlatforms struct {
 }
 
 type ConditionalAccessGrantControls struct {
-	Operator                    *string   `json:"operator,omitempty"`
-	BuiltInControls             *[]string `json:"builtInControls,omitempty"`
-	CustomAuthenticationFactors *[]string `json:"customAuthenticationFactors,omitempty"`
-	TermsOfUse                  *[]string `json:"termsOfUse,omitempty"`
+	AuthenticationStrength      *AuthenticationStrengthPolicy    `json:"authenticationStrength,omitempty"`
+	Operator                    *string                          `json:"operator,omitempty"`
+	BuiltInControls             *[]ConditionalAccessGrantControl `json:"builtInControls,omitempty"`
+	CustomAuthenticationFactors *[]string                        `json:"customAuthenticationFactors,omitempty"`
+	TermsOfUse                  *[]string                        `json:"termsOfUse,omitempty"`
 }
 
+type ConditionalAccessGrantControl string
+
+const (
+	ConditionalAccessGrantControlApprovedApplication  ConditionalAccessGrantControl = "approvedApplication"
+	ConditionalAccessGrantControlBlock                ConditionalAccessGrantControl = "block"
+	ConditionalAccessGrantControlCompliantApplication ConditionalAccessGrantControl = "compliantApplication"
+	ConditionalAccessGrantControlCompliantDevice      ConditionalAccessGrantControl = "compliantDevice"
+	ConditionalAccessGrantControlDomainJoinedDevice   ConditionalAccessGrantControl = "domainJoinedDevice"
+	ConditionalAccessGrantControlMfa                  ConditionalAccessGrantControl = "mfa"
+	ConditionalAccessGrantControlPasswordChange       ConditionalAccessGrantControl = "passwordChange"
+)
+
 type ConditionalAccessSessionControls struct {
 	ApplicationEnforcedRestrictions *ApplicationEnforcedRestrictionsSessionControl `json:"applicationEnforcedRestrictions,omitempty"`
 	CloudAppSecurity                *CloudAppSecurityControl                       `json:"cloudAppSecurity,omitempty"`
+	ContinuousAccessEvaluation      *ContinuousAccessEvaluationSessionControl      `json:"continuousAccessEvaluation,omitempty"`
+	DisableResilienceDefaults       *bool                                          `json:"disableResilienceDefaults,omitempty"`
 	PersistentBrowser               *PersistentBrowserSessionControl               `json:"persistentBrowser,omitempty"`
+	SecureSignInSession             *SecureSignInSessionControl                    `json:"secureSignInSession,omitempty"`
 	SignInFrequency                 *SignInFrequencySessionControl                 `json:"signInFrequency,omitempty"`
 }
 
+type ContinuousAccessEvaluationMode string
+
+const (
+	ContinuousAccessEvaluationModeDisabled          ContinuousAccessEvaluationMode = "disabled"
+	ContinuousAccessEvaluationModeStrictEnforcement ContinuousAccessEvaluationMode = "strictEnforcement"
+	ContinuousAccessEvaluationModeStrictLocation    ContinuousAccessEvaluationMode = "strictLocation"
+)
+
+type ContinuousAccessEvaluationSessionControl struct {
+	Mode *ContinuousAccessEvaluationMode `json:"mode,omitempty"`
+}
+
 // CountryNamedLocation describes an Country Named Location object.
 type CountryNamedLocation struct {
 	*BaseNamedLocation
@@ -402,6 +1127,56 @@ type CountryNamedLocation struct {
 	IncludeUnknownCountriesAndRegions *bool     `json:"includeUnknownCountriesAndRegions,omitempty"`
 }
 
+// Device describes a Device object.
+type Device struct {
+	ID                            *string                        `json:"id,omitempty"`
+	AccountEnabled                *bool                          `json:"accountEnabled,omitempty"`
+	AlternativeSecurityIds        *[]AlternativeSecurityId       `json:"alternativeSecurityIds,omitempty"`
+	ApproximateLastSignInDateTime *time.Time                     `json:"approximateLastSignInDateTime,omitempty"`
+	ComplianceExpirationDateTime  *time.Time                     `json:"complianceExpirationDateTime,omitempty"`
+	DeletedDateTime               *time.Time                     `json:"deletedDateTime,omitempty"`
+	DeviceCategory                *string                        `json:"deviceCategory,omitempty"`
+	DeviceId                      *string                        `json:"deviceId,omitempty"`
+	DeviceMetadata                *string                        `json:"deviceMetadata,omitempty"`
+	DeviceOwnership               *string                        `json:"deviceOwnership,omitempty"`
+	DeviceVersion                 *int32                         `json:"deviceVersion,omitempty"`
+	DisplayName                   *string                        `json:"displayName,omitempty"`
+	EnrollmentProfileName         *string                        `json:"enrollmentProfileName,omitempty"`
+	ExtensionAttributes           *OnPremisesExtensionAttributes `json:"extensionAttributes,omitempty"`
+	IsCompliant                   *bool                          `json:"isCompliant,omitempty"`
+	IsManaged                     *bool                          `json:"isManaged,omitempty"`
+	Manufacturer                  *string                        `json:"manufacturer,omitempty"`
+	MdmAppId                      *string                        `json:"mdmAppId,omitempty"`
+	Model                         *string                        `json:"model,omitempty"`
+	OnPremisesLastSyncDateTime    *time.Time                     `json:"onPremisesLastSyncDateTime,omitempty"`
+	OnPremisesSyncEnabled         *bool                          `json:"onPremisesSyncEnabled,omitempty"`
+	OperatingSystem               *string                        `json:"operatingSystem,omitempty"`
+	OperatingSystemVersion        *string                        `json:"operatingSystemVersion,omitempty"`
+	PhysicalIds                   *[]string                      `json:"physicalIds,omitempty"`
+	ProfileType                   *DeviceProfileType             `json:"profileType,omitempty"`
+	RegistrationDateTime          *time.Time                     `json:"registrationDateTime,omitempty"`
+	SystemLabels                  *[]string                      `json:"systemLabels,omitempty"`
+	TrustType                     *DeviceTrustType               `json:"trustType,omitempty"`
+}
+
+type DeviceProfileType string
+
+const (
+	DeviceProfileTypeRegisteredDevice DeviceProfileType = "RegisteredDevice"
+	DeviceProfileTypeSecureVM         DeviceProfileType = "SecureVM"
+	DeviceProfileTypePrinter          DeviceProfileType = "Printer"
+	DeviceProfileTypeShared           DeviceProfileType = "Shared"
+	DeviceProfileTypeIoT              DeviceProfileType = "IoT"
+)
+
+type DeviceTrustType string
+
+const (
+	DeviceTrustTypeWorkplace DeviceTrustType = "Workplace"
+	DeviceTrustTypeAzureAd   DeviceTrustType = "AzureAd"
+	DeviceTrustTypeServerAd  DeviceTrustType = "ServerAd"
+)
+
 // DirectoryRoleTemplate describes a Directory Role Template.
 type DirectoryRoleTemplate struct {
 	ID              *string    `json:"id,omitempty"`
@@ -457,12 +1232,81 @@ type EmailAddress struct {
 	Name    *string `json:"name,omitempty"`
 }
 
+// EmailAuthenticationMethod describes an email address registered to a user for self-service password reset.
+type EmailAuthenticationMethod struct {
+	ID           *string `json:"id,omitempty"`
+	EmailAddress *string `json:"emailAddress,omitempty"`
+}
+
+// EntitlementManagementSchedule describes when an access package assignment starts, and when it expires.
+type EntitlementManagementSchedule struct {
+	Expiration    *ExpirationPattern   `json:"expiration,omitempty"`
+	Recurrence    *PatternedRecurrence `json:"recurrence,omitempty"`
+	StartDateTime *time.Time           `json:"startDateTime,omitempty"`
+}
+
+// ExcludeTarget describes a user or group which is excluded from an authentication method.
+type ExcludeTarget struct {
+	ID         *string                         `json:"id,omitempty"`
+	TargetType *AuthenticationMethodTargetType `json:"targetType,omitempty"`
+}
+
+// ExpirationPattern describes when a role assignment or eligibility expires.
+type ExpirationPattern struct {
+	Duration    *string                `json:"duration,omitempty"`
+	EndDateTime *time.Time             `json:"endDateTime,omitempty"`
+	Type        *ExpirationPatternType `json:"type,omitempty"`
+}
+
+type ExpirationPatternType string
+
+const (
+	ExpirationPatternTypeAfterDateTime ExpirationPatternType = "afterDateTime"
+	ExpirationPatternTypeAfterDuration ExpirationPatternType = "afterDuration"
+	ExpirationPatternTypeNoExpiration  ExpirationPatternType = "noExpiration"
+	ExpirationPatternTypeNotSpecified  ExpirationPatternType = "notSpecified"
+)
+
+type ExternalEmailOtpState string
+
+const (
+	ExternalEmailOtpStateDefault  ExternalEmailOtpState = "default"
+	ExternalEmailOtpStateDisabled ExternalEmailOtpState = "disabled"
+	ExternalEmailOtpStateEnabled  ExternalEmailOtpState = "enabled"
+)
+
+// Fido2AuthenticationMethod describes a FIDO2 security key registered to a user.
+type Fido2AuthenticationMethod struct {
+	ID                      *string           `json:"id,omitempty"`
+	AaGuid                  *string           `json:"aaGuid,omitempty"`
+	AttestationCertificates *[]string         `json:"attestationCertificates,omitempty"`
+	AttestationLevel        *AttestationLevel `json:"attestationLevel,omitempty"`
+	CreatedDateTime         *time.Time        `json:"createdDateTime,omitempty"`
+	DisplayName             *string           `json:"displayName,omitempty"`
+	Model                   *string           `json:"model,omitempty"`
+}
+
+// Fido2KeyRestrictions describes the models of FIDO2 security keys which users can register, identified by their
+// Authenticator Attestation GUID.
+type Fido2KeyRestrictions struct {
+	AaGuids         *[]string                       `json:"aaGuids,omitempty"`
+	EnforcementType *FidoRestrictionEnforcementType `json:"enforcementType,omitempty"`
+	IsEnforced      *bool                           `json:"isEnforced,omitempty"`
+}
+
+type FidoRestrictionEnforcementType string
+
+const (
+	FidoRestrictionEnforcementTypeAllow FidoRestrictionEnforcementType = "allow"
+	FidoRestrictionEnforcementTypeBlock FidoRestrictionEnforcementType = "block"
+)
+
 // Group describes a Group object.
 type Group struct {
 	ID                            *string                             `json:"id,omitempty"`
 	AllowExternalSenders          *string                             `json:"allowExternalSenders,omitempty"`
 	AssignedLabels                *[]GroupAssignedLabel               `json:"assignedLabels,omitempty"`
-	AssignedLicenses              *[]GroupAssignedLicense             `json:"assignLicenses,omitempty"`
+	AssignedLicenses              *[]GroupAssignedLicense             `json:"assignedLicenses,omitempty"`
 	AutoSubscribeNewMembers       *bool                               `json:"autoSubscribeNewMembers,omitempty"`
 	Classification                *string                             `json:"classification,omitempty"`
 	CreatedDateTime               *time.Time                          `json:"createdDateTime,omitempty"`
@@ -501,6 +1345,8 @@ type Group struct {
 
 	Members *[]string `json:"members@odata.bind,omitempty"`
 	Owners  *[]string `json:"owners@odata.bind,omitempty"`
+
+	Removed *odata.Removed `json:"@removed,omitempty"`
 }
 
 // AppendMember appends a new member object URI to the Members slice.
@@ -527,7 +1373,7 @@ func (g *Group) AppendOwner(endpoint environments.ApiEndpoint, apiVersion ApiVer
 
 type GroupAssignedLabel struct {
 	LabelId     *string `json:"labelId,omitempty"`
-	DisplayName *string `json:"displayNanme,omitempty"`
+	DisplayName *string `json:"displayName,omitempty"`
 }
 
 type GroupAssignedLicense struct {
@@ -552,6 +1398,12 @@ type GroupOnPremisesProvisioningError struct {
 	Value                *string   `json:"value,omitempty"`
 }
 
+// Identity describes an identity, such as the principal to which a scoped role is assigned.
+type Identity struct {
+	ID          *string `json:"id,omitempty"`
+	DisplayName *string `json:"displayName,omitempty"`
+}
+
 type ImplicitGrantSettings struct {
 	EnableAccessTokenIssuance *bool `json:"enableAccessTokenIssuance,omitempty"`
 	EnableIdTokenIssuance     *bool `json:"enableIdTokenIssuance,omitempty"`
@@ -599,7 +1451,7 @@ type IPNamedLocationIPRange struct {
 
 type KerberosSignOnSettings struct {
 	ServicePrincipalName       *string `json:"kerberosServicePrincipalName,omitempty"`
-	SignOnMappingAttributeType *string `jsonL:"kerberosSignOnMappingAttributeType,omitempty"`
+	SignOnMappingAttributeType *string `json:"kerberosSignOnMappingAttributeType,omitempty"`
 }
 
 // KeyCredential describes a key (certificate) credential for an object.
@@ -635,8 +1487,44 @@ type Me struct {
 	UserPrincipalName *string `json:"userPrincipalName"`
 }
 
+// MicrosoftAuthenticatorAuthenticationMethod describes a Microsoft Authenticator app registered to a user.
+type MicrosoftAuthenticatorAuthenticationMethod struct {
+	ID              *string    `json:"id,omitempty"`
+	CreatedDateTime *time.Time `json:"createdDateTime,omitempty"`
+	DeviceTag       *string    `json:"deviceTag,omitempty"`
+	DisplayName     *string    `json:"displayName,omitempty"`
+	PhoneAppVersion *string    `json:"phoneAppVersion,omitempty"`
+}
+
+type MicrosoftAuthenticatorAuthenticationMode string
+
+const (
+	MicrosoftAuthenticatorAuthenticationModeAny             MicrosoftAuthenticatorAuthenticationMode = "any"
+	MicrosoftAuthenticatorAuthenticationModeDeviceBasedPush MicrosoftAuthenticatorAuthenticationMode = "deviceBasedPush"
+	MicrosoftAuthenticatorAuthenticationModePush            MicrosoftAuthenticatorAuthenticationMode = "push"
+)
+
 type NamedLocation interface{}
 
+// OnPremisesExtensionAttributes describes the extension attributes of a Device or User, numbered 1 to 15.
+type OnPremisesExtensionAttributes struct {
+	ExtensionAttribute1  *string `json:"extensionAttribute1,omitempty"`
+	ExtensionAttribute2  *string `json:"extensionAttribute2,omitempty"`
+	ExtensionAttribute3  *string `json:"extensionAttribute3,omitempty"`
+	ExtensionAttribute4  *string `json:"extensionAttribute4,omitempty"`
+	ExtensionAttribute5  *string `json:"extensionAttribute5,omitempty"`
+	ExtensionAttribute6  *string `json:"extensionAttribute6,omitempty"`
+	ExtensionAttribute7  *string `json:"extensionAttribute7,omitempty"`
+	ExtensionAttribute8  *string `json:"extensionAttribute8,omitempty"`
+	ExtensionAttribute9  *string `json:"extensionAttribute9,omitempty"`
+	ExtensionAttribute10 *string `json:"extensionAttribute10,omitempty"`
+	ExtensionAttribute11 *string `json:"extensionAttribute11,omitempty"`
+	ExtensionAttribute12 *string `json:"extensionAttribute12,omitempty"`
+	ExtensionAttribute13 *string `json:"extensionAttribute13,omitempty"`
+	ExtensionAttribute14 *string `json:"extensionAttribute14,omitempty"`
+	ExtensionAttribute15 *string `json:"extensionAttribute15,omitempty"`
+}
+
 type OnPremisesPublishing struct {
 	AlternateUrl                  *string `json:"alternateUrl,omitempty"`
 	ApplicationServerTimeout      *string `json:"applicationServerTimeout,omitempty"`
@@ -683,11 +1571,35 @@ type OptionalClaims struct {
 	Saml2Token  *[]OptionalClaim `json:"saml2Token,omitempty"`
 }
 
+// OrgContact describes an Organizational Contact object.
+type OrgContact struct {
+	ID                         *string    `json:"id,omitempty"`
+	CompanyName                *string    `json:"companyName,omitempty"`
+	DeletedDateTime            *time.Time `json:"deletedDateTime,omitempty"`
+	Department                 *string    `json:"department,omitempty"`
+	DisplayName                *string    `json:"displayName,omitempty"`
+	GivenName                  *string    `json:"givenName,omitempty"`
+	JobTitle                   *string    `json:"jobTitle,omitempty"`
+	Mail                       *string    `json:"mail,omitempty"`
+	MailNickname               *string    `json:"mailNickname,omitempty"`
+	OnPremisesLastSyncDateTime *time.Time `json:"onPremisesLastSyncDateTime,omitempty"`
+	OnPremisesSyncEnabled      *bool      `json:"onPremisesSyncEnabled,omitempty"`
+	ProxyAddresses             *[]string  `json:"proxyAddresses,omitempty"`
+	Surname                    *string    `json:"surname,omitempty"`
+}
+
 type ParentalControlSettings struct {
 	CountriesBlockedForMinors *[]string `json:"countriesBlockedForMinors,omitempty"`
 	LegalAgeGroupRule         *string   `json:"legalAgeGroupRule,omitempty"`
 }
 
+// PasswordAuthenticationMethod describes the password of a user. The password itself is never returned.
+type PasswordAuthenticationMethod struct {
+	ID              *string    `json:"id,omitempty"`
+	CreatedDateTime *time.Time `json:"createdDateTime,omitempty"`
+	Password        *string    `json:"password,omitempty"`
+}
+
 // PasswordCredential describes a password credential for an object.
 type PasswordCredential struct {
 	CustomKeyIdentifier *string    `json:"customKeyIdentifier,omitempty"`
@@ -699,10 +1611,30 @@ type PasswordCredential struct {
 	StartDateTime       *time.Time `json:"startDateTime,omitempty"`
 }
 
+// PasswordResetResponse describes the result of resetting the password of a user. NewPassword is only returned when
+// the password was generated by the service.
+type PasswordResetResponse struct {
+	NewPassword *string `json:"newPassword,omitempty"`
+}
+
 type PasswordSingleSignOnSettings struct {
 	Fields *[]SingleSignOnField `json:"fields,omitempty"`
 }
 
+// PhoneAuthenticationMethod describes a phone number registered to a user for SMS or voice call authentication.
+type PhoneAuthenticationMethod struct {
+	ID             *string                          `json:"id,omitempty"`
+	PhoneNumber    *string                          `json:"phoneNumber,omitempty"`
+	PhoneType      *AuthenticationPhoneType         `json:"phoneType,omitempty"`
+	SmsSignInState *AuthenticationMethodSignInState `json:"smsSignInState,omitempty"`
+}
+
+// PatternedRecurrence describes how often an event, such as an access review, recurs.
+type PatternedRecurrence struct {
+	Pattern *RecurrencePattern `json:"pattern,omitempty"`
+	Range   *RecurrenceRange   `json:"range,omitempty"`
+}
+
 type PermissionScope struct {
 	ID                      *string             `json:"id,omitempty"`
 	AdminConsentDescription *string             `json:"adminConsentDescription,omitempty"`
@@ -734,6 +1666,51 @@ type Recipient struct {
 	EmailAddress *EmailAddress `json:"emailAddress,omitempty"`
 }
 
+// RequestSchedule describes the period for which a role assignment or eligibility is requested.
+type RequestSchedule struct {
+	Expiration    *ExpirationPattern `json:"expiration,omitempty"`
+	StartDateTime *time.Time         `json:"startDateTime,omitempty"`
+}
+
+// RecurrencePattern describes the frequency of a PatternedRecurrence.
+type RecurrencePattern struct {
+	DayOfMonth     *int32                 `json:"dayOfMonth,omitempty"`
+	DaysOfWeek     *[]string              `json:"daysOfWeek,omitempty"`
+	FirstDayOfWeek *string                `json:"firstDayOfWeek,omitempty"`
+	Index          *string                `json:"index,omitempty"`
+	Interval       *int32                 `json:"interval,omitempty"`
+	Month          *int32                 `json:"month,omitempty"`
+	Type           *RecurrencePatternType `json:"type,omitempty"`
+}
+
+type RecurrencePatternType string
+
+const (
+	RecurrencePatternTypeAbsoluteMonthly RecurrencePatternType = "absoluteMonthly"
+	RecurrencePatternTypeAbsoluteYearly  RecurrencePatternType = "absoluteYearly"
+	RecurrencePatternTypeDaily           RecurrencePatternType = "daily"
+	RecurrencePatternTypeRelativeMonthly RecurrencePatternType = "relativeMonthly"
+	RecurrencePatternTypeRelativeYearly  RecurrencePatternType = "relativeYearly"
+	RecurrencePatternTypeWeekly          RecurrencePatternType = "weekly"
+)
+
+// RecurrenceRange describes the duration of a PatternedRecurrence. Dates are specified in the format "2006-01-02".
+type RecurrenceRange struct {
+	EndDate             *string              `json:"endDate,omitempty"`
+	NumberOfOccurrences *int32               `json:"numberOfOccurrences,omitempty"`
+	RecurrenceTimeZone  *string              `json:"recurrenceTimeZone,omitempty"`
+	StartDate           *string              `json:"startDate,omitempty"`
+	Type                *RecurrenceRangeType `json:"type,omitempty"`
+}
+
+type RecurrenceRangeType string
+
+const (
+	RecurrenceRangeTypeEndDate  RecurrenceRangeType = "endDate"
+	RecurrenceRangeTypeNoEnd    RecurrenceRangeType = "noEnd"
+	RecurrenceRangeTypeNumbered RecurrenceRangeType = "numbered"
+)
+
 type RequiredResourceAccess struct {
 	ResourceAccess *[]ResourceAccess `json:"resourceAccess,omitempty"`
 	ResourceAppId  *string           `json:"resourceAppId,omitempty"`
@@ -755,6 +1732,19 @@ type SamlSingleSignOnSettings struct {
 	RelayState *string `json:"relayState,omitempty"`
 }
 
+// ScopedRoleMembership describes the assignment of a directory role to a principal, scoped to an Administrative Unit.
+type ScopedRoleMembership struct {
+	ID                   *string   `json:"id,omitempty"`
+	AdministrativeUnitId *string   `json:"administrativeUnitId,omitempty"`
+	RoleId               *string   `json:"roleId,omitempty"`
+	RoleMemberInfo       *Identity `json:"roleMemberInfo,omitempty"`
+}
+
+// SecureSignInSessionControl enables token protection, which binds sign-in sessions to the device they were issued to.
+type SecureSignInSessionControl struct {
+	IsEnabled *bool `json:"isEnabled,omitempty"`
+}
+
 // ServicePrincipal describes a Service Principal object.
 type ServicePrincipal struct {
 	ID                                  *string                       `json:"id,omitempty"`
@@ -790,6 +1780,8 @@ type ServicePrincipal struct {
 	VerifiedPublisher                   *VerifiedPublisher            `json:"verifiedPublisher,omitempty"`
 
 	Owners *[]string `json:"owners@odata.bind,omitempty"`
+
+	Removed *odata.Removed `json:"@removed,omitempty"`
 }
 
 // AppendOwner appends a new owner object URI to the Owners slice.
@@ -811,10 +1803,26 @@ const (
 	SignInAudienceAzureADandPersonalMicrosoftAccount SignInAudience = "AzureADandPersonalMicrosoftAccount"
 )
 
+type SignInFrequencyAuthenticationType string
+
+const (
+	SignInFrequencyAuthenticationTypePrimaryAndSecondaryAuthentication SignInFrequencyAuthenticationType = "primaryAndSecondaryAuthentication"
+	SignInFrequencyAuthenticationTypeSecondaryAuthentication           SignInFrequencyAuthenticationType = "secondaryAuthentication"
+)
+
+type SignInFrequencyInterval string
+
+const (
+	SignInFrequencyIntervalEveryTime SignInFrequencyInterval = "everyTime"
+	SignInFrequencyIntervalTimeBased SignInFrequencyInterval = "timeBased"
+)
+
 type SignInFrequencySessionControl struct {
-	IsEnabled *bool   `json:"isEnabled,omitempty"`
-	Type      *string `json:"type,omitempty"`
-	Value     *int32  `json:"value,omitempty"`
+	AuthenticationType *SignInFrequencyAuthenticationType `json:"authenticationType,omitempty"`
+	FrequencyInterval  *SignInFrequencyInterval           `json:"frequencyInterval,omitempty"`
+	IsEnabled          *bool                              `json:"isEnabled,omitempty"`
+	Type               *string                            `json:"type,omitempty"`
+	Value              *int32                             `json:"value,omitempty"`
 }
 
 type SingleSignOnField struct {
@@ -824,6 +1832,32 @@ type SingleSignOnField struct {
 	Type            *string `json:"type,omitempty"`
 }
 
+// SoftwareOathAuthenticationMethod describes a software OATH token registered to a user.
+type SoftwareOathAuthenticationMethod struct {
+	ID        *string `json:"id,omitempty"`
+	SecretKey *string `json:"secretKey,omitempty"`
+}
+
+// SubjectSet describes a set of users, such as the approvers of an access package assignment request or the users
+// allowed to request an access package. Set ODataType to one of the SubjectSet* constants, along with the UserId of a
+// single user, the GroupId of group members, or the ManagerLevel of the requestor's manager.
+type SubjectSet struct {
+	ODataType    *string `json:"@odata.type,omitempty"`
+	Description  *string `json:"description,omitempty"`
+	GroupId      *string `json:"groupId,omitempty"`
+	IsBackup     *bool   `json:"isBackup,omitempty"`
+	ManagerLevel *int32  `json:"managerLevel,omitempty"`
+	UserId       *string `json:"userId,omitempty"`
+}
+
+// The @odata.type of each kind of SubjectSet.
+const (
+	SubjectSetGroupMembers     = "#microsoft.graph.groupMembers"
+	SubjectSetInternalSponsors = "#microsoft.graph.internalSponsors"
+	SubjectSetRequestorManager = "#microsoft.graph.requestorManager"
+	SubjectSetSingleUser       = "#microsoft.graph.singleUser"
+)
+
 // User describes a User object.
 type User struct {
 	ID                              *string    `json:"id,omitempty"`
@@ -884,6 +1918,120 @@ type User struct {
 	UserType                        *string    `json:"userType,omitempty"`
 
 	PasswordProfile *UserPasswordProfile `json:"passwordProfile,omitempty"`
+
+	Removed *odata.Removed `json:"@removed,omitempty"`
+}
+
+// TemporaryAccessPassAuthenticationMethod describes a time-limited passcode issued to a user, which can be used to sign
+// in and register other authentication methods. The passcode is only returned when the method is created.
+type TemporaryAccessPassAuthenticationMethod struct {
+	ID                    *string    `json:"id,omitempty"`
+	CreatedDateTime       *time.Time `json:"createdDateTime,omitempty"`
+	IsUsable              *bool      `json:"isUsable,omitempty"`
+	IsUsableOnce          *bool      `json:"isUsableOnce,omitempty"`
+	LifetimeInMinutes     *int32     `json:"lifetimeInMinutes,omitempty"`
+	MethodUsabilityReason *string    `json:"methodUsabilityReason,omitempty"`
+	StartDateTime         *time.Time `json:"startDateTime,omitempty"`
+	TemporaryAccessPass   *string    `json:"temporaryAccessPass,omitempty"`
+}
+
+// TicketInfo describes a ticket in an external system, which is recorded as the reason for a request.
+type TicketInfo struct {
+	TicketNumber *string `json:"ticketNumber,omitempty"`
+	TicketSystem *string `json:"ticketSystem,omitempty"`
+}
+
+// UnifiedRoleAssignment describes a permanent assignment of a role definition to a principal, at a directory scope or an
+// application-specific scope.
+type UnifiedRoleAssignment struct {
+	ID               *string `json:"id,omitempty"`
+	AppScopeId       *string `json:"appScopeId,omitempty"`
+	Condition        *string `json:"condition,omitempty"`
+	DirectoryScopeId *string `json:"directoryScopeId,omitempty"`
+	PrincipalId      *string `json:"principalId,omitempty"`
+	RoleDefinitionId *string `json:"roleDefinitionId,omitempty"`
+}
+
+// UnifiedRoleAssignmentScheduleRequest describes a request to create, extend or remove an active, optionally
+// time-bound, assignment of a role definition to a principal.
+type UnifiedRoleAssignmentScheduleRequest struct {
+	ID                *string                           `json:"id,omitempty"`
+	Action            *UnifiedRoleScheduleRequestAction `json:"action,omitempty"`
+	AppScopeId        *string                           `json:"appScopeId,omitempty"`
+	ApprovalId        *string                           `json:"approvalId,omitempty"`
+	CompletedDateTime *time.Time                        `json:"completedDateTime,omitempty"`
+	CreatedDateTime   *time.Time                        `json:"createdDateTime,omitempty"`
+	DirectoryScopeId  *string                           `json:"directoryScopeId,omitempty"`
+	IsValidationOnly  *bool                             `json:"isValidationOnly,omitempty"`
+	Justification     *string                           `json:"justification,omitempty"`
+	PrincipalId       *string                           `json:"principalId,omitempty"`
+	RoleDefinitionId  *string                           `json:"roleDefinitionId,omitempty"`
+	ScheduleInfo      *RequestSchedule                  `json:"scheduleInfo,omitempty"`
+	Status            *string                           `json:"status,omitempty"`
+	TargetScheduleId  *string                           `json:"targetScheduleId,omitempty"`
+	TicketInfo        *TicketInfo                       `json:"ticketInfo,omitempty"`
+}
+
+// UnifiedRoleDefinition describes a built-in or custom directory role definition.
+type UnifiedRoleDefinition struct {
+	ID              *string                  `json:"id,omitempty"`
+	Description     *string                  `json:"description,omitempty"`
+	DisplayName     *string                  `json:"displayName,omitempty"`
+	IsBuiltIn       *bool                    `json:"isBuiltIn,omitempty"`
+	IsEnabled       *bool                    `json:"isEnabled,omitempty"`
+	ResourceScopes  *[]string                `json:"resourceScopes,omitempty"`
+	RolePermissions *[]UnifiedRolePermission `json:"rolePermissions,omitempty"`
+	TemplateId      *string                  `json:"templateId,omitempty"`
+	Version         *string                  `json:"version,omitempty"`
+}
+
+// UnifiedRoleEligibilityScheduleRequest describes a request to create, extend or remove an eligibility for a
+// principal to activate a role definition.
+type UnifiedRoleEligibilityScheduleRequest struct {
+	ID                *string                           `json:"id,omitempty"`
+	Action            *UnifiedRoleScheduleRequestAction `json:"action,omitempty"`
+	AppScopeId        *string                           `json:"appScopeId,omitempty"`
+	ApprovalId        *string                           `json:"approvalId,omitempty"`
+	CompletedDateTime *time.Time                        `json:"completedDateTime,omitempty"`
+	CreatedDateTime   *time.Time                        `json:"createdDateTime,omitempty"`
+	DirectoryScopeId  *string                           `json:"directoryScopeId,omitempty"`
+	IsValidationOnly  *bool                             `json:"isValidationOnly,omitempty"`
+	Justification     *string                           `json:"justification,omitempty"`
+	PrincipalId       *string                           `json:"principalId,omitempty"`
+	RoleDefinitionId  *string                           `json:"roleDefinitionId,omitempty"`
+	ScheduleInfo      *RequestSchedule                  `json:"scheduleInfo,omitempty"`
+	Status            *string                           `json:"status,omitempty"`
+	TargetScheduleId  *string                           `json:"targetScheduleId,omitempty"`
+	TicketInfo        *TicketInfo                       `json:"ticketInfo,omitempty"`
+}
+
+// UnifiedRolePermission describes the actions permitted by a role definition.
+type UnifiedRolePermission struct {
+	AllowedResourceActions  *[]string `json:"allowedResourceActions,omitempty"`
+	Condition               *string   `json:"condition,omitempty"`
+	ExcludedResourceActions *[]string `json:"excludedResourceActions,omitempty"`
+}
+
+type UnifiedRoleScheduleRequestAction string
+
+const (
+	UnifiedRoleScheduleRequestActionAdminAssign    UnifiedRoleScheduleRequestAction = "adminAssign"
+	UnifiedRoleScheduleRequestActionAdminExtend    UnifiedRoleScheduleRequestAction = "adminExtend"
+	UnifiedRoleScheduleRequestActionAdminRemove    UnifiedRoleScheduleRequestAction = "adminRemove"
+	UnifiedRoleScheduleRequestActionAdminRenew     UnifiedRoleScheduleRequestAction = "adminRenew"
+	UnifiedRoleScheduleRequestActionAdminUpdate    UnifiedRoleScheduleRequestAction = "adminUpdate"
+	UnifiedRoleScheduleRequestActionSelfActivate   UnifiedRoleScheduleRequestAction = "selfActivate"
+	UnifiedRoleScheduleRequestActionSelfDeactivate UnifiedRoleScheduleRequestAction = "selfDeactivate"
+	UnifiedRoleScheduleRequestActionSelfExtend     UnifiedRoleScheduleRequestAction = "selfExtend"
+	UnifiedRoleScheduleRequestActionSelfRenew      UnifiedRoleScheduleRequestAction = "selfRenew"
+)
+
+// UserIdentity describes the identity of a user, such as the reviewer of an access review decision.
+type UserIdentity struct {
+	ID                *string `json:"id,omitempty"`
+	DisplayName       *string `json:"displayName,omitempty"`
+	IPAddress         *string `json:"ipAddress,omitempty"`
+	UserPrincipalName *string `json:"userPrincipalName,omitempty"`
 }
 
 type UserPasswordProfile struct {
@@ -0,0 +1,261 @@
+package msgraphtest
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"time"
+)
+
+// Collections of the access reviews API.
+const (
+	accessReviewDefinitions = "identityGovernance/accessReviews/definitions"
+	accessReviewInstances   = "accessReviewInstances"
+	accessReviewDecisions   = "accessReviewInstanceDecisionItems"
+)
+
+// accessReviewScopeQuery matches the supported scope queries of an access review, which review either the members of
+// a group, optionally filtered by type, or the app role assignments of a service principal.
+var accessReviewScopeQuery = regexp.MustCompile(`^/(groups|servicePrincipals)/([^/]+)/(members|transitiveMembers|appRoleAssignedTo)(/microsoft\.graph\.\w+)?$`)
+
+// accessReviewDecisionValues are the decisions which can be recorded for an access review decision item.
+var accessReviewDecisionValues = []string{"Approve", "Deny", "DontKnow", "NotReviewed"}
+
+// validateAccessReviewDefinition checks that an access review definition has a name and a supported scope.
+func (s *Server) validateAccessReviewDefinition(props map[string]interface{}) *apiError {
+	if e := required(props, "accessReviewScheduleDefinition", "displayName"); e != nil {
+		return e
+	}
+	scope, _ := props["scope"].(map[string]interface{})
+	query, _ := scope["query"].(string)
+	m := accessReviewScopeQuery.FindStringSubmatch(query)
+	if m == nil || (m[1] == "groups") == (m[3] == "appRoleAssignedTo") {
+		return badRequest("Invalid value specified for property 'scope' of resource 'accessReviewScheduleDefinition'.")
+	}
+	if s.get(collectionByName(m[1]), m[2]) == nil {
+		return notFound(m[2])
+	}
+	return nil
+}
+
+// createAccessReviewInstance starts the first instance of the access review definition o, with a decision item for
+// each principal in the scope of the review. Recurring reviews are not started again by the fake.
+func (s *Server) createAccessReviewInstance(o *object) {
+	days := 7.0
+	if settings, ok := o.props["settings"].(map[string]interface{}); ok {
+		if d, ok := settings["instanceDurationInDays"].(float64); ok && d > 0 {
+			days = d
+		}
+	}
+	start := time.Now().UTC()
+	instance := s.insert(collectionByName(accessReviewInstances), map[string]interface{}{
+		"startDateTime":     start.Format(time.RFC3339),
+		"endDateTime":       start.Add(time.Duration(days*24) * time.Hour).Format(time.RFC3339),
+		"fallbackReviewers": o.props["fallbackReviewers"],
+		"reviewers":         o.props["reviewers"],
+		"scope":             o.props["scope"],
+		"status":            "InProgress",
+	})
+	s.relations[relationKey(o, "instances")] = append(s.relations[relationKey(o, "instances")], instance.id())
+
+	m := accessReviewScopeQuery.FindStringSubmatch(o.props["scope"].(map[string]interface{})["query"].(string))
+	resource := s.get(collectionByName(m[1]), m[2])
+	resourceType := map[string]string{"groups": "Group", "servicePrincipals": "ServicePrincipal"}[m[1]]
+
+	var principals []*object
+	switch m[3] {
+	case "members", "transitiveMembers":
+		principals = s.groupMembers(resource, m[3] == "transitiveMembers")
+	case "appRoleAssignedTo":
+		for _, a := range s.appRoleAssignments(resource, true) {
+			if p, ok := s.objects[a.props["principalId"].(string)]; ok {
+				principals = append(principals, p)
+			}
+		}
+	}
+	for _, p := range principals {
+		if m[4] != "" && p.odataType() != "#"+m[4][1:] {
+			continue
+		}
+		s.insert(collectionByName(accessReviewDecisions), map[string]interface{}{
+			"accessReviewId": instance.id(),
+			"applyResult":    "New",
+			"decision":       "NotReviewed",
+			"principal": map[string]interface{}{
+				"@odata.type": p.odataType(),
+				"id":          p.id(),
+				"displayName": p.props["displayName"],
+			},
+			"recommendation": "NoInfoAvailable",
+			"resource": map[string]interface{}{
+				"id":          resource.id(),
+				"displayName": resource.props["displayName"],
+				"type":        resourceType,
+			},
+		})
+	}
+}
+
+// groupMembers returns the members of the group o. When transitive is true, the members of nested groups are returned
+// in place of the nested groups themselves.
+func (s *Server) groupMembers(o *object, transitive bool) []*object {
+	ret := make([]*object, 0)
+	seen := make(map[string]bool)
+	queue := []*object{o}
+	for len(queue) > 0 {
+		group := queue[0]
+		queue = queue[1:]
+		for _, member := range s.lookup(s.relations[relationKey(group, "members")]) {
+			if seen[member.id()] {
+				continue
+			}
+			seen[member.id()] = true
+			if transitive && member.collection.name == "groups" {
+				queue = append(queue, member)
+				continue
+			}
+			ret = append(ret, member)
+		}
+	}
+	return ret
+}
+
+// accessReviewInstances returns the instances of the access review definition o.
+func (s *Server) accessReviewInstances(o *object) []*object {
+	ret := make([]*object, 0)
+	for _, id := range s.relations[relationKey(o, "instances")] {
+		if instance := s.get(collectionByName(accessReviewInstances), id); instance != nil {
+			ret = append(ret, instance)
+		}
+	}
+	return ret
+}
+
+// accessReviewDecisionItems returns the decision items of the access review instance o.
+func (s *Server) accessReviewDecisionItems(o *object) []*object {
+	ret := make([]*object, 0)
+	for _, d := range s.list(collectionByName(accessReviewDecisions)) {
+		if d.props["accessReviewId"] == o.id() {
+			ret = append(ret, d)
+		}
+	}
+	return ret
+}
+
+// routeAccessReviewInstances handles a request for the instances of the access review definition o, their decisions,
+// and the stop and applyDecisions actions.
+func (s *Server) routeAccessReviewInstances(r *request, o *object, segments []string) (int, interface{}, *apiError) {
+	if len(segments) == 0 {
+		if r.Method == http.MethodGet {
+			return s.page(r, s.accessReviewInstances(o), accessReviewInstances, false)
+		}
+		return 0, nil, methodNotAllowed()
+	}
+
+	var instance *object
+	for _, i := range s.accessReviewInstances(o) {
+		if i.id() == segments[0] {
+			instance = i
+		}
+	}
+	if instance == nil {
+		return 0, nil, notFound(segments[0])
+	}
+
+	switch {
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		return entity(r, instance, accessReviewInstances)
+	case len(segments) == 2 && segments[1] == "stop" && r.Method == http.MethodPost:
+		if instance.props["status"] != "InProgress" {
+			return 0, nil, badRequest("The access review instance is not in progress.")
+		}
+		instance.props["status"] = "Completed"
+		instance.props["endDateTime"] = now()
+		return http.StatusNoContent, nil, nil
+	case len(segments) == 2 && segments[1] == "applyDecisions" && r.Method == http.MethodPost:
+		return s.applyAccessReviewDecisions(instance)
+	case len(segments) == 2 && segments[1] == "decisions" && r.Method == http.MethodGet:
+		return s.page(r, s.accessReviewDecisionItems(instance), accessReviewDecisions, false)
+	case len(segments) == 3 && segments[1] == "decisions":
+		var decision *object
+		for _, d := range s.accessReviewDecisionItems(instance) {
+			if d.id() == segments[2] {
+				decision = d
+			}
+		}
+		if decision == nil {
+			return 0, nil, notFound(segments[2])
+		}
+		switch r.Method {
+		case http.MethodGet:
+			return entity(r, decision, accessReviewDecisions)
+		case http.MethodPatch:
+			return s.recordAccessReviewDecision(r, instance, decision)
+		}
+	case len(segments) == 2 && !containsFold([]string{"applyDecisions", "decisions", "stop"}, segments[1]):
+		return 0, nil, segmentNotFound(segments[1])
+	}
+	return 0, nil, methodNotAllowed()
+}
+
+// recordAccessReviewDecision handles a request to record a decision for an access review decision item. Only the
+// decision and justification can be specified.
+func (s *Server) recordAccessReviewDecision(r *request, instance, decision *object) (int, interface{}, *apiError) {
+	props, e := r.decode()
+	if e != nil {
+		return 0, nil, e
+	}
+	if instance.props["status"] != "InProgress" {
+		return 0, nil, badRequest("Decisions can only be recorded while the access review instance is in progress.")
+	}
+	for k := range props {
+		if k != "decision" && k != "justification" {
+			return 0, nil, badRequest(fmt.Sprintf("Property '%s' of resource 'accessReviewInstanceDecisionItem' cannot be updated.", k))
+		}
+	}
+	if v, ok := props["decision"].(string); !ok || !contains(accessReviewDecisionValues, v) {
+		return 0, nil, badRequest("Invalid value specified for property 'decision' of resource 'accessReviewInstanceDecisionItem'.")
+	}
+	decision.props["decision"] = props["decision"]
+	if justification, ok := props["justification"]; ok {
+		decision.props["justification"] = justification
+	}
+	decision.props["reviewedDateTime"] = now()
+	return http.StatusNoContent, nil, nil
+}
+
+// applyAccessReviewDecisions handles a request to apply the decisions of a completed access review instance, removing
+// the access of denied principals, i.e. their group membership or app role assignments.
+func (s *Server) applyAccessReviewDecisions(instance *object) (int, interface{}, *apiError) {
+	if instance.props["status"] != "Completed" {
+		return 0, nil, badRequest("Decisions can only be applied once the access review instance has completed.")
+	}
+	for _, d := range s.accessReviewDecisionItems(instance) {
+		if d.props["decision"] == "NotReviewed" {
+			continue
+		}
+		result := "AppliedSuccessfully"
+		if d.props["decision"] == "Deny" {
+			principalId := d.props["principal"].(map[string]interface{})["id"].(string)
+			resource := d.props["resource"].(map[string]interface{})
+			switch resource["type"] {
+			case "Group":
+				if group := s.get(collectionByName("groups"), resource["id"].(string)); group == nil || s.removeReference(group, "members", principalId) != nil {
+					result = "AppliedSuccessfullyButObjectNotFound"
+				}
+			case "ServicePrincipal":
+				if sp := s.get(collectionByName("servicePrincipals"), resource["id"].(string)); sp != nil {
+					for _, a := range s.appRoleAssignments(sp, true) {
+						if a.props["principalId"] == principalId {
+							s.remove(a)
+						}
+					}
+				}
+			}
+		}
+		d.props["applyResult"] = result
+		d.props["appliedDateTime"] = now()
+	}
+	instance.props["status"] = "Applied"
+	return http.StatusNoContent, nil, nil
+}
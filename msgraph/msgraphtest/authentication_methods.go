@@ -0,0 +1,255 @@
+package msgraphtest
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// authenticationMethods is the contained collection of authentication methods registered to users. Each method has its
+// own @odata.type, and is related to its user by the "authenticationMethods" relation.
+const authenticationMethods = "authenticationMethods"
+
+// passwordMethodId is the well-known ID of the password authentication method of every user.
+const passwordMethodId = "28c10230-6103-485e-b985-444c60001490"
+
+// authenticationMethodTypes maps the navigation properties of a user's authentication to the type of methods they
+// contain. The "methods" property contains methods of all types.
+var authenticationMethodTypes = map[string]string{
+	"emailMethods":                  "#microsoft.graph.emailAuthenticationMethod",
+	"fido2Methods":                  "#microsoft.graph.fido2AuthenticationMethod",
+	"methods":                       "",
+	"microsoftAuthenticatorMethods": "#microsoft.graph.microsoftAuthenticatorAuthenticationMethod",
+	"passwordMethods":               "#microsoft.graph.passwordAuthenticationMethod",
+	"phoneMethods":                  "#microsoft.graph.phoneAuthenticationMethod",
+	"softwareOathMethods":           "#microsoft.graph.softwareOathAuthenticationMethod",
+	"temporaryAccessPassMethods":    "#microsoft.graph.temporaryAccessPassAuthenticationMethod",
+}
+
+// AddAuthenticationMethod stores an authentication method of the specified @odata.type for a user without any
+// validation, and returns its ID. The user is specified by its object ID or user principal name. This is useful for
+// seeding methods which cannot be registered using the API, such as FIDO2 security keys, and panics if the user does
+// not exist or the method cannot be marshaled.
+func (s *Server) AddAuthenticationMethod(user, odataType string, v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic(fmt.Sprintf("msgraphtest: json.Marshal(): %v", err))
+	}
+	var props map[string]interface{}
+	if err := json.Unmarshal(b, &props); err != nil {
+		panic(fmt.Sprintf("msgraphtest: json.Unmarshal(): %v", err))
+	}
+	props["@odata.type"] = odataType
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	o := s.get(collectionByName("users"), user)
+	if o == nil {
+		panic(fmt.Sprintf("msgraphtest: unknown user %q", user))
+	}
+	return s.insertAuthenticationMethod(o, props).id()
+}
+
+// insertAuthenticationMethod stores an authentication method for the user o.
+func (s *Server) insertAuthenticationMethod(o *object, props map[string]interface{}) *object {
+	method := s.insert(collectionByName(authenticationMethods), props)
+	s.relations[relationKey(o, authenticationMethods)] = append(s.relations[relationKey(o, authenticationMethods)], method.id())
+	return method
+}
+
+// userAuthenticationMethods returns the authentication methods of the user o which have the specified @odata.type, or
+// all methods when odataType is empty. Every user has a password method, which is not stored since its ID is the same
+// for all users.
+func (s *Server) userAuthenticationMethods(o *object, odataType string) []*object {
+	password := &object{
+		collection: collectionByName(authenticationMethods),
+		props: map[string]interface{}{
+			"@odata.type":     authenticationMethodTypes["passwordMethods"],
+			"id":              passwordMethodId,
+			"createdDateTime": o.props["createdDateTime"],
+		},
+	}
+	methods := append([]*object{password}, s.containedObjects(o, authenticationMethods, authenticationMethods)...)
+
+	ret := make([]*object, 0)
+	for _, m := range methods {
+		if odataType == "" || m.odataType() == odataType {
+			ret = append(ret, m)
+		}
+	}
+	return ret
+}
+
+// routeAuthenticationMethods handles a request for the authentication methods of the user o.
+func (s *Server) routeAuthenticationMethods(r *request, o *object, segments []string) (int, interface{}, *apiError) {
+	if len(segments) == 0 {
+		return 0, nil, methodNotAllowed()
+	}
+	odataType, ok := authenticationMethodTypes[segments[0]]
+	if !ok {
+		return 0, nil, segmentNotFound(segments[0])
+	}
+	methods := s.userAuthenticationMethods(o, odataType)
+	context := fmt.Sprintf("users('%s')/authentication/%s", o.id(), segments[0])
+
+	if len(segments) == 1 {
+		switch r.Method {
+		case http.MethodGet:
+			return s.page(r, methods, context, segments[0] == "methods")
+		case http.MethodPost:
+			return s.createAuthenticationMethod(r, o, segments[0], methods)
+		}
+		return 0, nil, methodNotAllowed()
+	}
+
+	var method *object
+	for _, m := range methods {
+		if m.id() == segments[1] {
+			method = m
+		}
+	}
+	if method == nil {
+		return 0, nil, notFound(segments[1])
+	}
+
+	switch {
+	case len(segments) == 2 && r.Method == http.MethodGet:
+		return entity(r, method, context)
+	case len(segments) == 2 && r.Method == http.MethodPatch:
+		if segments[0] != "emailMethods" && segments[0] != "phoneMethods" {
+			return 0, nil, methodNotAllowed()
+		}
+		props, e := r.decode()
+		if e != nil {
+			return 0, nil, e
+		}
+		for k, v := range props {
+			if k == "emailAddress" || k == "phoneNumber" || k == "phoneType" {
+				method.props[k] = v
+			}
+		}
+		return http.StatusNoContent, nil, nil
+	case len(segments) == 2 && r.Method == http.MethodDelete:
+		if segments[0] == "methods" || segments[0] == "passwordMethods" {
+			return 0, nil, methodNotAllowed()
+		}
+		s.remove(method)
+		return http.StatusNoContent, nil, nil
+	case len(segments) == 3 && segments[2] == "resetPassword" && r.Method == http.MethodPost:
+		if method.id() != passwordMethodId {
+			return 0, nil, methodNotAllowed()
+		}
+		return s.resetPassword(r, o)
+	}
+	return 0, nil, methodNotAllowed()
+}
+
+// createAuthenticationMethod validates and registers an email, phone or Temporary Access Pass authentication method for
+// the user o. Other types of method must be registered by the user.
+func (s *Server) createAuthenticationMethod(r *request, o *object, property string, existing []*object) (int, interface{}, *apiError) {
+	props, e := r.decode()
+	if e != nil {
+		return 0, nil, e
+	}
+	delete(props, "id")
+	props["@odata.type"] = authenticationMethodTypes[property]
+
+	var passcode string
+	switch property {
+	case "emailMethods":
+		if e := required(props, "emailAuthenticationMethod", "emailAddress"); e != nil {
+			return 0, nil, e
+		}
+		if len(existing) > 0 {
+			return 0, nil, badRequest("An email authentication method is already registered for the user.")
+		}
+
+	case "phoneMethods":
+		if e := required(props, "phoneAuthenticationMethod", "phoneNumber", "phoneType"); e != nil {
+			return 0, nil, e
+		}
+		if !contains([]string{"alternateMobile", "mobile", "office"}, props["phoneType"].(string)) {
+			return 0, nil, badRequest("Invalid value specified for property 'phoneType' of resource 'phoneAuthenticationMethod'.")
+		}
+		for _, m := range existing {
+			if m.props["phoneType"] == props["phoneType"] {
+				return 0, nil, badRequest(fmt.Sprintf("A phone authentication method of type '%s' is already registered for the user.", props["phoneType"]))
+			}
+		}
+		props["smsSignInState"] = "notSupported"
+		if props["phoneType"] == "mobile" {
+			props["smsSignInState"] = "notAllowedByPolicy"
+		}
+
+	case "temporaryAccessPassMethods":
+		if len(existing) > 0 {
+			return 0, nil, badRequest("A Temporary Access Pass is already registered for the user.")
+		}
+		start := time.Now().UTC()
+		if v, ok := props["startDateTime"].(string); ok {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return 0, nil, badRequest("Invalid value specified for property 'startDateTime' of resource 'temporaryAccessPassAuthenticationMethod'.")
+			}
+			start = t
+		}
+		if _, ok := props["lifetimeInMinutes"].(float64); !ok {
+			props["lifetimeInMinutes"] = 60
+		}
+		if _, ok := props["isUsableOnce"].(bool); !ok {
+			props["isUsableOnce"] = false
+		}
+		props["startDateTime"] = start.Format(time.RFC3339)
+		props["isUsable"] = !start.After(time.Now())
+		props["methodUsabilityReason"] = "EnabledByPolicy"
+		if start.After(time.Now()) {
+			props["methodUsabilityReason"] = "NotYetValid"
+		}
+		passcode = newPasscode()
+
+	default:
+		return 0, nil, methodNotAllowed()
+	}
+
+	props["createdDateTime"] = now()
+	method := s.insertAuthenticationMethod(o, props)
+
+	_, ret, _ := entity(r, method, fmt.Sprintf("users('%s')/authentication/%s", o.id(), property))
+	if passcode != "" {
+		// the passcode is only returned when the Temporary Access Pass is created
+		ret.(map[string]interface{})["temporaryAccessPass"] = passcode
+	}
+	return http.StatusCreated, ret, nil
+}
+
+// resetPassword resets the password of the user o. When no new password is specified, one is generated and returned.
+func (s *Server) resetPassword(r *request, o *object) (int, interface{}, *apiError) {
+	props, e := r.decode()
+	if e != nil {
+		return 0, nil, e
+	}
+	newPassword, _ := props["newPassword"].(string)
+	generated := newPassword == ""
+	if generated {
+		newPassword = newPasscode()
+	}
+	o.props["lastPasswordChangeDateTime"] = now()
+	if !generated {
+		return http.StatusAccepted, nil, nil
+	}
+	return http.StatusAccepted, map[string]interface{}{"newPassword": newPassword}, nil
+}
+
+// newPasscode returns a random passcode, for use as a Temporary Access Pass or a generated password.
+func newPasscode() string {
+	const chars = "abcdefghjkmnpqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("msgraphtest: rand.Read(): %v", err))
+	}
+	for i := range b {
+		b[i] = chars[int(b[i])%len(chars)]
+	}
+	return string(b)
+}
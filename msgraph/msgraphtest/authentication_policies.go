@@ -0,0 +1,308 @@
+package msgraphtest
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Collections of the authentication methods policy and authentication strength policies.
+const (
+	authenticationStrengthPolicies = "policies/authenticationStrengthPolicies"
+
+	authenticationMethodConfigurations = "authenticationMethodConfigurations"
+	authenticationMethodsPolicies      = "authenticationMethodsPolicies"
+)
+
+// authenticationMethodsPolicyId is the ID of the authentication methods policy, which is a singleton.
+const authenticationMethodsPolicyId = "authenticationMethodsPolicy"
+
+// authenticationMethodModes are the authentication methods which can be combined in the allowed combinations of an
+// authentication strength policy.
+var authenticationMethodModes = []string{
+	"deviceBasedPush", "email", "federatedMultiFactor", "federatedSingleFactor", "fido2", "hardwareOath",
+	"microsoftAuthenticatorPush", "password", "sms", "softwareOath", "temporaryAccessPassMultiUse",
+	"temporaryAccessPassOneTime", "voice", "windowsHelloForBusiness", "x509CertificateMultiFactor",
+	"x509CertificateSingleFactor",
+}
+
+// builtInAuthenticationStrengthPolicies are the authentication strength policies present in every tenant.
+var builtInAuthenticationStrengthPolicies = []struct {
+	id                  string
+	displayName         string
+	description         string
+	allowedCombinations []string
+}{
+	{"00000000-0000-0000-0000-000000000002", "Multifactor authentication", "Combinations of methods that satisfy strong authentication, such as a password + SMS", []string{
+		"windowsHelloForBusiness", "fido2", "x509CertificateMultiFactor", "deviceBasedPush", "temporaryAccessPassOneTime",
+		"temporaryAccessPassMultiUse", "password,microsoftAuthenticatorPush", "password,softwareOath",
+		"password,hardwareOath", "password,sms", "password,voice", "federatedMultiFactor",
+		"microsoftAuthenticatorPush,federatedSingleFactor", "softwareOath,federatedSingleFactor",
+		"hardwareOath,federatedSingleFactor", "sms,federatedSingleFactor", "voice,federatedSingleFactor",
+	}},
+	{"00000000-0000-0000-0000-000000000003", "Passwordless MFA", "Passwordless methods that satisfy strong authentication, such as Passwordless sign-in with the Microsoft Authenticator", []string{
+		"windowsHelloForBusiness", "fido2", "x509CertificateMultiFactor", "deviceBasedPush",
+	}},
+	{"00000000-0000-0000-0000-000000000004", "Phishing-resistant MFA", "Phishing-resistant, Passwordless methods for the strongest authentication, such as a FIDO2 security key", []string{
+		"windowsHelloForBusiness", "fido2", "x509CertificateMultiFactor",
+	}},
+}
+
+// defaultAuthenticationMethodConfigurations are the authentication method configurations of a new tenant.
+var defaultAuthenticationMethodConfigurations = []struct {
+	id        string
+	odataType string
+	state     string
+}{
+	{"Email", "#microsoft.graph.emailAuthenticationMethodConfiguration", "disabled"},
+	{"Fido2", "#microsoft.graph.fido2AuthenticationMethodConfiguration", "disabled"},
+	{"MicrosoftAuthenticator", "#microsoft.graph.microsoftAuthenticatorAuthenticationMethodConfiguration", "enabled"},
+	{"Sms", "#microsoft.graph.smsAuthenticationMethodConfiguration", "disabled"},
+	{"SoftwareOath", "#microsoft.graph.softwareOathAuthenticationMethodConfiguration", "disabled"},
+	{"TemporaryAccessPass", "#microsoft.graph.temporaryAccessPassAuthenticationMethodConfiguration", "disabled"},
+	{"Voice", "#microsoft.graph.voiceAuthenticationMethodConfiguration", "disabled"},
+}
+
+// insertAuthenticationPolicies stores the authentication methods policy with its default method configurations, and
+// the built-in authentication strength policies.
+func (s *Server) insertAuthenticationPolicies() {
+	policy := s.insert(collectionByName(authenticationMethodsPolicies), map[string]interface{}{
+		"id":                   authenticationMethodsPolicyId,
+		"displayName":          "Authentication Methods Policy",
+		"description":          "The tenant-wide policy that controls which authentication methods are allowed in the tenant, authentication method registration requirements, and self-service password reset settings",
+		"lastModifiedDateTime": now(),
+		"policyMigrationState": "preMigration",
+		"policyVersion":        "1.5",
+		"reconfirmationInDays": 0,
+	})
+	for _, c := range defaultAuthenticationMethodConfigurations {
+		configuration := s.insert(collectionByName(authenticationMethodConfigurations), map[string]interface{}{
+			"@odata.type":    c.odataType,
+			"id":             c.id,
+			"state":          c.state,
+			"excludeTargets": []interface{}{},
+			"includeTargets": []interface{}{
+				map[string]interface{}{"id": "all_users", "isRegistrationRequired": false, "targetType": "group"},
+			},
+		})
+		s.relations[relationKey(policy, authenticationMethodConfigurations)] = append(s.relations[relationKey(policy, authenticationMethodConfigurations)], configuration.id())
+	}
+
+	for _, p := range builtInAuthenticationStrengthPolicies {
+		combinations := make([]interface{}, 0, len(p.allowedCombinations))
+		for _, c := range p.allowedCombinations {
+			combinations = append(combinations, c)
+		}
+		s.insert(collectionByName(authenticationStrengthPolicies), map[string]interface{}{
+			"id":                    p.id,
+			"displayName":           p.displayName,
+			"description":           p.description,
+			"allowedCombinations":   combinations,
+			"createdDateTime":       "2021-12-01T00:00:00Z",
+			"modifiedDateTime":      "2021-12-01T00:00:00Z",
+			"policyType":            "builtIn",
+			"requirementsSatisfied": "mfa",
+		})
+	}
+}
+
+// routeAuthenticationMethodsPolicy handles a request for the authentication methods policy, or for the configuration
+// of an authentication method.
+func (s *Server) routeAuthenticationMethodsPolicy(r *request, segments []string) (int, interface{}, *apiError) {
+	policy := s.get(collectionByName(authenticationMethodsPolicies), authenticationMethodsPolicyId)
+	configurations := s.containedObjects(policy, authenticationMethodConfigurations, authenticationMethodConfigurations)
+
+	if len(segments) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			_, ret, _ := entity(r, policy, "policies/authenticationMethodsPolicy")
+			items := make([]interface{}, 0, len(configurations))
+			for _, c := range configurations {
+				items = append(items, c.render(nil, true))
+			}
+			ret.(map[string]interface{})["authenticationMethodConfigurations"] = items
+			return http.StatusOK, ret, nil
+		case http.MethodPatch:
+			props, e := r.decode()
+			if e != nil {
+				return 0, nil, e
+			}
+			for _, k := range []string{"description", "displayName", "policyMigrationState", "reconfirmationInDays"} {
+				if v, ok := props[k]; ok {
+					policy.props[k] = v
+				}
+			}
+			policy.props["lastModifiedDateTime"] = now()
+			return http.StatusNoContent, nil, nil
+		}
+		return 0, nil, methodNotAllowed()
+	}
+
+	if segments[0] != authenticationMethodConfigurations {
+		return 0, nil, segmentNotFound(segments[0])
+	}
+	if len(segments) == 1 {
+		if r.Method == http.MethodGet {
+			return s.page(r, configurations, "policies/authenticationMethodsPolicy/authenticationMethodConfigurations", true)
+		}
+		return 0, nil, methodNotAllowed()
+	}
+
+	var configuration *object
+	for _, c := range configurations {
+		if strings.EqualFold(c.id(), segments[1]) {
+			configuration = c
+		}
+	}
+	if configuration == nil || len(segments) > 2 {
+		return 0, nil, notFound(segments[1])
+	}
+	switch r.Method {
+	case http.MethodGet:
+		_, ret, _ := entity(r, configuration, "authenticationMethodConfigurations")
+		ret.(map[string]interface{})["@odata.type"] = configuration.odataType()
+		return http.StatusOK, ret, nil
+	case http.MethodPatch:
+		return s.updateAuthenticationMethodConfiguration(r, configuration)
+	}
+	return 0, nil, methodNotAllowed()
+}
+
+// updateAuthenticationMethodConfiguration validates and applies an update to the configuration of an authentication
+// method. The @odata.type of the configuration must be specified, and targeted users and groups must exist.
+func (s *Server) updateAuthenticationMethodConfiguration(r *request, o *object) (int, interface{}, *apiError) {
+	props, e := r.decode()
+	if e != nil {
+		return 0, nil, e
+	}
+	if t, _ := props["@odata.type"].(string); !strings.EqualFold(t, o.odataType()) {
+		return 0, nil, badRequest("The @odata.type of an authentication method configuration must be specified, and cannot be changed.")
+	}
+	if state, ok := props["state"]; ok && state != "enabled" && state != "disabled" {
+		return 0, nil, badRequest("Invalid value specified for property 'state' of resource 'authenticationMethodConfiguration'.")
+	}
+	for _, k := range []string{"excludeTargets", "includeTargets"} {
+		targets, _ := props[k].([]interface{})
+		for _, t := range targets {
+			target, _ := t.(map[string]interface{})
+			id, _ := target["id"].(string)
+			if target["targetType"] == "group" && id == "all_users" {
+				continue
+			}
+			collection := map[interface{}]string{"group": "groups", "user": "users"}[target["targetType"]]
+			if collection == "" || s.get(collectionByName(collection), id) == nil {
+				return 0, nil, badRequest(fmt.Sprintf("Invalid value specified for property '%s' of resource 'authenticationMethodConfiguration'.", k))
+			}
+		}
+	}
+
+	for k, v := range props {
+		if k != "id" && k != "@odata.type" {
+			o.props[k] = v
+		}
+	}
+	policy := s.get(collectionByName(authenticationMethodsPolicies), authenticationMethodsPolicyId)
+	policy.props["lastModifiedDateTime"] = now()
+	return http.StatusNoContent, nil, nil
+}
+
+// validateAuthenticationStrengthPolicy checks that a custom authentication strength policy has a name and at least one
+// combination of known authentication methods, and populates the properties set by the API.
+func validateAuthenticationStrengthPolicy(props map[string]interface{}) *apiError {
+	if e := required(props, "authenticationStrengthPolicy", "displayName"); e != nil {
+		return e
+	}
+	if e := validateAllowedCombinations(props["allowedCombinations"]); e != nil {
+		return e
+	}
+	props["policyType"] = "custom"
+	props["requirementsSatisfied"] = "mfa"
+	props["createdDateTime"] = now()
+	props["modifiedDateTime"] = now()
+	return nil
+}
+
+// validateAllowedCombinations checks that v is a non-empty list of combinations of known authentication methods.
+func validateAllowedCombinations(v interface{}) *apiError {
+	combinations, _ := v.([]interface{})
+	valid := len(combinations) > 0
+	for _, c := range combinations {
+		combination, _ := c.(string)
+		for _, mode := range strings.Split(combination, ",") {
+			if !contains(authenticationMethodModes, strings.TrimSpace(mode)) {
+				valid = false
+			}
+		}
+	}
+	if !valid {
+		return badRequest("Invalid value specified for property 'allowedCombinations' of resource 'authenticationStrengthPolicy'.")
+	}
+	return nil
+}
+
+// builtInAuthenticationStrengthPolicy returns an error when o is a built-in authentication strength policy, which
+// cannot be updated or deleted.
+func builtInAuthenticationStrengthPolicy(o *object) *apiError {
+	if o.collection.name == authenticationStrengthPolicies && o.props["policyType"] == "builtIn" {
+		return badRequest("Built-in authentication strength policies cannot be modified.")
+	}
+	return nil
+}
+
+// authenticationStrengthPolicyInUse returns an error when o is an authentication strength policy which is required by
+// a conditional access policy, since it cannot be deleted.
+func (s *Server) authenticationStrengthPolicyInUse(o *object) *apiError {
+	if o.collection.name != authenticationStrengthPolicies {
+		return nil
+	}
+	for _, p := range s.list(collectionByName("identity/conditionalAccess/policies")) {
+		grantControls, _ := p.props["grantControls"].(map[string]interface{})
+		strength, _ := grantControls["authenticationStrength"].(map[string]interface{})
+		if strength["id"] == o.id() {
+			return badRequest("The authentication strength policy cannot be deleted because it is used by a conditional access policy.")
+		}
+	}
+	return nil
+}
+
+// updateAllowedCombinations replaces the allowed combinations of a custom authentication strength policy.
+func (s *Server) updateAllowedCombinations(r *request, o *object) (int, interface{}, *apiError) {
+	if e := builtInAuthenticationStrengthPolicy(o); e != nil {
+		return 0, nil, e
+	}
+	props, e := r.decode()
+	if e != nil {
+		return 0, nil, e
+	}
+	if e := validateAllowedCombinations(props["allowedCombinations"]); e != nil {
+		return 0, nil, e
+	}
+	o.props["allowedCombinations"] = props["allowedCombinations"]
+	o.props["modifiedDateTime"] = now()
+	return http.StatusOK, map[string]interface{}{
+		"@odata.context":      fmt.Sprintf("%s/$metadata#microsoft.graph.updateAllowedCombinationsResult", r.base),
+		"currentCombinations": props["allowedCombinations"],
+	}, nil
+}
+
+// validateConditionalAccessGrantControls checks that the authentication strength required by the grant controls of a
+// conditional access policy exists, and is not combined with the built-in mfa control.
+func (s *Server) validateConditionalAccessGrantControls(props map[string]interface{}) *apiError {
+	grantControls, _ := props["grantControls"].(map[string]interface{})
+	strength, ok := grantControls["authenticationStrength"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	id, _ := strength["id"].(string)
+	if s.get(collectionByName(authenticationStrengthPolicies), id) == nil {
+		return badRequest("Invalid value specified for property 'authenticationStrength' of resource 'conditionalAccessGrantControls'.")
+	}
+	builtInControls, _ := grantControls["builtInControls"].([]interface{})
+	for _, c := range builtInControls {
+		if c == "mfa" {
+			return badRequest("The built-in control 'mfa' cannot be combined with an authentication strength.")
+		}
+	}
+	grantControls["authenticationStrength"] = map[string]interface{}{"id": id}
+	return nil
+}
@@ -0,0 +1,613 @@
+package msgraphtest
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/manicminer/hamilton/odata"
+)
+
+// directoryRoleTemplates are seeded into every Server, so that directory roles can be activated.
+var directoryRoleTemplates = []struct {
+	id          string
+	displayName string
+	description string
+}{
+	{"62e90394-69f5-4237-9190-012177145e10", "Global Administrator", "Can manage all aspects of Azure AD and Microsoft services that use Azure AD identities."},
+	{"9b895d92-2cd3-44c7-9d02-a6ac2d5ea5c3", "Application Administrator", "Can create and manage all aspects of app registrations and enterprise apps."},
+	{"88d8e3e3-8f55-4a1e-953a-9b9898b8876b", "Directory Readers", "Can read basic directory information. Commonly used to grant directory read access to applications and guests."},
+	{"fe930be7-5e62-47db-91af-98c3a49a38b1", "User Administrator", "Can manage all aspects of users and groups, including resetting passwords for limited admins."},
+}
+
+// namedLocationTypes are the supported types of named location.
+var namedLocationTypes = []string{
+	"#microsoft.graph.countryNamedLocation",
+	"#microsoft.graph.ipNamedLocation",
+}
+
+// create handles a request to create an object in collection c, validating and populating properties as the real
+// API would.
+func (s *Server) create(r *request, c *collection) (int, interface{}, *apiError) {
+	props, e := r.decode()
+	if e != nil {
+		return 0, nil, e
+	}
+	binds, e := s.extractBinds(c, props)
+	if e != nil {
+		return 0, nil, e
+	}
+
+	// IDs are always generated by the API
+	delete(props, "id")
+
+	switch c.name {
+	case accessReviewDefinitions:
+		if e := s.validateAccessReviewDefinition(props); e != nil {
+			return 0, nil, e
+		}
+		props["createdDateTime"] = now()
+		props["lastModifiedDateTime"] = now()
+		props["status"] = "InProgress"
+
+	case accessPackageAssignmentPolicies:
+		if e := s.validateAccessPackageAssignmentPolicy(props); e != nil {
+			return 0, nil, e
+		}
+		props["createdDateTime"] = now()
+		props["modifiedDateTime"] = now()
+
+	case accessPackageAssignmentRequests:
+		if e := s.applyAccessPackageAssignmentRequest(props); e != nil {
+			return 0, nil, e
+		}
+
+	case accessPackageCatalogs:
+		if e := required(props, "accessPackageCatalog", "displayName"); e != nil {
+			return 0, nil, e
+		}
+		if _, ok := props["catalogType"].(string); !ok {
+			props["catalogType"] = "userManaged"
+		}
+		if _, ok := props["state"].(string); !ok {
+			props["state"] = "published"
+		}
+		props["createdDateTime"] = now()
+		props["modifiedDateTime"] = now()
+
+	case accessPackageResourceRequests:
+		if e := s.applyAccessPackageResourceRequest(props); e != nil {
+			return 0, nil, e
+		}
+
+	case accessPackages:
+		if e := s.validateAccessPackage(props); e != nil {
+			return 0, nil, e
+		}
+		props["createdDateTime"] = now()
+		props["modifiedDateTime"] = now()
+
+	case "administrativeUnits":
+		if e := required(props, "administrativeUnit", "displayName"); e != nil {
+			return 0, nil, e
+		}
+
+	case "applications":
+		if e := required(props, "application", "displayName"); e != nil {
+			return 0, nil, e
+		}
+		props["appId"] = newId()
+		props["createdDateTime"] = now()
+
+	case "devices":
+		if e := required(props, "device", "deviceId", "displayName", "operatingSystem", "operatingSystemVersion"); e != nil {
+			return 0, nil, e
+		}
+		props["registrationDateTime"] = now()
+
+	case "directoryRoles":
+		templateId, _ := props["roleTemplateId"].(string)
+		template := s.get(collectionByName("directoryRoleTemplates"), templateId)
+		if template == nil {
+			return 0, nil, badRequest("Invalid value specified for property 'roleTemplateId' of resource 'DirectoryRole'.")
+		}
+		for _, role := range s.list(c) {
+			if role.props["roleTemplateId"] == templateId {
+				return 0, nil, conflict()
+			}
+		}
+		props["displayName"] = template.props["displayName"]
+		props["description"] = template.props["description"]
+
+	case "groups":
+		if e := required(props, "group", "displayName", "mailNickname"); e != nil {
+			return 0, nil, e
+		}
+		props["createdDateTime"] = now()
+
+	case "identity/conditionalAccess/namedLocations":
+		if t, _ := props["@odata.type"].(string); !containsFold(namedLocationTypes, t) {
+			return 0, nil, badRequest("A type must be specified for a named location using @odata.type.")
+		}
+		if e := required(props, "namedLocation", "displayName"); e != nil {
+			return 0, nil, e
+		}
+		props["createdDateTime"] = now()
+		props["modifiedDateTime"] = now()
+
+	case "identity/conditionalAccess/policies":
+		if e := required(props, "conditionalAccessPolicy", "displayName"); e != nil {
+			return 0, nil, e
+		}
+		if e := s.validateConditionalAccessGrantControls(props); e != nil {
+			return 0, nil, e
+		}
+		props["createdDateTime"] = now()
+
+	case authenticationStrengthPolicies:
+		if e := validateAuthenticationStrengthPolicy(props); e != nil {
+			return 0, nil, e
+		}
+
+	case roleAssignments:
+		if e := s.validateRoleAssignment(props, "unifiedRoleAssignment"); e != nil {
+			return 0, nil, e
+		}
+		if s.findRoleAssignment(c, props) != nil {
+			return 0, nil, conflict()
+		}
+
+	case roleDefinitions:
+		if e := validateRoleDefinition(props); e != nil {
+			return 0, nil, e
+		}
+
+	case roleAssignmentScheduleRequests, roleEligibilityScheduleRequests:
+		if e := s.applyRoleScheduleRequest(c, props); e != nil {
+			return 0, nil, e
+		}
+
+	case "servicePrincipals":
+		appId, _ := props["appId"].(string)
+		var app *object
+		for _, a := range s.list(collectionByName("applications")) {
+			if a.props["appId"] == appId {
+				app = a
+			}
+		}
+		if app == nil {
+			return 0, nil, badRequest(fmt.Sprintf("The appId '%s' of the service principal does not reference a valid application object.", appId))
+		}
+		for _, sp := range s.list(c) {
+			if sp.props["appId"] == appId {
+				return 0, nil, conflict()
+			}
+		}
+		props["appDisplayName"] = app.props["displayName"]
+		if _, ok := props["displayName"]; !ok {
+			props["displayName"] = app.props["displayName"]
+		}
+		props["servicePrincipalNames"] = []interface{}{appId}
+
+	case "users":
+		if e := required(props, "user", "displayName", "userPrincipalName"); e != nil {
+			return 0, nil, e
+		}
+		for _, u := range s.list(c) {
+			if upn, ok := u.props["userPrincipalName"].(string); ok && strings.EqualFold(upn, props["userPrincipalName"].(string)) {
+				return 0, nil, conflict()
+			}
+		}
+		// passwords are never returned by the API
+		delete(props, "passwordProfile")
+		props["createdDateTime"] = now()
+	}
+
+	o := s.insert(c, props)
+	for rel, ids := range binds {
+		if e := s.addReferences(o, rel, ids); e != nil {
+			return 0, nil, e
+		}
+	}
+	if c.name == accessReviewDefinitions {
+		s.createAccessReviewInstance(o)
+	}
+
+	_, ret, _ := entity(r, o, c.name)
+	return http.StatusCreated, ret, nil
+}
+
+// update handles a request to update an object, merging the provided properties.
+func (s *Server) update(r *request, o *object) (int, interface{}, *apiError) {
+	if e := builtInRoleDefinition(o); e != nil {
+		return 0, nil, e
+	}
+	if e := builtInAuthenticationStrengthPolicy(o); e != nil {
+		return 0, nil, e
+	}
+	props, e := r.decode()
+	if e != nil {
+		return 0, nil, e
+	}
+	switch o.collection.name {
+	case authenticationStrengthPolicies:
+		if _, ok := props["allowedCombinations"]; ok {
+			return 0, nil, badRequest("The allowed combinations of an authentication strength policy must be updated using updateAllowedCombinations.")
+		}
+	case "identity/conditionalAccess/policies":
+		if e := s.validateConditionalAccessGrantControls(props); e != nil {
+			return 0, nil, e
+		}
+	}
+	binds, e := s.extractBinds(o.collection, props)
+	if e != nil {
+		return 0, nil, e
+	}
+	for rel, ids := range binds {
+		for _, id := range ids {
+			if contains(s.relations[relationKey(o, rel)], id) {
+				return 0, nil, referenceAlreadyExists(rel)
+			}
+		}
+	}
+
+	for k, v := range props {
+		if k == "id" {
+			continue
+		}
+		// extension attributes are merged with those already set, rather than replaced
+		if attrs, ok := v.(map[string]interface{}); ok && k == "extensionAttributes" {
+			if existing, ok := o.props[k].(map[string]interface{}); ok {
+				for name, value := range attrs {
+					existing[name] = value
+				}
+				continue
+			}
+		}
+		o.props[k] = v
+	}
+	switch o.collection.name {
+	case accessPackageCatalogs, accessPackages, authenticationStrengthPolicies, "identity/conditionalAccess/namedLocations":
+		o.props["modifiedDateTime"] = now()
+	}
+	for rel, ids := range binds {
+		if e := s.addReferences(o, rel, ids); e != nil {
+			return 0, nil, e
+		}
+	}
+	return http.StatusNoContent, nil, nil
+}
+
+// replace handles a request to replace an object using PUT. Properties which are set by the API are retained.
+func (s *Server) replace(r *request, o *object) (int, interface{}, *apiError) {
+	props, e := r.decode()
+	if e != nil {
+		return 0, nil, e
+	}
+	switch o.collection.name {
+	case accessReviewDefinitions:
+		if e := s.validateAccessReviewDefinition(props); e != nil {
+			return 0, nil, e
+		}
+		if scope, _ := props["scope"].(map[string]interface{}); scope["query"] != o.props["scope"].(map[string]interface{})["query"] {
+			return 0, nil, badRequest("The scope of an access review cannot be changed.")
+		}
+		for _, k := range []string{"createdDateTime", "status"} {
+			props[k] = o.props[k]
+		}
+		props["lastModifiedDateTime"] = now()
+
+	case accessPackageAssignmentPolicies:
+		if e := s.validateAccessPackageAssignmentPolicy(props); e != nil {
+			return 0, nil, e
+		}
+		props["createdDateTime"] = o.props["createdDateTime"]
+		props["modifiedDateTime"] = now()
+	}
+	props["id"] = o.id()
+	o.props = props
+	return http.StatusNoContent, nil, nil
+}
+
+// extractBinds removes any properties with the @odata.bind annotation from props, and returns the referenced object
+// IDs for each navigation property.
+func (s *Server) extractBinds(c *collection, props map[string]interface{}) (map[string][]string, *apiError) {
+	binds := make(map[string][]string)
+	for k, v := range props {
+		if !strings.HasSuffix(k, "@odata.bind") {
+			continue
+		}
+		rel := strings.TrimSuffix(k, "@odata.bind")
+		if rel != "members" && rel != "owners" || !containsFold(navigations[c.name], rel) {
+			return nil, badRequest(fmt.Sprintf("Property '%s' does not exist as a declared property or extension property.", rel))
+		}
+		refs, ok := v.([]interface{})
+		if !ok {
+			refs = []interface{}{v}
+		}
+		for _, ref := range refs {
+			id := referenceId(ref)
+			if _, ok := s.objects[id]; !ok {
+				return nil, notFound(id)
+			}
+			binds[rel] = append(binds[rel], id)
+		}
+		delete(props, k)
+	}
+	return binds, nil
+}
+
+// addReferences adds objects to a navigation property of o, such as members or owners.
+func (s *Server) addReferences(o *object, rel string, ids []string) *apiError {
+	key := relationKey(o, rel)
+	for _, id := range ids {
+		if _, ok := s.objects[id]; !ok {
+			return notFound(id)
+		}
+		if contains(s.relations[key], id) {
+			return referenceAlreadyExists(rel)
+		}
+	}
+	s.relations[key] = append(s.relations[key], ids...)
+	return nil
+}
+
+// removeReference removes an object from a navigation property of o, such as members or owners.
+func (s *Server) removeReference(o *object, rel, id string) *apiError {
+	key := relationKey(o, rel)
+	for i, v := range s.relations[key] {
+		if v == id {
+			s.relations[key] = append(s.relations[key][:i:i], s.relations[key][i+1:]...)
+			return nil
+		}
+	}
+	return &apiError{
+		status:  http.StatusBadRequest,
+		code:    "Request_BadRequest",
+		message: fmt.Sprintf("%s for the following modified properties: '%s'.", odata.ErrorRemovedObjectReferencesDoNotExist, rel),
+	}
+}
+
+// memberOf returns the groups and directory roles which o is a member of. When transitive is true, groups which o is
+// a member of via nested groups are also returned.
+func (s *Server) memberOf(o *object, transitive bool) []*object {
+	ret := make([]*object, 0)
+	seen := make(map[string]bool)
+	queue := []string{o.id()}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, container := range s.lookup(s.order) {
+			if seen[container.id()] || !contains(s.relations[relationKey(container, "members")], id) {
+				continue
+			}
+			seen[container.id()] = true
+			ret = append(ret, container)
+			if transitive && container.collection.name == "groups" {
+				queue = append(queue, container.id())
+			}
+		}
+	}
+	return ret
+}
+
+// ownedObjects returns the objects which o is an owner of.
+func (s *Server) ownedObjects(o *object) []*object {
+	ret := make([]*object, 0)
+	for _, owned := range s.lookup(s.order) {
+		if contains(s.relations[relationKey(owned, "owners")], o.id()) {
+			ret = append(ret, owned)
+		}
+	}
+	return ret
+}
+
+// addPassword handles a request to add a password credential to an application or service principal. The generated
+// secret is only included in the response.
+func (s *Server) addPassword(r *request, o *object) (int, interface{}, *apiError) {
+	props, e := r.decode()
+	if e != nil {
+		return 0, nil, e
+	}
+	credential, _ := props["passwordCredential"].(map[string]interface{})
+	if credential == nil {
+		credential = make(map[string]interface{})
+	}
+
+	secret := strings.ReplaceAll(newId(), "-", "")
+	credential["keyId"] = newId()
+	credential["secretText"] = secret
+	credential["hint"] = secret[:3]
+	if _, ok := credential["startDateTime"].(string); !ok {
+		credential["startDateTime"] = now()
+	}
+	if _, ok := credential["endDateTime"].(string); !ok {
+		credential["endDateTime"] = time.Now().UTC().AddDate(2, 0, 0).Format(time.RFC3339)
+	}
+
+	stored := make(map[string]interface{}, len(credential))
+	for k, v := range credential {
+		if k != "secretText" {
+			stored[k] = v
+		}
+	}
+	existing, _ := o.props["passwordCredentials"].([]interface{})
+	o.props["passwordCredentials"] = append(append([]interface{}{}, existing...), stored)
+
+	credential["@odata.context"] = fmt.Sprintf("%s/$metadata#microsoft.graph.passwordCredential", r.base)
+	return http.StatusOK, credential, nil
+}
+
+// removePassword handles a request to remove a password credential from an application or service principal.
+func (s *Server) removePassword(r *request, o *object) (int, interface{}, *apiError) {
+	props, e := r.decode()
+	if e != nil {
+		return 0, nil, e
+	}
+	keyId, _ := props["keyId"].(string)
+	existing, _ := o.props["passwordCredentials"].([]interface{})
+	credentials := make([]interface{}, 0, len(existing))
+	for _, c := range existing {
+		if m, ok := c.(map[string]interface{}); ok && m["keyId"] == keyId {
+			continue
+		}
+		credentials = append(credentials, c)
+	}
+	if len(credentials) == len(existing) {
+		return 0, nil, badRequest(fmt.Sprintf("No password credential found with keyId '%s'.", keyId))
+	}
+	o.props["passwordCredentials"] = credentials
+	return http.StatusNoContent, nil, nil
+}
+
+// appRoleAssignments returns the app role assignments granted to o, or when byResource is true, the assignments for
+// app roles exposed by o.
+func (s *Server) appRoleAssignments(o *object, byResource bool) []*object {
+	key := "principalId"
+	if byResource {
+		key = "resourceId"
+	}
+	ret := make([]*object, 0)
+	for _, a := range s.list(collectionByName("appRoleAssignments")) {
+		if a.props[key] == o.id() {
+			ret = append(ret, a)
+		}
+	}
+	return ret
+}
+
+// createAppRoleAssignment handles a request to grant an app role, either to o when byResource is false, or for an app
+// role exposed by o when byResource is true.
+func (s *Server) createAppRoleAssignment(r *request, o *object, byResource bool) (int, interface{}, *apiError) {
+	props, e := r.decode()
+	if e != nil {
+		return 0, nil, e
+	}
+	principalId, _ := props["principalId"].(string)
+	resourceId, _ := props["resourceId"].(string)
+	appRoleId, _ := props["appRoleId"].(string)
+	if (byResource && resourceId != o.id()) || (!byResource && principalId != o.id()) {
+		return 0, nil, badRequest("The principalId or resourceId of the app role assignment does not match the request URL.")
+	}
+
+	principal := s.objects[principalId]
+	if principal == nil {
+		return 0, nil, notFound(principalId)
+	}
+	resource := s.get(collectionByName("servicePrincipals"), resourceId)
+	if resource == nil {
+		return 0, nil, notFound(resourceId)
+	}
+	for _, a := range s.appRoleAssignments(resource, true) {
+		if a.props["principalId"] == principalId && a.props["appRoleId"] == appRoleId {
+			return 0, nil, badRequest("Permission being assigned already exists on the object")
+		}
+	}
+
+	a := s.grantAppRole(principal, resource, appRoleId)
+	_, ret, _ := entity(r, a, "appRoleAssignments")
+	return http.StatusCreated, ret, nil
+}
+
+// grantAppRole assigns the app role with the specified ID, exposed by the service principal resource, to principal.
+func (s *Server) grantAppRole(principal, resource *object, appRoleId string) *object {
+	principalType := map[string]string{
+		"groups":            "Group",
+		"servicePrincipals": "ServicePrincipal",
+		"users":             "User",
+	}[principal.collection.name]
+	return s.insert(collectionByName("appRoleAssignments"), map[string]interface{}{
+		"appRoleId":            appRoleId,
+		"createdDateTime":      now(),
+		"principalDisplayName": principal.props["displayName"],
+		"principalId":          principal.id(),
+		"principalType":        principalType,
+		"resourceDisplayName":  resource.props["displayName"],
+		"resourceId":           resource.id(),
+	})
+}
+
+// scopedRoleMembers returns the scoped role memberships of the administrative unit o.
+func (s *Server) scopedRoleMembers(o *object) []*object {
+	ret := make([]*object, 0)
+	for _, m := range s.list(collectionByName("scopedRoleMemberships")) {
+		if m.props["administrativeUnitId"] == o.id() {
+			ret = append(ret, m)
+		}
+	}
+	return ret
+}
+
+// createScopedRoleMember handles a request to assign a directory role to a principal, scoped to the administrative
+// unit o.
+func (s *Server) createScopedRoleMember(r *request, o *object) (int, interface{}, *apiError) {
+	props, e := r.decode()
+	if e != nil {
+		return 0, nil, e
+	}
+	roleId, _ := props["roleId"].(string)
+	memberInfo, _ := props["roleMemberInfo"].(map[string]interface{})
+	memberId, _ := memberInfo["id"].(string)
+
+	if s.get(collectionByName("directoryRoles"), roleId) == nil {
+		return 0, nil, badRequest("Invalid value specified for property 'roleId' of resource 'ScopedRoleMembership'.")
+	}
+	member := s.objects[memberId]
+	if member == nil {
+		return 0, nil, notFound(memberId)
+	}
+	for _, m := range s.scopedRoleMembers(o) {
+		if m.props["roleId"] == roleId && m.props["roleMemberInfo"].(map[string]interface{})["id"] == memberId {
+			return 0, nil, conflict()
+		}
+	}
+
+	m := s.insert(collectionByName("scopedRoleMemberships"), map[string]interface{}{
+		"administrativeUnitId": o.id(),
+		"roleId":               roleId,
+		"roleMemberInfo": map[string]interface{}{
+			"id":          memberId,
+			"displayName": member.props["displayName"],
+		},
+	})
+
+	_, ret, _ := entity(r, m, "scopedRoleMemberships")
+	return http.StatusCreated, ret, nil
+}
+
+func relationKey(o *object, rel string) string {
+	return o.id() + "/" + rel
+}
+
+// referenceId returns the object ID from a reference URL, such as https://graph.microsoft.com/v1.0/directoryObjects/{id}
+func referenceId(v interface{}) string {
+	s, _ := v.(string)
+	return s[strings.LastIndex(s, "/")+1:]
+}
+
+func required(props map[string]interface{}, resource string, names ...string) *apiError {
+	for _, name := range names {
+		if v, ok := props[name].(string); !ok || v == "" {
+			return badRequest(fmt.Sprintf("Invalid value specified for property '%s' of resource '%s'.", name, resource))
+		}
+	}
+	return nil
+}
+
+func conflict() *apiError {
+	return badRequest(odata.ErrorConflictingObjectPresentInDirectory + ".")
+}
+
+func referenceAlreadyExists(rel string) *apiError {
+	return badRequest(fmt.Sprintf("%s for the following modified properties: '%s'.", odata.ErrorAddedObjectReferencesAlreadyExist, rel))
+}
+
+func contains(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,387 @@
+package msgraphtest
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Collections of the entitlement management API.
+const (
+	accessPackageAssignmentPolicies = "identityGovernance/entitlementManagement/assignmentPolicies"
+	accessPackageAssignmentRequests = "identityGovernance/entitlementManagement/assignmentRequests"
+	accessPackageAssignments        = "identityGovernance/entitlementManagement/assignments"
+	accessPackageCatalogs           = "identityGovernance/entitlementManagement/catalogs"
+	accessPackageResourceRequests   = "identityGovernance/entitlementManagement/resourceRequests"
+	accessPackages                  = "identityGovernance/entitlementManagement/accessPackages"
+
+	accessPackageResourceRoles      = "accessPackageResourceRoles"
+	accessPackageResourceRoleScopes = "accessPackageResourceRoleScopes"
+	accessPackageResources          = "accessPackageResources"
+)
+
+// validateAccessPackage checks that an access package has a name and belongs to an existing catalog.
+func (s *Server) validateAccessPackage(props map[string]interface{}) *apiError {
+	if e := required(props, "accessPackage", "displayName"); e != nil {
+		return e
+	}
+	catalog, _ := props["catalog"].(map[string]interface{})
+	catalogId, _ := catalog["id"].(string)
+	if s.get(collectionByName(accessPackageCatalogs), catalogId) == nil {
+		return badRequest("Invalid value specified for property 'catalog' of resource 'accessPackage'.")
+	}
+	props["catalog"] = map[string]interface{}{"id": catalogId}
+	return nil
+}
+
+// validateAccessPackageAssignmentPolicy checks that an assignment policy has a name and belongs to an existing access
+// package.
+func (s *Server) validateAccessPackageAssignmentPolicy(props map[string]interface{}) *apiError {
+	if e := required(props, "accessPackageAssignmentPolicy", "displayName"); e != nil {
+		return e
+	}
+	accessPackage, _ := props["accessPackage"].(map[string]interface{})
+	accessPackageId, _ := accessPackage["id"].(string)
+	if s.get(collectionByName(accessPackages), accessPackageId) == nil {
+		return badRequest("Invalid value specified for property 'accessPackage' of resource 'accessPackageAssignmentPolicy'.")
+	}
+	props["accessPackage"] = map[string]interface{}{"id": accessPackageId}
+	if _, ok := props["allowedTargetScope"].(string); !ok {
+		props["allowedTargetScope"] = "notSpecified"
+	}
+	return nil
+}
+
+// accessPackageInUse returns an error when o is a catalog which contains access packages, or an access package which
+// has assignments, since these cannot be deleted.
+func (s *Server) accessPackageInUse(o *object) *apiError {
+	switch o.collection.name {
+	case accessPackageCatalogs:
+		for _, p := range s.list(collectionByName(accessPackages)) {
+			if p.props["catalog"].(map[string]interface{})["id"] == o.id() {
+				return badRequest("The catalog cannot be deleted because it contains access packages.")
+			}
+		}
+	case accessPackages:
+		for _, a := range s.list(collectionByName(accessPackageAssignments)) {
+			if a.props["accessPackageId"] == o.id() && a.props["state"] == "delivered" {
+				return badRequest("The access package cannot be deleted because it has assignments.")
+			}
+		}
+	}
+	return nil
+}
+
+// containedObjects returns the objects in the named contained collection which are related to o by rel.
+func (s *Server) containedObjects(o *object, rel, name string) []*object {
+	ret := make([]*object, 0)
+	for _, id := range s.relations[relationKey(o, rel)] {
+		if c := s.get(collectionByName(name), id); c != nil {
+			ret = append(ret, c)
+		}
+	}
+	return ret
+}
+
+// catalogResource returns the resource in the catalog o which has the specified origin ID, or nil if there is none.
+func (s *Server) catalogResource(o *object, originId string) *object {
+	for _, r := range s.containedObjects(o, "resources", accessPackageResources) {
+		if r.props["originId"] == originId {
+			return r
+		}
+	}
+	return nil
+}
+
+// applyAccessPackageResourceRequest validates a request to add a group or application to a catalog, or to remove it,
+// and applies it immediately. Adding a resource also adds its roles to the catalog: the member and owner roles of a
+// group, or the app roles of an application.
+func (s *Server) applyAccessPackageResourceRequest(props map[string]interface{}) *apiError {
+	catalogProps, _ := props["catalog"].(map[string]interface{})
+	catalogId, _ := catalogProps["id"].(string)
+	catalog := s.get(collectionByName(accessPackageCatalogs), catalogId)
+	if catalog == nil {
+		return badRequest("Invalid value specified for property 'catalog' of resource 'accessPackageResourceRequest'.")
+	}
+	resourceProps, _ := props["resource"].(map[string]interface{})
+	if e := required(resourceProps, "accessPackageResource", "originId", "originSystem"); e != nil {
+		return e
+	}
+	originId := resourceProps["originId"].(string)
+	originSystem := resourceProps["originSystem"].(string)
+	existing := s.catalogResource(catalog, originId)
+
+	switch props["requestType"] {
+	case "adminAdd":
+		if existing != nil {
+			return &apiError{status: http.StatusBadRequest, code: "ResourceAlreadyOnboarded", message: "The resource has already been added to the catalog."}
+		}
+		var origin *object
+		switch originSystem {
+		case "AadGroup":
+			origin = s.get(collectionByName("groups"), originId)
+		case "AadApplication":
+			origin = s.get(collectionByName("servicePrincipals"), originId)
+		default:
+			return badRequest("Invalid value specified for property 'originSystem' of resource 'accessPackageResource'.")
+		}
+		if origin == nil {
+			return notFound(originId)
+		}
+
+		resource := s.insert(collectionByName(accessPackageResources), map[string]interface{}{
+			"createdDateTime":  now(),
+			"description":      origin.props["description"],
+			"displayName":      origin.props["displayName"],
+			"modifiedDateTime": now(),
+			"originId":         originId,
+			"originSystem":     originSystem,
+		})
+		s.relations[relationKey(catalog, "resources")] = append(s.relations[relationKey(catalog, "resources")], resource.id())
+
+		// roles are described by their origin ID and display name
+		roles := [][2]string{{"Member_" + originId, "Member"}, {"Owner_" + originId, "Owner"}}
+		if originSystem == "AadApplication" {
+			roles = nil
+			appRoles, _ := origin.props["appRoles"].([]interface{})
+			for _, r := range appRoles {
+				if appRole, ok := r.(map[string]interface{}); ok {
+					id, _ := appRole["id"].(string)
+					displayName, _ := appRole["displayName"].(string)
+					roles = append(roles, [2]string{id, displayName})
+				}
+			}
+		}
+		for _, r := range roles {
+			role := s.insert(collectionByName(accessPackageResourceRoles), map[string]interface{}{
+				"displayName":  r[1],
+				"originId":     r[0],
+				"originSystem": originSystem,
+				"resource":     resource.render([]string{"displayName", "originId", "originSystem"}, false),
+			})
+			s.relations[relationKey(catalog, "resourceRoles")] = append(s.relations[relationKey(catalog, "resourceRoles")], role.id())
+		}
+		resourceProps["id"] = resource.id()
+
+	case "adminRemove":
+		if existing == nil {
+			return badRequest("The resource does not exist in the catalog.")
+		}
+		for _, role := range s.containedObjects(catalog, "resourceRoles", accessPackageResourceRoles) {
+			if role.props["resource"].(map[string]interface{})["id"] == existing.id() {
+				s.remove(role)
+			}
+		}
+		s.remove(existing)
+		resourceProps["id"] = existing.id()
+
+	default:
+		return badRequest("Invalid value specified for property 'requestType' of resource 'accessPackageResourceRequest'.")
+	}
+
+	props["createdDateTime"] = now()
+	props["state"] = "delivered"
+	return nil
+}
+
+// createAccessPackageResourceRoleScope handles a request to add a resource role to the access package o. The role must
+// belong to a resource in the catalog of the access package, and must be scoped to the whole resource.
+func (s *Server) createAccessPackageResourceRoleScope(r *request, o *object) (int, interface{}, *apiError) {
+	props, e := r.decode()
+	if e != nil {
+		return 0, nil, e
+	}
+	roleProps, _ := props["role"].(map[string]interface{})
+	roleResource, _ := roleProps["resource"].(map[string]interface{})
+	scopeProps, _ := props["scope"].(map[string]interface{})
+
+	catalog := s.get(collectionByName(accessPackageCatalogs), o.props["catalog"].(map[string]interface{})["id"].(string))
+	var role *object
+	if catalog != nil {
+		for _, candidate := range s.containedObjects(catalog, "resourceRoles", accessPackageResourceRoles) {
+			resource := candidate.props["resource"].(map[string]interface{})
+			if candidate.props["originId"] == roleProps["originId"] && resource["id"] == roleResource["id"] {
+				role = candidate
+			}
+		}
+	}
+	if role == nil {
+		return 0, nil, badRequest("The role does not belong to a resource in the catalog of the access package.")
+	}
+	resource := role.props["resource"].(map[string]interface{})
+	if scopeProps["originId"] != resource["originId"] {
+		return 0, nil, badRequest("Invalid value specified for property 'scope' of resource 'accessPackageResourceRoleScope'.")
+	}
+	for _, existing := range s.containedObjects(o, "resourceRoleScopes", accessPackageResourceRoleScopes) {
+		if existing.props["role"].(map[string]interface{})["id"] == role.id() {
+			return 0, nil, badRequest("The access package already contains the resource role.")
+		}
+	}
+
+	roleScope := s.insert(collectionByName(accessPackageResourceRoleScopes), map[string]interface{}{
+		"createdDateTime": now(),
+		"role":            role.render(nil, false),
+		"scope": map[string]interface{}{
+			"id":           newId(),
+			"displayName":  resource["displayName"],
+			"isRootScope":  true,
+			"originId":     resource["originId"],
+			"originSystem": resource["originSystem"],
+		},
+	})
+	s.relations[relationKey(o, "resourceRoleScopes")] = append(s.relations[relationKey(o, "resourceRoleScopes")], roleScope.id())
+
+	_, ret, _ := entity(r, roleScope, accessPackageResourceRoleScopes)
+	return http.StatusCreated, ret, nil
+}
+
+// applyAccessPackageAssignmentRequest validates a request to assign an access package to a subject or to remove an
+// assignment, and applies it immediately by granting or revoking the resource roles of the access package. Requests
+// scheduled to start in the future are not delivered by the fake, but can be canceled.
+func (s *Server) applyAccessPackageAssignmentRequest(props map[string]interface{}) *apiError {
+	assignmentProps, _ := props["assignment"].(map[string]interface{})
+	props["createdDateTime"] = now()
+
+	switch props["requestType"] {
+	case "adminAdd":
+		if e := required(assignmentProps, "accessPackageAssignment", "accessPackageId", "assignmentPolicyId", "targetId"); e != nil {
+			return e
+		}
+		accessPackage := s.get(collectionByName(accessPackages), assignmentProps["accessPackageId"].(string))
+		if accessPackage == nil {
+			return notFound(assignmentProps["accessPackageId"].(string))
+		}
+		policy := s.get(collectionByName(accessPackageAssignmentPolicies), assignmentProps["assignmentPolicyId"].(string))
+		if policy == nil || policy.props["accessPackage"].(map[string]interface{})["id"] != accessPackage.id() {
+			return badRequest("Invalid value specified for property 'assignmentPolicyId' of resource 'accessPackageAssignment'.")
+		}
+		target, ok := s.objects[assignmentProps["targetId"].(string)]
+		if !ok {
+			return notFound(assignmentProps["targetId"].(string))
+		}
+		for _, a := range s.list(collectionByName(accessPackageAssignments)) {
+			if a.props["accessPackageId"] == accessPackage.id() && a.props["targetId"] == target.id() && a.props["state"] == "delivered" {
+				return badRequest("The target already has an assignment for the access package.")
+			}
+		}
+		props["accessPackage"] = map[string]interface{}{"id": accessPackage.id()}
+
+		schedule, _ := props["schedule"].(map[string]interface{})
+		if start, ok := schedule["startDateTime"].(string); ok {
+			if t, err := time.Parse(time.RFC3339, start); err == nil && t.After(time.Now()) {
+				props["state"] = "scheduled"
+				props["status"] = "Scheduled"
+				return nil
+			}
+		}
+		if schedule == nil {
+			schedule = map[string]interface{}{"startDateTime": now()}
+		}
+
+		assignment := s.insert(collectionByName(accessPackageAssignments), map[string]interface{}{
+			"accessPackageId":    accessPackage.id(),
+			"assignmentPolicyId": policy.id(),
+			"schedule":           schedule,
+			"state":              "delivered",
+			"status":             "Delivered",
+			"target": map[string]interface{}{
+				"id":            newId(),
+				"displayName":   target.props["displayName"],
+				"objectId":      target.id(),
+				"principalName": target.props["userPrincipalName"],
+			},
+			"targetId": target.id(),
+		})
+		s.grantAccessPackageRoles(accessPackage, target, true)
+		props["assignment"] = assignment.render([]string{"accessPackageId", "assignmentPolicyId", "targetId"}, false)
+
+	case "adminRemove":
+		assignmentId, _ := assignmentProps["id"].(string)
+		assignment := s.get(collectionByName(accessPackageAssignments), assignmentId)
+		if assignment == nil || assignment.props["state"] != "delivered" {
+			return notFound(assignmentId)
+		}
+		accessPackage := s.get(collectionByName(accessPackages), assignment.props["accessPackageId"].(string))
+		if target, ok := s.objects[assignment.props["targetId"].(string)]; ok && accessPackage != nil {
+			s.grantAccessPackageRoles(accessPackage, target, false)
+		}
+		assignment.props["expiredDateTime"] = now()
+		assignment.props["state"] = "expired"
+		assignment.props["status"] = "Expired"
+		props["accessPackage"] = map[string]interface{}{"id": assignment.props["accessPackageId"]}
+
+	case "adminUpdate", "userAdd", "userRemove", "userUpdate":
+		return &apiError{
+			status:  http.StatusNotImplemented,
+			code:    "NotImplemented",
+			message: fmt.Sprintf("Requests of type '%s' are not supported by msgraphtest.", props["requestType"]),
+		}
+
+	default:
+		return badRequest("Invalid value specified for property 'requestType' of resource 'accessPackageAssignmentRequest'.")
+	}
+
+	props["completedDateTime"] = now()
+	props["state"] = "delivered"
+	props["status"] = "Delivered"
+	return nil
+}
+
+// grantAccessPackageRoles grants the resource roles of the access package o to target when grant is true, or revokes
+// them when grant is false. Group roles are granted as membership or ownership of the group, and application roles as
+// app role assignments.
+func (s *Server) grantAccessPackageRoles(o, target *object, grant bool) {
+	for _, roleScope := range s.containedObjects(o, "resourceRoleScopes", accessPackageResourceRoleScopes) {
+		role := roleScope.props["role"].(map[string]interface{})
+		roleOriginId := role["originId"].(string)
+		resourceOriginId := role["resource"].(map[string]interface{})["originId"].(string)
+
+		switch role["originSystem"] {
+		case "AadGroup":
+			group := s.get(collectionByName("groups"), resourceOriginId)
+			if group == nil {
+				continue
+			}
+			rel := "members"
+			if strings.HasPrefix(roleOriginId, "Owner_") {
+				rel = "owners"
+			}
+			if grant {
+				_ = s.addReferences(group, rel, []string{target.id()})
+			} else {
+				_ = s.removeReference(group, rel, target.id())
+			}
+
+		case "AadApplication":
+			resource := s.get(collectionByName("servicePrincipals"), resourceOriginId)
+			if resource == nil {
+				continue
+			}
+			var existing *object
+			for _, a := range s.appRoleAssignments(resource, true) {
+				if a.props["principalId"] == target.id() && a.props["appRoleId"] == roleOriginId {
+					existing = a
+				}
+			}
+			switch {
+			case grant && existing == nil:
+				s.grantAppRole(target, resource, roleOriginId)
+			case !grant && existing != nil:
+				s.remove(existing)
+			}
+		}
+	}
+}
+
+// cancelAccessPackageAssignmentRequest handles a request to cancel an access package assignment request, which is only
+// possible before the request has been delivered.
+func (s *Server) cancelAccessPackageAssignmentRequest(o *object) (int, interface{}, *apiError) {
+	switch o.props["state"] {
+	case "pendingApproval", "scheduled", "submitted":
+		o.props["state"] = "canceled"
+		o.props["status"] = "Canceled"
+		return http.StatusOK, nil, nil
+	}
+	return 0, nil, badRequest("Only requests which have not been delivered can be canceled.")
+}
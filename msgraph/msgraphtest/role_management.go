@@ -0,0 +1,164 @@
+package msgraphtest
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Collections of the unified role management API.
+const (
+	roleAssignments                 = "roleManagement/directory/roleAssignments"
+	roleAssignmentScheduleRequests  = "roleManagement/directory/roleAssignmentScheduleRequests"
+	roleDefinitions                 = "roleManagement/directory/roleDefinitions"
+	roleEligibilitySchedules        = "roleManagement/directory/roleEligibilitySchedules"
+	roleEligibilityScheduleRequests = "roleManagement/directory/roleEligibilityScheduleRequests"
+)
+
+// validateRoleDefinition checks that a custom role definition has a name and grants at least one permission, and
+// populates the properties set by the API.
+func validateRoleDefinition(props map[string]interface{}) *apiError {
+	if e := required(props, "unifiedRoleDefinition", "displayName"); e != nil {
+		return e
+	}
+	if _, ok := props["isEnabled"].(bool); !ok {
+		return badRequest("Invalid value specified for property 'isEnabled' of resource 'unifiedRoleDefinition'.")
+	}
+	permissions, _ := props["rolePermissions"].([]interface{})
+	valid := len(permissions) > 0
+	for _, p := range permissions {
+		permission, _ := p.(map[string]interface{})
+		if actions, _ := permission["allowedResourceActions"].([]interface{}); len(actions) == 0 {
+			valid = false
+		}
+	}
+	if !valid {
+		return badRequest("Invalid value specified for property 'rolePermissions' of resource 'unifiedRoleDefinition'.")
+	}
+	props["id"] = newId()
+	props["templateId"] = props["id"]
+	props["isBuiltIn"] = false
+	return nil
+}
+
+// builtInRoleDefinition returns an error when o is a built-in role definition, which cannot be updated or deleted.
+func builtInRoleDefinition(o *object) *apiError {
+	if o.collection.name == roleDefinitions && o.props["isBuiltIn"] == true {
+		return badRequest("Built-in role definitions cannot be modified.")
+	}
+	return nil
+}
+
+// validateRoleAssignment checks that the role definition and principal of a role assignment, role eligibility or
+// schedule request exist, and that it has a valid scope. The directory scope is either "/" for the whole tenant, or
+// the path of an object such as "/administrativeUnits/{id}" or "/{id}". Alternatively an application-specific scope
+// may be specified using the appScopeId.
+func (s *Server) validateRoleAssignment(props map[string]interface{}, resource string) *apiError {
+	if e := required(props, resource, "roleDefinitionId", "principalId"); e != nil {
+		return e
+	}
+	roleDefinitionId := props["roleDefinitionId"].(string)
+	if s.get(collectionByName(roleDefinitions), roleDefinitionId) == nil {
+		return notFound(roleDefinitionId)
+	}
+	principalId := props["principalId"].(string)
+	if _, ok := s.objects[principalId]; !ok {
+		return notFound(principalId)
+	}
+
+	directoryScope, _ := props["directoryScopeId"].(string)
+	appScope, _ := props["appScopeId"].(string)
+	switch {
+	case directoryScope != "" && appScope != "", directoryScope == "" && appScope == "":
+		return badRequest(fmt.Sprintf("Exactly one of the properties 'directoryScopeId' or 'appScopeId' must be specified for resource '%s'.", resource))
+	case appScope != "":
+		if !strings.HasPrefix(appScope, "/") {
+			return badRequest(fmt.Sprintf("Invalid value specified for property 'appScopeId' of resource '%s'.", resource))
+		}
+	case directoryScope != "/":
+		id := strings.TrimPrefix(strings.TrimPrefix(directoryScope, "/administrativeUnits"), "/")
+		if _, ok := s.objects[id]; !ok || !strings.HasPrefix(directoryScope, "/") {
+			return badRequest(fmt.Sprintf("Invalid value specified for property 'directoryScopeId' of resource '%s'.", resource))
+		}
+	}
+	return nil
+}
+
+// findRoleAssignment returns the object in collection c which assigns the same role definition to the same principal
+// at the same scope as props, or nil if there is no such object.
+func (s *Server) findRoleAssignment(c *collection, props map[string]interface{}) *object {
+	for _, o := range s.list(c) {
+		if o.props["roleDefinitionId"] == props["roleDefinitionId"] && o.props["principalId"] == props["principalId"] && o.props["directoryScopeId"] == props["directoryScopeId"] && o.props["appScopeId"] == props["appScopeId"] {
+			return o
+		}
+	}
+	return nil
+}
+
+// applyRoleScheduleRequest validates a role assignment or eligibility schedule request, and applies it by creating or
+// removing the corresponding role assignment or eligibility schedule. Requests take effect immediately, regardless of
+// their scheduled start time.
+func (s *Server) applyRoleScheduleRequest(c *collection, props map[string]interface{}) *apiError {
+	resource := "unifiedRoleAssignmentScheduleRequest"
+	target := collectionByName(roleAssignments)
+	if c.name == roleEligibilityScheduleRequests {
+		resource = "unifiedRoleEligibilityScheduleRequest"
+		target = collectionByName(roleEligibilitySchedules)
+	}
+	if e := s.validateRoleAssignment(props, resource); e != nil {
+		return e
+	}
+	existing := s.findRoleAssignment(target, props)
+
+	action, _ := props["action"].(string)
+	switch action {
+	case "adminAssign", "selfActivate":
+		if existing != nil {
+			return &apiError{status: http.StatusBadRequest, code: "RoleAssignmentExists", message: "The Role assignment already exists."}
+		}
+		if action == "selfActivate" && s.findRoleAssignment(collectionByName(roleEligibilitySchedules), props) == nil {
+			return &apiError{status: http.StatusBadRequest, code: "RoleAssignmentRequestPolicyValidationFailed", message: "The principal is not eligible to activate the role."}
+		}
+	case "adminExtend", "adminRemove", "adminRenew", "adminUpdate", "selfDeactivate", "selfExtend", "selfRenew":
+		if existing == nil {
+			return &apiError{status: http.StatusBadRequest, code: "RoleAssignmentDoesNotExist", message: "The Role assignment does not exist."}
+		}
+	default:
+		return badRequest(fmt.Sprintf("Invalid value specified for property 'action' of resource '%s'.", resource))
+	}
+
+	props["createdDateTime"] = now()
+	if validationOnly, _ := props["isValidationOnly"].(bool); validationOnly {
+		props["status"] = "Granted"
+		return nil
+	}
+
+	switch action {
+	case "adminAssign", "selfActivate":
+		assignment := map[string]interface{}{
+			"principalId":      props["principalId"],
+			"roleDefinitionId": props["roleDefinitionId"],
+		}
+		for _, scope := range []string{"appScopeId", "directoryScopeId"} {
+			if v, ok := props[scope]; ok {
+				assignment[scope] = v
+			}
+		}
+		existing = s.insert(target, assignment)
+	case "adminRemove", "selfDeactivate":
+		s.remove(existing)
+	}
+	props["completedDateTime"] = now()
+	props["status"] = "Provisioned"
+	props["targetScheduleId"] = existing.id()
+	return nil
+}
+
+// cancelRoleScheduleRequest handles a request to cancel a role assignment or eligibility schedule request.
+func (s *Server) cancelRoleScheduleRequest(o *object) (int, interface{}, *apiError) {
+	if o.props["status"] == "Canceled" {
+		return 0, nil, badRequest("The request has already been canceled.")
+	}
+	o.props["status"] = "Canceled"
+	return http.StatusNoContent, nil, nil
+}
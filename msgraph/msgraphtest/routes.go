@@ -0,0 +1,326 @@
+package msgraphtest
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// navigations are the navigation properties and actions supported for objects in each collection.
+var navigations = map[string][]string{
+	accessPackageAssignmentRequests: {"cancel"},
+	accessPackageCatalogs:           {"resourceRoles", "resources"},
+	accessPackages:                  {"resourceRoleScopes"},
+	accessReviewDefinitions:         {"instances"},
+	authenticationStrengthPolicies:  {"updateAllowedCombinations"},
+	"administrativeUnits":           {"members", "scopedRoleMembers"},
+	"applications":                  {"addPassword", "owners", "removePassword"},
+	"devices":                       {"memberOf", "registeredOwners", "registeredUsers", "transitiveMemberOf"},
+	"directoryRoles":                {"members"},
+	"groups":                        {"appRoleAssignments", "memberOf", "members", "owners", "transitiveMemberOf"},
+	roleAssignmentScheduleRequests:  {"cancel"},
+	roleEligibilityScheduleRequests: {"cancel"},
+	"servicePrincipals":             {"addPassword", "appRoleAssignedTo", "appRoleAssignments", "memberOf", "ownedObjects", "owners", "removePassword", "transitiveMemberOf"},
+	"users":                         {"appRoleAssignments", "authentication", "memberOf", "ownedObjects", "sendMail", "transitiveMemberOf"},
+}
+
+// route handles a request for the path described by segments, which are relative to the API version and tenant ID.
+func (s *Server) route(r *request, segments []string) (int, interface{}, *apiError) {
+	if len(segments) == 0 || segments[0] == "" {
+		return 0, nil, segmentNotFound("")
+	}
+	if segments[0] == "directory" {
+		return s.routeDirectory(r, segments[1:])
+	}
+	if len(segments) >= 2 && segments[0] == "policies" && segments[1] == authenticationMethodsPolicyId {
+		return s.routeAuthenticationMethodsPolicy(r, segments[2:])
+	}
+	for _, c := range collections {
+		if c.contained {
+			continue
+		}
+		parts := strings.Split(c.name, "/")
+		if len(segments) >= len(parts) && strings.Join(segments[:len(parts)], "/") == c.name {
+			return s.routeCollection(r, c, segments[len(parts):])
+		}
+	}
+	return 0, nil, segmentNotFound(segments[len(segments)-1])
+}
+
+// routeCollection handles a request for a collection, an object in the collection, or a navigation property of the
+// object.
+func (s *Server) routeCollection(r *request, c *collection, segments []string) (int, interface{}, *apiError) {
+	if len(segments) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			return s.page(r, s.list(c), c.name, false)
+		case http.MethodPost:
+			if c.readOnly {
+				return 0, nil, methodNotAllowed()
+			}
+			return s.create(r, c)
+		}
+		return 0, nil, methodNotAllowed()
+	}
+
+	if segments[0] == "delta" {
+		return 0, nil, &apiError{
+			status:  http.StatusNotImplemented,
+			code:    "NotImplemented",
+			message: "Delta queries are not supported by msgraphtest.",
+		}
+	}
+
+	o := s.get(c, segments[0])
+	if o == nil {
+		return 0, nil, notFound(segments[0])
+	}
+
+	if len(segments) == 1 {
+		switch r.Method {
+		case http.MethodGet:
+			return entity(r, o, c.name)
+		case http.MethodPatch:
+			if c.readOnly || c.immutable || c.replaceable {
+				return 0, nil, methodNotAllowed()
+			}
+			return s.update(r, o)
+		case http.MethodPut:
+			if !c.replaceable {
+				return 0, nil, methodNotAllowed()
+			}
+			return s.replace(r, o)
+		case http.MethodDelete:
+			if c.readOnly || c.immutable {
+				return 0, nil, methodNotAllowed()
+			}
+			if e := builtInRoleDefinition(o); e != nil {
+				return 0, nil, e
+			}
+			if e := builtInAuthenticationStrengthPolicy(o); e != nil {
+				return 0, nil, e
+			}
+			if e := s.accessPackageInUse(o); e != nil {
+				return 0, nil, e
+			}
+			if e := s.authenticationStrengthPolicyInUse(o); e != nil {
+				return 0, nil, e
+			}
+			s.remove(o)
+			return http.StatusNoContent, nil, nil
+		}
+		return 0, nil, methodNotAllowed()
+	}
+
+	return s.routeNavigation(r, o, segments[1:])
+}
+
+// routeNavigation handles a request for a navigation property or action of an object.
+func (s *Server) routeNavigation(r *request, o *object, segments []string) (int, interface{}, *apiError) {
+	if !containsFold(navigations[o.collection.name], segments[0]) {
+		return 0, nil, segmentNotFound(segments[0])
+	}
+
+	switch segments[0] {
+	case "members", "owners", "registeredOwners", "registeredUsers":
+		rel := segments[0]
+		switch {
+		case len(segments) == 1 && r.Method == http.MethodGet:
+			return s.page(r, s.lookup(s.relations[relationKey(o, rel)]), "directoryObjects", true)
+		case len(segments) == 2 && segments[1] == "$ref" && r.Method == http.MethodPost:
+			props, e := r.decode()
+			if e != nil {
+				return 0, nil, e
+			}
+			if e := s.addReferences(o, rel, []string{referenceId(props["@odata.id"])}); e != nil {
+				return 0, nil, e
+			}
+			return http.StatusNoContent, nil, nil
+		case len(segments) == 3 && segments[2] == "$ref" && r.Method == http.MethodGet:
+			ref, ok := s.objects[segments[1]]
+			if !ok || !contains(s.relations[relationKey(o, rel)], ref.id()) {
+				return 0, nil, notFound(segments[1])
+			}
+			ret := ref.render([]string{"id"}, true)
+			ret["@odata.context"] = fmt.Sprintf("%s/$metadata#directoryObjects/$entity", r.base)
+			ret["url"] = fmt.Sprintf("%s/directoryObjects/%s", r.base, ref.id())
+			return http.StatusOK, ret, nil
+		case len(segments) == 3 && segments[2] == "$ref" && r.Method == http.MethodDelete:
+			if e := s.removeReference(o, rel, segments[1]); e != nil {
+				return 0, nil, e
+			}
+			return http.StatusNoContent, nil, nil
+		}
+
+	case "memberOf", "transitiveMemberOf":
+		if len(segments) == 1 && r.Method == http.MethodGet {
+			return s.page(r, s.memberOf(o, segments[0] == "transitiveMemberOf"), "directoryObjects", true)
+		}
+
+	case "ownedObjects":
+		if len(segments) == 1 && r.Method == http.MethodGet {
+			return s.page(r, s.ownedObjects(o), "directoryObjects", true)
+		}
+
+	case "addPassword":
+		if len(segments) == 1 && r.Method == http.MethodPost {
+			return s.addPassword(r, o)
+		}
+
+	case "removePassword":
+		if len(segments) == 1 && r.Method == http.MethodPost {
+			return s.removePassword(r, o)
+		}
+
+	case "appRoleAssignments", "appRoleAssignedTo":
+		byResource := segments[0] == "appRoleAssignedTo"
+		switch {
+		case len(segments) == 1 && r.Method == http.MethodGet:
+			return s.page(r, s.appRoleAssignments(o, byResource), "appRoleAssignments", false)
+		case len(segments) == 1 && r.Method == http.MethodPost:
+			return s.createAppRoleAssignment(r, o, byResource)
+		case len(segments) == 2:
+			var assignment *object
+			for _, a := range s.appRoleAssignments(o, byResource) {
+				if a.id() == segments[1] {
+					assignment = a
+				}
+			}
+			if assignment == nil {
+				return 0, nil, notFound(segments[1])
+			}
+			switch r.Method {
+			case http.MethodGet:
+				return entity(r, assignment, "appRoleAssignments")
+			case http.MethodDelete:
+				s.remove(assignment)
+				return http.StatusNoContent, nil, nil
+			}
+		}
+
+	case "scopedRoleMembers":
+		switch {
+		case len(segments) == 1 && r.Method == http.MethodGet:
+			return s.page(r, s.scopedRoleMembers(o), "scopedRoleMemberships", false)
+		case len(segments) == 1 && r.Method == http.MethodPost:
+			return s.createScopedRoleMember(r, o)
+		case len(segments) == 2:
+			var membership *object
+			for _, m := range s.scopedRoleMembers(o) {
+				if m.id() == segments[1] {
+					membership = m
+				}
+			}
+			if membership == nil {
+				return 0, nil, notFound(segments[1])
+			}
+			switch r.Method {
+			case http.MethodGet:
+				return entity(r, membership, "scopedRoleMemberships")
+			case http.MethodDelete:
+				s.remove(membership)
+				return http.StatusNoContent, nil, nil
+			}
+		}
+
+	case "authentication":
+		return s.routeAuthenticationMethods(r, o, segments[1:])
+
+	case "instances":
+		return s.routeAccessReviewInstances(r, o, segments[1:])
+
+	case "resources", "resourceRoles":
+		if len(segments) == 1 && r.Method == http.MethodGet {
+			name := map[string]string{"resources": accessPackageResources, "resourceRoles": accessPackageResourceRoles}[segments[0]]
+			return s.page(r, s.containedObjects(o, segments[0], name), name, false)
+		}
+
+	case "resourceRoleScopes":
+		switch {
+		case len(segments) == 1 && r.Method == http.MethodGet:
+			return s.page(r, s.containedObjects(o, "resourceRoleScopes", accessPackageResourceRoleScopes), accessPackageResourceRoleScopes, false)
+		case len(segments) == 1 && r.Method == http.MethodPost:
+			return s.createAccessPackageResourceRoleScope(r, o)
+		case len(segments) == 2:
+			var roleScope *object
+			for _, rs := range s.containedObjects(o, "resourceRoleScopes", accessPackageResourceRoleScopes) {
+				if rs.id() == segments[1] {
+					roleScope = rs
+				}
+			}
+			if roleScope == nil {
+				return 0, nil, notFound(segments[1])
+			}
+			switch r.Method {
+			case http.MethodGet:
+				return entity(r, roleScope, accessPackageResourceRoleScopes)
+			case http.MethodDelete:
+				s.remove(roleScope)
+				return http.StatusNoContent, nil, nil
+			}
+		}
+
+	case "cancel":
+		if len(segments) == 1 && r.Method == http.MethodPost {
+			if o.collection.name == accessPackageAssignmentRequests {
+				return s.cancelAccessPackageAssignmentRequest(o)
+			}
+			return s.cancelRoleScheduleRequest(o)
+		}
+
+	case "updateAllowedCombinations":
+		if len(segments) == 1 && r.Method == http.MethodPost {
+			return s.updateAllowedCombinations(r, o)
+		}
+
+	case "sendMail":
+		if len(segments) == 1 && r.Method == http.MethodPost {
+			return http.StatusAccepted, nil, nil
+		}
+	}
+
+	return 0, nil, methodNotAllowed()
+}
+
+// routeDirectory handles a request for the directory's deleted items.
+func (s *Server) routeDirectory(r *request, segments []string) (int, interface{}, *apiError) {
+	if len(segments) < 2 || !strings.EqualFold(segments[0], "deletedItems") {
+		return 0, nil, segmentNotFound(strings.Join(segments, "/"))
+	}
+
+	if strings.HasPrefix(strings.ToLower(segments[1]), "microsoft.graph.") {
+		if len(segments) != 2 || r.Method != http.MethodGet {
+			return 0, nil, methodNotAllowed()
+		}
+		odataType := "#" + segments[1]
+		objects := make([]*object, 0)
+		for _, id := range s.order {
+			if o, ok := s.deleted[id]; ok && strings.EqualFold(o.odataType(), odataType) {
+				objects = append(objects, o)
+			}
+		}
+		return s.page(r, objects, "directoryObjects", true)
+	}
+
+	o, ok := s.deleted[segments[1]]
+	if !ok {
+		return 0, nil, notFound(segments[1])
+	}
+	switch {
+	case len(segments) == 2 && r.Method == http.MethodGet:
+		status, ret, e := entity(r, o, "directoryObjects")
+		if ret, ok := ret.(map[string]interface{}); ok {
+			ret["@odata.type"] = o.odataType()
+		}
+		return status, ret, e
+	case len(segments) == 2 && r.Method == http.MethodDelete:
+		delete(s.deleted, o.id())
+		return http.StatusNoContent, nil, nil
+	case len(segments) == 3 && segments[2] == "restore" && r.Method == http.MethodPost:
+		delete(s.deleted, o.id())
+		delete(o.props, "deletedDateTime")
+		s.objects[o.key()] = o
+		return entity(r, o, o.collection.name)
+	}
+	return 0, nil, methodNotAllowed()
+}
@@ -0,0 +1,460 @@
+// Package msgraphtest provides an in-process fake of the Microsoft Graph API, for testing code that uses the msgraph
+// package without a network connection or a real tenant.
+//
+// The fake implements users and their authentication methods, groups, devices, administrative units and their scoped
+// role members, applications, service principals, directory roles and role templates, unified role definitions, role
+// assignments and role eligibility and assignment schedule requests, app role assignments, access review definitions
+// with their instances and decisions, entitlement management catalogs, access packages and their resource role scopes,
+// assignment policies and assignment requests, named locations, conditional access policies, the authentication methods
+// policy and authentication strength policies. Responses use realistic OData envelopes, collections are paginated using
+// @odata.nextLink, errors are returned using the same JSON error bodies as the real API, and JSON batching is
+// supported. Simple $filter expressions using eq, ne and startswith are supported, along with $select, $top, $orderby
+// and $count.
+//
+// To use the fake, point the Endpoint of a client at the server:
+//
+//	server := msgraphtest.NewServer()
+//	defer server.Close()
+//
+//	client := msgraph.NewUsersClient("tenant")
+//	client.BaseClient.Endpoint = server.Endpoint()
+//
+// Throttling and other faults can be injected using Throttle() and Intercept(), and the requests sent by a client can
+// be inspected using Requests().
+package msgraphtest
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/manicminer/hamilton/environments"
+)
+
+// batchMaxRequests is the maximum number of requests accepted in a single JSON batch.
+const batchMaxRequests = 20
+
+// Interceptor is called for every request before it is handled by the fake. If the interceptor writes a response, it
+// should return true and the request will not be handled further. Interceptors are useful for injecting faults.
+type Interceptor func(w http.ResponseWriter, r *http.Request) bool
+
+// Server is an in-process fake of the Microsoft Graph API. Objects are stored in memory and are shared by both API
+// versions and all tenant IDs.
+type Server struct {
+	// PageSize is the maximum number of objects returned in each page of a collection when $top is not specified.
+	PageSize int
+
+	server       *httptest.Server
+	mutex        sync.Mutex
+	objects      map[string]*object
+	deleted      map[string]*object
+	order        []string
+	relations    map[string][]string
+	throttle     int
+	retryAfter   time.Duration
+	interceptors []Interceptor
+	requests     []Request
+}
+
+// Request is a request received by the fake API, as returned by Requests().
+type Request struct {
+	// Method is the HTTP method of the request.
+	Method string
+
+	// Version is the API version of the request, either "v1.0" or "beta".
+	Version string
+
+	// Path is the path of the request relative to the API version, excluding any tenant ID, e.g. "/users/{id}".
+	Path string
+
+	// Query contains the query string parameters of the request.
+	Query url.Values
+
+	// Body is the request body, which is empty when no body was sent.
+	Body []byte
+}
+
+// NewServer starts and returns a new Server, which should be closed when finished with. The server is seeded with
+// some well-known directory role templates, along with their built-in role definitions.
+func NewServer() *Server {
+	s := &Server{
+		PageSize:  100,
+		objects:   make(map[string]*object),
+		deleted:   make(map[string]*object),
+		relations: make(map[string][]string),
+	}
+	for _, t := range directoryRoleTemplates {
+		s.insert(collectionByName("directoryRoleTemplates"), map[string]interface{}{
+			"id":          t.id,
+			"displayName": t.displayName,
+			"description": t.description,
+		})
+		s.insert(collectionByName(roleDefinitions), map[string]interface{}{
+			"id":          t.id,
+			"templateId":  t.id,
+			"displayName": t.displayName,
+			"description": t.description,
+			"isBuiltIn":   true,
+			"isEnabled":   true,
+		})
+	}
+	s.insertAuthenticationPolicies()
+	s.server = httptest.NewServer(s)
+	return s
+}
+
+// URL returns the base URL of the server.
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+// Endpoint returns the base URL of the server, suitable for the Endpoint field of msgraph.Client.
+func (s *Server) Endpoint() environments.ApiEndpoint {
+	return environments.ApiEndpoint(s.server.URL)
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Throttle causes the next n requests to receive a 429 Too Many Requests response with a Retry-After header
+// specifying retryAfter. Requests contained in a JSON batch are throttled individually.
+func (s *Server) Throttle(n int, retryAfter time.Duration) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.throttle = n
+	s.retryAfter = retryAfter
+}
+
+// Intercept adds an Interceptor which is called for every subsequent request, including requests contained in a JSON
+// batch. Interceptors are called in the order they were added.
+func (s *Server) Intercept(i Interceptor) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.interceptors = append(s.interceptors, i)
+}
+
+// Requests returns the requests received by the server in the order they were received, excluding requests which were
+// handled by an Interceptor. The requests contained in a JSON batch are returned individually, in place of the batch.
+func (s *Server) Requests() []Request {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	ret := make([]Request, len(s.requests))
+	copy(ret, s.requests)
+	return ret
+}
+
+// ClearRequests discards the requests received by the server so far, so that subsequent calls to Requests() only
+// return requests received after this call.
+func (s *Server) ClearRequests() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.requests = nil
+}
+
+// Add stores an object in the named collection without any validation, and returns its ID. The collection is the
+// path of the collection relative to the API version, for example "users" or "identity/conditionalAccess/policies".
+// An ID is generated when the object does not have one. This is useful for seeding objects which cannot be created
+// using the API, and panics if the collection is unknown or the object cannot be marshaled.
+func (s *Server) Add(collection string, v interface{}) string {
+	c := collectionByName(collection)
+	if c == nil {
+		panic(fmt.Sprintf("msgraphtest: unknown collection %q", collection))
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic(fmt.Sprintf("msgraphtest: json.Marshal(): %v", err))
+	}
+	var props map[string]interface{}
+	if err := json.Unmarshal(b, &props); err != nil {
+		panic(fmt.Sprintf("msgraphtest: json.Unmarshal(): %v", err))
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.insert(c, props).id()
+}
+
+// ServeHTTP handles a request to the fake API.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	requestId := newId()
+	w.Header().Set("request-id", requestId)
+	clientRequestId := r.Header.Get("client-request-id")
+	if clientRequestId == "" {
+		clientRequestId = requestId
+	}
+	w.Header().Set("client-request-id", clientRequestId)
+
+	s.mutex.Lock()
+	interceptors := s.interceptors
+	s.mutex.Unlock()
+	for _, i := range interceptors {
+		if i(w, r) {
+			return
+		}
+	}
+
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(segments) == 0 || (segments[0] != "v1.0" && segments[0] != "beta") {
+		writeError(w, requestId, badRequest("Invalid version."))
+		return
+	}
+	version := segments[0]
+	origin := "http://" + r.Host
+	base := fmt.Sprintf("%s/%s", origin, version)
+	segments = segments[1:]
+	if len(segments) > 0 && !isRootSegment(segments[0]) {
+		// the first segment is a tenant ID
+		segments = segments[1:]
+	}
+
+	if len(segments) == 1 && segments[0] == "$batch" {
+		if r.Method != http.MethodPost {
+			writeError(w, requestId, methodNotAllowed())
+			return
+		}
+		s.batch(w, r, requestId, base)
+		return
+	}
+
+	var body []byte
+	if r.Body != nil {
+		var err error
+		if body, err = ioutil.ReadAll(r.Body); err != nil {
+			writeError(w, requestId, badRequest("Unable to read request body."))
+			return
+		}
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.requests = append(s.requests, Request{
+		Method:  r.Method,
+		Version: version,
+		Path:    "/" + strings.Join(segments, "/"),
+		Query:   r.URL.Query(),
+		Body:    body,
+	})
+
+	if s.throttle > 0 {
+		s.throttle--
+		w.Header().Set("Retry-After", strconv.FormatFloat(s.retryAfter.Seconds(), 'f', -1, 64))
+		writeError(w, requestId, &apiError{
+			status:  http.StatusTooManyRequests,
+			code:    "TooManyRequests",
+			message: "Too many requests.",
+		})
+		return
+	}
+
+	req := &request{
+		Request: r,
+		origin:  origin,
+		base:    base,
+		body:    body,
+	}
+	status, ret, e := s.route(req, segments)
+	if e != nil {
+		writeError(w, requestId, e)
+		return
+	}
+	if ret == nil {
+		w.WriteHeader(status)
+		return
+	}
+	writeJson(w, status, ret)
+}
+
+// request is a request being handled by the fake API.
+type request struct {
+	*http.Request
+
+	// origin is the scheme and host of the server, used to build @odata.nextLink values.
+	origin string
+
+	// base is the URL of the API version, used to build @odata.context values.
+	base string
+
+	// body is the request body, which has already been read.
+	body []byte
+}
+
+// decode unmarshals the request body as a JSON object.
+func (r *request) decode() (map[string]interface{}, *apiError) {
+	var props map[string]interface{}
+	if err := json.Unmarshal(r.body, &props); err != nil || props == nil {
+		return nil, badRequest("Invalid JSON in request body.")
+	}
+	return props, nil
+}
+
+type batchRequest struct {
+	ID        string            `json:"id"`
+	Method    string            `json:"method"`
+	Url       string            `json:"url"`
+	Headers   map[string]string `json:"headers"`
+	Body      json.RawMessage   `json:"body"`
+	DependsOn []string          `json:"dependsOn"`
+}
+
+type batchResponse struct {
+	ID      string            `json:"id"`
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    json.RawMessage   `json:"body,omitempty"`
+}
+
+// batch handles a JSON batch by serving each contained request in order.
+func (s *Server) batch(w http.ResponseWriter, r *http.Request, requestId, base string) {
+	var input struct {
+		Requests []batchRequest `json:"requests"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		writeError(w, requestId, badRequest("Invalid batch payload format."))
+		return
+	}
+	if len(input.Requests) > batchMaxRequests {
+		writeError(w, requestId, badRequest(fmt.Sprintf("Number of batch request steps exceeds the maximum of %d.", batchMaxRequests)))
+		return
+	}
+
+	failed := make(map[string]bool)
+	responses := make([]batchResponse, 0, len(input.Requests))
+	for _, item := range input.Requests {
+		dependencyFailed := false
+		for _, d := range item.DependsOn {
+			if failed[d] {
+				dependencyFailed = true
+			}
+		}
+		if dependencyFailed {
+			failed[item.ID] = true
+			body, _ := json.Marshal(errorBody(newId(), &apiError{
+				status:  http.StatusFailedDependency,
+				code:    "FailedDependency",
+				message: "Request failed because a dependent request failed.",
+			}))
+			responses = append(responses, batchResponse{
+				ID:      item.ID,
+				Status:  http.StatusFailedDependency,
+				Headers: map[string]string{"Content-Type": "application/json"},
+				Body:    body,
+			})
+			continue
+		}
+
+		req := httptest.NewRequest(item.Method, base+"/"+strings.TrimLeft(item.Url, "/"), bytes.NewReader(item.Body))
+		req.Host = r.Host
+		req = req.WithContext(r.Context())
+		for k, v := range item.Headers {
+			req.Header.Set(k, v)
+		}
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, req)
+
+		resp := batchResponse{
+			ID:      item.ID,
+			Status:  rec.Code,
+			Headers: make(map[string]string),
+		}
+		for k := range rec.Header() {
+			resp.Headers[k] = rec.Header().Get(k)
+		}
+		if b := rec.Body.Bytes(); len(b) > 0 {
+			if json.Valid(b) {
+				resp.Body = b
+			} else {
+				resp.Body, _ = json.Marshal(string(b))
+			}
+		}
+		if rec.Code < 200 || rec.Code > 299 {
+			failed[item.ID] = true
+		}
+		responses = append(responses, resp)
+	}
+
+	writeJson(w, http.StatusOK, map[string]interface{}{
+		"responses": responses,
+	})
+}
+
+// apiError describes an error response returned by the fake API.
+type apiError struct {
+	status  int
+	code    string
+	message string
+}
+
+func badRequest(message string) *apiError {
+	return &apiError{status: http.StatusBadRequest, code: "Request_BadRequest", message: message}
+}
+
+func methodNotAllowed() *apiError {
+	return &apiError{status: http.StatusMethodNotAllowed, code: "Request_BadRequest", message: "Specified HTTP method is not allowed for the request target."}
+}
+
+func notFound(id string) *apiError {
+	return &apiError{
+		status:  http.StatusNotFound,
+		code:    "Request_ResourceNotFound",
+		message: fmt.Sprintf("Resource '%s' does not exist or one of its queried reference-property objects are not present.", id),
+	}
+}
+
+func segmentNotFound(segment string) *apiError {
+	return &apiError{
+		status:  http.StatusBadRequest,
+		code:    "BadRequest",
+		message: fmt.Sprintf("Resource not found for the segment '%s'.", segment),
+	}
+}
+
+func errorBody(requestId string, e *apiError) map[string]interface{} {
+	return map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    e.code,
+			"message": e.message,
+			"innerError": map[string]interface{}{
+				"date":              time.Now().UTC().Format("2006-01-02T15:04:05"),
+				"request-id":        requestId,
+				"client-request-id": requestId,
+			},
+		},
+	}
+}
+
+func writeError(w http.ResponseWriter, requestId string, e *apiError) {
+	writeJson(w, e.status, errorBody(requestId, e))
+}
+
+func writeJson(w http.ResponseWriter, status int, v interface{}) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json;odata.metadata=minimal;odata.streaming=true;IEEE754Compatible=false;charset=utf-8")
+	w.WriteHeader(status)
+	_, _ = w.Write(b)
+}
+
+// newId returns a random UUID.
+func newId() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("msgraphtest: rand.Read(): %v", err))
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
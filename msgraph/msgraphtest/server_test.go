@@ -0,0 +1,604 @@
+package msgraphtest_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/manicminer/hamilton/errors"
+	"github.com/manicminer/hamilton/internal/utils"
+	"github.com/manicminer/hamilton/msgraph"
+	"github.com/manicminer/hamilton/msgraph/msgraphtest"
+	"github.com/manicminer/hamilton/odata"
+	"github.com/manicminer/hamilton/retry"
+)
+
+func TestServer_Users(t *testing.T) {
+	server := msgraphtest.NewServer()
+	defer server.Close()
+	server.PageSize = 2
+
+	client := msgraph.NewUsersClient("tenant")
+	client.BaseClient.Endpoint = server.Endpoint()
+	ctx := context.Background()
+
+	var ids []string
+	for i := 0; i < 3; i++ {
+		user, status, err := client.Create(ctx, msgraph.User{
+			AccountEnabled:    utils.BoolPtr(true),
+			DisplayName:       utils.StringPtr(fmt.Sprintf("test-user-%d", i)),
+			MailNickname:      utils.StringPtr(fmt.Sprintf("test-user-%d", i)),
+			UserPrincipalName: utils.StringPtr(fmt.Sprintf("test-user-%d@example.com", i)),
+			PasswordProfile:   &msgraph.UserPasswordProfile{Password: utils.StringPtr("hunter2")},
+		})
+		if err != nil {
+			t.Fatalf("UsersClient.Create(): %v", err)
+		}
+		if status != http.StatusCreated || user.ID == nil {
+			t.Fatalf("UsersClient.Create(): expected a new user with status 201, got status %d", status)
+		}
+		if user.PasswordProfile != nil {
+			t.Errorf("UsersClient.Create(): expected password not to be returned")
+		}
+		ids = append(ids, *user.ID)
+	}
+
+	_, _, err := client.Create(ctx, msgraph.User{
+		DisplayName:       utils.StringPtr("duplicate"),
+		UserPrincipalName: utils.StringPtr("TEST-USER-0@example.com"),
+	})
+	if !errors.IsConflict(err) {
+		t.Errorf("UsersClient.Create(): expected a conflict error for a duplicate userPrincipalName, got: %v", err)
+	}
+
+	users, _, err := client.List(ctx, odata.Query{})
+	if err != nil {
+		t.Fatalf("UsersClient.List(): %v", err)
+	}
+	if len(*users) != 3 {
+		t.Fatalf("UsersClient.List(): expected 3 users across all pages, got %d", len(*users))
+	}
+
+	users, _, err = client.List(ctx, odata.Query{Filter: "startswith(displayName,'test-user') and userPrincipalName ne 'test-user-1@example.com'", OrderBy: odata.OrderBy{Field: "displayName", Direction: odata.Descending}})
+	if err != nil {
+		t.Fatalf("UsersClient.List(): %v", err)
+	}
+	if len(*users) != 2 || *(*users)[0].DisplayName != "test-user-2" {
+		t.Fatalf("UsersClient.List(): unexpected filtered users: %d", len(*users))
+	}
+
+	if _, err := client.Update(ctx, msgraph.User{ID: utils.StringPtr(ids[0]), DisplayName: utils.StringPtr("updated")}); err != nil {
+		t.Fatalf("UsersClient.Update(): %v", err)
+	}
+	user, _, err := client.Get(ctx, ids[0])
+	if err != nil {
+		t.Fatalf("UsersClient.Get(): %v", err)
+	}
+	if *user.DisplayName != "updated" || *user.UserPrincipalName != "test-user-0@example.com" {
+		t.Errorf("UsersClient.Get(): expected update to be merged, got %q (%q)", *user.DisplayName, *user.UserPrincipalName)
+	}
+
+	if _, err := client.Delete(ctx, ids[0]); err != nil {
+		t.Fatalf("UsersClient.Delete(): %v", err)
+	}
+	if _, status, err := client.Get(ctx, ids[0]); !errors.IsNotFound(err) || status != http.StatusNotFound {
+		t.Errorf("UsersClient.Get(): expected a not found error for a deleted user, got: %v", err)
+	}
+	deleted, _, err := client.GetDeleted(ctx, ids[0])
+	if err != nil {
+		t.Fatalf("UsersClient.GetDeleted(): %v", err)
+	}
+	if deleted.DeletedDateTime == nil {
+		t.Errorf("UsersClient.GetDeleted(): expected deletedDateTime to be set")
+	}
+	deletedUsers, _, err := client.ListDeleted(ctx, odata.Query{})
+	if err != nil {
+		t.Fatalf("UsersClient.ListDeleted(): %v", err)
+	}
+	if len(*deletedUsers) != 1 {
+		t.Errorf("UsersClient.ListDeleted(): expected 1 deleted user, got %d", len(*deletedUsers))
+	}
+}
+
+func TestServer_Groups(t *testing.T) {
+	server := msgraphtest.NewServer()
+	defer server.Close()
+
+	usersClient := msgraph.NewUsersClient("tenant")
+	usersClient.BaseClient.Endpoint = server.Endpoint()
+	groupsClient := msgraph.NewGroupsClient("tenant")
+	groupsClient.BaseClient.Endpoint = server.Endpoint()
+	ctx := context.Background()
+
+	var userIds []string
+	for i := 0; i < 25; i++ {
+		user, _, err := usersClient.Create(ctx, msgraph.User{
+			DisplayName:       utils.StringPtr(fmt.Sprintf("test-user-%d", i)),
+			UserPrincipalName: utils.StringPtr(fmt.Sprintf("test-user-%d@example.com", i)),
+		})
+		if err != nil {
+			t.Fatalf("UsersClient.Create(): %v", err)
+		}
+		userIds = append(userIds, *user.ID)
+	}
+
+	parent := msgraph.Group{
+		DisplayName:     utils.StringPtr("parent"),
+		MailEnabled:     utils.BoolPtr(false),
+		MailNickname:    utils.StringPtr("parent"),
+		SecurityEnabled: utils.BoolPtr(true),
+	}
+	parent.AppendOwner(groupsClient.BaseClient.Endpoint, groupsClient.BaseClient.ApiVersion, userIds[0])
+	newParent, _, err := groupsClient.Create(ctx, parent)
+	if err != nil {
+		t.Fatalf("GroupsClient.Create(): %v", err)
+	}
+	child, _, err := groupsClient.Create(ctx, msgraph.Group{
+		DisplayName:     utils.StringPtr("child"),
+		MailEnabled:     utils.BoolPtr(false),
+		MailNickname:    utils.StringPtr("child"),
+		SecurityEnabled: utils.BoolPtr(true),
+	})
+	if err != nil {
+		t.Fatalf("GroupsClient.Create(): %v", err)
+	}
+
+	owners, _, err := groupsClient.ListOwners(ctx, *newParent.ID)
+	if err != nil {
+		t.Fatalf("GroupsClient.ListOwners(): %v", err)
+	}
+	if len(*owners) != 1 || *(*owners)[0].GetID() != userIds[0] {
+		t.Errorf("GroupsClient.ListOwners(): unexpected owners: %v", *owners)
+	}
+
+	// adding more than 20 members requires more than one request in a JSON batch
+	for _, id := range userIds {
+		child.AppendMember(groupsClient.BaseClient.Endpoint, groupsClient.BaseClient.ApiVersion, id)
+	}
+	if _, err := groupsClient.AddMembers(ctx, child); err != nil {
+		t.Fatalf("GroupsClient.AddMembers(): %v", err)
+	}
+	// adding existing members is not an error
+	if _, err := groupsClient.AddMembers(ctx, child); err != nil {
+		t.Fatalf("GroupsClient.AddMembers(): %v", err)
+	}
+	newParent.Members = nil
+	newParent.AppendMember(groupsClient.BaseClient.Endpoint, groupsClient.BaseClient.ApiVersion, *child.ID)
+	if _, err := groupsClient.AddMembers(ctx, newParent); err != nil {
+		t.Fatalf("GroupsClient.AddMembers(): %v", err)
+	}
+
+	members, _, err := groupsClient.ListMembers(ctx, *child.ID)
+	if err != nil {
+		t.Fatalf("GroupsClient.ListMembers(): %v", err)
+	}
+	if len(*members) != 25 {
+		t.Errorf("GroupsClient.ListMembers(): expected 25 members, got %d", len(*members))
+	}
+	for _, member := range *members {
+		if user, ok := member.(*msgraph.User); !ok || user.UserPrincipalName == nil {
+			t.Errorf("GroupsClient.ListMembers(): expected a *msgraph.User with its properties, got %#v", member)
+		}
+	}
+
+	members, _, err = groupsClient.ListMembers(ctx, *newParent.ID)
+	if err != nil {
+		t.Fatalf("GroupsClient.ListMembers(): %v", err)
+	}
+	if len(*members) != 1 || (*members)[0].GetODataType() != msgraph.ODataTypeGroup {
+		t.Errorf("GroupsClient.ListMembers(): expected the nested group, got %v", *members)
+	} else if group := (*members)[0].(*msgraph.Group); group.DisplayName == nil || *group.DisplayName != *child.DisplayName {
+		t.Errorf("GroupsClient.ListMembers(): expected the nested group to have its properties, got %#v", group)
+	}
+
+	groups, _, err := usersClient.ListGroupMemberships(ctx, userIds[1], odata.Query{})
+	if err != nil {
+		t.Fatalf("UsersClient.ListGroupMemberships(): %v", err)
+	}
+	if len(*groups) != 2 {
+		t.Errorf("UsersClient.ListGroupMemberships(): expected transitive membership of 2 groups, got %d", len(*groups))
+	}
+
+	if _, err := groupsClient.RemoveMembers(ctx, *child.ID, &[]string{userIds[1], userIds[2]}); err != nil {
+		t.Fatalf("GroupsClient.RemoveMembers(): %v", err)
+	}
+	// removing members which are already gone is not an error
+	if _, err := groupsClient.RemoveMembers(ctx, *child.ID, &[]string{userIds[1]}); err != nil {
+		t.Fatalf("GroupsClient.RemoveMembers(): %v", err)
+	}
+	if _, status, err := groupsClient.GetMember(ctx, *child.ID, userIds[1]); err == nil || status != http.StatusNotFound {
+		t.Errorf("GroupsClient.GetMember(): expected removed member not to be found, got status %d", status)
+	}
+}
+
+func TestServer_Applications(t *testing.T) {
+	server := msgraphtest.NewServer()
+	defer server.Close()
+
+	appsClient := msgraph.NewApplicationsClient("tenant")
+	appsClient.BaseClient.Endpoint = server.Endpoint()
+	spClient := msgraph.NewServicePrincipalsClient("tenant")
+	spClient.BaseClient.Endpoint = server.Endpoint()
+	assignmentsClient := msgraph.NewUsersAppRoleAssignmentsClient("tenant")
+	assignmentsClient.BaseClient.Endpoint = server.Endpoint()
+	usersClient := msgraph.NewUsersClient("tenant")
+	usersClient.BaseClient.Endpoint = server.Endpoint()
+	ctx := context.Background()
+
+	user, _, err := usersClient.Create(ctx, msgraph.User{
+		DisplayName:       utils.StringPtr("test-user"),
+		UserPrincipalName: utils.StringPtr("test-user@example.com"),
+	})
+	if err != nil {
+		t.Fatalf("UsersClient.Create(): %v", err)
+	}
+
+	app := msgraph.Application{DisplayName: utils.StringPtr("test-app")}
+	app.AppendOwner(appsClient.BaseClient.Endpoint, appsClient.BaseClient.ApiVersion, *user.ID)
+	newApp, _, err := appsClient.Create(ctx, app)
+	if err != nil {
+		t.Fatalf("ApplicationsClient.Create(): %v", err)
+	}
+	if newApp.AppId == nil {
+		t.Fatalf("ApplicationsClient.Create(): expected appId to be generated")
+	}
+
+	if _, _, err := spClient.Create(ctx, msgraph.ServicePrincipal{AppId: utils.StringPtr("00000000-0000-0000-0000-000000000000")}); err == nil {
+		t.Errorf("ServicePrincipalsClient.Create(): expected an error for an unknown appId")
+	}
+	sp, _, err := spClient.Create(ctx, msgraph.ServicePrincipal{AppId: newApp.AppId})
+	if err != nil {
+		t.Fatalf("ServicePrincipalsClient.Create(): %v", err)
+	}
+	if sp.DisplayName == nil || *sp.DisplayName != "test-app" {
+		t.Errorf("ServicePrincipalsClient.Create(): expected displayName to be inherited from the application")
+	}
+
+	credential, _, err := appsClient.AddPassword(ctx, *newApp.ID, msgraph.PasswordCredential{DisplayName: utils.StringPtr("test")})
+	if err != nil {
+		t.Fatalf("ApplicationsClient.AddPassword(): %v", err)
+	}
+	if credential.SecretText == nil || credential.KeyId == nil {
+		t.Fatalf("ApplicationsClient.AddPassword(): expected a secret and key ID to be returned")
+	}
+	app2, _, err := appsClient.Get(ctx, *newApp.ID)
+	if err != nil {
+		t.Fatalf("ApplicationsClient.Get(): %v", err)
+	}
+	if app2.PasswordCredentials == nil || len(*app2.PasswordCredentials) != 1 || (*app2.PasswordCredentials)[0].SecretText != nil {
+		t.Errorf("ApplicationsClient.Get(): expected one password credential without its secret")
+	}
+	if _, err := appsClient.RemovePassword(ctx, *newApp.ID, *credential.KeyId); err != nil {
+		t.Fatalf("ApplicationsClient.RemovePassword(): %v", err)
+	}
+
+	owners, _, err := appsClient.ListOwners(ctx, *newApp.ID)
+	if err != nil {
+		t.Fatalf("ApplicationsClient.ListOwners(): %v", err)
+	}
+	if len(*owners) != 1 || *(*owners)[0].GetID() != *user.ID {
+		t.Errorf("ApplicationsClient.ListOwners(): unexpected owners: %v", *owners)
+	}
+
+	appRoleId := "11111111-1111-1111-1111-111111111111"
+	assignment, _, err := assignmentsClient.Assign(ctx, *user.ID, *sp.ID, appRoleId)
+	if err != nil {
+		t.Fatalf("AppRoleAssignmentsClient.Assign(): %v", err)
+	}
+	if *assignment.PrincipalType != "User" || *assignment.ResourceDisplayName != "test-app" {
+		t.Errorf("AppRoleAssignmentsClient.Assign(): unexpected assignment: %s to %s", *assignment.PrincipalType, *assignment.ResourceDisplayName)
+	}
+	assignments, _, err := spClient.ListAppRoleAssignments(ctx, *sp.ID)
+	if err != nil {
+		t.Fatalf("ServicePrincipalsClient.ListAppRoleAssignments(): %v", err)
+	}
+	if len(*assignments) != 1 || *(*assignments)[0].PrincipalId != *user.ID {
+		t.Errorf("ServicePrincipalsClient.ListAppRoleAssignments(): expected 1 assignment for the user, got %d", len(*assignments))
+	}
+	if _, err := assignmentsClient.Remove(ctx, *user.ID, *assignment.Id); err != nil {
+		t.Fatalf("AppRoleAssignmentsClient.Remove(): %v", err)
+	}
+}
+
+func TestServer_DirectoryRoles(t *testing.T) {
+	server := msgraphtest.NewServer()
+	defer server.Close()
+
+	client := msgraph.NewDirectoryRolesClient("tenant")
+	client.BaseClient.Endpoint = server.Endpoint()
+	ctx := context.Background()
+
+	templatesClient := msgraph.NewDirectoryRoleTemplatesClient("tenant")
+	templatesClient.BaseClient.Endpoint = server.Endpoint()
+	templates, _, err := templatesClient.List(ctx)
+	if err != nil {
+		t.Fatalf("DirectoryRoleTemplatesClient.List(): %v", err)
+	}
+	if len(*templates) == 0 {
+		t.Fatalf("DirectoryRoleTemplatesClient.List(): expected seeded role templates")
+	}
+
+	role, _, err := client.Activate(ctx, *(*templates)[0].ID)
+	if err != nil {
+		t.Fatalf("DirectoryRolesClient.Activate(): %v", err)
+	}
+	if *role.DisplayName != *(*templates)[0].DisplayName {
+		t.Errorf("DirectoryRolesClient.Activate(): expected displayName %q, got %q", *(*templates)[0].DisplayName, *role.DisplayName)
+	}
+
+	userId := server.Add("users", msgraph.User{DisplayName: utils.StringPtr("seeded-user")})
+	role.AppendMember(client.BaseClient.Endpoint, client.BaseClient.ApiVersion, userId)
+	if _, err := client.AddMembers(ctx, role); err != nil {
+		t.Fatalf("DirectoryRolesClient.AddMembers(): %v", err)
+	}
+	members, _, err := client.ListMembers(ctx, *role.ID)
+	if err != nil {
+		t.Fatalf("DirectoryRolesClient.ListMembers(): %v", err)
+	}
+	if len(*members) != 1 || *(*members)[0].GetID() != userId {
+		t.Errorf("DirectoryRolesClient.ListMembers(): unexpected members: %v", *members)
+	}
+}
+
+func TestServer_ConditionalAccess(t *testing.T) {
+	server := msgraphtest.NewServer()
+	defer server.Close()
+
+	locationsClient := msgraph.NewNamedLocationsClient("tenant")
+	locationsClient.BaseClient.Endpoint = server.Endpoint()
+	policiesClient := msgraph.NewConditionalAccessPolicyClient("tenant")
+	policiesClient.BaseClient.Endpoint = server.Endpoint()
+	ctx := context.Background()
+
+	location, _, err := locationsClient.CreateIP(ctx, msgraph.IPNamedLocation{
+		BaseNamedLocation: &msgraph.BaseNamedLocation{DisplayName: utils.StringPtr("test-location")},
+		IPRanges:          &[]msgraph.IPNamedLocationIPRange{{CIDRAddress: utils.StringPtr("192.0.2.0/24")}},
+	})
+	if err != nil {
+		t.Fatalf("NamedLocationsClient.CreateIP(): %v", err)
+	}
+	location.IsTrusted = utils.BoolPtr(true)
+	if _, err := locationsClient.UpdateIP(ctx, *location); err != nil {
+		t.Fatalf("NamedLocationsClient.UpdateIP(): %v", err)
+	}
+	location, _, err = locationsClient.GetIP(ctx, *location.ID)
+	if err != nil {
+		t.Fatalf("NamedLocationsClient.GetIP(): %v", err)
+	}
+	if location.IsTrusted == nil || !*location.IsTrusted || location.IPRanges == nil || len(*location.IPRanges) != 1 {
+		t.Errorf("NamedLocationsClient.GetIP(): expected updated location")
+	}
+	var locations msgraph.NamedLocations
+	if _, _, err := locationsClient.ListPager(odata.Query{}).Next(ctx, &locations); err != nil {
+		t.Fatalf("NamedLocationsClient.ListPager(): %v", err)
+	}
+	if len(locations) != 1 {
+		t.Fatalf("NamedLocationsClient.ListPager(): expected 1 location, got %d", len(locations))
+	}
+	if _, ok := locations[0].(msgraph.IPNamedLocation); !ok {
+		t.Errorf("NamedLocationsClient.ListPager(): expected an IPNamedLocation, got %T", locations[0])
+	}
+
+	state := msgraph.ConditionalAccessPolicyStateDisabled
+	filterMode := msgraph.ConditionalAccessFilterModeExclude
+	membershipKind := msgraph.ConditionalAccessExternalTenantsMembershipKindAll
+	guestTypes := msgraph.ConditionalAccessGuestOrExternalUserTypesInternalGuest + "," + msgraph.ConditionalAccessGuestOrExternalUserTypesB2bCollaborationGuest
+	caeMode := msgraph.ContinuousAccessEvaluationModeStrictEnforcement
+	transferMethods := msgraph.ConditionalAccessTransferMethodsDeviceCodeFlow + "," + msgraph.ConditionalAccessTransferMethodsAuthenticationTransfer
+	authenticationType := msgraph.SignInFrequencyAuthenticationTypePrimaryAndSecondaryAuthentication
+	frequencyInterval := msgraph.SignInFrequencyIntervalEveryTime
+	policy, _, err := policiesClient.Create(ctx, msgraph.ConditionalAccessPolicy{
+		DisplayName: utils.StringPtr("test-policy"),
+		State:       &state,
+		Conditions: &msgraph.ConditionalAccessConditionSet{
+			Applications: &msgraph.ConditionalAccessApplications{
+				IncludeApplications: &[]string{"All"},
+				ApplicationFilter: &msgraph.ConditionalAccessFilter{
+					Mode: &filterMode,
+					Rule: utils.StringPtr(`CustomSecurityAttribute.Project_Name -eq "test"`),
+				},
+			},
+			AuthenticationFlows: &msgraph.ConditionalAccessAuthenticationFlows{TransferMethods: &transferMethods},
+			ClientAppTypes:      &[]msgraph.ConditionalAccessClientAppType{msgraph.ConditionalAccessClientAppTypeAll},
+			Devices: &msgraph.ConditionalAccessDevices{
+				DeviceFilter: &msgraph.ConditionalAccessFilter{
+					Mode: &filterMode,
+					Rule: utils.StringPtr(`device.trustType -eq "ServerAD"`),
+				},
+			},
+			Locations:        &msgraph.ConditionalAccessLocations{IncludeLocations: &[]string{*location.ID}},
+			SignInRiskLevels: &[]msgraph.ConditionalAccessRiskLevel{msgraph.ConditionalAccessRiskLevelHigh, msgraph.ConditionalAccessRiskLevelMedium},
+			Users: &msgraph.ConditionalAccessUsers{
+				IncludeGuestsOrExternalUsers: &msgraph.ConditionalAccessGuestsOrExternalUsers{
+					ExternalTenants: &msgraph.ConditionalAccessExternalTenants{
+						ODataType:      utils.StringPtr(msgraph.ConditionalAccessExternalTenantsAll),
+						MembershipKind: &membershipKind,
+					},
+					GuestOrExternalUserTypes: &guestTypes,
+				},
+			},
+		},
+		GrantControls: &msgraph.ConditionalAccessGrantControls{
+			Operator:        utils.StringPtr("OR"),
+			BuiltInControls: &[]msgraph.ConditionalAccessGrantControl{msgraph.ConditionalAccessGrantControlMfa},
+		},
+		SessionControls: &msgraph.ConditionalAccessSessionControls{
+			ContinuousAccessEvaluation: &msgraph.ContinuousAccessEvaluationSessionControl{Mode: &caeMode},
+			DisableResilienceDefaults:  utils.BoolPtr(true),
+			SecureSignInSession:        &msgraph.SecureSignInSessionControl{IsEnabled: utils.BoolPtr(true)},
+			SignInFrequency: &msgraph.SignInFrequencySessionControl{
+				AuthenticationType: &authenticationType,
+				FrequencyInterval:  &frequencyInterval,
+				IsEnabled:          utils.BoolPtr(true),
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("ConditionalAccessPolicyClient.Create(): %v", err)
+	}
+	policy, _, err = policiesClient.Get(ctx, *policy.ID)
+	if err != nil {
+		t.Fatalf("ConditionalAccessPolicyClient.Get(): %v", err)
+	}
+	if policy.State == nil || *policy.State != state {
+		t.Errorf("ConditionalAccessPolicyClient.Get(): expected state %q, got %v", state, policy.State)
+	}
+	if c := policy.Conditions; c == nil {
+		t.Errorf("ConditionalAccessPolicyClient.Get(): expected conditions")
+	} else {
+		if c.Applications == nil || c.Applications.ApplicationFilter == nil || c.Applications.ApplicationFilter.Mode == nil || *c.Applications.ApplicationFilter.Mode != filterMode {
+			t.Errorf("ConditionalAccessPolicyClient.Get(): expected an application filter, got %v", c.Applications)
+		}
+		if c.AuthenticationFlows == nil || c.AuthenticationFlows.TransferMethods == nil || *c.AuthenticationFlows.TransferMethods != transferMethods {
+			t.Errorf("ConditionalAccessPolicyClient.Get(): expected authentication flows, got %v", c.AuthenticationFlows)
+		}
+		if c.Devices == nil || c.Devices.DeviceFilter == nil || c.Devices.DeviceFilter.Mode == nil || *c.Devices.DeviceFilter.Mode != filterMode {
+			t.Errorf("ConditionalAccessPolicyClient.Get(): expected a device filter, got %v", c.Devices)
+		}
+		if c.Users == nil || c.Users.IncludeGuestsOrExternalUsers == nil || c.Users.IncludeGuestsOrExternalUsers.GuestOrExternalUserTypes == nil || *c.Users.IncludeGuestsOrExternalUsers.GuestOrExternalUserTypes != guestTypes {
+			t.Errorf("ConditionalAccessPolicyClient.Get(): expected guest or external users to be included, got %v", c.Users)
+		}
+		if c.SignInRiskLevels == nil || len(*c.SignInRiskLevels) != 2 || (*c.SignInRiskLevels)[0] != msgraph.ConditionalAccessRiskLevelHigh {
+			t.Errorf("ConditionalAccessPolicyClient.Get(): expected sign-in risk levels, got %v", c.SignInRiskLevels)
+		}
+	}
+	if sc := policy.SessionControls; sc == nil {
+		t.Errorf("ConditionalAccessPolicyClient.Get(): expected session controls")
+	} else {
+		if sc.ContinuousAccessEvaluation == nil || sc.ContinuousAccessEvaluation.Mode == nil || *sc.ContinuousAccessEvaluation.Mode != caeMode {
+			t.Errorf("ConditionalAccessPolicyClient.Get(): expected continuous access evaluation, got %v", sc.ContinuousAccessEvaluation)
+		}
+		if sc.DisableResilienceDefaults == nil || !*sc.DisableResilienceDefaults {
+			t.Errorf("ConditionalAccessPolicyClient.Get(): expected resilience defaults to be disabled, got %v", sc.DisableResilienceDefaults)
+		}
+		if sc.SecureSignInSession == nil || sc.SecureSignInSession.IsEnabled == nil || !*sc.SecureSignInSession.IsEnabled {
+			t.Errorf("ConditionalAccessPolicyClient.Get(): expected secure sign-in sessions to be enabled, got %v", sc.SecureSignInSession)
+		}
+		if f := sc.SignInFrequency; f == nil || f.AuthenticationType == nil || *f.AuthenticationType != authenticationType || f.FrequencyInterval == nil || *f.FrequencyInterval != frequencyInterval || f.IsEnabled == nil || !*f.IsEnabled {
+			t.Errorf("ConditionalAccessPolicyClient.Get(): expected sign-in frequency, got %v", sc.SignInFrequency)
+		}
+	}
+
+	// workload identity policies target service principals rather than users
+	workloadPolicy, _, err := policiesClient.Create(ctx, msgraph.ConditionalAccessPolicy{
+		DisplayName: utils.StringPtr("test-workload-policy"),
+		State:       &state,
+		Conditions: &msgraph.ConditionalAccessConditionSet{
+			Applications: &msgraph.ConditionalAccessApplications{IncludeApplications: &[]string{"All"}},
+			ClientApplications: &msgraph.ConditionalAccessClientApplications{
+				IncludeServicePrincipals: &[]string{"ServicePrincipalsInMyTenant"},
+				ExcludeServicePrincipals: &[]string{"00000000-0000-0000-0000-000000000001"},
+			},
+			ServicePrincipalRiskLevels: &[]msgraph.ConditionalAccessRiskLevel{msgraph.ConditionalAccessRiskLevelHigh, msgraph.ConditionalAccessRiskLevelUnknownFutureValue},
+		},
+		GrantControls: &msgraph.ConditionalAccessGrantControls{
+			Operator:        utils.StringPtr("OR"),
+			BuiltInControls: &[]msgraph.ConditionalAccessGrantControl{msgraph.ConditionalAccessGrantControlBlock},
+		},
+	})
+	if err != nil {
+		t.Fatalf("ConditionalAccessPolicyClient.Create(): %v", err)
+	}
+	workloadPolicy, _, err = policiesClient.Get(ctx, *workloadPolicy.ID)
+	if err != nil {
+		t.Fatalf("ConditionalAccessPolicyClient.Get(): %v", err)
+	}
+	if c := workloadPolicy.Conditions; c == nil {
+		t.Errorf("ConditionalAccessPolicyClient.Get(): expected conditions")
+	} else {
+		if c.ClientApplications == nil || c.ClientApplications.IncludeServicePrincipals == nil || len(*c.ClientApplications.IncludeServicePrincipals) != 1 || (*c.ClientApplications.IncludeServicePrincipals)[0] != "ServicePrincipalsInMyTenant" || c.ClientApplications.ExcludeServicePrincipals == nil || len(*c.ClientApplications.ExcludeServicePrincipals) != 1 {
+			t.Errorf("ConditionalAccessPolicyClient.Get(): expected client applications, got %v", c.ClientApplications)
+		}
+		if c.ServicePrincipalRiskLevels == nil || len(*c.ServicePrincipalRiskLevels) != 2 || (*c.ServicePrincipalRiskLevels)[1] != msgraph.ConditionalAccessRiskLevelUnknownFutureValue {
+			t.Errorf("ConditionalAccessPolicyClient.Get(): expected service principal risk levels, got %v", c.ServicePrincipalRiskLevels)
+		}
+	}
+	if _, err := policiesClient.Delete(ctx, *workloadPolicy.ID); err != nil {
+		t.Fatalf("ConditionalAccessPolicyClient.Delete(): %v", err)
+	}
+
+	if _, err := policiesClient.Delete(ctx, *policy.ID); err != nil {
+		t.Fatalf("ConditionalAccessPolicyClient.Delete(): %v", err)
+	}
+	if _, _, err := policiesClient.Get(ctx, *policy.ID); !errors.IsNotFound(err) {
+		t.Errorf("ConditionalAccessPolicyClient.Get(): expected a not found error, got: %v", err)
+	}
+}
+
+func TestServer_Faults(t *testing.T) {
+	server := msgraphtest.NewServer()
+	defer server.Close()
+
+	client := msgraph.NewUsersClient("tenant")
+	client.BaseClient.Endpoint = server.Endpoint()
+	ctx := context.Background()
+
+	server.Throttle(2, 10*time.Millisecond)
+	if _, _, err := client.List(ctx, odata.Query{}); err != nil {
+		t.Fatalf("UsersClient.List(): expected throttled requests to be retried, got: %v", err)
+	}
+
+	server.Throttle(1, 10*time.Millisecond)
+	_, status, err := client.List(retry.WithPolicy(ctx, retry.Disabled), odata.Query{})
+	if !errors.IsThrottled(err) || status != http.StatusTooManyRequests {
+		t.Fatalf("UsersClient.List(): expected a throttling error with retries disabled, got: %v", err)
+	}
+	if apiErr := errors.AsApiError(err); apiErr.RequestId() == "" {
+		t.Errorf("UsersClient.List(): expected error to include a request ID")
+	}
+
+	server.Intercept(func(w http.ResponseWriter, r *http.Request) bool {
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusForbidden)
+			return true
+		}
+		return false
+	})
+	if _, _, err := client.Create(ctx, msgraph.User{DisplayName: utils.StringPtr("test"), UserPrincipalName: utils.StringPtr("test@example.com")}); !errors.IsAuthorizationDenied(err) {
+		t.Errorf("UsersClient.Create(): expected an authorization error, got: %v", err)
+	}
+}
+
+func TestServer_Requests(t *testing.T) {
+	server := msgraphtest.NewServer()
+	defer server.Close()
+
+	client := msgraph.NewGroupsClient("tenant")
+	client.BaseClient.Endpoint = server.Endpoint()
+	ctx := context.Background()
+
+	group, _, err := client.Create(ctx, msgraph.Group{
+		DisplayName:     utils.StringPtr("test-group"),
+		MailNickname:    utils.StringPtr("test-group"),
+		SecurityEnabled: utils.BoolPtr(true),
+	})
+	if err != nil {
+		t.Fatalf("GroupsClient.Create(): %v", err)
+	}
+	if _, _, err := client.List(ctx, odata.Query{Filter: "displayName eq 'test-group'"}); err != nil {
+		t.Fatalf("GroupsClient.List(): %v", err)
+	}
+
+	requests := server.Requests()
+	if len(requests) != 2 {
+		t.Fatalf("Server.Requests(): expected 2 requests, got %d", len(requests))
+	}
+	if r := requests[0]; r.Method != http.MethodPost || r.Version != "beta" || r.Path != "/groups" || !strings.Contains(string(r.Body), `"displayName":"test-group"`) {
+		t.Errorf("Server.Requests(): unexpected request %s /%s%s %s", r.Method, r.Version, r.Path, r.Body)
+	}
+	if r := requests[1]; r.Method != http.MethodGet || r.Path != "/groups" || r.Query.Get("$filter") != "displayName eq 'test-group'" || len(r.Body) != 0 {
+		t.Errorf("Server.Requests(): unexpected request %s %s?%s", r.Method, r.Path, r.Query.Encode())
+	}
+
+	server.ClearRequests()
+	if _, err := client.Delete(ctx, *group.ID); err != nil {
+		t.Fatalf("GroupsClient.Delete(): %v", err)
+	}
+	requests = server.Requests()
+	if len(requests) != 1 || requests[0].Method != http.MethodDelete || requests[0].Path != fmt.Sprintf("/groups/%s", *group.ID) {
+		t.Errorf("Server.Requests(): expected only the DELETE request after clearing, got %v", requests)
+	}
+}
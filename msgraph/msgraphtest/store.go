@@ -0,0 +1,419 @@
+package msgraphtest
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// collection describes a collection of objects served by the fake API.
+type collection struct {
+	// name is the path of the collection relative to the API version.
+	name string
+
+	// odataType is the type of objects in the collection. When empty, objects must specify their own @odata.type.
+	odataType string
+
+	// softDelete indicates that deleted objects are moved to the directory's deleted items, from where they can be
+	// restored.
+	softDelete bool
+
+	// readOnly indicates that objects cannot be created, updated or deleted.
+	readOnly bool
+
+	// immutable indicates that objects can be created, but cannot be updated or deleted.
+	immutable bool
+
+	// replaceable indicates that objects are updated by replacing them using PUT, rather than PATCH.
+	replaceable bool
+
+	// contained indicates that objects are only addressable via a navigation property of another object.
+	contained bool
+
+	// directoryObject indicates that objects are directory objects, which can be referenced by ID alone, for example
+	// as members of a group.
+	directoryObject bool
+}
+
+// key returns the key under which the object with the specified ID is stored. Directory objects share a single
+// namespace, whereas other objects are stored per collection since their IDs are not necessarily unique, for example
+// built-in role definitions have the same IDs as the corresponding directory role templates.
+func (c *collection) key(id string) string {
+	if c.directoryObject {
+		return id
+	}
+	return c.name + "/" + id
+}
+
+var collections = []*collection{
+	{name: accessPackageAssignmentPolicies, odataType: "#microsoft.graph.accessPackageAssignmentPolicy", replaceable: true},
+	{name: accessPackageAssignmentRequests, odataType: "#microsoft.graph.accessPackageAssignmentRequest"},
+	{name: accessPackageAssignments, odataType: "#microsoft.graph.accessPackageAssignment", readOnly: true},
+	{name: accessPackageCatalogs, odataType: "#microsoft.graph.accessPackageCatalog"},
+	{name: accessPackageResourceRequests, odataType: "#microsoft.graph.accessPackageResourceRequest", immutable: true},
+	{name: accessPackages, odataType: "#microsoft.graph.accessPackage"},
+	{name: accessReviewDefinitions, odataType: "#microsoft.graph.accessReviewScheduleDefinition", replaceable: true},
+	{name: "administrativeUnits", odataType: "#microsoft.graph.administrativeUnit", softDelete: true, directoryObject: true},
+	{name: "applications", odataType: "#microsoft.graph.application", softDelete: true, directoryObject: true},
+	{name: "devices", odataType: "#microsoft.graph.device", directoryObject: true},
+	{name: "directoryRoles", odataType: "#microsoft.graph.directoryRole", immutable: true, directoryObject: true},
+	{name: "directoryRoleTemplates", odataType: "#microsoft.graph.directoryRoleTemplate", readOnly: true, directoryObject: true},
+	{name: "groups", odataType: "#microsoft.graph.group", softDelete: true, directoryObject: true},
+	{name: "identity/conditionalAccess/namedLocations"},
+	{name: "identity/conditionalAccess/policies", odataType: "#microsoft.graph.conditionalAccessPolicy"},
+	{name: authenticationStrengthPolicies, odataType: "#microsoft.graph.authenticationStrengthPolicy"},
+	{name: roleAssignments, odataType: "#microsoft.graph.unifiedRoleAssignment"},
+	{name: roleAssignmentScheduleRequests, odataType: "#microsoft.graph.unifiedRoleAssignmentScheduleRequest", immutable: true},
+	{name: roleDefinitions, odataType: "#microsoft.graph.unifiedRoleDefinition"},
+	{name: roleEligibilitySchedules, odataType: "#microsoft.graph.unifiedRoleEligibilitySchedule", readOnly: true},
+	{name: roleEligibilityScheduleRequests, odataType: "#microsoft.graph.unifiedRoleEligibilityScheduleRequest", immutable: true},
+	{name: "servicePrincipals", odataType: "#microsoft.graph.servicePrincipal", directoryObject: true},
+	{name: "users", odataType: "#microsoft.graph.user", softDelete: true, directoryObject: true},
+
+	{name: accessPackageResourceRoles, odataType: "#microsoft.graph.accessPackageResourceRole", contained: true},
+	{name: accessPackageResourceRoleScopes, odataType: "#microsoft.graph.accessPackageResourceRoleScope", contained: true},
+	{name: accessPackageResources, odataType: "#microsoft.graph.accessPackageResource", contained: true},
+	{name: accessReviewDecisions, odataType: "#microsoft.graph.accessReviewInstanceDecisionItem", contained: true},
+	{name: accessReviewInstances, odataType: "#microsoft.graph.accessReviewInstance", contained: true},
+	{name: "appRoleAssignments", odataType: "#microsoft.graph.appRoleAssignment", contained: true},
+	{name: authenticationMethodConfigurations, contained: true},
+	{name: authenticationMethods, contained: true},
+	{name: authenticationMethodsPolicies, odataType: "#microsoft.graph.authenticationMethodsPolicy", contained: true},
+	{name: "scopedRoleMemberships", odataType: "#microsoft.graph.scopedRoleMembership", contained: true},
+}
+
+// collectionByName returns the collection with the specified name, or nil if there is no such collection.
+func collectionByName(name string) *collection {
+	for _, c := range collections {
+		if c.name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// isRootSegment returns whether segment is the first segment of a path served by the fake API.
+func isRootSegment(segment string) bool {
+	switch segment {
+	case "$batch", "directory", "identity", "identityGovernance", "policies", "roleManagement":
+		return true
+	}
+	c := collectionByName(segment)
+	return c != nil && !c.contained
+}
+
+// object is an object stored by the fake API.
+type object struct {
+	collection *collection
+	props      map[string]interface{}
+}
+
+func (o *object) id() string {
+	id, _ := o.props["id"].(string)
+	return id
+}
+
+func (o *object) key() string {
+	return o.collection.key(o.id())
+}
+
+func (o *object) odataType() string {
+	if t, ok := o.props["@odata.type"].(string); ok {
+		return t
+	}
+	return o.collection.odataType
+}
+
+// render returns a copy of the object's properties for inclusion in a response. When fields is not empty, only the
+// named properties are included. When withType is true, the @odata.type of the object is always included.
+func (o *object) render(fields []string, withType bool) map[string]interface{} {
+	ret := make(map[string]interface{}, len(o.props)+1)
+	for k, v := range o.props {
+		if len(fields) == 0 || k == "id" || k == "@odata.type" || containsFold(fields, k) {
+			ret[k] = v
+		}
+	}
+	if withType && o.odataType() != "" {
+		ret["@odata.type"] = o.odataType()
+	}
+	return ret
+}
+
+// insert stores a new object in collection c, generating an ID if necessary.
+func (s *Server) insert(c *collection, props map[string]interface{}) *object {
+	if id, ok := props["id"].(string); !ok || id == "" {
+		props["id"] = newId()
+	}
+	o := &object{collection: c, props: props}
+	s.objects[o.key()] = o
+	s.order = append(s.order, o.key())
+	return o
+}
+
+// get returns the object with the specified ID in collection c, or nil if it does not exist. Users may also be
+// retrieved using their userPrincipalName.
+func (s *Server) get(c *collection, id string) *object {
+	if o, ok := s.objects[c.key(id)]; ok && o.collection == c {
+		return o
+	}
+	if c.name == "users" {
+		for _, o := range s.list(c) {
+			if upn, ok := o.props["userPrincipalName"].(string); ok && strings.EqualFold(upn, id) {
+				return o
+			}
+		}
+	}
+	return nil
+}
+
+// list returns all objects in collection c, in the order they were created.
+func (s *Server) list(c *collection) []*object {
+	ret := make([]*object, 0)
+	for _, id := range s.order {
+		if o, ok := s.objects[id]; ok && o.collection == c {
+			ret = append(ret, o)
+		}
+	}
+	return ret
+}
+
+// lookup returns the directory objects with the specified IDs, ignoring any that have been deleted.
+func (s *Server) lookup(ids []string) []*object {
+	ret := make([]*object, 0, len(ids))
+	for _, id := range ids {
+		if o, ok := s.objects[id]; ok {
+			ret = append(ret, o)
+		}
+	}
+	return ret
+}
+
+// remove deletes the object, moving it to the deleted items when its collection supports soft deletion.
+func (s *Server) remove(o *object) {
+	delete(s.objects, o.key())
+	if o.collection.softDelete {
+		o.props["deletedDateTime"] = now()
+		s.deleted[o.id()] = o
+	}
+}
+
+// page writes a page of objects matching the OData query options in the request, as a collection response.
+func (s *Server) page(r *request, objects []*object, context string, withType bool) (int, interface{}, *apiError) {
+	query := r.URL.Query()
+
+	matches, e := filterObjects(objects, query.Get("$filter"))
+	if e != nil {
+		return 0, nil, e
+	}
+	if orderBy := query.Get("$orderby"); orderBy != "" {
+		if e := sortObjects(matches, orderBy); e != nil {
+			return 0, nil, e
+		}
+	}
+
+	size := s.PageSize
+	if top := query.Get("$top"); top != "" {
+		n, err := strconv.Atoi(top)
+		if err != nil || n < 1 {
+			return 0, nil, badRequest(fmt.Sprintf("Invalid page size specified: '%s'.", top))
+		}
+		size = n
+	}
+	skip := 0
+	if token := query.Get("$skiptoken"); token != "" {
+		n, err := strconv.Atoi(token)
+		if err != nil || n < 0 {
+			return 0, nil, badRequest("Invalid skip token.")
+		}
+		skip = n
+	}
+	if skip > len(matches) {
+		skip = len(matches)
+	}
+	end := skip + size
+	if end > len(matches) {
+		end = len(matches)
+	}
+
+	fields := selected(query)
+	values := make([]interface{}, 0, end-skip)
+	for _, o := range matches[skip:end] {
+		values = append(values, o.render(fields, withType))
+	}
+	ret := map[string]interface{}{
+		"@odata.context": fmt.Sprintf("%s/$metadata#%s", r.base, context),
+		"value":          values,
+	}
+	if strings.EqualFold(query.Get("$count"), "true") {
+		ret["@odata.count"] = len(matches)
+	}
+	if end < len(matches) {
+		query.Set("$skiptoken", strconv.Itoa(end))
+		ret["@odata.nextLink"] = fmt.Sprintf("%s%s?%s", r.origin, r.URL.Path, query.Encode())
+	}
+	return http.StatusOK, ret, nil
+}
+
+// selected returns the properties specified by $select, or nil when all properties should be returned.
+func selected(query url.Values) []string {
+	sel := query.Get("$select")
+	if sel == "" {
+		return nil
+	}
+	fields := strings.Split(sel, ",")
+	for i := range fields {
+		fields[i] = strings.TrimSpace(fields[i])
+	}
+	return fields
+}
+
+// entity returns a single object as a response.
+func entity(r *request, o *object, context string) (int, interface{}, *apiError) {
+	ret := o.render(selected(r.URL.Query()), false)
+	ret["@odata.context"] = fmt.Sprintf("%s/$metadata#%s/$entity", r.base, context)
+	return http.StatusOK, ret, nil
+}
+
+var (
+	filterAnd        = regexp.MustCompile(`(?i)\s+and\s+`)
+	filterStartsWith = regexp.MustCompile(`(?i)^startswith\(\s*([\w/]+)\s*,\s*'((?:[^']|'')*)'\s*\)$`)
+	filterCompare    = regexp.MustCompile(`(?i)^([\w/]+)\s+(eq|ne)\s+(.+)$`)
+	guidLiteral      = regexp.MustCompile(`^[0-9a-fA-F-]{36}$`)
+)
+
+// filterObjects returns the objects matching a $filter expression. Only clauses using eq, ne and startswith, combined
+// using and, are supported.
+func filterObjects(objects []*object, filter string) ([]*object, *apiError) {
+	filter = strings.TrimSpace(filter)
+	if filter == "" {
+		return objects, nil
+	}
+
+	var predicates []func(o *object) bool
+	for _, clause := range filterAnd.Split(filter, -1) {
+		clause = strings.TrimSpace(clause)
+		if m := filterStartsWith.FindStringSubmatch(clause); m != nil {
+			name, prefix := m[1], strings.ToLower(strings.ReplaceAll(m[2], "''", "'"))
+			predicates = append(predicates, func(o *object) bool {
+				v, ok := property(o, name).(string)
+				return ok && strings.HasPrefix(strings.ToLower(v), prefix)
+			})
+			continue
+		}
+		if m := filterCompare.FindStringSubmatch(clause); m != nil {
+			name, negate := m[1], strings.EqualFold(m[2], "ne")
+			value, ok := parseLiteral(strings.TrimSpace(m[3]))
+			if !ok {
+				return nil, badRequest(fmt.Sprintf("Invalid filter clause: %s", clause))
+			}
+			predicates = append(predicates, func(o *object) bool {
+				return equal(property(o, name), value) != negate
+			})
+			continue
+		}
+		return nil, &apiError{
+			status:  http.StatusBadRequest,
+			code:    "Request_UnsupportedQuery",
+			message: fmt.Sprintf("Unsupported or invalid query filter clause specified: %s", clause),
+		}
+	}
+
+	ret := make([]*object, 0, len(objects))
+	for _, o := range objects {
+		match := true
+		for _, p := range predicates {
+			if !p(o) {
+				match = false
+				break
+			}
+		}
+		if match {
+			ret = append(ret, o)
+		}
+	}
+	return ret, nil
+}
+
+// sortObjects sorts objects in place according to an $orderby expression with a single property.
+func sortObjects(objects []*object, orderBy string) *apiError {
+	fields := strings.Fields(orderBy)
+	if len(fields) == 0 || len(fields) > 2 || (len(fields) == 2 && !strings.EqualFold(fields[1], "asc") && !strings.EqualFold(fields[1], "desc")) {
+		return badRequest(fmt.Sprintf("Invalid orderby clause: %s", orderBy))
+	}
+	desc := len(fields) == 2 && strings.EqualFold(fields[1], "desc")
+	sort.SliceStable(objects, func(i, j int) bool {
+		a := strings.ToLower(fmt.Sprint(property(objects[i], fields[0])))
+		b := strings.ToLower(fmt.Sprint(property(objects[j], fields[0])))
+		if desc {
+			return a > b
+		}
+		return a < b
+	})
+	return nil
+}
+
+// property returns the value of a property of o, which may be a path to a nested property such as
+// "passwordProfile/forceChangePasswordNextSignIn".
+func property(o *object, path string) interface{} {
+	var v interface{} = o.props
+	for _, p := range strings.Split(path, "/") {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		v = m[p]
+	}
+	return v
+}
+
+// parseLiteral parses an OData literal value from a $filter expression.
+func parseLiteral(s string) (interface{}, bool) {
+	switch {
+	case len(s) >= 2 && strings.HasPrefix(s, "'") && strings.HasSuffix(s, "'"):
+		return strings.ReplaceAll(s[1:len(s)-1], "''", "'"), true
+	case strings.EqualFold(s, "null"):
+		return nil, true
+	case strings.EqualFold(s, "true"):
+		return true, true
+	case strings.EqualFold(s, "false"):
+		return false, true
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return f, true
+	}
+	if guidLiteral.MatchString(s) {
+		return s, true
+	}
+	return nil, false
+}
+
+// equal compares a property value with a literal. Strings are compared case-insensitively, as with the real API.
+func equal(v, literal interface{}) bool {
+	switch l := literal.(type) {
+	case nil:
+		return v == nil
+	case string:
+		s, ok := v.(string)
+		return ok && strings.EqualFold(s, l)
+	default:
+		return v == l
+	}
+}
+
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func now() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
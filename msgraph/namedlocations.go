@@ -3,10 +3,7 @@ package msgraph
 import (
 	"context"
 	"encoding/json"
-	"fmt"
-	"io/ioutil"
-	"net/http"
-	"net/url"
+	"errors"
 
 	"github.com/manicminer/hamilton/internal/utils"
 	"github.com/manicminer/hamilton/odata"
@@ -24,201 +21,109 @@ func NewNamedLocationsClient(tenantId string) *NamedLocationsClient {
 	}
 }
 
-// List returns a list of Named Locations, optionally filtered using OData.
-func (c *NamedLocationsClient) List(ctx context.Context, filter string) (*[]NamedLocation, int, error) {
-	params := url.Values{}
-	if filter != "" {
-		params.Add("$filter", filter)
-	}
-
-	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
-		ValidStatusCodes: []int{http.StatusOK},
-		Uri: Uri{
-			Entity:      "/identity/conditionalAccess/namedLocations",
-			Params:      params,
-			HasTenantId: true,
-		},
-	})
-
-	if err != nil {
-		return nil, status, fmt.Errorf("NamedLocationsClient.BaseClient.Get(): %v", err)
-	}
-
-	defer resp.Body.Close()
-	respBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, status, fmt.Errorf("ioutil.ReadAll(): %v", err)
-	}
-
-	var data struct {
-		NamedLocations *[]json.RawMessage `json:"value"`
-	}
-
-	if err := json.Unmarshal(respBody, &data); err != nil {
-		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
-	}
-
-	// The Graph API returns a mixture of types, this loop matches up the result to the appropriate model
-	var ret []NamedLocation
-
-	if data.NamedLocations == nil {
-		// Treat this as no result
-		return &ret, status, nil
+// resource returns a Resource for performing common operations on Named Locations.
+func (c *NamedLocationsClient) resource() Resource {
+	return Resource{
+		Client: c.BaseClient,
+		Name:   "NamedLocationsClient",
+		Entity: "/identity/conditionalAccess/namedLocations",
 	}
+}
 
-	for _, namedLocation := range *data.NamedLocations {
-		var o odata.OData
-		if err := json.Unmarshal(namedLocation, &o); err != nil {
-			return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
-		}
+// namedLocationTypes are the Named Location types which are decoded by List.
+var namedLocationTypes = odataTypes{
+	"#microsoft.graph.countryNamedLocation": func() interface{} { return &CountryNamedLocation{} },
+	"#microsoft.graph.ipNamedLocation":      func() interface{} { return &IPNamedLocation{} },
+}
 
-		if o.Type == nil {
-			continue
+// NamedLocations is a collection of Named Locations of mixed types. Each location is unmarshaled as a
+// CountryNamedLocation or an IPNamedLocation, so a *NamedLocations can be passed to Pager{}.Next() to retrieve typed
+// locations. Locations of other types are skipped.
+type NamedLocations []NamedLocation
+
+// UnmarshalJSON unmarshals a JSON array of Named Locations.
+func (n *NamedLocations) UnmarshalJSON(data []byte) error {
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+	ret := make(NamedLocations, 0, len(items))
+	for _, item := range items {
+		v, err := namedLocationTypes.unmarshal(item)
+		if err != nil {
+			return err
 		}
-		switch *o.Type {
-		case "#microsoft.graph.countryNamedLocation":
-			var loc CountryNamedLocation
-			if err := json.Unmarshal(namedLocation, &loc); err != nil {
-				return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
-			}
-			ret = append(ret, loc)
-		case "#microsoft.graph.ipNamedLocation":
-			var loc IPNamedLocation
-			if err := json.Unmarshal(namedLocation, &loc); err != nil {
-				return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
-			}
-			ret = append(ret, loc)
+		switch loc := v.(type) {
+		case *CountryNamedLocation:
+			ret = append(ret, *loc)
+		case *IPNamedLocation:
+			ret = append(ret, *loc)
 		}
 	}
+	*n = ret
+	return nil
+}
 
+// List returns a list of Named Locations, optionally queried using OData.
+func (c *NamedLocationsClient) List(ctx context.Context, query odata.Query) (*[]NamedLocation, int, error) {
+	var namedLocations NamedLocations
+	status, err := c.resource().List(ctx, query, &namedLocations)
+	if err != nil {
+		return nil, status, err
+	}
+	ret := []NamedLocation(namedLocations)
 	return &ret, status, nil
+}
 
+// ListPager returns a Pager for retrieving Named Locations one page at a time, optionally queried using OData. Pass a
+// *NamedLocations to Pager{}.Next() to retrieve typed locations.
+func (c *NamedLocationsClient) ListPager(query odata.Query) *Pager {
+	return c.resource().ListPager(query)
 }
 
 // Delete removes a Named Location.
 func (c *NamedLocationsClient) Delete(ctx context.Context, id string) (int, error) {
-	_, status, _, err := c.BaseClient.Delete(ctx, DeleteHttpRequestInput{
-		ValidStatusCodes: []int{http.StatusNoContent},
-		Uri: Uri{
-			Entity:      fmt.Sprintf("/identity/conditionalAccess/namedLocations/%s", id),
-			HasTenantId: true,
-		},
-	})
-	if err != nil {
-		return status, fmt.Errorf("NamedLocationsClient.BaseClient.Delete(): %v", err)
-	}
-	return status, nil
+	return c.resource().Delete(ctx, id)
 }
 
 // CreateIP creates a new IP Named Location.
 func (c *NamedLocationsClient) CreateIP(ctx context.Context, ipNamedLocation IPNamedLocation) (*IPNamedLocation, int, error) {
-	var status int
-
 	ipNamedLocation.ODataType = utils.StringPtr("#microsoft.graph.ipNamedLocation")
-	body, err := json.Marshal(ipNamedLocation)
-	if err != nil {
-		return nil, status, fmt.Errorf("json.Marshal(): %v", err)
-	}
-	resp, status, _, err := c.BaseClient.Post(ctx, PostHttpRequestInput{
-		Body:             body,
-		ValidStatusCodes: []int{http.StatusCreated},
-		Uri: Uri{
-			Entity:      "/identity/conditionalAccess/namedLocations",
-			HasTenantId: true,
-		},
-	})
-	if err != nil {
-		return nil, status, fmt.Errorf("NamedLocationsClient.BaseClient.Post(): %v", err)
-	}
-	defer resp.Body.Close()
-	respBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, status, fmt.Errorf("ioutil.ReadAll(): %v", err)
-	}
 	var newIPNamedLocation IPNamedLocation
-	if err := json.Unmarshal(respBody, &newIPNamedLocation); err != nil {
-		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	status, err := c.resource().Create(ctx, ipNamedLocation, &newIPNamedLocation)
+	if err != nil {
+		return nil, status, err
 	}
 	return &newIPNamedLocation, status, nil
 }
 
 // CreateCountry creates a new Country Named Location.
 func (c *NamedLocationsClient) CreateCountry(ctx context.Context, countryNamedLocation CountryNamedLocation) (*CountryNamedLocation, int, error) {
-	var status int
-
 	countryNamedLocation.ODataType = utils.StringPtr("#microsoft.graph.countryNamedLocation")
-
-	body, err := json.Marshal(countryNamedLocation)
-	if err != nil {
-		return nil, status, fmt.Errorf("json.Marshal(): %v", err)
-	}
-	resp, status, _, err := c.BaseClient.Post(ctx, PostHttpRequestInput{
-		Body:             body,
-		ValidStatusCodes: []int{http.StatusCreated},
-		Uri: Uri{
-			Entity:      "/identity/conditionalAccess/namedLocations",
-			HasTenantId: true,
-		},
-	})
-	if err != nil {
-		return nil, status, fmt.Errorf("NamedLocationsClient.BaseClient.Post(): %v", err)
-	}
-	defer resp.Body.Close()
-	respBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, status, fmt.Errorf("ioutil.ReadAll(): %v", err)
-	}
 	var newCountryNamedLocation CountryNamedLocation
-	if err := json.Unmarshal(respBody, &newCountryNamedLocation); err != nil {
-		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	status, err := c.resource().Create(ctx, countryNamedLocation, &newCountryNamedLocation)
+	if err != nil {
+		return nil, status, err
 	}
 	return &newCountryNamedLocation, status, nil
 }
 
 // GetIP retrieves an IP Named Location.
 func (c *NamedLocationsClient) GetIP(ctx context.Context, id string) (*IPNamedLocation, int, error) {
-	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
-		ValidStatusCodes: []int{http.StatusOK},
-		Uri: Uri{
-			Entity:      fmt.Sprintf("/identity/conditionalAccess/namedLocations/%s", id),
-			HasTenantId: true,
-		},
-	})
-	if err != nil {
-		return nil, status, fmt.Errorf("NamedLocationsClient.BaseClient.Get(): %v", err)
-	}
-	defer resp.Body.Close()
-	respBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, status, fmt.Errorf("ioutil.ReadAll(): %v", err)
-	}
 	var ipNamedLocation IPNamedLocation
-	if err := json.Unmarshal(respBody, &ipNamedLocation); err != nil {
-		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	status, err := c.resource().Get(ctx, id, odata.Query{}, &ipNamedLocation)
+	if err != nil {
+		return nil, status, err
 	}
 	return &ipNamedLocation, status, nil
 }
 
 // GetCountry retrieves an Country Named Location.
 func (c *NamedLocationsClient) GetCountry(ctx context.Context, id string) (*CountryNamedLocation, int, error) {
-	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
-		ValidStatusCodes: []int{http.StatusOK},
-		Uri: Uri{
-			Entity:      fmt.Sprintf("/identity/conditionalAccess/namedLocations/%s", id),
-			HasTenantId: true,
-		},
-	})
-	if err != nil {
-		return nil, status, fmt.Errorf("NamedLocationsClient.BaseClient.Get(): %v", err)
-	}
-	defer resp.Body.Close()
-	respBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, status, fmt.Errorf("ioutil.ReadAll(): %v", err)
-	}
 	var countryNamedLocation CountryNamedLocation
-	if err := json.Unmarshal(respBody, &countryNamedLocation); err != nil {
-		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	status, err := c.resource().Get(ctx, id, odata.Query{}, &countryNamedLocation)
+	if err != nil {
+		return nil, status, err
 	}
 	return &countryNamedLocation, status, nil
 }
@@ -226,43 +131,17 @@ func (c *NamedLocationsClient) GetCountry(ctx context.Context, id string) (*Coun
 // UpdateIP amends an existing IP Named Location.
 func (c *NamedLocationsClient) UpdateIP(ctx context.Context, ipNamedLocation IPNamedLocation) (int, error) {
 	var status int
-
-	body, err := json.Marshal(ipNamedLocation)
-	if err != nil {
-		return status, fmt.Errorf("json.Marshal(): %v", err)
-	}
-	_, status, _, err = c.BaseClient.Patch(ctx, PatchHttpRequestInput{
-		Body:             body,
-		ValidStatusCodes: []int{http.StatusNoContent},
-		Uri: Uri{
-			Entity:      fmt.Sprintf("/identity/conditionalAccess/namedLocations/%s", *ipNamedLocation.ID),
-			HasTenantId: true,
-		},
-	})
-	if err != nil {
-		return status, fmt.Errorf("NamedLocationsClient.BaseClient.Patch(): %v", err)
+	if ipNamedLocation.ID == nil {
+		return status, errors.New("NamedLocationsClient.UpdateIP(): cannot update IP named location with nil ID")
 	}
-	return status, nil
+	return c.resource().Update(ctx, *ipNamedLocation.ID, ipNamedLocation)
 }
 
 // UpdateCountry amends an existing Country Named Location.
 func (c *NamedLocationsClient) UpdateCountry(ctx context.Context, countryNamedLocation CountryNamedLocation) (int, error) {
 	var status int
-
-	body, err := json.Marshal(countryNamedLocation)
-	if err != nil {
-		return status, fmt.Errorf("json.Marshal(): %v", err)
-	}
-	_, status, _, err = c.BaseClient.Patch(ctx, PatchHttpRequestInput{
-		Body:             body,
-		ValidStatusCodes: []int{http.StatusNoContent},
-		Uri: Uri{
-			Entity:      fmt.Sprintf("/identity/conditionalAccess/namedLocations/%s", *countryNamedLocation.ID),
-			HasTenantId: true,
-		},
-	})
-	if err != nil {
-		return status, fmt.Errorf("NamedLocationsClient.BaseClient.Patch(): %v", err)
+	if countryNamedLocation.ID == nil {
+		return status, errors.New("NamedLocationsClient.UpdateCountry(): cannot update country named location with nil ID")
 	}
-	return status, nil
+	return c.resource().Update(ctx, *countryNamedLocation.ID, countryNamedLocation)
 }
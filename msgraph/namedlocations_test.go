@@ -1,3 +1,6 @@
+//go:build live
+// +build live
+
 package msgraph_test
 
 import (
@@ -8,6 +11,7 @@ import (
 	"github.com/manicminer/hamilton/internal/test"
 	"github.com/manicminer/hamilton/internal/utils"
 	"github.com/manicminer/hamilton/msgraph"
+	"github.com/manicminer/hamilton/odata"
 )
 
 type NamedLocationsClientTest struct {
@@ -59,7 +63,7 @@ func TestNamedLocationsClient(t *testing.T) {
 	testNamedLocationsClient_UpdateIP(t, c, *ipNamedLocation)
 	testNamedLocationsClient_UpdateCountry(t, c, *countryNamedLocation)
 
-	testNamedLocationsClient_List(t, c, "")
+	testNamedLocationsClient_List(t, c, odata.Query{})
 	// Running get after the update to give the API a chance to catch up
 	testNamedLocationsClient_GetIP(t, c, *ipNamedLocation.ID)
 	testNamedLocationsClient_GetCountry(t, c, *countryNamedLocation.ID)
@@ -150,8 +154,8 @@ func testNamedLocationsClient_UpdateCountry(t *testing.T, c NamedLocationsClient
 	}
 }
 
-func testNamedLocationsClient_List(t *testing.T, c NamedLocationsClientTest, f string) (namedLocations *[]msgraph.NamedLocation) {
-	namedLocations, _, err := c.client.List(c.connection.Context, f)
+func testNamedLocationsClient_List(t *testing.T, c NamedLocationsClientTest, query odata.Query) (namedLocations *[]msgraph.NamedLocation) {
+	namedLocations, _, err := c.client.List(c.connection.Context, query)
 	if err != nil {
 		t.Fatalf("NamedLocationsClient.List(): %v", err)
 	}
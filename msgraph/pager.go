@@ -0,0 +1,141 @@
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+
+	"github.com/manicminer/hamilton/odata"
+)
+
+// Pager retrieves a collection from Microsoft Graph one page at a time, making a single request each time Next is
+// called. Iteration can be stopped at any time and resumed later from the value returned by NextLink.
+type Pager struct {
+	client    Client
+	input     GetHttpRequestInput
+	count     *int
+	deltaLink *string
+	nextLink  *string
+	started   bool
+}
+
+// NewPager returns a Pager for the collection described by input. No requests are made until Next is called.
+func (c Client) NewPager(input GetHttpRequestInput) *Pager {
+	return &Pager{
+		client: c,
+		input:  input,
+	}
+}
+
+// ResumePager returns a Pager that continues from a nextLink saved from a previous Pager. The nextLink already
+// includes the query options of the original request, but query should be the same odata.Query used to create the
+// original Pager so that headers such as ConsistencyLevel are also sent with each request.
+func (c Client) ResumePager(nextLink string, query odata.Query) *Pager {
+	return &Pager{
+		client: c,
+		input: GetHttpRequestInput{
+			OData:            query,
+			ValidStatusCodes: []int{http.StatusOK},
+		},
+		nextLink: &nextLink,
+		started:  true,
+	}
+}
+
+// More reports whether there are more pages to retrieve.
+func (p *Pager) More() bool {
+	return !p.started || p.nextLink != nil
+}
+
+// Count returns the total number of items in the collection, when requested using odata.Query{}.Count. Microsoft Graph
+// only returns @odata.count with the first page, so this is nil until Next has been called, and for a Pager created
+// using Client.ResumePager.
+func (p *Pager) Count() *int {
+	return p.count
+}
+
+// NextLink returns the link to the next page, or nil when there are no more pages.
+// This can be persisted and later passed to Client.ResumePager to continue iteration.
+func (p *Pager) NextLink() *string {
+	return p.nextLink
+}
+
+// Next retrieves the next page of the collection and unmarshals the items it contains into v, which should be a
+// pointer to a slice of a suitable model. When v is nil the items are left undecoded in the Value field of the
+// returned OData.
+func (p *Pager) Next(ctx context.Context, v interface{}) (*odata.OData, int, error) {
+	var status int
+	if !p.More() {
+		return nil, status, errors.New("no more pages")
+	}
+
+	input := p.input
+	if p.nextLink != nil {
+		input.rawUri = *p.nextLink
+	}
+
+	resp, status, _, err := p.client.Get(ctx, input)
+	if err != nil {
+		return nil, status, fmt.Errorf("Pager.client.Get(): %w", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("ioutil.ReadAll(): %v", err)
+	}
+
+	var page odata.OData
+	if err := json.Unmarshal(respBody, &page); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	p.started = true
+	p.deltaLink = page.DeltaLink
+	p.nextLink = page.NextLink
+	if page.Count != nil {
+		p.count = page.Count
+	}
+
+	if v != nil {
+		var data struct {
+			Value json.RawMessage `json:"value"`
+		}
+		if err := json.Unmarshal(respBody, &data); err != nil {
+			return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+		}
+		if len(data.Value) > 0 {
+			if err := json.Unmarshal(data.Value, v); err != nil {
+				return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+			}
+		}
+	}
+
+	return &page, status, nil
+}
+
+// all retrieves each remaining page in turn, appending the items it contains to v, which should be a pointer to a
+// slice of a suitable model. Only the items are retained, so the collection is never buffered as a single response.
+func (p *Pager) all(ctx context.Context, v interface{}) (int, error) {
+	var status int
+	items := reflect.ValueOf(v)
+	if items.Kind() != reflect.Ptr || items.Elem().Kind() != reflect.Slice {
+		return status, fmt.Errorf("expected a pointer to a slice, got %T", v)
+	}
+	items = items.Elem()
+	if items.IsNil() {
+		items.Set(reflect.MakeSlice(items.Type(), 0, 0))
+	}
+	for p.More() {
+		page := reflect.New(items.Type())
+		var err error
+		if _, status, err = p.Next(ctx, page.Interface()); err != nil {
+			return status, err
+		}
+		items.Set(reflect.AppendSlice(items, page.Elem()))
+	}
+	return status, nil
+}
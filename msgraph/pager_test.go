@@ -0,0 +1,205 @@
+package msgraph_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/manicminer/hamilton/environments"
+	"github.com/manicminer/hamilton/msgraph"
+	"github.com/manicminer/hamilton/odata"
+)
+
+func newPagedServer(pages int) *httptest.Server {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := 0
+		if p := r.URL.Query().Get("page"); p != "" {
+			if _, err := fmt.Sscanf(p, "%d", &page); err != nil {
+				http.Error(w, "invalid page", http.StatusBadRequest)
+				return
+			}
+		}
+		nextLink := ""
+		if page < pages-1 {
+			nextLink = fmt.Sprintf(`"@odata.nextLink": "%s%s?page=%d",`, server.URL, r.URL.Path, page+1)
+		}
+		if page == 0 && r.URL.Query().Get("$count") == "true" {
+			if r.Header.Get("ConsistencyLevel") != "eventual" {
+				http.Error(w, "advanced query requires ConsistencyLevel header", http.StatusBadRequest)
+				return
+			}
+			nextLink = fmt.Sprintf(`"@odata.count": %d, %s`, pages*2, nextLink)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{%s "value": [{"id": "user-%d-a"}, {"id": "user-%d-b"}]}`, nextLink, page, page)
+	}))
+	return server
+}
+
+func TestPager(t *testing.T) {
+	server := newPagedServer(3)
+	defer server.Close()
+
+	client := msgraph.NewUsersClient("tenant")
+	client.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+	ctx := context.Background()
+
+	pager := client.ListPager(odata.Query{})
+	var savedLink string
+	var ids []string
+	for pager.More() {
+		var users []msgraph.User
+		if _, _, err := pager.Next(ctx, &users); err != nil {
+			t.Fatalf("Pager.Next(): %v", err)
+		}
+		for _, u := range users {
+			ids = append(ids, *u.ID)
+		}
+		if len(ids) == 2 {
+			// stop early and save our position
+			savedLink = *pager.NextLink()
+			break
+		}
+	}
+	if len(ids) != 2 {
+		t.Fatalf("expected 2 users before stopping, got %d", len(ids))
+	}
+
+	pager = client.BaseClient.ResumePager(savedLink, odata.Query{})
+	for pager.More() {
+		var users []msgraph.User
+		if _, _, err := pager.Next(ctx, &users); err != nil {
+			t.Fatalf("Pager.Next(): %v", err)
+		}
+		for _, u := range users {
+			ids = append(ids, *u.ID)
+		}
+	}
+	if len(ids) != 6 {
+		t.Fatalf("expected 6 users after resuming, got %d", len(ids))
+	}
+	if ids[5] != "user-2-b" {
+		t.Fatalf("expected last user to be %q, got %q", "user-2-b", ids[5])
+	}
+
+	if _, _, err := pager.Next(ctx, nil); err == nil {
+		t.Fatal("expected an error when calling Pager.Next() with no more pages")
+	}
+}
+
+func TestClientGetSinglePage(t *testing.T) {
+	server := newPagedServer(3)
+	defer server.Close()
+
+	client := msgraph.NewClient(msgraph.Version10, "tenant")
+	client.Endpoint = environments.ApiEndpoint(server.URL)
+
+	resp, _, _, err := client.Get(context.Background(), msgraph.GetHttpRequestInput{
+		ValidStatusCodes: []int{http.StatusOK},
+		Uri:              msgraph.Uri{Entity: "/users", HasTenantId: true},
+	})
+	if err != nil {
+		t.Fatalf("Client.Get(): %v", err)
+	}
+	defer resp.Body.Close()
+	var page odata.OData
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		t.Fatalf("json.Decode(): %v", err)
+	}
+	if page.NextLink == nil || page.Value == nil || len(*page.Value) != 2 {
+		t.Fatalf("Client.Get(): expected the first page only, got %v", page)
+	}
+}
+
+func TestListRetrievesAllPages(t *testing.T) {
+	server := newPagedServer(50)
+	defer server.Close()
+
+	client := msgraph.NewUsersClient("tenant")
+	client.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+
+	users, _, err := client.List(context.Background(), odata.Query{})
+	if err != nil {
+		t.Fatalf("UsersClient.List(): %v", err)
+	}
+	if users == nil {
+		t.Fatal("UsersClient.List(): users was nil")
+	}
+	if len(*users) != 100 {
+		t.Fatalf("UsersClient.List(): expected 100 users, got %d", len(*users))
+	}
+}
+
+func TestPagerCount(t *testing.T) {
+	server := newPagedServer(3)
+	defer server.Close()
+
+	client := msgraph.NewGroupsClient("tenant")
+	client.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+
+	pager := client.ListPager(odata.Query{Count: true, Top: 2})
+	if pager.Count() != nil {
+		t.Fatal("Pager.Count(): expected nil before retrieving the first page")
+	}
+	o, _, err := pager.Next(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Pager.Next(): %v", err)
+	}
+	if o.Count == nil {
+		t.Fatal("Pager.Next(): expected @odata.count to be returned")
+	}
+	if *o.Count != 6 {
+		t.Fatalf("Pager.Next(): expected @odata.count of 6, got %d", *o.Count)
+	}
+	for pager.More() {
+		if _, _, err := pager.Next(context.Background(), nil); err != nil {
+			t.Fatalf("Pager.Next(): %v", err)
+		}
+	}
+	if count := pager.Count(); count == nil || *count != 6 {
+		t.Fatalf("Pager.Count(): expected a count of 6 after retrieving all pages, got %v", count)
+	}
+}
+
+func TestPagerResumeAdvancedQuery(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("$count") == "true" && r.Header.Get("ConsistencyLevel") != "eventual" {
+			http.Error(w, "advanced query requires ConsistencyLevel header", http.StatusBadRequest)
+			return
+		}
+		nextLink := ""
+		if r.URL.Query().Get("$skiptoken") == "" {
+			nextLink = fmt.Sprintf(`"@odata.nextLink": "%s%s?$count=true&$skiptoken=1",`, server.URL, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{%s "value": [{"id": "group-%s"}]}`, nextLink, r.URL.Query().Get("$skiptoken"))
+	}))
+	defer server.Close()
+
+	client := msgraph.NewGroupsClient("tenant")
+	client.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+	ctx := context.Background()
+	query := odata.Query{Count: true, Filter: "endsWith(mail,'@example.com')"}
+
+	pager := client.ListPager(query)
+	if _, _, err := pager.Next(ctx, nil); err != nil {
+		t.Fatalf("Pager.Next(): %v", err)
+	}
+	if pager.NextLink() == nil {
+		t.Fatal("Pager.NextLink(): expected a link to the next page")
+	}
+
+	pager = client.BaseClient.ResumePager(*pager.NextLink(), query)
+	var groups []msgraph.Group
+	if _, _, err := pager.Next(ctx, &groups); err != nil {
+		t.Fatalf("Pager.Next(): expected the resumed request to include the ConsistencyLevel header, got: %v", err)
+	}
+	if len(groups) != 1 || *groups[0].ID != "group-1" || pager.More() {
+		t.Fatalf("Pager.Next(): expected the last page, got %v", groups)
+	}
+}
@@ -0,0 +1,279 @@
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/manicminer/hamilton/odata"
+)
+
+// Resource performs common operations on a collection of entities, such as /users or
+// /identity/conditionalAccess/policies. Entity clients are built on Resource so that errors, pagination and query
+// options are handled consistently, and a new entity type can be supported by describing its collection:
+//
+//	r := Resource{Client: c.BaseClient, Name: "UsersClient", Entity: "/users", DeletedType: "microsoft.graph.user"}
+//	var users []User
+//	status, err := r.List(ctx, odata.Query{Top: 10}, &users)
+//
+// Methods which return entities unmarshal them into v, which should be a pointer to a suitable model, or to a slice
+// of a suitable model for methods which return collections.
+type Resource struct {
+	// Client is used to send requests.
+	Client Client
+
+	// Name identifies the calling client in error messages, e.g. "UsersClient".
+	Name string
+
+	// Entity is the path of the collection, relative to the API version and tenant ID, e.g. "/users". For singletons,
+	// such as "/policies/authenticationMethodsPolicy", Entity is the path of the singleton, which is retrieved and
+	// updated using GetSingleton and UpdateSingleton.
+	Entity string
+
+	// DeletedType is the OData type used to list deleted items of this entity type, e.g. "microsoft.graph.user".
+	// It should be empty for entities which are not retained as deleted items.
+	DeletedType string
+}
+
+// List retrieves the collection, optionally queried using OData, and unmarshals the items into v. All pages are
+// retrieved, so ListPager should be used for large collections.
+func (r Resource) List(ctx context.Context, query odata.Query, v interface{}) (int, error) {
+	return r.list(ctx, r.Entity, query, v)
+}
+
+// ListPager returns a Pager for retrieving the collection one page at a time, optionally queried using OData.
+func (r Resource) ListPager(query odata.Query) *Pager {
+	return r.Client.NewPager(r.getInput(r.Entity, query))
+}
+
+// Delta retrieves entities which have been created, updated or removed since a previous delta query, and unmarshals
+// them into v. Specify an empty deltaLink to retrieve all entities. The returned deltaLink should be persisted and
+// used to retrieve subsequent changes.
+func (r Resource) Delta(ctx context.Context, query odata.Query, deltaLink string, v interface{}) (*string, int, error) {
+	input := r.getInput(fmt.Sprintf("%s/delta", r.Entity), query)
+	if deltaLink != "" {
+		input.rawUri = deltaLink
+	}
+	pager := r.Client.NewPager(input)
+	status, err := pager.all(ctx, v)
+	if err != nil {
+		return nil, status, fmt.Errorf("%s.BaseClient.Get(): %w", r.Name, err)
+	}
+	return pager.deltaLink, status, nil
+}
+
+// Get retrieves the entity with the specified ID, optionally queried using OData, and unmarshals it into v.
+func (r Resource) Get(ctx context.Context, id string, query odata.Query, v interface{}) (int, error) {
+	var status int
+	path, err := r.path(id)
+	if err != nil {
+		return status, err
+	}
+	return r.get(ctx, r.getInput(path, query), v)
+}
+
+// GetSingleton retrieves the singleton entity at the path of the Resource, optionally queried using OData, and
+// unmarshals it into v.
+func (r Resource) GetSingleton(ctx context.Context, query odata.Query, v interface{}) (int, error) {
+	return r.get(ctx, r.getInput(r.Entity, query), v)
+}
+
+// Create creates a new entity from model, and unmarshals the entity returned by the API into v.
+func (r Resource) Create(ctx context.Context, model interface{}, v interface{}) (int, error) {
+	var status int
+	body, err := json.Marshal(model)
+	if err != nil {
+		return status, fmt.Errorf("json.Marshal(): %v", err)
+	}
+	resp, status, _, err := r.Client.Post(ctx, PostHttpRequestInput{
+		Body:             body,
+		ValidStatusCodes: []int{http.StatusCreated},
+		Uri: Uri{
+			Entity:      r.Entity,
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return status, fmt.Errorf("%s.BaseClient.Post(): %w", r.Name, err)
+	}
+	return status, decode(resp, v)
+}
+
+// Update amends the entity with the specified ID using model. Only fields which are set in model are changed.
+func (r Resource) Update(ctx context.Context, id string, model interface{}) (int, error) {
+	var status int
+	path, err := r.path(id)
+	if err != nil {
+		return status, err
+	}
+	return r.update(ctx, path, model)
+}
+
+// UpdateSingleton amends the singleton entity at the path of the Resource using model. Only fields which are set in
+// model are changed.
+func (r Resource) UpdateSingleton(ctx context.Context, model interface{}) (int, error) {
+	return r.update(ctx, r.Entity, model)
+}
+
+// Replace replaces the entity with the specified ID using model, for entities which are updated using PUT rather than
+// PATCH. Fields which are not set in model may be reset to their default values.
+func (r Resource) Replace(ctx context.Context, id string, model interface{}) (int, error) {
+	var status int
+	path, err := r.path(id)
+	if err != nil {
+		return status, err
+	}
+	body, err := json.Marshal(model)
+	if err != nil {
+		return status, fmt.Errorf("json.Marshal(): %v", err)
+	}
+	_, status, _, err = r.Client.Put(ctx, PutHttpRequestInput{
+		Body:             body,
+		ValidStatusCodes: []int{http.StatusOK, http.StatusNoContent},
+		Uri: Uri{
+			Entity:      path,
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return status, fmt.Errorf("%s.BaseClient.Put(): %w", r.Name, err)
+	}
+	return status, nil
+}
+
+// Delete removes the entity with the specified ID.
+func (r Resource) Delete(ctx context.Context, id string) (int, error) {
+	var status int
+	path, err := r.path(id)
+	if err != nil {
+		return status, err
+	}
+	_, status, _, err = r.Client.Delete(ctx, DeleteHttpRequestInput{
+		ValidStatusCodes: []int{http.StatusNoContent},
+		Uri: Uri{
+			Entity:      path,
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return status, fmt.Errorf("%s.BaseClient.Delete(): %w", r.Name, err)
+	}
+	return status, nil
+}
+
+// Action invokes the named action of the entity with the specified ID, such as "cancel" or "stop", which is expected to
+// return no content.
+func (r Resource) Action(ctx context.Context, id, action string) (int, error) {
+	var status int
+	path, err := r.path(id)
+	if err != nil {
+		return status, err
+	}
+	_, status, _, err = r.Client.Post(ctx, PostHttpRequestInput{
+		ValidStatusCodes: []int{http.StatusNoContent},
+		Uri: Uri{
+			Entity:      fmt.Sprintf("%s/%s", path, action),
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return status, fmt.Errorf("%s.BaseClient.Post(): %w", r.Name, err)
+	}
+	return status, nil
+}
+
+// GetDeleted retrieves the deleted entity with the specified ID and unmarshals it into v.
+func (r Resource) GetDeleted(ctx context.Context, id string, query odata.Query, v interface{}) (int, error) {
+	return r.get(ctx, r.getInput(fmt.Sprintf("/directory/deletedItems/%s", id), query), v)
+}
+
+// ListDeleted retrieves recently deleted entities, optionally queried using OData, and unmarshals the items into v.
+func (r Resource) ListDeleted(ctx context.Context, query odata.Query, v interface{}) (int, error) {
+	return r.list(ctx, r.deletedPath(), query, v)
+}
+
+// ListDeletedPager returns a Pager for retrieving recently deleted entities one page at a time, optionally queried
+// using OData.
+func (r Resource) ListDeletedPager(query odata.Query) *Pager {
+	return r.Client.NewPager(r.getInput(r.deletedPath(), query))
+}
+
+// path returns the path of the entity with the specified ID. An empty ID is rejected, since it would otherwise address
+// the collection rather than an entity.
+func (r Resource) path(id string) (string, error) {
+	if id == "" {
+		return "", fmt.Errorf("%s: cannot address an entity with an empty ID", r.Name)
+	}
+	return fmt.Sprintf("%s/%s", r.Entity, id), nil
+}
+
+// deletedPath returns the path of the collection of deleted entities.
+func (r Resource) deletedPath() string {
+	return fmt.Sprintf("/directory/deleteditems/%s", r.DeletedType)
+}
+
+// getInput returns a GetHttpRequestInput for the specified path.
+func (r Resource) getInput(path string, query odata.Query) GetHttpRequestInput {
+	return GetHttpRequestInput{
+		OData:            query,
+		ValidStatusCodes: []int{http.StatusOK},
+		Uri: Uri{
+			Entity:      path,
+			HasTenantId: true,
+		},
+	}
+}
+
+// list retrieves every page of a collection using a Pager, and appends the items to v.
+func (r Resource) list(ctx context.Context, path string, query odata.Query, v interface{}) (int, error) {
+	status, err := r.Client.NewPager(r.getInput(path, query)).all(ctx, v)
+	if err != nil {
+		return status, fmt.Errorf("%s.BaseClient.Get(): %w", r.Name, err)
+	}
+	return status, nil
+}
+
+// update sends a PATCH request to path using model.
+func (r Resource) update(ctx context.Context, path string, model interface{}) (int, error) {
+	var status int
+	body, err := json.Marshal(model)
+	if err != nil {
+		return status, fmt.Errorf("json.Marshal(): %v", err)
+	}
+	_, status, _, err = r.Client.Patch(ctx, PatchHttpRequestInput{
+		Body:             body,
+		ValidStatusCodes: []int{http.StatusNoContent},
+		Uri: Uri{
+			Entity:      path,
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return status, fmt.Errorf("%s.BaseClient.Patch(): %w", r.Name, err)
+	}
+	return status, nil
+}
+
+// get sends a GET request and unmarshals the response into v.
+func (r Resource) get(ctx context.Context, input GetHttpRequestInput, v interface{}) (int, error) {
+	resp, status, _, err := r.Client.Get(ctx, input)
+	if err != nil {
+		return status, fmt.Errorf("%s.BaseClient.Get(): %w", r.Name, err)
+	}
+	return status, decode(resp, v)
+}
+
+// decode reads and closes the body of resp, and unmarshals it into v.
+func decode(resp *http.Response, v interface{}) error {
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("ioutil.ReadAll(): %v", err)
+	}
+	if err := json.Unmarshal(respBody, v); err != nil {
+		return fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+	return nil
+}
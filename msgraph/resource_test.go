@@ -0,0 +1,171 @@
+package msgraph_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/manicminer/hamilton/errors"
+	"github.com/manicminer/hamilton/internal/utils"
+	"github.com/manicminer/hamilton/msgraph"
+	"github.com/manicminer/hamilton/msgraph/msgraphtest"
+	"github.com/manicminer/hamilton/odata"
+)
+
+func TestResource(t *testing.T) {
+	server := msgraphtest.NewServer()
+	defer server.Close()
+	server.PageSize = 2
+
+	client := msgraph.NewClient(msgraph.Version10, "tenant")
+	client.Endpoint = server.Endpoint()
+	r := msgraph.Resource{
+		Client:      client,
+		Name:        "TestClient",
+		Entity:      "/groups",
+		DeletedType: "microsoft.graph.group",
+	}
+	ctx := context.Background()
+
+	var ids []string
+	for i := 0; i < 3; i++ {
+		var group msgraph.Group
+		status, err := r.Create(ctx, msgraph.Group{
+			DisplayName:     utils.StringPtr(fmt.Sprintf("test-group-%d", i)),
+			MailEnabled:     utils.BoolPtr(false),
+			MailNickname:    utils.StringPtr(fmt.Sprintf("test-group-%d", i)),
+			SecurityEnabled: utils.BoolPtr(true),
+		}, &group)
+		if err != nil {
+			t.Fatalf("Resource.Create(): %v", err)
+		}
+		if status != http.StatusCreated || group.ID == nil {
+			t.Fatalf("Resource.Create(): expected a new group with status 201, got status %d", status)
+		}
+		ids = append(ids, *group.ID)
+	}
+
+	var groups []msgraph.Group
+	if _, err := r.List(ctx, odata.Query{}, &groups); err != nil {
+		t.Fatalf("Resource.List(): %v", err)
+	}
+	if len(groups) != 3 {
+		t.Errorf("Resource.List(): expected all pages to be retrieved, got %d groups", len(groups))
+	}
+
+	pager := r.ListPager(odata.Query{OrderBy: odata.OrderBy{Field: "displayName", Direction: odata.Descending}})
+	var page []msgraph.Group
+	if _, _, err := pager.Next(ctx, &page); err != nil {
+		t.Fatalf("Pager.Next(): %v", err)
+	}
+	if len(page) != 2 || *page[0].DisplayName != "test-group-2" || !pager.More() {
+		t.Errorf("Resource.ListPager(): expected a first page of 2 groups in descending order, got %d", len(page))
+	}
+
+	if _, err := r.Update(ctx, ids[0], msgraph.Group{Description: utils.StringPtr("updated")}); err != nil {
+		t.Fatalf("Resource.Update(): %v", err)
+	}
+	var group msgraph.Group
+	if _, err := r.Get(ctx, ids[0], odata.Query{Select: []string{"id", "description"}}, &group); err != nil {
+		t.Fatalf("Resource.Get(): %v", err)
+	}
+	if group.Description == nil || *group.Description != "updated" || group.DisplayName != nil {
+		t.Errorf("Resource.Get(): expected the selected properties of the updated group, got %+v", group)
+	}
+
+	// an empty ID must not address the collection
+	if status, err := r.Get(ctx, "", odata.Query{}, &group); err == nil || status != 0 {
+		t.Errorf("Resource.Get(): expected an error without sending a request for an empty ID, got status %d", status)
+	}
+	if _, err := r.Update(ctx, "", msgraph.Group{}); err == nil {
+		t.Errorf("Resource.Update(): expected an error for an empty ID")
+	}
+	if _, err := r.Delete(ctx, ""); err == nil {
+		t.Errorf("Resource.Delete(): expected an error for an empty ID")
+	}
+	if _, err := r.Action(ctx, "", "restore"); err == nil {
+		t.Errorf("Resource.Action(): expected an error for an empty ID")
+	}
+
+	if _, err := r.Delete(ctx, ids[0]); err != nil {
+		t.Fatalf("Resource.Delete(): %v", err)
+	}
+	status, err := r.Get(ctx, ids[0], odata.Query{}, &group)
+	if status != http.StatusNotFound || !errors.IsNotFound(err) {
+		t.Errorf("Resource.Get(): expected a not found error for a deleted group, got status %d: %v", status, err)
+	}
+	if err == nil || !strings.HasPrefix(err.Error(), "TestClient.BaseClient.Get(): ") {
+		t.Errorf("Resource.Get(): expected error to identify the client, got: %v", err)
+	}
+
+	var deleted []msgraph.Group
+	if _, err := r.ListDeleted(ctx, odata.Query{}, &deleted); err != nil {
+		t.Fatalf("Resource.ListDeleted(): %v", err)
+	}
+	if len(deleted) != 1 || *deleted[0].ID != ids[0] {
+		t.Errorf("Resource.ListDeleted(): expected the deleted group, got %d groups", len(deleted))
+	}
+	group = msgraph.Group{}
+	if _, err := r.GetDeleted(ctx, ids[0], odata.Query{}, &group); err != nil {
+		t.Fatalf("Resource.GetDeleted(): %v", err)
+	}
+	if group.DeletedDateTime == nil {
+		t.Errorf("Resource.GetDeleted(): expected deletedDateTime to be set")
+	}
+}
+
+// expectedRequest describes a request which a client is expected to send to msgraphtest.
+type expectedRequest struct {
+	method string
+	path   string
+
+	// body is a JSON object containing properties which the request body must include, or is empty when the request
+	// should not have a body.
+	body string
+}
+
+// expectRequests checks that the requests received by server since it was last checked match expected, and then
+// clears them.
+func expectRequests(t *testing.T, server *msgraphtest.Server, expected ...expectedRequest) {
+	t.Helper()
+	requests := server.Requests()
+	server.ClearRequests()
+	if len(requests) != len(expected) {
+		received := make([]string, 0, len(requests))
+		for _, r := range requests {
+			received = append(received, fmt.Sprintf("%s %s", r.Method, r.Path))
+		}
+		t.Errorf("expected %d requests, got %d: %v", len(expected), len(requests), received)
+		return
+	}
+	for i, e := range expected {
+		r := requests[i]
+		if r.Method != e.method || r.Path != e.path {
+			t.Errorf("request %d: expected %s %s, got %s %s", i, e.method, e.path, r.Method, r.Path)
+			continue
+		}
+		if e.body == "" {
+			if len(r.Body) != 0 {
+				t.Errorf("request %d: expected no body for %s %s, got %s", i, r.Method, r.Path, r.Body)
+			}
+			continue
+		}
+		var want, got map[string]interface{}
+		if err := json.Unmarshal([]byte(e.body), &want); err != nil {
+			t.Fatalf("request %d: invalid expected body %s: %v", i, e.body, err)
+		}
+		if err := json.Unmarshal(r.Body, &got); err != nil {
+			t.Errorf("request %d: expected a JSON body for %s %s, got %s", i, r.Method, r.Path, r.Body)
+			continue
+		}
+		for k, v := range want {
+			if gv, ok := got[k]; !ok || !reflect.DeepEqual(gv, v) {
+				t.Errorf("request %d: expected %s %s to send %q: %v, got %s", i, r.Method, r.Path, k, v, r.Body)
+			}
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package msgraph
+
+import (
+	"context"
+
+	"github.com/manicminer/hamilton/odata"
+)
+
+// RoleAssignmentScheduleRequestsClient performs operations on requests to grant, extend or remove active, optionally
+// time-bound, directory role assignments, using Privileged Identity Management.
+type RoleAssignmentScheduleRequestsClient struct {
+	BaseClient Client
+}
+
+// NewRoleAssignmentScheduleRequestsClient returns a new RoleAssignmentScheduleRequestsClient.
+func NewRoleAssignmentScheduleRequestsClient(tenantId string) *RoleAssignmentScheduleRequestsClient {
+	return &RoleAssignmentScheduleRequestsClient{
+		BaseClient: NewClient(Version10, tenantId),
+	}
+}
+
+// resource returns a Resource for performing common operations on Role Assignment Schedule Requests.
+func (c *RoleAssignmentScheduleRequestsClient) resource() Resource {
+	return Resource{
+		Client: c.BaseClient,
+		Name:   "RoleAssignmentScheduleRequestsClient",
+		Entity: "/roleManagement/directory/roleAssignmentScheduleRequests",
+	}
+}
+
+// List returns a list of Role Assignment Schedule Requests, optionally queried using OData.
+func (c *RoleAssignmentScheduleRequestsClient) List(ctx context.Context, query odata.Query) (*[]UnifiedRoleAssignmentScheduleRequest, int, error) {
+	var requests []UnifiedRoleAssignmentScheduleRequest
+	status, err := c.resource().List(ctx, query, &requests)
+	if err != nil {
+		return nil, status, err
+	}
+	return &requests, status, nil
+}
+
+// ListPager returns a Pager for retrieving Role Assignment Schedule Requests one page at a time, optionally queried
+// using OData.
+func (c *RoleAssignmentScheduleRequestsClient) ListPager(query odata.Query) *Pager {
+	return c.resource().ListPager(query)
+}
+
+// Get retrieves a Role Assignment Schedule Request.
+func (c *RoleAssignmentScheduleRequestsClient) Get(ctx context.Context, id string) (*UnifiedRoleAssignmentScheduleRequest, int, error) {
+	var request UnifiedRoleAssignmentScheduleRequest
+	status, err := c.resource().Get(ctx, id, odata.Query{}, &request)
+	if err != nil {
+		return nil, status, err
+	}
+	return &request, status, nil
+}
+
+// Create submits a new Role Assignment Schedule Request.
+func (c *RoleAssignmentScheduleRequestsClient) Create(ctx context.Context, request UnifiedRoleAssignmentScheduleRequest) (*UnifiedRoleAssignmentScheduleRequest, int, error) {
+	var newRequest UnifiedRoleAssignmentScheduleRequest
+	status, err := c.resource().Create(ctx, request, &newRequest)
+	if err != nil {
+		return nil, status, err
+	}
+	return &newRequest, status, nil
+}
+
+// Cancel cancels a Role Assignment Schedule Request which has not yet taken effect.
+func (c *RoleAssignmentScheduleRequestsClient) Cancel(ctx context.Context, id string) (int, error) {
+	return c.resource().Action(ctx, id, "cancel")
+}
@@ -0,0 +1,122 @@
+package msgraph_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/manicminer/hamilton/internal/utils"
+	"github.com/manicminer/hamilton/msgraph"
+	"github.com/manicminer/hamilton/msgraph/msgraphtest"
+	"github.com/manicminer/hamilton/odata"
+)
+
+func TestRoleAssignmentScheduleRequestsClient(t *testing.T) {
+	server := msgraphtest.NewServer()
+	defer server.Close()
+	ctx := context.Background()
+
+	client := msgraph.NewRoleAssignmentScheduleRequestsClient("tenant")
+	client.BaseClient.Endpoint = server.Endpoint()
+	eligibilityClient := msgraph.NewRoleEligibilityScheduleRequestsClient("tenant")
+	eligibilityClient.BaseClient.Endpoint = server.Endpoint()
+	assignmentsClient := msgraph.NewRoleAssignmentsClient("tenant")
+	assignmentsClient.BaseClient.Endpoint = server.Endpoint()
+	requestsPath := "/roleManagement/directory/roleAssignmentScheduleRequests"
+
+	userId := server.Add("users", msgraph.User{
+		DisplayName:       utils.StringPtr("test-user"),
+		UserPrincipalName: utils.StringPtr("test-user@example.com"),
+	})
+	activate := msgraph.UnifiedRoleScheduleRequestActionSelfActivate
+	expiration := msgraph.ExpirationPatternTypeAfterDuration
+	activation := msgraph.UnifiedRoleAssignmentScheduleRequest{
+		Action:           &activate,
+		DirectoryScopeId: utils.StringPtr("/"),
+		Justification:    utils.StringPtr("investigating an incident"),
+		PrincipalId:      utils.StringPtr(userId),
+		RoleDefinitionId: utils.StringPtr(userAdministratorRoleId),
+		ScheduleInfo: &msgraph.RequestSchedule{
+			Expiration: &msgraph.ExpirationPattern{
+				Duration: utils.StringPtr("PT8H"),
+				Type:     &expiration,
+			},
+		},
+	}
+
+	if _, _, err := client.Create(ctx, activation); err == nil {
+		t.Fatalf("RoleAssignmentScheduleRequestsClient.Create(): expected an error when activating a role without an eligibility")
+	}
+	activationBody := fmt.Sprintf(`{"action": "selfActivate", "directoryScopeId": "/", "justification": "investigating an incident", "principalId": %q, "roleDefinitionId": %q, "scheduleInfo": {"expiration": {"duration": "PT8H", "type": "afterDuration"}}}`, userId, userAdministratorRoleId)
+	expectRequests(t, server, expectedRequest{http.MethodPost, requestsPath, activationBody})
+
+	assign := msgraph.UnifiedRoleScheduleRequestActionAdminAssign
+	if _, _, err := eligibilityClient.Create(ctx, msgraph.UnifiedRoleEligibilityScheduleRequest{
+		Action:           &assign,
+		DirectoryScopeId: utils.StringPtr("/"),
+		PrincipalId:      utils.StringPtr(userId),
+		RoleDefinitionId: utils.StringPtr(userAdministratorRoleId),
+	}); err != nil {
+		t.Fatalf("RoleEligibilityScheduleRequestsClient.Create(): %v", err)
+	}
+	server.ClearRequests()
+
+	request, status, err := client.Create(ctx, activation)
+	if err != nil {
+		t.Fatalf("RoleAssignmentScheduleRequestsClient.Create(): %v", err)
+	}
+	if status != http.StatusCreated || request.ID == nil || request.TargetScheduleId == nil {
+		t.Fatalf("RoleAssignmentScheduleRequestsClient.Create(): expected a new request with status 201, got %v with status %d", request, status)
+	}
+	expectRequests(t, server, expectedRequest{http.MethodPost, requestsPath, activationBody})
+
+	roleAssignment, _, err := assignmentsClient.Get(ctx, *request.TargetScheduleId)
+	if err != nil {
+		t.Fatalf("RoleAssignmentsClient.Get(): %v", err)
+	}
+	if *roleAssignment.PrincipalId != userId {
+		t.Errorf("RoleAssignmentScheduleRequestsClient.Create(): expected the role to be assigned to the test user, got %v", roleAssignment)
+	}
+
+	requests, _, err := client.List(ctx, odata.Query{})
+	if err != nil {
+		t.Fatalf("RoleAssignmentScheduleRequestsClient.List(): %v", err)
+	}
+	if requests == nil || len(*requests) != 1 {
+		t.Fatalf("RoleAssignmentScheduleRequestsClient.List(): expected 1 request, got %v", requests)
+	}
+	expectRequests(t, server,
+		expectedRequest{http.MethodGet, "/roleManagement/directory/roleAssignments/" + *request.TargetScheduleId, ""},
+		expectedRequest{http.MethodGet, requestsPath, ""},
+	)
+
+	deactivate := msgraph.UnifiedRoleScheduleRequestActionSelfDeactivate
+	activation.Action = &deactivate
+	activation.ScheduleInfo = nil
+	request, _, err = client.Create(ctx, activation)
+	if err != nil {
+		t.Fatalf("RoleAssignmentScheduleRequestsClient.Create(): %v", err)
+	}
+	if _, status, err := assignmentsClient.Get(ctx, *request.TargetScheduleId); err == nil || status != http.StatusNotFound {
+		t.Errorf("RoleAssignmentScheduleRequestsClient.Create(): expected the role assignment to be removed, got status %d", status)
+	}
+
+	request, _, err = client.Get(ctx, *request.ID)
+	if err != nil {
+		t.Fatalf("RoleAssignmentScheduleRequestsClient.Get(): %v", err)
+	}
+	if *request.Action != deactivate {
+		t.Errorf("RoleAssignmentScheduleRequestsClient.Get(): unexpected request %v", request)
+	}
+	expectRequests(t, server,
+		expectedRequest{http.MethodPost, requestsPath, `{"action": "selfDeactivate"}`},
+		expectedRequest{http.MethodGet, "/roleManagement/directory/roleAssignments/" + *request.TargetScheduleId, ""},
+		expectedRequest{http.MethodGet, requestsPath + "/" + *request.ID, ""},
+	)
+
+	if _, err := client.Cancel(ctx, *request.ID); err != nil {
+		t.Fatalf("RoleAssignmentScheduleRequestsClient.Cancel(): %v", err)
+	}
+	expectRequests(t, server, expectedRequest{http.MethodPost, requestsPath + "/" + *request.ID + "/cancel", ""})
+}
@@ -0,0 +1,87 @@
+package msgraph
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/manicminer/hamilton/odata"
+)
+
+// DirectoryScopeTenant is the directory scope of role assignments which apply to the whole tenant.
+const DirectoryScopeTenant = "/"
+
+// AdministrativeUnitScope returns the directory scope of role assignments which apply only to the members of the
+// specified administrative unit.
+func AdministrativeUnitScope(administrativeUnitId string) string {
+	return fmt.Sprintf("/administrativeUnits/%s", administrativeUnitId)
+}
+
+// ObjectScope returns the directory scope of role assignments which apply only to the specified directory object, such
+// as an application registration.
+func ObjectScope(objectId string) string {
+	return fmt.Sprintf("/%s", objectId)
+}
+
+// RoleAssignmentsClient performs operations on directory role assignments using the unified role management API.
+type RoleAssignmentsClient struct {
+	BaseClient Client
+}
+
+// NewRoleAssignmentsClient returns a new RoleAssignmentsClient.
+func NewRoleAssignmentsClient(tenantId string) *RoleAssignmentsClient {
+	return &RoleAssignmentsClient{
+		BaseClient: NewClient(Version10, tenantId),
+	}
+}
+
+// resource returns a Resource for performing common operations on Role Assignments.
+func (c *RoleAssignmentsClient) resource() Resource {
+	return Resource{
+		Client: c.BaseClient,
+		Name:   "RoleAssignmentsClient",
+		Entity: "/roleManagement/directory/roleAssignments",
+	}
+}
+
+// List returns a list of Role Assignments, queried using OData. The API requires a $filter on the principalId or
+// roleDefinitionId of the assignments.
+func (c *RoleAssignmentsClient) List(ctx context.Context, query odata.Query) (*[]UnifiedRoleAssignment, int, error) {
+	var roleAssignments []UnifiedRoleAssignment
+	status, err := c.resource().List(ctx, query, &roleAssignments)
+	if err != nil {
+		return nil, status, err
+	}
+	return &roleAssignments, status, nil
+}
+
+// ListPager returns a Pager for retrieving Role Assignments one page at a time, queried using OData.
+func (c *RoleAssignmentsClient) ListPager(query odata.Query) *Pager {
+	return c.resource().ListPager(query)
+}
+
+// Get retrieves a Role Assignment.
+func (c *RoleAssignmentsClient) Get(ctx context.Context, id string) (*UnifiedRoleAssignment, int, error) {
+	var roleAssignment UnifiedRoleAssignment
+	status, err := c.resource().Get(ctx, id, odata.Query{}, &roleAssignment)
+	if err != nil {
+		return nil, status, err
+	}
+	return &roleAssignment, status, nil
+}
+
+// Create permanently assigns a role definition to a principal. The scope of the assignment is specified using either
+// its DirectoryScopeId, e.g. DirectoryScopeTenant, AdministrativeUnitScope() or ObjectScope(), or its AppScopeId for
+// roles with application-specific scopes.
+func (c *RoleAssignmentsClient) Create(ctx context.Context, roleAssignment UnifiedRoleAssignment) (*UnifiedRoleAssignment, int, error) {
+	var newRoleAssignment UnifiedRoleAssignment
+	status, err := c.resource().Create(ctx, roleAssignment, &newRoleAssignment)
+	if err != nil {
+		return nil, status, err
+	}
+	return &newRoleAssignment, status, nil
+}
+
+// Delete removes a Role Assignment.
+func (c *RoleAssignmentsClient) Delete(ctx context.Context, id string) (int, error) {
+	return c.resource().Delete(ctx, id)
+}
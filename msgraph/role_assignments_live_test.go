@@ -0,0 +1,136 @@
+//go:build live
+// +build live
+
+package msgraph_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/manicminer/hamilton/auth"
+	"github.com/manicminer/hamilton/internal/test"
+	"github.com/manicminer/hamilton/internal/utils"
+	"github.com/manicminer/hamilton/msgraph"
+	"github.com/manicminer/hamilton/odata"
+)
+
+type RoleAssignmentsClientTest struct {
+	connection        *test.Connection
+	client            *msgraph.RoleAssignmentsClient
+	definitionsClient *msgraph.RoleDefinitionsClient
+	randomString      string
+}
+
+func TestRoleAssignmentsClient_Live(t *testing.T) {
+	rs := test.RandomString()
+	c := RoleAssignmentsClientTest{
+		connection:   test.NewConnection(auth.MsGraph, auth.TokenVersion2),
+		randomString: rs,
+	}
+	c.client = msgraph.NewRoleAssignmentsClient(c.connection.AuthConfig.TenantID)
+	c.client.BaseClient.Authorizer = c.connection.Authorizer
+	c.definitionsClient = msgraph.NewRoleDefinitionsClient(c.connection.AuthConfig.TenantID)
+	c.definitionsClient.BaseClient.Authorizer = c.connection.Authorizer
+
+	u := UsersClientTest{
+		connection:   test.NewConnection(auth.MsGraph, auth.TokenVersion2),
+		randomString: rs,
+	}
+	u.client = msgraph.NewUsersClient(u.connection.AuthConfig.TenantID)
+	u.client.BaseClient.Authorizer = u.connection.Authorizer
+
+	user := testUsersClient_Create(t, u, msgraph.User{
+		AccountEnabled:    utils.BoolPtr(true),
+		DisplayName:       utils.StringPtr("test-user-role-assignment"),
+		MailNickname:      utils.StringPtr(fmt.Sprintf("test-user-role-assignment-%s", c.randomString)),
+		UserPrincipalName: utils.StringPtr(fmt.Sprintf("test-user-role-assignment-%s@%s", c.randomString, c.connection.DomainName)),
+		PasswordProfile: &msgraph.UserPasswordProfile{
+			Password: utils.StringPtr(fmt.Sprintf("IrPa55w0rd%s", c.randomString)),
+		},
+	})
+
+	roleDefinition := testRoleDefinitionsClient_GetByDisplayName(t, c, "User Administrator")
+	roleAssignment := testRoleAssignmentsClient_Create(t, c, msgraph.UnifiedRoleAssignment{
+		DirectoryScopeId: utils.StringPtr("/"),
+		PrincipalId:      user.ID,
+		RoleDefinitionId: roleDefinition.ID,
+	})
+	testRoleAssignmentsClient_Get(t, c, *roleAssignment.ID)
+	testRoleAssignmentsClient_List(t, c, *user.ID)
+	testRoleAssignmentsClient_Delete(t, c, *roleAssignment.ID)
+
+	testUsersClient_Delete(t, u, *user.ID)
+}
+
+func testRoleDefinitionsClient_GetByDisplayName(t *testing.T, c RoleAssignmentsClientTest, displayName string) (roleDefinition *msgraph.UnifiedRoleDefinition) {
+	roleDefinitions, _, err := c.definitionsClient.List(c.connection.Context, odata.Query{Filter: fmt.Sprintf("displayName eq '%s'", displayName)})
+	if err != nil {
+		t.Fatalf("RoleDefinitionsClient.List(): %v", err)
+	}
+	if roleDefinitions == nil || len(*roleDefinitions) != 1 {
+		t.Fatalf("RoleDefinitionsClient.List(): expected 1 role definition named %q, got %v", displayName, roleDefinitions)
+	}
+	roleDefinition, status, err := c.definitionsClient.Get(c.connection.Context, *(*roleDefinitions)[0].ID)
+	if err != nil {
+		t.Fatalf("RoleDefinitionsClient.Get(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("RoleDefinitionsClient.Get(): invalid status: %d", status)
+	}
+	if roleDefinition == nil || roleDefinition.IsBuiltIn == nil || !*roleDefinition.IsBuiltIn {
+		t.Fatalf("RoleDefinitionsClient.Get(): expected a built-in role definition, got %v", roleDefinition)
+	}
+	return
+}
+
+func testRoleAssignmentsClient_Create(t *testing.T, c RoleAssignmentsClientTest, r msgraph.UnifiedRoleAssignment) (roleAssignment *msgraph.UnifiedRoleAssignment) {
+	roleAssignment, status, err := c.client.Create(c.connection.Context, r)
+	if err != nil {
+		t.Fatalf("RoleAssignmentsClient.Create(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("RoleAssignmentsClient.Create(): invalid status: %d", status)
+	}
+	if roleAssignment == nil {
+		t.Fatal("RoleAssignmentsClient.Create(): roleAssignment was nil")
+	}
+	if roleAssignment.ID == nil {
+		t.Fatal("RoleAssignmentsClient.Create(): roleAssignment.ID was nil")
+	}
+	return
+}
+
+func testRoleAssignmentsClient_Get(t *testing.T, c RoleAssignmentsClientTest, id string) (roleAssignment *msgraph.UnifiedRoleAssignment) {
+	roleAssignment, status, err := c.client.Get(c.connection.Context, id)
+	if err != nil {
+		t.Fatalf("RoleAssignmentsClient.Get(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("RoleAssignmentsClient.Get(): invalid status: %d", status)
+	}
+	if roleAssignment == nil {
+		t.Fatal("RoleAssignmentsClient.Get(): roleAssignment was nil")
+	}
+	return
+}
+
+func testRoleAssignmentsClient_List(t *testing.T, c RoleAssignmentsClientTest, principalId string) (roleAssignments *[]msgraph.UnifiedRoleAssignment) {
+	roleAssignments, _, err := c.client.List(c.connection.Context, odata.Query{Filter: fmt.Sprintf("principalId eq '%s'", principalId)})
+	if err != nil {
+		t.Fatalf("RoleAssignmentsClient.List(): %v", err)
+	}
+	if roleAssignments == nil || len(*roleAssignments) != 1 {
+		t.Fatalf("RoleAssignmentsClient.List(): expected 1 role assignment, got %v", roleAssignments)
+	}
+	return
+}
+
+func testRoleAssignmentsClient_Delete(t *testing.T, c RoleAssignmentsClientTest, id string) {
+	status, err := c.client.Delete(c.connection.Context, id)
+	if err != nil {
+		t.Fatalf("RoleAssignmentsClient.Delete(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("RoleAssignmentsClient.Delete(): invalid status: %d", status)
+	}
+}
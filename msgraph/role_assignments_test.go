@@ -0,0 +1,131 @@
+package msgraph_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/manicminer/hamilton/internal/utils"
+	"github.com/manicminer/hamilton/msgraph"
+	"github.com/manicminer/hamilton/msgraph/msgraphtest"
+	"github.com/manicminer/hamilton/odata"
+)
+
+// userAdministratorRoleId is the ID of the built-in User Administrator role definition.
+const userAdministratorRoleId = "fe930be7-5e62-47db-91af-98c3a49a38b1"
+
+func TestRoleAssignmentsClient(t *testing.T) {
+	server := msgraphtest.NewServer()
+	defer server.Close()
+	ctx := context.Background()
+
+	client := msgraph.NewRoleAssignmentsClient("tenant")
+	client.BaseClient.Endpoint = server.Endpoint()
+	assignmentsPath := "/roleManagement/directory/roleAssignments"
+
+	userId := server.Add("users", msgraph.User{
+		DisplayName:       utils.StringPtr("test-user"),
+		UserPrincipalName: utils.StringPtr("test-user@example.com"),
+	})
+
+	roleAssignment, status, err := client.Create(ctx, msgraph.UnifiedRoleAssignment{
+		DirectoryScopeId: utils.StringPtr(msgraph.DirectoryScopeTenant),
+		PrincipalId:      utils.StringPtr(userId),
+		RoleDefinitionId: utils.StringPtr(userAdministratorRoleId),
+	})
+	if err != nil {
+		t.Fatalf("RoleAssignmentsClient.Create(): %v", err)
+	}
+	if status != http.StatusCreated || roleAssignment.ID == nil {
+		t.Fatalf("RoleAssignmentsClient.Create(): expected a new role assignment with status 201, got status %d", status)
+	}
+	expectRequests(t, server, expectedRequest{http.MethodPost, assignmentsPath, fmt.Sprintf(`{"directoryScopeId": "/", "principalId": %q, "roleDefinitionId": %q}`, userId, userAdministratorRoleId)})
+
+	if _, _, err := client.Create(ctx, msgraph.UnifiedRoleAssignment{
+		DirectoryScopeId: utils.StringPtr(msgraph.DirectoryScopeTenant),
+		PrincipalId:      utils.StringPtr(userId),
+		RoleDefinitionId: utils.StringPtr("00000000-0000-0000-0000-000000000000"),
+	}); err == nil {
+		t.Fatalf("RoleAssignmentsClient.Create(): expected an error for a nonexistent role definition")
+	}
+
+	roleAssignments, _, err := client.List(ctx, odata.Query{Filter: fmt.Sprintf("principalId eq '%s'", userId)})
+	if err != nil {
+		t.Fatalf("RoleAssignmentsClient.List(): %v", err)
+	}
+	if roleAssignments == nil || len(*roleAssignments) != 1 {
+		t.Fatalf("RoleAssignmentsClient.List(): expected 1 role assignment, got %v", roleAssignments)
+	}
+
+	roleAssignment, _, err = client.Get(ctx, *roleAssignment.ID)
+	if err != nil {
+		t.Fatalf("RoleAssignmentsClient.Get(): %v", err)
+	}
+	if *roleAssignment.RoleDefinitionId != userAdministratorRoleId || *roleAssignment.DirectoryScopeId != "/" {
+		t.Errorf("RoleAssignmentsClient.Get(): unexpected role assignment %v", roleAssignment)
+	}
+	expectRequests(t, server,
+		expectedRequest{http.MethodPost, assignmentsPath, `{"roleDefinitionId": "00000000-0000-0000-0000-000000000000"}`},
+		expectedRequest{http.MethodGet, assignmentsPath, ""},
+		expectedRequest{http.MethodGet, assignmentsPath + "/" + *roleAssignment.ID, ""},
+	)
+
+	auId := server.Add("administrativeUnits", msgraph.AdministrativeUnit{DisplayName: utils.StringPtr("test-administrative-unit")})
+	appId := server.Add("applications", msgraph.Application{DisplayName: utils.StringPtr("test-application")})
+	for _, scope := range []string{msgraph.AdministrativeUnitScope(auId), msgraph.ObjectScope(appId)} {
+		scoped, _, err := client.Create(ctx, msgraph.UnifiedRoleAssignment{
+			DirectoryScopeId: utils.StringPtr(scope),
+			PrincipalId:      utils.StringPtr(userId),
+			RoleDefinitionId: utils.StringPtr(userAdministratorRoleId),
+		})
+		if err != nil {
+			t.Fatalf("RoleAssignmentsClient.Create(): scope %q: %v", scope, err)
+		}
+		if *scoped.DirectoryScopeId != scope {
+			t.Errorf("RoleAssignmentsClient.Create(): expected directory scope %q, got %q", scope, *scoped.DirectoryScopeId)
+		}
+	}
+	appScoped, _, err := client.Create(ctx, msgraph.UnifiedRoleAssignment{
+		AppScopeId:       utils.StringPtr("/"),
+		PrincipalId:      utils.StringPtr(userId),
+		RoleDefinitionId: utils.StringPtr(userAdministratorRoleId),
+	})
+	if err != nil {
+		t.Fatalf("RoleAssignmentsClient.Create(): app scope: %v", err)
+	}
+	if appScoped.AppScopeId == nil || *appScoped.AppScopeId != "/" || appScoped.DirectoryScopeId != nil {
+		t.Errorf("RoleAssignmentsClient.Create(): unexpected app scoped role assignment %v", appScoped)
+	}
+	if _, _, err := client.Create(ctx, msgraph.UnifiedRoleAssignment{
+		DirectoryScopeId: utils.StringPtr(msgraph.AdministrativeUnitScope("00000000-0000-0000-0000-000000000000")),
+		PrincipalId:      utils.StringPtr(userId),
+		RoleDefinitionId: utils.StringPtr(userAdministratorRoleId),
+	}); err == nil {
+		t.Fatalf("RoleAssignmentsClient.Create(): expected an error for a nonexistent administrative unit scope")
+	}
+
+	pager := client.ListPager(odata.Query{Filter: fmt.Sprintf("principalId eq '%s'", userId)})
+	var page []msgraph.UnifiedRoleAssignment
+	if _, _, err := pager.Next(ctx, &page); err != nil {
+		t.Fatalf("RoleAssignmentsClient.ListPager(): %v", err)
+	}
+	if len(page) != 4 {
+		t.Errorf("RoleAssignmentsClient.ListPager(): expected 4 role assignments, got %d", len(page))
+	}
+	expectRequests(t, server,
+		expectedRequest{http.MethodPost, assignmentsPath, fmt.Sprintf(`{"directoryScopeId": "/administrativeUnits/%s"}`, auId)},
+		expectedRequest{http.MethodPost, assignmentsPath, fmt.Sprintf(`{"directoryScopeId": "/%s"}`, appId)},
+		expectedRequest{http.MethodPost, assignmentsPath, `{"appScopeId": "/"}`},
+		expectedRequest{http.MethodPost, assignmentsPath, `{"directoryScopeId": "/administrativeUnits/00000000-0000-0000-0000-000000000000"}`},
+		expectedRequest{http.MethodGet, assignmentsPath, ""},
+	)
+
+	if _, err := client.Delete(ctx, *roleAssignment.ID); err != nil {
+		t.Fatalf("RoleAssignmentsClient.Delete(): %v", err)
+	}
+	expectRequests(t, server, expectedRequest{http.MethodDelete, assignmentsPath + "/" + *roleAssignment.ID, ""})
+	if _, status, err := client.Get(ctx, *roleAssignment.ID); err == nil || status != http.StatusNotFound {
+		t.Errorf("RoleAssignmentsClient.Get(): expected status 404 for a deleted role assignment, got %d", status)
+	}
+}
@@ -0,0 +1,149 @@
+//go:build live
+// +build live
+
+package msgraph_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/manicminer/hamilton/auth"
+	"github.com/manicminer/hamilton/internal/test"
+	"github.com/manicminer/hamilton/internal/utils"
+	"github.com/manicminer/hamilton/msgraph"
+)
+
+type RoleDefinitionsClientTest struct {
+	connection   *test.Connection
+	client       *msgraph.RoleDefinitionsClient
+	randomString string
+}
+
+// TestRoleDefinitionsClient_Live requires a tenant licensed for custom roles.
+func TestRoleDefinitionsClient_Live(t *testing.T) {
+	rs := test.RandomString()
+	c := RoleDefinitionsClientTest{
+		connection:   test.NewConnection(auth.MsGraph, auth.TokenVersion2),
+		randomString: rs,
+	}
+	c.client = msgraph.NewRoleDefinitionsClient(c.connection.AuthConfig.TenantID)
+	c.client.BaseClient.Authorizer = c.connection.Authorizer
+
+	a := RoleAssignmentsClientTest{
+		connection:   test.NewConnection(auth.MsGraph, auth.TokenVersion2),
+		randomString: rs,
+	}
+	a.client = msgraph.NewRoleAssignmentsClient(a.connection.AuthConfig.TenantID)
+	a.client.BaseClient.Authorizer = a.connection.Authorizer
+
+	au := AdministrativeUnitsClientTest{
+		connection:   test.NewConnection(auth.MsGraph, auth.TokenVersion2),
+		randomString: rs,
+	}
+	au.client = msgraph.NewAdministrativeUnitsClient(au.connection.AuthConfig.TenantID)
+	au.client.BaseClient.Authorizer = au.connection.Authorizer
+
+	u := UsersClientTest{
+		connection:   test.NewConnection(auth.MsGraph, auth.TokenVersion2),
+		randomString: rs,
+	}
+	u.client = msgraph.NewUsersClient(u.connection.AuthConfig.TenantID)
+	u.client.BaseClient.Authorizer = u.connection.Authorizer
+
+	roleDefinition := testRoleDefinitionsClient_Create(t, c, msgraph.UnifiedRoleDefinition{
+		Description: utils.StringPtr("Can update the basic properties of applications"),
+		DisplayName: utils.StringPtr(fmt.Sprintf("test-role-%s", c.randomString)),
+		IsEnabled:   utils.BoolPtr(true),
+		RolePermissions: &[]msgraph.UnifiedRolePermission{
+			{
+				AllowedResourceActions: &[]string{"microsoft.directory/applications/basic/update"},
+			},
+		},
+	})
+	testRoleDefinitionsClient_Update(t, c, msgraph.UnifiedRoleDefinition{
+		ID: roleDefinition.ID,
+		RolePermissions: &[]msgraph.UnifiedRolePermission{
+			{
+				AllowedResourceActions: &[]string{
+					"microsoft.directory/applications/basic/update",
+					"microsoft.directory/applications/credentials/update",
+				},
+			},
+		},
+	})
+	testRoleDefinitionsClient_Get(t, c, *roleDefinition.ID)
+
+	user := testUsersClient_Create(t, u, msgraph.User{
+		AccountEnabled:    utils.BoolPtr(true),
+		DisplayName:       utils.StringPtr("test-user-scoped-role"),
+		MailNickname:      utils.StringPtr(fmt.Sprintf("test-user-scoped-role-%s", c.randomString)),
+		UserPrincipalName: utils.StringPtr(fmt.Sprintf("test-user-scoped-role-%s@%s", c.randomString, c.connection.DomainName)),
+		PasswordProfile: &msgraph.UserPasswordProfile{
+			Password: utils.StringPtr(fmt.Sprintf("IrPa55w0rd%s", c.randomString)),
+		},
+	})
+	administrativeUnit := testAdministrativeUnitsClient_Create(t, au, msgraph.AdministrativeUnit{
+		DisplayName: utils.StringPtr(fmt.Sprintf("test-administrative-unit-scoped-role-%s", c.randomString)),
+	})
+	roleAssignment := testRoleAssignmentsClient_Create(t, a, msgraph.UnifiedRoleAssignment{
+		DirectoryScopeId: utils.StringPtr(msgraph.AdministrativeUnitScope(*administrativeUnit.ID)),
+		PrincipalId:      user.ID,
+		RoleDefinitionId: utils.StringPtr(userAdministratorRoleId),
+	})
+	testRoleAssignmentsClient_Delete(t, a, *roleAssignment.ID)
+
+	testAdministrativeUnitsClient_Delete(t, au, *administrativeUnit.ID)
+	testUsersClient_Delete(t, u, *user.ID)
+	testRoleDefinitionsClient_Delete(t, c, *roleDefinition.ID)
+}
+
+func testRoleDefinitionsClient_Create(t *testing.T, c RoleDefinitionsClientTest, r msgraph.UnifiedRoleDefinition) (roleDefinition *msgraph.UnifiedRoleDefinition) {
+	roleDefinition, status, err := c.client.Create(c.connection.Context, r)
+	if err != nil {
+		t.Fatalf("RoleDefinitionsClient.Create(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("RoleDefinitionsClient.Create(): invalid status: %d", status)
+	}
+	if roleDefinition == nil {
+		t.Fatal("RoleDefinitionsClient.Create(): roleDefinition was nil")
+	}
+	if roleDefinition.ID == nil {
+		t.Fatal("RoleDefinitionsClient.Create(): roleDefinition.ID was nil")
+	}
+	return
+}
+
+func testRoleDefinitionsClient_Update(t *testing.T, c RoleDefinitionsClientTest, r msgraph.UnifiedRoleDefinition) {
+	status, err := c.client.Update(c.connection.Context, r)
+	if err != nil {
+		t.Fatalf("RoleDefinitionsClient.Update(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("RoleDefinitionsClient.Update(): invalid status: %d", status)
+	}
+}
+
+func testRoleDefinitionsClient_Get(t *testing.T, c RoleDefinitionsClientTest, id string) (roleDefinition *msgraph.UnifiedRoleDefinition) {
+	roleDefinition, status, err := c.client.Get(c.connection.Context, id)
+	if err != nil {
+		t.Fatalf("RoleDefinitionsClient.Get(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("RoleDefinitionsClient.Get(): invalid status: %d", status)
+	}
+	if roleDefinition == nil {
+		t.Fatal("RoleDefinitionsClient.Get(): roleDefinition was nil")
+	}
+	return
+}
+
+func testRoleDefinitionsClient_Delete(t *testing.T, c RoleDefinitionsClientTest, id string) {
+	status, err := c.client.Delete(c.connection.Context, id)
+	if err != nil {
+		t.Fatalf("RoleDefinitionsClient.Delete(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("RoleDefinitionsClient.Delete(): invalid status: %d", status)
+	}
+}
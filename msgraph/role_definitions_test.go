@@ -0,0 +1,121 @@
+package msgraph_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/manicminer/hamilton/internal/utils"
+	"github.com/manicminer/hamilton/msgraph"
+	"github.com/manicminer/hamilton/msgraph/msgraphtest"
+	"github.com/manicminer/hamilton/odata"
+)
+
+func TestRoleDefinitionsClient(t *testing.T) {
+	server := msgraphtest.NewServer()
+	defer server.Close()
+	ctx := context.Background()
+
+	client := msgraph.NewRoleDefinitionsClient("tenant")
+	client.BaseClient.Endpoint = server.Endpoint()
+	definitionsPath := "/roleManagement/directory/roleDefinitions"
+
+	roleDefinitions, _, err := client.List(ctx, odata.Query{Filter: "displayName eq 'User Administrator'"})
+	if err != nil {
+		t.Fatalf("RoleDefinitionsClient.List(): %v", err)
+	}
+	if roleDefinitions == nil || len(*roleDefinitions) != 1 {
+		t.Fatalf("RoleDefinitionsClient.List(): expected 1 role definition, got %v", roleDefinitions)
+	}
+
+	roleDefinition, _, err := client.Get(ctx, *(*roleDefinitions)[0].ID)
+	if err != nil {
+		t.Fatalf("RoleDefinitionsClient.Get(): %v", err)
+	}
+	if roleDefinition.IsBuiltIn == nil || !*roleDefinition.IsBuiltIn || *roleDefinition.TemplateId != *roleDefinition.ID {
+		t.Errorf("RoleDefinitionsClient.Get(): expected a built-in role definition, got %v", roleDefinition)
+	}
+	expectRequests(t, server,
+		expectedRequest{http.MethodGet, definitionsPath, ""},
+		expectedRequest{http.MethodGet, definitionsPath + "/" + *roleDefinition.ID, ""},
+	)
+
+	if _, err := client.Delete(ctx, *roleDefinition.ID); err == nil {
+		t.Errorf("RoleDefinitionsClient.Delete(): expected an error when deleting a built-in role definition")
+	}
+
+	newRoleDefinition, status, err := client.Create(ctx, msgraph.UnifiedRoleDefinition{
+		Description: utils.StringPtr("Can update the basic properties of applications"),
+		DisplayName: utils.StringPtr("test-application-updater"),
+		IsEnabled:   utils.BoolPtr(true),
+		RolePermissions: &[]msgraph.UnifiedRolePermission{
+			{
+				AllowedResourceActions: &[]string{"microsoft.directory/applications/basic/update"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("RoleDefinitionsClient.Create(): %v", err)
+	}
+	if status != http.StatusCreated || newRoleDefinition.ID == nil || newRoleDefinition.IsBuiltIn == nil || *newRoleDefinition.IsBuiltIn {
+		t.Fatalf("RoleDefinitionsClient.Create(): expected a new custom role definition with status 201, got %v with status %d", newRoleDefinition, status)
+	}
+	expectRequests(t, server,
+		expectedRequest{http.MethodDelete, definitionsPath + "/" + *roleDefinition.ID, ""},
+		expectedRequest{http.MethodPost, definitionsPath, `{"displayName": "test-application-updater", "isEnabled": true, "rolePermissions": [{"allowedResourceActions": ["microsoft.directory/applications/basic/update"]}]}`},
+	)
+
+	if _, _, err := client.Create(ctx, msgraph.UnifiedRoleDefinition{
+		DisplayName: utils.StringPtr("test-empty-role"),
+		IsEnabled:   utils.BoolPtr(true),
+	}); err == nil {
+		t.Fatalf("RoleDefinitionsClient.Create(): expected an error for a role definition without permissions")
+	}
+
+	newRoleDefinition.RolePermissions = &[]msgraph.UnifiedRolePermission{
+		{
+			AllowedResourceActions: &[]string{
+				"microsoft.directory/applications/basic/update",
+				"microsoft.directory/applications/credentials/update",
+			},
+		},
+	}
+	if _, err := client.Update(ctx, msgraph.UnifiedRoleDefinition{ID: newRoleDefinition.ID, RolePermissions: newRoleDefinition.RolePermissions}); err != nil {
+		t.Fatalf("RoleDefinitionsClient.Update(): %v", err)
+	}
+	if _, err := client.Update(ctx, msgraph.UnifiedRoleDefinition{}); err == nil {
+		t.Fatalf("RoleDefinitionsClient.Update(): expected an error for a role definition with nil ID")
+	}
+	expectRequests(t, server,
+		expectedRequest{http.MethodPost, definitionsPath, `{"displayName": "test-empty-role"}`},
+		expectedRequest{http.MethodPatch, definitionsPath + "/" + *newRoleDefinition.ID, `{"rolePermissions": [{"allowedResourceActions": ["microsoft.directory/applications/basic/update", "microsoft.directory/applications/credentials/update"]}]}`},
+	)
+
+	roleDefinition, _, err = client.Get(ctx, *newRoleDefinition.ID)
+	if err != nil {
+		t.Fatalf("RoleDefinitionsClient.Get(): %v", err)
+	}
+	if perms := roleDefinition.RolePermissions; perms == nil || len(*perms) != 1 || len(*(*perms)[0].AllowedResourceActions) != 2 {
+		t.Errorf("RoleDefinitionsClient.Update(): unexpected role permissions %v", perms)
+	}
+
+	roleDefinitions, _, err = client.List(ctx, odata.Query{Filter: "isBuiltIn eq false"})
+	if err != nil {
+		t.Fatalf("RoleDefinitionsClient.List(): %v", err)
+	}
+	if len(*roleDefinitions) != 1 || *(*roleDefinitions)[0].ID != *newRoleDefinition.ID {
+		t.Errorf("RoleDefinitionsClient.List(): expected only the custom role definition, got %v", roleDefinitions)
+	}
+	expectRequests(t, server,
+		expectedRequest{http.MethodGet, definitionsPath + "/" + *newRoleDefinition.ID, ""},
+		expectedRequest{http.MethodGet, definitionsPath, ""},
+	)
+
+	if _, err := client.Delete(ctx, *newRoleDefinition.ID); err != nil {
+		t.Fatalf("RoleDefinitionsClient.Delete(): %v", err)
+	}
+	expectRequests(t, server, expectedRequest{http.MethodDelete, definitionsPath + "/" + *newRoleDefinition.ID, ""})
+	if _, status, err := client.Get(ctx, *newRoleDefinition.ID); err == nil || status != http.StatusNotFound {
+		t.Errorf("RoleDefinitionsClient.Get(): expected status 404 for a deleted role definition, got %d", status)
+	}
+}
@@ -0,0 +1,70 @@
+package msgraph
+
+import (
+	"context"
+
+	"github.com/manicminer/hamilton/odata"
+)
+
+// RoleEligibilityScheduleRequestsClient performs operations on requests to grant, extend or remove eligibilities for
+// principals to activate directory roles, using Privileged Identity Management.
+type RoleEligibilityScheduleRequestsClient struct {
+	BaseClient Client
+}
+
+// NewRoleEligibilityScheduleRequestsClient returns a new RoleEligibilityScheduleRequestsClient.
+func NewRoleEligibilityScheduleRequestsClient(tenantId string) *RoleEligibilityScheduleRequestsClient {
+	return &RoleEligibilityScheduleRequestsClient{
+		BaseClient: NewClient(Version10, tenantId),
+	}
+}
+
+// resource returns a Resource for performing common operations on Role Eligibility Schedule Requests.
+func (c *RoleEligibilityScheduleRequestsClient) resource() Resource {
+	return Resource{
+		Client: c.BaseClient,
+		Name:   "RoleEligibilityScheduleRequestsClient",
+		Entity: "/roleManagement/directory/roleEligibilityScheduleRequests",
+	}
+}
+
+// List returns a list of Role Eligibility Schedule Requests, optionally queried using OData.
+func (c *RoleEligibilityScheduleRequestsClient) List(ctx context.Context, query odata.Query) (*[]UnifiedRoleEligibilityScheduleRequest, int, error) {
+	var requests []UnifiedRoleEligibilityScheduleRequest
+	status, err := c.resource().List(ctx, query, &requests)
+	if err != nil {
+		return nil, status, err
+	}
+	return &requests, status, nil
+}
+
+// ListPager returns a Pager for retrieving Role Eligibility Schedule Requests one page at a time, optionally queried
+// using OData.
+func (c *RoleEligibilityScheduleRequestsClient) ListPager(query odata.Query) *Pager {
+	return c.resource().ListPager(query)
+}
+
+// Get retrieves a Role Eligibility Schedule Request.
+func (c *RoleEligibilityScheduleRequestsClient) Get(ctx context.Context, id string) (*UnifiedRoleEligibilityScheduleRequest, int, error) {
+	var request UnifiedRoleEligibilityScheduleRequest
+	status, err := c.resource().Get(ctx, id, odata.Query{}, &request)
+	if err != nil {
+		return nil, status, err
+	}
+	return &request, status, nil
+}
+
+// Create submits a new Role Eligibility Schedule Request.
+func (c *RoleEligibilityScheduleRequestsClient) Create(ctx context.Context, request UnifiedRoleEligibilityScheduleRequest) (*UnifiedRoleEligibilityScheduleRequest, int, error) {
+	var newRequest UnifiedRoleEligibilityScheduleRequest
+	status, err := c.resource().Create(ctx, request, &newRequest)
+	if err != nil {
+		return nil, status, err
+	}
+	return &newRequest, status, nil
+}
+
+// Cancel cancels a Role Eligibility Schedule Request which has not yet taken effect.
+func (c *RoleEligibilityScheduleRequestsClient) Cancel(ctx context.Context, id string) (int, error) {
+	return c.resource().Action(ctx, id, "cancel")
+}
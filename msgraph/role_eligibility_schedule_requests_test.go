@@ -0,0 +1,105 @@
+package msgraph_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/manicminer/hamilton/internal/utils"
+	"github.com/manicminer/hamilton/msgraph"
+	"github.com/manicminer/hamilton/msgraph/msgraphtest"
+	"github.com/manicminer/hamilton/odata"
+)
+
+func TestRoleEligibilityScheduleRequestsClient(t *testing.T) {
+	server := msgraphtest.NewServer()
+	defer server.Close()
+	ctx := context.Background()
+
+	client := msgraph.NewRoleEligibilityScheduleRequestsClient("tenant")
+	client.BaseClient.Endpoint = server.Endpoint()
+	requestsPath := "/roleManagement/directory/roleEligibilityScheduleRequests"
+
+	userId := server.Add("users", msgraph.User{
+		DisplayName:       utils.StringPtr("test-user"),
+		UserPrincipalName: utils.StringPtr("test-user@example.com"),
+	})
+
+	action := msgraph.UnifiedRoleScheduleRequestActionAdminAssign
+	expiration := msgraph.ExpirationPatternTypeAfterDuration
+	now := time.Now()
+	request, status, err := client.Create(ctx, msgraph.UnifiedRoleEligibilityScheduleRequest{
+		Action:           &action,
+		DirectoryScopeId: utils.StringPtr("/"),
+		Justification:    utils.StringPtr("on call rotation"),
+		PrincipalId:      utils.StringPtr(userId),
+		RoleDefinitionId: utils.StringPtr(userAdministratorRoleId),
+		ScheduleInfo: &msgraph.RequestSchedule{
+			StartDateTime: &now,
+			Expiration: &msgraph.ExpirationPattern{
+				Duration: utils.StringPtr("P90D"),
+				Type:     &expiration,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("RoleEligibilityScheduleRequestsClient.Create(): %v", err)
+	}
+	if status != http.StatusCreated || request.ID == nil || request.Status == nil || *request.Status != "Provisioned" {
+		t.Fatalf("RoleEligibilityScheduleRequestsClient.Create(): expected a provisioned request with status 201, got %v with status %d", request, status)
+	}
+	expectRequests(t, server, expectedRequest{http.MethodPost, requestsPath, fmt.Sprintf(`{"action": "adminAssign", "directoryScopeId": "/", "justification": "on call rotation", "principalId": %q, "roleDefinitionId": %q}`, userId, userAdministratorRoleId)})
+
+	if _, _, err := client.Create(ctx, msgraph.UnifiedRoleEligibilityScheduleRequest{
+		Action:           &action,
+		DirectoryScopeId: utils.StringPtr("/"),
+		PrincipalId:      utils.StringPtr(userId),
+		RoleDefinitionId: utils.StringPtr(userAdministratorRoleId),
+	}); err == nil {
+		t.Fatalf("RoleEligibilityScheduleRequestsClient.Create(): expected an error for an existing eligibility")
+	}
+	expectRequests(t, server, expectedRequest{http.MethodPost, requestsPath, fmt.Sprintf(`{"principalId": %q}`, userId)})
+
+	requests, _, err := client.List(ctx, odata.Query{})
+	if err != nil {
+		t.Fatalf("RoleEligibilityScheduleRequestsClient.List(): %v", err)
+	}
+	if requests == nil || len(*requests) != 1 {
+		t.Fatalf("RoleEligibilityScheduleRequestsClient.List(): expected 1 request, got %v", requests)
+	}
+	pager := client.ListPager(odata.Query{})
+	var page []msgraph.UnifiedRoleEligibilityScheduleRequest
+	if _, _, err := pager.Next(ctx, &page); err != nil {
+		t.Fatalf("RoleEligibilityScheduleRequestsClient.ListPager(): %v", err)
+	}
+	if len(page) != 1 || pager.More() {
+		t.Fatalf("RoleEligibilityScheduleRequestsClient.ListPager(): expected a single page with 1 request, got %v", page)
+	}
+	expectRequests(t, server,
+		expectedRequest{http.MethodGet, requestsPath, ""},
+		expectedRequest{http.MethodGet, requestsPath, ""},
+	)
+
+	request, _, err = client.Get(ctx, *request.ID)
+	if err != nil {
+		t.Fatalf("RoleEligibilityScheduleRequestsClient.Get(): %v", err)
+	}
+	if request.ScheduleInfo == nil || request.ScheduleInfo.Expiration == nil || *request.ScheduleInfo.Expiration.Duration != "P90D" {
+		t.Errorf("RoleEligibilityScheduleRequestsClient.Get(): unexpected request %v", request)
+	}
+	expectRequests(t, server, expectedRequest{http.MethodGet, requestsPath + "/" + *request.ID, ""})
+
+	if _, err := client.Cancel(ctx, *request.ID); err != nil {
+		t.Fatalf("RoleEligibilityScheduleRequestsClient.Cancel(): %v", err)
+	}
+	expectRequests(t, server, expectedRequest{http.MethodPost, requestsPath + "/" + *request.ID + "/cancel", ""})
+	request, _, err = client.Get(ctx, *request.ID)
+	if err != nil {
+		t.Fatalf("RoleEligibilityScheduleRequestsClient.Get(): %v", err)
+	}
+	if *request.Status != "Canceled" {
+		t.Errorf("RoleEligibilityScheduleRequestsClient.Cancel(): expected status Canceled, got %q", *request.Status)
+	}
+}
@@ -0,0 +1,154 @@
+//go:build live
+// +build live
+
+package msgraph_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/manicminer/hamilton/auth"
+	"github.com/manicminer/hamilton/internal/test"
+	"github.com/manicminer/hamilton/internal/utils"
+	"github.com/manicminer/hamilton/msgraph"
+)
+
+type RoleScheduleRequestsClientTest struct {
+	connection        *test.Connection
+	eligibilityClient *msgraph.RoleEligibilityScheduleRequestsClient
+	assignmentClient  *msgraph.RoleAssignmentScheduleRequestsClient
+	randomString      string
+}
+
+// TestRoleScheduleRequestsClients_Live requires a tenant licensed for Privileged Identity Management.
+func TestRoleScheduleRequestsClients_Live(t *testing.T) {
+	rs := test.RandomString()
+	c := RoleScheduleRequestsClientTest{
+		connection:   test.NewConnection(auth.MsGraph, auth.TokenVersion2),
+		randomString: rs,
+	}
+	c.eligibilityClient = msgraph.NewRoleEligibilityScheduleRequestsClient(c.connection.AuthConfig.TenantID)
+	c.eligibilityClient.BaseClient.Authorizer = c.connection.Authorizer
+	c.assignmentClient = msgraph.NewRoleAssignmentScheduleRequestsClient(c.connection.AuthConfig.TenantID)
+	c.assignmentClient.BaseClient.Authorizer = c.connection.Authorizer
+
+	u := UsersClientTest{
+		connection:   test.NewConnection(auth.MsGraph, auth.TokenVersion2),
+		randomString: rs,
+	}
+	u.client = msgraph.NewUsersClient(u.connection.AuthConfig.TenantID)
+	u.client.BaseClient.Authorizer = u.connection.Authorizer
+
+	user := testUsersClient_Create(t, u, msgraph.User{
+		AccountEnabled:    utils.BoolPtr(true),
+		DisplayName:       utils.StringPtr("test-user-role-schedule"),
+		MailNickname:      utils.StringPtr(fmt.Sprintf("test-user-role-schedule-%s", c.randomString)),
+		UserPrincipalName: utils.StringPtr(fmt.Sprintf("test-user-role-schedule-%s@%s", c.randomString, c.connection.DomainName)),
+		PasswordProfile: &msgraph.UserPasswordProfile{
+			Password: utils.StringPtr(fmt.Sprintf("IrPa55w0rd%s", c.randomString)),
+		},
+	})
+
+	expiration := msgraph.ExpirationPatternTypeAfterDuration
+	schedule := &msgraph.RequestSchedule{
+		Expiration: &msgraph.ExpirationPattern{
+			Duration: utils.StringPtr("PT8H"),
+			Type:     &expiration,
+		},
+	}
+
+	assign := msgraph.UnifiedRoleScheduleRequestActionAdminAssign
+	remove := msgraph.UnifiedRoleScheduleRequestActionAdminRemove
+	eligibility := testRoleEligibilityScheduleRequestsClient_Create(t, c, msgraph.UnifiedRoleEligibilityScheduleRequest{
+		Action:           &assign,
+		DirectoryScopeId: utils.StringPtr("/"),
+		Justification:    utils.StringPtr("hamilton acceptance test"),
+		PrincipalId:      user.ID,
+		RoleDefinitionId: utils.StringPtr(userAdministratorRoleId),
+		ScheduleInfo:     schedule,
+	})
+	testRoleEligibilityScheduleRequestsClient_Get(t, c, *eligibility.ID)
+
+	assignment := testRoleAssignmentScheduleRequestsClient_Create(t, c, msgraph.UnifiedRoleAssignmentScheduleRequest{
+		Action:           &assign,
+		DirectoryScopeId: utils.StringPtr("/"),
+		Justification:    utils.StringPtr("hamilton acceptance test"),
+		PrincipalId:      user.ID,
+		RoleDefinitionId: utils.StringPtr(userAdministratorRoleId),
+		ScheduleInfo:     schedule,
+	})
+	testRoleAssignmentScheduleRequestsClient_Get(t, c, *assignment.ID)
+	testRoleAssignmentScheduleRequestsClient_Create(t, c, msgraph.UnifiedRoleAssignmentScheduleRequest{
+		Action:           &remove,
+		DirectoryScopeId: utils.StringPtr("/"),
+		Justification:    utils.StringPtr("hamilton acceptance test"),
+		PrincipalId:      user.ID,
+		RoleDefinitionId: utils.StringPtr(userAdministratorRoleId),
+	})
+
+	testRoleEligibilityScheduleRequestsClient_Create(t, c, msgraph.UnifiedRoleEligibilityScheduleRequest{
+		Action:           &remove,
+		DirectoryScopeId: utils.StringPtr("/"),
+		Justification:    utils.StringPtr("hamilton acceptance test"),
+		PrincipalId:      user.ID,
+		RoleDefinitionId: utils.StringPtr(userAdministratorRoleId),
+	})
+
+	testUsersClient_Delete(t, u, *user.ID)
+}
+
+func testRoleEligibilityScheduleRequestsClient_Create(t *testing.T, c RoleScheduleRequestsClientTest, r msgraph.UnifiedRoleEligibilityScheduleRequest) (request *msgraph.UnifiedRoleEligibilityScheduleRequest) {
+	request, status, err := c.eligibilityClient.Create(c.connection.Context, r)
+	if err != nil {
+		t.Fatalf("RoleEligibilityScheduleRequestsClient.Create(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("RoleEligibilityScheduleRequestsClient.Create(): invalid status: %d", status)
+	}
+	if request == nil || request.ID == nil {
+		t.Fatal("RoleEligibilityScheduleRequestsClient.Create(): request.ID was nil")
+	}
+	return
+}
+
+func testRoleEligibilityScheduleRequestsClient_Get(t *testing.T, c RoleScheduleRequestsClientTest, id string) (request *msgraph.UnifiedRoleEligibilityScheduleRequest) {
+	request, status, err := c.eligibilityClient.Get(c.connection.Context, id)
+	if err != nil {
+		t.Fatalf("RoleEligibilityScheduleRequestsClient.Get(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("RoleEligibilityScheduleRequestsClient.Get(): invalid status: %d", status)
+	}
+	if request == nil {
+		t.Fatal("RoleEligibilityScheduleRequestsClient.Get(): request was nil")
+	}
+	return
+}
+
+func testRoleAssignmentScheduleRequestsClient_Create(t *testing.T, c RoleScheduleRequestsClientTest, r msgraph.UnifiedRoleAssignmentScheduleRequest) (request *msgraph.UnifiedRoleAssignmentScheduleRequest) {
+	request, status, err := c.assignmentClient.Create(c.connection.Context, r)
+	if err != nil {
+		t.Fatalf("RoleAssignmentScheduleRequestsClient.Create(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("RoleAssignmentScheduleRequestsClient.Create(): invalid status: %d", status)
+	}
+	if request == nil || request.ID == nil {
+		t.Fatal("RoleAssignmentScheduleRequestsClient.Create(): request.ID was nil")
+	}
+	return
+}
+
+func testRoleAssignmentScheduleRequestsClient_Get(t *testing.T, c RoleScheduleRequestsClientTest, id string) (request *msgraph.UnifiedRoleAssignmentScheduleRequest) {
+	request, status, err := c.assignmentClient.Get(c.connection.Context, id)
+	if err != nil {
+		t.Fatalf("RoleAssignmentScheduleRequestsClient.Get(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("RoleAssignmentScheduleRequestsClient.Get(): invalid status: %d", status)
+	}
+	if request == nil {
+		t.Fatal("RoleAssignmentScheduleRequestsClient.Get(): request was nil")
+	}
+	return
+}
@@ -24,89 +24,85 @@ func NewServicePrincipalsClient(tenantId string) *ServicePrincipalsClient {
 	}
 }
 
-// List returns a list of Service Principals, optionally filtered using OData.
-func (c *ServicePrincipalsClient) List(ctx context.Context, filter string) (*[]ServicePrincipal, int, error) {
-	params := url.Values{}
-	if filter != "" {
-		params.Add("$filter", filter)
+// resource returns a Resource for performing common operations on Service Principals.
+func (c *ServicePrincipalsClient) resource() Resource {
+	return Resource{
+		Client: c.BaseClient,
+		Name:   "ServicePrincipalsClient",
+		Entity: "/servicePrincipals",
 	}
-	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
-		ValidStatusCodes: []int{http.StatusOK},
-		Uri: Uri{
-			Entity:      "/servicePrincipals",
-			Params:      params,
-			HasTenantId: true,
-		},
-	})
+}
+
+// List returns a list of Service Principals, optionally queried using OData.
+func (c *ServicePrincipalsClient) List(ctx context.Context, query odata.Query) (*[]ServicePrincipal, int, error) {
+	var servicePrincipals []ServicePrincipal
+	status, err := c.resource().List(ctx, query, &servicePrincipals)
 	if err != nil {
-		return nil, status, fmt.Errorf("ServicePrincipalsClient.BaseClient.Get(): %v", err)
+		return nil, status, err
 	}
-	defer resp.Body.Close()
-	respBody, err := ioutil.ReadAll(resp.Body)
+	return &servicePrincipals, status, nil
+}
+
+// ListPager returns a Pager for retrieving Service Principals one page at a time, optionally queried using OData.
+func (c *ServicePrincipalsClient) ListPager(query odata.Query) *Pager {
+	return c.resource().ListPager(query)
+}
+
+// Delta retrieves Service Principals which have been created, updated or removed since a previous delta query, optionally
+// queried using OData. Specify an empty deltaLink to retrieve all Service Principals, or a deltaLink returned from a previous
+// call to retrieve only changes since that call. Service Principals which have been removed have their Removed field populated.
+// The returned deltaLink should be persisted and used to retrieve subsequent changes.
+func (c *ServicePrincipalsClient) Delta(ctx context.Context, query odata.Query, deltaLink string) (*[]ServicePrincipal, *string, int, error) {
+	var servicePrincipals []ServicePrincipal
+	link, status, err := c.resource().Delta(ctx, query, deltaLink, &servicePrincipals)
 	if err != nil {
-		return nil, status, fmt.Errorf("ioutil.ReadAll(): %v", err)
-	}
-	var data struct {
-		ServicePrincipals []ServicePrincipal `json:"value"`
+		return nil, nil, status, err
 	}
-	if err := json.Unmarshal(respBody, &data); err != nil {
-		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
-	}
-	return &data.ServicePrincipals, status, nil
+	return &servicePrincipals, link, status, nil
 }
 
 // Create creates a new Service Principal.
 func (c *ServicePrincipalsClient) Create(ctx context.Context, servicePrincipal ServicePrincipal) (*ServicePrincipal, int, error) {
-	var status int
-	body, err := json.Marshal(servicePrincipal)
-	if err != nil {
-		return nil, status, fmt.Errorf("json.Marshal(): %v", err)
-	}
-	resp, status, _, err := c.BaseClient.Post(ctx, PostHttpRequestInput{
-		Body:             body,
-		ValidStatusCodes: []int{http.StatusCreated},
-		Uri: Uri{
-			Entity:      "/servicePrincipals",
-			HasTenantId: true,
-		},
-	})
-	if err != nil {
-		return nil, status, fmt.Errorf("ServicePrincipalsClient.BaseClient.Post(): %v", err)
-	}
-	defer resp.Body.Close()
-	respBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, status, fmt.Errorf("ioutil.ReadAll(): %v", err)
-	}
 	var newServicePrincipal ServicePrincipal
-	if err := json.Unmarshal(respBody, &newServicePrincipal); err != nil {
-		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	status, err := c.resource().Create(ctx, servicePrincipal, &newServicePrincipal)
+	if err != nil {
+		return nil, status, err
 	}
 	return &newServicePrincipal, status, nil
 }
 
 // Get retrieves a Service Principal.
 func (c *ServicePrincipalsClient) Get(ctx context.Context, id string) (*ServicePrincipal, int, error) {
-	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
-		ValidStatusCodes: []int{http.StatusOK},
-		Uri: Uri{
-			Entity:      fmt.Sprintf("/servicePrincipals/%s", id),
-			HasTenantId: true,
-		},
-	})
+	var servicePrincipal ServicePrincipal
+	status, err := c.resource().Get(ctx, id, odata.Query{}, &servicePrincipal)
 	if err != nil {
-		return nil, status, fmt.Errorf("ServicePrincipalsClient.BaseClient.Get(): %v", err)
+		return nil, status, err
 	}
-	defer resp.Body.Close()
-	respBody, err := ioutil.ReadAll(resp.Body)
+	return &servicePrincipal, status, nil
+}
+
+// WaitFor polls the specified Service Principal until predicate returns true, and then returns it.
+// The predicate is called with nil when the Service Principal does not exist, so WaitFor can be used to wait until a Service Principal
+// has been created or deleted, or until it has a particular property value. ctx should have a deadline, as WaitFor
+// will otherwise wait indefinitely for the predicate to be satisfied.
+func (c *ServicePrincipalsClient) WaitFor(ctx context.Context, id string, predicate func(servicePrincipal *ServicePrincipal) bool) (*ServicePrincipal, int, error) {
+	var servicePrincipal *ServicePrincipal
+	var status int
+	err := c.BaseClient.waitFor(ctx, func(ctx context.Context) (bool, error) {
+		var err error
+		servicePrincipal, status, err = c.Get(ctx, id)
+		if err != nil {
+			if status != http.StatusNotFound {
+				return false, err
+			}
+			servicePrincipal = nil
+		}
+		return predicate(servicePrincipal), nil
+	})
 	if err != nil {
-		return nil, status, fmt.Errorf("ioutil.ReadAll(): %v", err)
-	}
-	var servicePrincipal ServicePrincipal
-	if err := json.Unmarshal(respBody, &servicePrincipal); err != nil {
-		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+		return nil, status, fmt.Errorf("ServicePrincipalsClient.BaseClient.waitFor(): %w", err)
 	}
-	return &servicePrincipal, status, nil
+	return servicePrincipal, status, nil
 }
 
 // Update amends an existing Service Principal.
@@ -115,72 +111,37 @@ func (c *ServicePrincipalsClient) Update(ctx context.Context, servicePrincipal S
 	if servicePrincipal.ID == nil {
 		return status, errors.New("cannot update service principal with nil ID")
 	}
-	body, err := json.Marshal(servicePrincipal)
-	if err != nil {
-		return status, fmt.Errorf("json.Marshal(): %v", err)
-	}
-	_, status, _, err = c.BaseClient.Patch(ctx, PatchHttpRequestInput{
-		Body:             body,
-		ValidStatusCodes: []int{http.StatusNoContent},
-		Uri: Uri{
-			Entity:      fmt.Sprintf("/servicePrincipals/%s", *servicePrincipal.ID),
-			HasTenantId: true,
-		},
-	})
-	if err != nil {
-		return status, fmt.Errorf("ServicePrincipalsClient.BaseClient.Patch(): %v", err)
-	}
-	return status, nil
+	return c.resource().Update(ctx, *servicePrincipal.ID, servicePrincipal)
 }
 
 // Delete removes a Service Principal.
 func (c *ServicePrincipalsClient) Delete(ctx context.Context, id string) (int, error) {
-	_, status, _, err := c.BaseClient.Delete(ctx, DeleteHttpRequestInput{
-		ValidStatusCodes: []int{http.StatusNoContent},
-		Uri: Uri{
-			Entity:      fmt.Sprintf("/servicePrincipals/%s", id),
-			HasTenantId: true,
-		},
-	})
+	return c.resource().Delete(ctx, id)
+}
+
+// ListOwners retrieves the owners of the specified Service Principal. Each owner is returned as the model for its
+// type, e.g. *User or *ServicePrincipal.
+// id is the object ID of the service principal.
+func (c *ServicePrincipalsClient) ListOwners(ctx context.Context, id string) (*[]DirectoryObject, int, error) {
+	var owners DirectoryObjects
+	status, err := c.resource().list(ctx, fmt.Sprintf("/servicePrincipals/%s/owners", id), odata.Query{}, &owners)
 	if err != nil {
-		return status, fmt.Errorf("ServicePrincipalsClient.BaseClient.Delete(): %v", err)
+		return nil, status, err
 	}
-	return status, nil
+	ret := []DirectoryObject(owners)
+	return &ret, status, nil
 }
 
-// ListOwners retrieves the owners of the specified Service Principal.
+// ListOwnersPager returns a Pager for retrieving the owners of the specified Service Principal one page at a time.
 // id is the object ID of the service principal.
-func (c *ServicePrincipalsClient) ListOwners(ctx context.Context, id string) (*[]string, int, error) {
-	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
+func (c *ServicePrincipalsClient) ListOwnersPager(id string) *Pager {
+	return c.BaseClient.NewPager(GetHttpRequestInput{
 		ValidStatusCodes: []int{http.StatusOK},
 		Uri: Uri{
 			Entity:      fmt.Sprintf("/servicePrincipals/%s/owners", id),
-			Params:      url.Values{"$select": []string{"id"}},
 			HasTenantId: true,
 		},
 	})
-	if err != nil {
-		return nil, status, fmt.Errorf("ServicePrincipalsClient.BaseClient.Get(): %v", err)
-	}
-	defer resp.Body.Close()
-	respBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, status, fmt.Errorf("ioutil.ReadAll(): %v", err)
-	}
-	var data struct {
-		Owners []struct {
-			Type string `json:"@odata.type"`
-			Id   string `json:"id"`
-		} `json:"value"`
-	}
-	if err := json.Unmarshal(respBody, &data); err != nil {
-		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
-	}
-	ret := make([]string, len(data.Owners))
-	for i, v := range data.Owners {
-		ret[i] = v.Id
-	}
-	return &ret, status, nil
 }
 
 // GetOwner retrieves a single owner for the specified Service Principal.
@@ -196,7 +157,7 @@ func (c *ServicePrincipalsClient) GetOwner(ctx context.Context, servicePrincipal
 		},
 	})
 	if err != nil {
-		return nil, status, fmt.Errorf("ServicePrincipalsClient.BaseClient.Get(): %v", err)
+		return nil, status, fmt.Errorf("ServicePrincipalsClient.BaseClient.Get(): %w", err)
 	}
 	defer resp.Body.Close()
 	respBody, err := ioutil.ReadAll(resp.Body)
@@ -255,7 +216,7 @@ func (c *ServicePrincipalsClient) AddOwners(ctx context.Context, servicePrincipa
 			},
 		})
 		if err != nil {
-			return status, fmt.Errorf("ServicePrincipalsClient.BaseClient.Post(): %v", err)
+			return status, fmt.Errorf("ServicePrincipalsClient.BaseClient.Post(): %w", err)
 		}
 	}
 	return status, nil
@@ -297,41 +258,33 @@ func (c *ServicePrincipalsClient) RemoveOwners(ctx context.Context, servicePrinc
 			},
 		})
 		if err != nil {
-			return status, fmt.Errorf("ServicePrincipalsClient.BaseClient.Delete(): %v", err)
+			return status, fmt.Errorf("ServicePrincipalsClient.BaseClient.Delete(): %w", err)
 		}
 	}
 	return status, nil
 }
 
-// ListGroupMemberships returns a list of Groups the Service Principal is member of, optionally filtered using OData.
-func (c *ServicePrincipalsClient) ListGroupMemberships(ctx context.Context, id string, filter string) (*[]Group, int, error) {
-	params := url.Values{}
-	if filter != "" {
-		params.Add("$filter", filter)
+// ListGroupMemberships returns a list of Groups the Service Principal is member of, optionally queried using OData.
+func (c *ServicePrincipalsClient) ListGroupMemberships(ctx context.Context, id string, query odata.Query) (*[]Group, int, error) {
+	var groups []Group
+	status, err := c.ListGroupMembershipsPager(id, query).all(ctx, &groups)
+	if err != nil {
+		return nil, status, fmt.Errorf("ServicePrincipalsClient.BaseClient.Get(): %w", err)
 	}
-	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
+	return &groups, status, nil
+}
+
+// ListGroupMembershipsPager returns a Pager for retrieving the Groups the Service Principal is member of one page at a
+// time, optionally queried using OData.
+func (c *ServicePrincipalsClient) ListGroupMembershipsPager(id string, query odata.Query) *Pager {
+	return c.BaseClient.NewPager(GetHttpRequestInput{
+		OData:            query,
 		ValidStatusCodes: []int{http.StatusOK},
 		Uri: Uri{
 			Entity:      fmt.Sprintf("/servicePrincipals/%s/transitiveMemberOf", id),
-			Params:      params,
 			HasTenantId: true,
 		},
 	})
-	if err != nil {
-		return nil, status, fmt.Errorf("ServicePrincipalsClient.BaseClient.Get(): %v", err)
-	}
-	defer resp.Body.Close()
-	respBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, status, fmt.Errorf("ioutil.ReadAll(): %v", err)
-	}
-	var data struct {
-		Groups []Group `json:"value"`
-	}
-	if err := json.Unmarshal(respBody, &data); err != nil {
-		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
-	}
-	return &data.Groups, status, nil
 }
 
 // AddPassword appends a new password credential to a Service Principal.
@@ -354,7 +307,7 @@ func (c *ServicePrincipalsClient) AddPassword(ctx context.Context, servicePrinci
 		},
 	})
 	if err != nil {
-		return nil, status, fmt.Errorf("ServicePrincipalsClient.BaseClient.Post(): %v", err)
+		return nil, status, fmt.Errorf("ServicePrincipalsClient.BaseClient.Post(): %w", err)
 	}
 	defer resp.Body.Close()
 	respBody, err := ioutil.ReadAll(resp.Body)
@@ -388,67 +341,56 @@ func (c *ServicePrincipalsClient) RemovePassword(ctx context.Context, servicePri
 		},
 	})
 	if err != nil {
-		return status, fmt.Errorf("ServicePrincipalsClient.BaseClient.Post(): %v", err)
+		return status, fmt.Errorf("ServicePrincipalsClient.BaseClient.Post(): %w", err)
 	}
 	return status, nil
 }
 
-// ListOwnedObjects retrieves the owned objects of the specified Service Principal.
+// ListOwnedObjects retrieves the owned objects of the specified Service Principal. Each object is returned as the
+// model for its type, e.g. *Application or *Group.
 // id is the object ID of the service principal.
-func (c *ServicePrincipalsClient) ListOwnedObjects(ctx context.Context, id string) (*[]string, int, error) {
-	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
+func (c *ServicePrincipalsClient) ListOwnedObjects(ctx context.Context, id string) (*[]DirectoryObject, int, error) {
+	var ownedObjects DirectoryObjects
+	status, err := c.resource().list(ctx, fmt.Sprintf("/servicePrincipals/%s/ownedObjects", id), odata.Query{}, &ownedObjects)
+	if err != nil {
+		return nil, status, err
+	}
+	ret := []DirectoryObject(ownedObjects)
+	return &ret, status, nil
+}
+
+// ListOwnedObjectsPager returns a Pager for retrieving the owned objects of the specified Service Principal one page at a time.
+// id is the object ID of the service principal.
+func (c *ServicePrincipalsClient) ListOwnedObjectsPager(id string) *Pager {
+	return c.BaseClient.NewPager(GetHttpRequestInput{
 		ValidStatusCodes: []int{http.StatusOK},
 		Uri: Uri{
 			Entity:      fmt.Sprintf("/servicePrincipals/%s/ownedObjects", id),
-			Params:      url.Values{"$select": []string{"id"}},
 			HasTenantId: true,
 		},
 	})
-	if err != nil {
-		return nil, status, err
-	}
-	defer resp.Body.Close()
-	respBody, _ := ioutil.ReadAll(resp.Body)
-	var data struct {
-		OwnedObjects []struct {
-			Type string `json:"@odata.type"`
-			Id   string `json:"id"`
-		} `json:"value"`
-	}
-	if err := json.Unmarshal(respBody, &data); err != nil {
-		return nil, status, err
-	}
-	ret := make([]string, len(data.OwnedObjects))
-	for i, v := range data.OwnedObjects {
-		ret[i] = v.Id
-	}
-	return &ret, status, nil
 }
 
 // ListAppRoleAssignments retrieves a list of appRoleAssignment that users, groups, or client service principals have been granted for the given resource service principal.
 func (c *ServicePrincipalsClient) ListAppRoleAssignments(ctx context.Context, resourceId string) (*[]AppRoleAssignment, int, error) {
-	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
+	var appRoleAssignments []AppRoleAssignment
+	status, err := c.ListAppRoleAssignmentsPager(resourceId).all(ctx, &appRoleAssignments)
+	if err != nil {
+		return nil, status, fmt.Errorf("ServicePrincipalsClient.BaseClient.Get(): %w", err)
+	}
+	return &appRoleAssignments, status, nil
+}
+
+// ListAppRoleAssignmentsPager returns a Pager for retrieving the appRoleAssignments granted for the given resource
+// service principal one page at a time.
+func (c *ServicePrincipalsClient) ListAppRoleAssignmentsPager(resourceId string) *Pager {
+	return c.BaseClient.NewPager(GetHttpRequestInput{
 		ValidStatusCodes: []int{http.StatusOK},
 		Uri: Uri{
 			Entity:      fmt.Sprintf("/servicePrincipals/%s/appRoleAssignedTo", resourceId),
 			HasTenantId: true,
 		},
 	})
-	if err != nil {
-		return nil, status, fmt.Errorf("ServicePrincipalsClient.BaseClient.Get(): %v", err)
-	}
-	defer resp.Body.Close()
-	respBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, status, fmt.Errorf("ioutil.ReadAll(): %v", err)
-	}
-	var data struct {
-		AppRoleAssignments []AppRoleAssignment `json:"value"`
-	}
-	if err := json.Unmarshal(respBody, &data); err != nil {
-		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
-	}
-	return &data.AppRoleAssignments, status, nil
 }
 
 // RemoveAppRoleAssignment deletes an appRoleAssignment that a user, group, or client service principal has been granted for a resource service principal.
@@ -461,7 +403,7 @@ func (c *ServicePrincipalsClient) RemoveAppRoleAssignment(ctx context.Context, r
 		},
 	})
 	if err != nil {
-		return status, fmt.Errorf("AppRoleAssignmentsClient.BaseClient.Delete(): %v", err)
+		return status, fmt.Errorf("AppRoleAssignmentsClient.BaseClient.Delete(): %w", err)
 	}
 	return status, nil
 }
@@ -497,7 +439,7 @@ func (c *ServicePrincipalsClient) AssignAppRoleForResource(ctx context.Context,
 		},
 	})
 	if err != nil {
-		return nil, status, fmt.Errorf("ServicePrincipalsClient.BaseClient.Post(): %v", err)
+		return nil, status, fmt.Errorf("ServicePrincipalsClient.BaseClient.Post(): %w", err)
 	}
 	defer resp.Body.Close()
 	respBody, err := ioutil.ReadAll(resp.Body)
@@ -1,3 +1,6 @@
+//go:build live
+// +build live
+
 package msgraph_test
 
 import (
@@ -10,6 +13,7 @@ import (
 	"github.com/manicminer/hamilton/internal/test"
 	"github.com/manicminer/hamilton/internal/utils"
 	"github.com/manicminer/hamilton/msgraph"
+	"github.com/manicminer/hamilton/odata"
 )
 
 type ServicePrincipalsClientTest struct {
@@ -225,7 +229,7 @@ func testServicePrincipalsClient_Update(t *testing.T, c ServicePrincipalsClientT
 }
 
 func testServicePrincipalsClient_List(t *testing.T, c ServicePrincipalsClientTest) (servicePrincipals *[]msgraph.ServicePrincipal) {
-	servicePrincipals, _, err := c.client.List(c.connection.Context, "")
+	servicePrincipals, _, err := c.client.List(c.connection.Context, odata.Query{})
 	if err != nil {
 		t.Fatalf("ServicePrincipalsClient.List(): %v", err)
 	}
@@ -260,7 +264,7 @@ func testServicePrincipalsClient_Delete(t *testing.T, c ServicePrincipalsClientT
 }
 
 func testServicePrincipalsClient_ListGroupMemberships(t *testing.T, c ServicePrincipalsClientTest, id string) (groups *[]msgraph.Group) {
-	groups, _, err := c.client.ListGroupMemberships(c.connection.Context, id, "")
+	groups, _, err := c.client.ListGroupMemberships(c.connection.Context, id, odata.Query{})
 	if err != nil {
 		t.Fatalf("ServicePrincipalsClient.ListGroupMemberships(): %v", err)
 	}
@@ -317,23 +321,30 @@ func testServicePrincipalsClient_AddOwners(t *testing.T, c ServicePrincipalsClie
 }
 
 func testServicePrincipalsClient_ListOwnedObjects(t *testing.T, c ServicePrincipalsClientTest, id string) (ownedObjects *[]string) {
-	ownedObjects, _, err := c.client.ListOwnedObjects(c.connection.Context, id)
+	result, _, err := c.client.ListOwnedObjects(c.connection.Context, id)
 	if err != nil {
 		t.Fatalf("ServicePrincipalsClient.ListOwnedObjects(): %v", err)
 	}
 
-	if ownedObjects == nil {
+	if result == nil {
 		t.Fatal("ServicePrincipalsClient.ListOwnedObjects(): ownedObjects was nil")
 	}
 
-	if len(*ownedObjects) != 1 {
-		t.Fatalf("ServicePrincipalsClient.ListOwnedObjects(): expected ownedObjects length 1. was: %d", len(*ownedObjects))
+	if len(*result) != 1 {
+		t.Fatalf("ServicePrincipalsClient.ListOwnedObjects(): expected ownedObjects length 1. was: %d", len(*result))
+	}
+	ownedObjects = &[]string{}
+	for _, o := range *result {
+		if o.GetID() == nil {
+			t.Fatal("ServicePrincipalsClient.ListOwnedObjects(): owned object ID was nil")
+		}
+		*ownedObjects = append(*ownedObjects, *o.GetID())
 	}
 	return
 }
 
 func testServicePrincipalsClient_ListOwners(t *testing.T, c ServicePrincipalsClientTest, id string, expected []string) (owners *[]string) {
-	owners, status, err := c.client.ListOwners(c.connection.Context, id)
+	result, status, err := c.client.ListOwners(c.connection.Context, id)
 	if err != nil {
 		t.Fatalf("ServicePrincipalsClient.ListOwners(): %v", err)
 	}
@@ -344,15 +355,15 @@ func testServicePrincipalsClient_ListOwners(t *testing.T, c ServicePrincipalsCli
 
 	ownersExpected := len(expected)
 
-	if len(*owners) < ownersExpected {
-		t.Fatalf("ServicePrincipalsClient.ListOwners(): expected at least %d owner. has: %d", ownersExpected, len(*owners))
+	if len(*result) < ownersExpected {
+		t.Fatalf("ServicePrincipalsClient.ListOwners(): expected at least %d owner. has: %d", ownersExpected, len(*result))
 	}
 
 	var ownersFound int
 
 	for _, e := range expected {
-		for _, o := range *owners {
-			if e == o {
+		for _, o := range *result {
+			if o.GetID() != nil && e == *o.GetID() {
 				ownersFound++
 				continue
 			}
@@ -362,6 +373,13 @@ func testServicePrincipalsClient_ListOwners(t *testing.T, c ServicePrincipalsCli
 	if ownersFound < ownersExpected {
 		t.Fatalf("ServicePrincipalsClient.ListOwners(): expected %d matching owners. found: %d", ownersExpected, ownersFound)
 	}
+	owners = &[]string{}
+	for _, o := range *result {
+		if o.GetID() == nil {
+			t.Fatal("ServicePrincipalsClient.ListOwners(): owner ID was nil")
+		}
+		*owners = append(*owners, *o.GetID())
+	}
 	return
 }
 
@@ -3,10 +3,11 @@ package msgraph
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io/ioutil"
 	"net/http"
-	"net/url"
+
+	"github.com/manicminer/hamilton/odata"
 )
 
 // UsersClient performs operations on Users.
@@ -21,111 +22,94 @@ func NewUsersClient(tenantId string) *UsersClient {
 	}
 }
 
-// List returns a list of Users, optionally filtered using OData.
-func (c *UsersClient) List(ctx context.Context, filter string) (*[]User, int, error) {
-	params := url.Values{}
-	if filter != "" {
-		params.Add("$filter", filter)
+// resource returns a Resource for performing common operations on Users.
+func (c *UsersClient) resource() Resource {
+	return Resource{
+		Client:      c.BaseClient,
+		Name:        "UsersClient",
+		Entity:      "/users",
+		DeletedType: "microsoft.graph.user",
 	}
-	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
-		ValidStatusCodes: []int{http.StatusOK},
-		Uri: Uri{
-			Entity:      "/users",
-			Params:      params,
-			HasTenantId: true,
-		},
-	})
+}
+
+// List returns a list of Users, optionally queried using OData.
+func (c *UsersClient) List(ctx context.Context, query odata.Query) (*[]User, int, error) {
+	var users []User
+	status, err := c.resource().List(ctx, query, &users)
 	if err != nil {
-		return nil, status, fmt.Errorf("UsersClient.BaseClient.Get(): %v", err)
+		return nil, status, err
 	}
-	defer resp.Body.Close()
-	respBody, err := ioutil.ReadAll(resp.Body)
+	return &users, status, nil
+}
+
+// ListPager returns a Pager for retrieving Users one page at a time, optionally queried using OData.
+func (c *UsersClient) ListPager(query odata.Query) *Pager {
+	return c.resource().ListPager(query)
+}
+
+// Delta retrieves Users which have been created, updated or removed since a previous delta query, optionally
+// queried using OData. Specify an empty deltaLink to retrieve all Users, or a deltaLink returned from a previous
+// call to retrieve only changes since that call. Users which have been removed have their Removed field populated.
+// The returned deltaLink should be persisted and used to retrieve subsequent changes.
+func (c *UsersClient) Delta(ctx context.Context, query odata.Query, deltaLink string) (*[]User, *string, int, error) {
+	var users []User
+	link, status, err := c.resource().Delta(ctx, query, deltaLink, &users)
 	if err != nil {
-		return nil, status, fmt.Errorf("ioutil.ReadAll(): %v", err)
-	}
-	var data struct {
-		Users []User `json:"value"`
-	}
-	if err := json.Unmarshal(respBody, &data); err != nil {
-		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+		return nil, nil, status, err
 	}
-	return &data.Users, status, nil
+	return &users, link, status, nil
 }
 
 // Create creates a new User.
 func (c *UsersClient) Create(ctx context.Context, user User) (*User, int, error) {
-	var status int
-	body, err := json.Marshal(user)
-	if err != nil {
-		return nil, status, fmt.Errorf("json.Marshal(): %v", err)
-	}
-	resp, status, _, err := c.BaseClient.Post(ctx, PostHttpRequestInput{
-		Body:             body,
-		ValidStatusCodes: []int{http.StatusCreated},
-		Uri: Uri{
-			Entity:      "/users",
-			HasTenantId: true,
-		},
-	})
-	if err != nil {
-		return nil, status, fmt.Errorf("UsersClient.BaseClient.Post(): %v", err)
-	}
-	defer resp.Body.Close()
-	respBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, status, fmt.Errorf("ioutil.ReadAll(): %v", err)
-	}
 	var newUser User
-	if err := json.Unmarshal(respBody, &newUser); err != nil {
-		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	status, err := c.resource().Create(ctx, user, &newUser)
+	if err != nil {
+		return nil, status, err
 	}
 	return &newUser, status, nil
 }
 
 // Get retrieves a User.
 func (c *UsersClient) Get(ctx context.Context, id string) (*User, int, error) {
-	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
-		ValidStatusCodes: []int{http.StatusOK},
-		Uri: Uri{
-			Entity:      fmt.Sprintf("/users/%s", id),
-			HasTenantId: true,
-		},
-	})
-	if err != nil {
-		return nil, status, fmt.Errorf("UsersClient.BaseClient.Get(): %v", err)
-	}
-	defer resp.Body.Close()
-	respBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, status, fmt.Errorf("ioutil.ReadAll(): %v", err)
-	}
 	var user User
-	if err := json.Unmarshal(respBody, &user); err != nil {
-		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	status, err := c.resource().Get(ctx, id, odata.Query{}, &user)
+	if err != nil {
+		return nil, status, err
 	}
 	return &user, status, nil
 }
 
-// GetDeleted retrieves a deleted User.
-func (c *UsersClient) GetDeleted(ctx context.Context, id string) (*User, int, error) {
-	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
-		ValidStatusCodes: []int{http.StatusOK},
-		Uri: Uri{
-			Entity:      fmt.Sprintf("/directory/deletedItems/%s", id),
-			HasTenantId: true,
-		},
+// WaitFor polls the specified User until predicate returns true, and then returns it.
+// The predicate is called with nil when the User does not exist, so WaitFor can be used to wait until a User
+// has been created or deleted, or until it has a particular property value. ctx should have a deadline, as WaitFor
+// will otherwise wait indefinitely for the predicate to be satisfied.
+func (c *UsersClient) WaitFor(ctx context.Context, id string, predicate func(user *User) bool) (*User, int, error) {
+	var user *User
+	var status int
+	err := c.BaseClient.waitFor(ctx, func(ctx context.Context) (bool, error) {
+		var err error
+		user, status, err = c.Get(ctx, id)
+		if err != nil {
+			if status != http.StatusNotFound {
+				return false, err
+			}
+			user = nil
+		}
+		return predicate(user), nil
 	})
 	if err != nil {
-		return nil, status, fmt.Errorf("UsersClient.BaseClient.Get(): %v", err)
-	}
-	defer resp.Body.Close()
-	respBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, status, fmt.Errorf("ioutil.ReadAll(): %v", err)
+		return nil, status, fmt.Errorf("UsersClient.BaseClient.waitFor(): %w", err)
 	}
+	return user, status, nil
+}
+
+// GetDeleted retrieves a deleted User.
+func (c *UsersClient) GetDeleted(ctx context.Context, id string) (*User, int, error) {
 	var user User
-	if err := json.Unmarshal(respBody, &user); err != nil {
-		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	status, err := c.resource().GetDeleted(ctx, id, odata.Query{}, &user)
+	if err != nil {
+		return nil, status, err
 	}
 	return &user, status, nil
 }
@@ -133,96 +117,53 @@ func (c *UsersClient) GetDeleted(ctx context.Context, id string) (*User, int, er
 // Update amends an existing User.
 func (c *UsersClient) Update(ctx context.Context, user User) (int, error) {
 	var status int
-	body, err := json.Marshal(user)
-	if err != nil {
-		return status, fmt.Errorf("json.Marshal(): %v", err)
+	if user.ID == nil {
+		return status, errors.New("UsersClient.Update(): cannot update user with nil ID")
 	}
-	_, status, _, err = c.BaseClient.Patch(ctx, PatchHttpRequestInput{
-		Body:             body,
-		ValidStatusCodes: []int{http.StatusNoContent},
-		Uri: Uri{
-			Entity:      fmt.Sprintf("/users/%s", *user.ID),
-			HasTenantId: true,
-		},
-	})
-	if err != nil {
-		return status, fmt.Errorf("UsersClient.BaseClient.Patch(): %v", err)
-	}
-	return status, nil
+	return c.resource().Update(ctx, *user.ID, user)
 }
 
 // Delete removes a User.
 func (c *UsersClient) Delete(ctx context.Context, id string) (int, error) {
-	_, status, _, err := c.BaseClient.Delete(ctx, DeleteHttpRequestInput{
-		ValidStatusCodes: []int{http.StatusNoContent},
-		Uri: Uri{
-			Entity:      fmt.Sprintf("/users/%s", id),
-			HasTenantId: true,
-		},
-	})
-	if err != nil {
-		return status, fmt.Errorf("UsersClient.BaseClient.Delete(): %v", err)
-	}
-	return status, nil
+	return c.resource().Delete(ctx, id)
 }
 
-// ListDeleted retrieves a list of recently deleted users, optionally filtered using OData.
-func (c *UsersClient) ListDeleted(ctx context.Context, filter string) (*[]User, int, error) {
-	params := url.Values{}
-	if filter != "" {
-		params.Add("$filter", filter)
-	}
-	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
-		ValidStatusCodes: []int{http.StatusOK},
-		Uri: Uri{
-			Entity:      "/directory/deleteditems/microsoft.graph.user",
-			Params:      params,
-			HasTenantId: true,
-		},
-	})
+// ListDeleted retrieves a list of recently deleted users, optionally queried using OData.
+func (c *UsersClient) ListDeleted(ctx context.Context, query odata.Query) (*[]User, int, error) {
+	var deletedUsers []User
+	status, err := c.resource().ListDeleted(ctx, query, &deletedUsers)
 	if err != nil {
 		return nil, status, err
 	}
-	defer resp.Body.Close()
-	respBody, _ := ioutil.ReadAll(resp.Body)
-	var data struct {
-		DeletedUsers []User `json:"value"`
-	}
-	if err = json.Unmarshal(respBody, &data); err != nil {
-		return nil, status, err
-	}
-	return &data.DeletedUsers, status, nil
+	return &deletedUsers, status, nil
 }
 
-// ListGroupMemberships returns a list of Groups the user is member of, optionally filtered using OData.
-func (c *UsersClient) ListGroupMemberships(ctx context.Context, id string, filter string) (*[]Group, int, error) {
-	params := url.Values{}
-	if filter != "" {
-		params.Add("$filter", filter)
+// ListDeletedPager returns a Pager for retrieving recently deleted users one page at a time, optionally queried using OData.
+func (c *UsersClient) ListDeletedPager(query odata.Query) *Pager {
+	return c.resource().ListDeletedPager(query)
+}
+
+// ListGroupMemberships returns a list of Groups the user is member of, optionally queried using OData.
+func (c *UsersClient) ListGroupMemberships(ctx context.Context, id string, query odata.Query) (*[]Group, int, error) {
+	var groups []Group
+	status, err := c.ListGroupMembershipsPager(id, query).all(ctx, &groups)
+	if err != nil {
+		return nil, status, fmt.Errorf("UsersClient.BaseClient.Get(): %w", err)
 	}
-	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
+	return &groups, status, nil
+}
+
+// ListGroupMembershipsPager returns a Pager for retrieving the Groups the user is member of one page at a time,
+// optionally queried using OData.
+func (c *UsersClient) ListGroupMembershipsPager(id string, query odata.Query) *Pager {
+	return c.BaseClient.NewPager(GetHttpRequestInput{
+		OData:            query,
 		ValidStatusCodes: []int{http.StatusOK},
 		Uri: Uri{
 			Entity:      fmt.Sprintf("/users/%s/transitiveMemberOf", id),
-			Params:      params,
 			HasTenantId: true,
 		},
 	})
-	if err != nil {
-		return nil, status, fmt.Errorf("UsersClient.BaseClient.Get(): %v", err)
-	}
-	defer resp.Body.Close()
-	respBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, status, fmt.Errorf("ioutil.ReadAll(): %v", err)
-	}
-	var data struct {
-		Groups []Group `json:"value"`
-	}
-	if err := json.Unmarshal(respBody, &data); err != nil {
-		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
-	}
-	return &data.Groups, status, nil
 }
 
 // SendMail sends message specified in the request body.
@@ -242,7 +183,7 @@ func (c *UsersClient) Sendmail(ctx context.Context, id string, message MailMessa
 		},
 	})
 	if err != nil {
-		return status, fmt.Errorf("UsersClient.BaseClient.Post(): %v", err)
+		return status, fmt.Errorf("UsersClient.BaseClient.Post(): %w", err)
 	}
 	return status, nil
 }
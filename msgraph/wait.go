@@ -0,0 +1,35 @@
+package msgraph
+
+import (
+	"context"
+	"time"
+
+	"github.com/manicminer/hamilton/retry"
+)
+
+const (
+	defaultPollInterval = 1 * time.Second
+	maxPollInterval     = 16 * time.Second
+)
+
+// waitFor is used by the package to poll until check reports that an object has reached the desired state, or until
+// ctx is done. Requests returning 404 Not Found are not retried, so that checks can observe that an object is absent.
+func (c Client) waitFor(ctx context.Context, check func(ctx context.Context) (bool, error)) error {
+	ctx = retry.WithPolicy(ctx, retry.WithoutEventualConsistency(c.retryPolicy(ctx)))
+	interval := defaultPollInterval
+	for {
+		done, err := check(ctx)
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+		if err := retry.Sleep(ctx, interval); err != nil {
+			return err
+		}
+		if interval *= 2; interval > maxPollInterval {
+			interval = maxPollInterval
+		}
+	}
+}
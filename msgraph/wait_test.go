@@ -0,0 +1,102 @@
+package msgraph_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/manicminer/hamilton/environments"
+	"github.com/manicminer/hamilton/msgraph"
+	"github.com/manicminer/hamilton/retry"
+)
+
+// newReplicatingServer returns a server for which a group is only visible once it has been requested a number of times.
+func newReplicatingServer(visibleAfter, deletedAfter int) (*httptest.Server, func() int) {
+	var mu sync.Mutex
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls++
+		n := calls
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		if n <= visibleAfter || (deletedAfter > 0 && n > deletedAfter) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error": {"code": "Request_ResourceNotFound", "message": "Resource 'group' does not exist."}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id": "group", "displayName": "test-group"}`))
+	}))
+	return server, func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return calls
+	}
+}
+
+func TestEventualConsistency(t *testing.T) {
+	server, calls := newReplicatingServer(2, 0)
+	defer server.Close()
+
+	client := msgraph.NewGroupsClient("tenant")
+	client.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+	ctx := context.Background()
+
+	if _, status, err := client.Get(retry.WithPolicy(ctx, retry.Disabled), "group"); err == nil || status != http.StatusNotFound {
+		t.Fatalf("GroupsClient.Get(): expected 404 without consistency mode, got status %d", status)
+	}
+
+	client.BaseClient.RetryPolicy = retry.EventualConsistency{
+		Window:   5 * time.Second,
+		Interval: 10 * time.Millisecond,
+	}
+	group, _, err := client.Get(ctx, "group")
+	if err != nil {
+		t.Fatalf("GroupsClient.Get(): %v", err)
+	}
+	if *group.DisplayName != "test-group" {
+		t.Fatalf("GroupsClient.Get(): unexpected group: %+v", group)
+	}
+	if n := calls(); n != 3 {
+		t.Fatalf("GroupsClient.Get(): expected 3 requests, got %d", n)
+	}
+}
+
+func TestGroupsClient_WaitFor(t *testing.T) {
+	server, calls := newReplicatingServer(1, 2)
+	defer server.Close()
+
+	client := msgraph.NewGroupsClient("tenant")
+	client.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+	client.BaseClient.RetryPolicy = retry.EventualConsistency{Window: time.Minute}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// the group is visible from the second request
+	group, _, err := client.WaitFor(ctx, "group", func(group *msgraph.Group) bool {
+		return group != nil && group.DisplayName != nil && *group.DisplayName == "test-group"
+	})
+	if err != nil {
+		t.Fatalf("GroupsClient.WaitFor(): %v", err)
+	}
+	if group == nil || *group.ID != "group" {
+		t.Fatalf("GroupsClient.WaitFor(): unexpected group: %+v", group)
+	}
+
+	// the group is deleted from the third request, which should not be retried despite the consistency mode
+	if _, _, err = client.WaitFor(ctx, "group", func(group *msgraph.Group) bool { return group == nil }); err != nil {
+		t.Fatalf("GroupsClient.WaitFor(): %v", err)
+	}
+	if n := calls(); n != 3 {
+		t.Fatalf("GroupsClient.WaitFor(): expected 3 requests, got %d", n)
+	}
+
+	shortCtx, shortCancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer shortCancel()
+	if _, _, err = client.WaitFor(shortCtx, "group", func(group *msgraph.Group) bool { return group != nil }); err == nil {
+		t.Fatalf("GroupsClient.WaitFor(): expected an error when the context deadline is exceeded")
+	}
+}
@@ -0,0 +1,228 @@
+package odata
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Filter is a composable $filter expression. Use the String method to render it, e.g. when populating Query.Filter.
+//
+// Property names inside an Any or All lambda are relative to the lambda variable. Use an empty property name to
+// refer to the lambda variable itself, which is useful when matching collections of primitive values:
+//
+//	odata.Any("groupTypes", odata.Eq("", "Unified")).String()
+//	// groupTypes/any(x:x eq 'Unified')
+type Filter interface {
+	String() string
+	render(lambdaVar string) string
+}
+
+// Guid is a UUID value, which is rendered as an unquoted Edm.Guid literal.
+// Note that many ID properties in Microsoft Graph, such as appId, are strings and should be compared using a string.
+type Guid string
+
+// Eq matches objects where property is equal to value.
+func Eq(property string, value interface{}) Filter {
+	return comparison{"eq", property, value}
+}
+
+// Ne matches objects where property is not equal to value.
+func Ne(property string, value interface{}) Filter {
+	return comparison{"ne", property, value}
+}
+
+// Gt matches objects where property is greater than value.
+func Gt(property string, value interface{}) Filter {
+	return comparison{"gt", property, value}
+}
+
+// Ge matches objects where property is greater than or equal to value.
+func Ge(property string, value interface{}) Filter {
+	return comparison{"ge", property, value}
+}
+
+// Lt matches objects where property is less than value.
+func Lt(property string, value interface{}) Filter {
+	return comparison{"lt", property, value}
+}
+
+// Le matches objects where property is less than or equal to value.
+func Le(property string, value interface{}) Filter {
+	return comparison{"le", property, value}
+}
+
+// StartsWith matches objects where the string property begins with value.
+func StartsWith(property, value string) Filter {
+	return function{"startswith", property, value}
+}
+
+// EndsWith matches objects where the string property ends with value.
+// This is an advanced query and requires ConsistencyLevelEventual and Count to be set in the Query.
+func EndsWith(property, value string) Filter {
+	return function{"endswith", property, value}
+}
+
+// In matches objects where property is equal to any of values.
+func In(property string, values ...interface{}) Filter {
+	return in{property, values}
+}
+
+// Any matches objects where at least one member of collection satisfies filter.
+func Any(collection string, filter Filter) Filter {
+	return lambda{"any", collection, filter}
+}
+
+// All matches objects where every member of collection satisfies filter.
+func All(collection string, filter Filter) Filter {
+	return lambda{"all", collection, filter}
+}
+
+// And matches objects satisfying all of filters.
+func And(filters ...Filter) Filter {
+	return logical{"and", filters}
+}
+
+// Or matches objects satisfying any of filters.
+func Or(filters ...Filter) Filter {
+	return logical{"or", filters}
+}
+
+// Not matches objects that do not satisfy filter.
+func Not(filter Filter) Filter {
+	return not{filter}
+}
+
+type comparison struct {
+	operator string
+	property string
+	value    interface{}
+}
+
+func (c comparison) String() string {
+	return c.render("")
+}
+
+func (c comparison) render(lambdaVar string) string {
+	return fmt.Sprintf("%s %s %s", propertyPath(lambdaVar, c.property), c.operator, formatValue(c.value))
+}
+
+type function struct {
+	name     string
+	property string
+	value    interface{}
+}
+
+func (f function) String() string {
+	return f.render("")
+}
+
+func (f function) render(lambdaVar string) string {
+	return fmt.Sprintf("%s(%s,%s)", f.name, propertyPath(lambdaVar, f.property), formatValue(f.value))
+}
+
+type in struct {
+	property string
+	values   []interface{}
+}
+
+func (i in) String() string {
+	return i.render("")
+}
+
+func (i in) render(lambdaVar string) string {
+	values := make([]string, len(i.values))
+	for n, v := range i.values {
+		values[n] = formatValue(v)
+	}
+	return fmt.Sprintf("%s in (%s)", propertyPath(lambdaVar, i.property), strings.Join(values, ","))
+}
+
+type lambda struct {
+	operator   string
+	collection string
+	filter     Filter
+}
+
+func (l lambda) String() string {
+	return l.render("")
+}
+
+func (l lambda) render(lambdaVar string) string {
+	// nested lambdas need their own variable name
+	v := lambdaVar + "x"
+	return fmt.Sprintf("%s/%s(%s:%s)", propertyPath(lambdaVar, l.collection), l.operator, v, l.filter.render(v))
+}
+
+type logical struct {
+	operator string
+	filters  []Filter
+}
+
+func (l logical) String() string {
+	return l.render("")
+}
+
+func (l logical) render(lambdaVar string) string {
+	exprs := make([]string, 0, len(l.filters))
+	for _, f := range l.filters {
+		if f == nil {
+			continue
+		}
+		expr := f.render(lambdaVar)
+		if _, ok := f.(logical); ok {
+			expr = fmt.Sprintf("(%s)", expr)
+		}
+		exprs = append(exprs, expr)
+	}
+	return strings.Join(exprs, fmt.Sprintf(" %s ", l.operator))
+}
+
+type not struct {
+	filter Filter
+}
+
+func (n not) String() string {
+	return n.render("")
+}
+
+func (n not) render(lambdaVar string) string {
+	return fmt.Sprintf("not(%s)", n.filter.render(lambdaVar))
+}
+
+// propertyPath returns the path to property, relative to the lambda variable when inside a lambda expression.
+func propertyPath(lambdaVar, property string) string {
+	switch {
+	case lambdaVar == "":
+		return property
+	case property == "":
+		return lambdaVar
+	}
+	return fmt.Sprintf("%s/%s", lambdaVar, property)
+}
+
+// formatValue returns an OData literal for the provided value.
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return fmt.Sprintf("'%s'", strings.ReplaceAll(v, "'", "''"))
+	case *string:
+		if v == nil {
+			return "null"
+		}
+		return formatValue(*v)
+	case Guid:
+		return string(v)
+	case bool:
+		return fmt.Sprintf("%t", v)
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return fmt.Sprintf("%v", v)
+	case time.Time:
+		return v.UTC().Format(time.RFC3339)
+	case fmt.Stringer:
+		return formatValue(v.String())
+	}
+	return formatValue(fmt.Sprintf("%v", value))
+}
@@ -0,0 +1,74 @@
+package odata_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/manicminer/hamilton/odata"
+)
+
+func TestFilter(t *testing.T) {
+	type testCase struct {
+		filter   odata.Filter
+		expected string
+	}
+	testCases := []testCase{
+		{
+			filter:   odata.Eq("displayName", "O'Brien's Group"),
+			expected: "displayName eq 'O''Brien''s Group'",
+		},
+		{
+			filter:   odata.Ne("accountEnabled", true),
+			expected: "accountEnabled ne true",
+		},
+		{
+			filter:   odata.Eq("appRoleId", odata.Guid("00000000-0000-0000-0000-000000000000")),
+			expected: "appRoleId eq 00000000-0000-0000-0000-000000000000",
+		},
+		{
+			filter:   odata.Ge("createdDateTime", time.Date(2021, 6, 1, 12, 30, 0, 0, time.FixedZone("", 3600))),
+			expected: "createdDateTime ge 2021-06-01T11:30:00Z",
+		},
+		{
+			filter:   odata.Eq("manager", nil),
+			expected: "manager eq null",
+		},
+		{
+			filter:   odata.StartsWith("displayName", "test-'"),
+			expected: "startswith(displayName,'test-''')",
+		},
+		{
+			filter:   odata.In("userType", "Guest", "Member"),
+			expected: "userType in ('Guest','Member')",
+		},
+		{
+			filter:   odata.Any("groupTypes", odata.Eq("", "Unified")),
+			expected: "groupTypes/any(x:x eq 'Unified')",
+		},
+		{
+			filter:   odata.All("identities", odata.Ne("issuer", "contoso.com")),
+			expected: "identities/all(x:x/issuer ne 'contoso.com')",
+		},
+		{
+			filter:   odata.Any("members", odata.Any("groupTypes", odata.Eq("", "Unified"))),
+			expected: "members/any(x:x/groupTypes/any(xx:xx eq 'Unified'))",
+		},
+		{
+			filter: odata.And(
+				odata.StartsWith("displayName", "test"),
+				odata.Or(odata.Eq("mailEnabled", true), odata.Eq("securityEnabled", true)),
+				odata.Not(odata.Any("groupTypes", odata.Eq("", "DynamicMembership"))),
+			),
+			expected: "startswith(displayName,'test') and (mailEnabled eq true or securityEnabled eq true) and not(groupTypes/any(x:x eq 'DynamicMembership'))",
+		},
+		{
+			filter:   odata.Le("passwordNotificationWindowInDays", 14),
+			expected: "passwordNotificationWindowInDays le 14",
+		},
+	}
+	for n, c := range testCases {
+		if s := c.filter.String(); s != c.expected {
+			t.Errorf("test case %d: expected %q, got %q", n, c.expected, s)
+		}
+	}
+}
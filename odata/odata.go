@@ -17,7 +17,7 @@ type OData struct {
 	Context      *string `json:"@odata.context"`
 	MetadataEtag *string `json:"@odata.metadataEtag"`
 	Type         *string `json:"@odata.type"`
-	Count        *string `json:"@odata.count"`
+	Count        *int    `json:"@odata.count"`
 	NextLink     *string `json:"@odata.nextLink"`
 	Delta        *string `json:"@odata.delta"`
 	DeltaLink    *string `json:"@odata.deltaLink"`
@@ -56,6 +56,21 @@ func (o *OData) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// Removed is returned by a delta query in place of an object which has been removed since the previous query.
+type Removed struct {
+	Reason *RemovedReason `json:"reason,omitempty"`
+}
+
+type RemovedReason string
+
+const (
+	// RemovedReasonChanged indicates the object was soft deleted and can be restored.
+	RemovedReasonChanged RemovedReason = "changed"
+
+	// RemovedReasonDeleted indicates the object was permanently deleted.
+	RemovedReasonDeleted RemovedReason = "deleted"
+)
+
 // Error is used to unmarshal an API error message.
 type Error struct {
 	Code            *string          `json:"code"`
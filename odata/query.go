@@ -0,0 +1,133 @@
+package odata
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ConsistencyLevel is the value of the ConsistencyLevel header sent with a request.
+type ConsistencyLevel string
+
+const (
+	// ConsistencyLevelEventual is required for advanced queries against directory objects, such as those using
+	// $count, $search, or $filter with the endsWith operator.
+	ConsistencyLevelEventual ConsistencyLevel = "eventual"
+)
+
+// Direction is the sort direction used with $orderby.
+type Direction string
+
+const (
+	Ascending  Direction = "asc"
+	Descending Direction = "desc"
+)
+
+// Expand describes a relationship to be retrieved inline using $expand.
+type Expand struct {
+	// Relationship is the name of the navigation property to expand.
+	Relationship string
+
+	// Select optionally restricts the properties returned for the expanded objects.
+	Select []string
+}
+
+func (e Expand) String() (s string) {
+	s = e.Relationship
+	if len(e.Select) > 0 {
+		s = fmt.Sprintf("%s($select=%s)", s, strings.Join(e.Select, ","))
+	}
+	return
+}
+
+// OrderBy describes the ordering of a collection using $orderby.
+type OrderBy struct {
+	// Field is the property to sort by.
+	Field string
+
+	// Direction is the sort direction, defaults to ascending when empty.
+	Direction Direction
+}
+
+func (o OrderBy) String() (s string) {
+	s = o.Field
+	if o.Direction != "" {
+		s = fmt.Sprintf("%s %s", s, o.Direction)
+	}
+	return
+}
+
+// Query describes the OData query options and headers to send with a request.
+// The zero value is an empty query which does not alter a request.
+type Query struct {
+	// ConsistencyLevel sets the ConsistencyLevel header. When Count or Search are specified and this is empty,
+	// ConsistencyLevelEventual is sent since these options are only supported as advanced queries.
+	ConsistencyLevel ConsistencyLevel
+
+	// Count requests a count of matching objects, returned in @odata.count. List methods return only the matching
+	// objects, so use a Pager to retrieve the count using Pager{}.Count().
+	Count bool
+
+	// Expand retrieves related objects inline.
+	Expand Expand
+
+	// Filter is a raw $filter expression.
+	Filter string
+
+	// OrderBy sorts the results.
+	OrderBy OrderBy
+
+	// Search restricts results using a $search expression, e.g. `displayName:foo`.
+	Search string
+
+	// Select restricts the properties returned for each object.
+	Select []string
+
+	// Top sets the page size.
+	Top int
+}
+
+// Headers returns an http.Header containing any headers required for the query.
+func (q Query) Headers() http.Header {
+	headers := http.Header{}
+	consistencyLevel := q.ConsistencyLevel
+	if consistencyLevel == "" && (q.Count || q.Search != "") {
+		consistencyLevel = ConsistencyLevelEventual
+	}
+	if consistencyLevel != "" {
+		headers.Set("ConsistencyLevel", string(consistencyLevel))
+	}
+	return headers
+}
+
+// Values returns a url.Values containing the query options.
+func (q Query) Values() url.Values {
+	params := url.Values{}
+	if q.Count {
+		params.Add("$count", "true")
+	}
+	if q.Expand.Relationship != "" {
+		params.Add("$expand", q.Expand.String())
+	}
+	if q.Filter != "" {
+		params.Add("$filter", q.Filter)
+	}
+	if q.OrderBy.Field != "" {
+		params.Add("$orderby", q.OrderBy.String())
+	}
+	if q.Search != "" {
+		search := q.Search
+		if !strings.HasPrefix(search, `"`) {
+			search = fmt.Sprintf("%q", search)
+		}
+		params.Add("$search", search)
+	}
+	if len(q.Select) > 0 {
+		params.Add("$select", strings.Join(q.Select, ","))
+	}
+	if q.Top > 0 {
+		params.Add("$top", fmt.Sprintf("%d", q.Top))
+	}
+	return params
+}
@@ -0,0 +1,75 @@
+package odata_test
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/manicminer/hamilton/odata"
+)
+
+func TestQuery(t *testing.T) {
+	type testCase struct {
+		query           odata.Query
+		expectedValues  url.Values
+		expectedHeaders http.Header
+	}
+	testCases := []testCase{
+		{
+			query:           odata.Query{},
+			expectedValues:  url.Values{},
+			expectedHeaders: http.Header{},
+		},
+		{
+			query: odata.Query{
+				Filter: "startswith(displayName,'test')",
+				Select: []string{"id", "displayName"},
+				Top:    50,
+			},
+			expectedValues: url.Values{
+				"$filter": []string{"startswith(displayName,'test')"},
+				"$select": []string{"id,displayName"},
+				"$top":    []string{"50"},
+			},
+			expectedHeaders: http.Header{},
+		},
+		{
+			query: odata.Query{
+				Count:   true,
+				Expand:  odata.Expand{Relationship: "members", Select: []string{"id"}},
+				OrderBy: odata.OrderBy{Field: "displayName", Direction: odata.Descending},
+				Search:  "displayName:test",
+			},
+			expectedValues: url.Values{
+				"$count":   []string{"true"},
+				"$expand":  []string{"members($select=id)"},
+				"$orderby": []string{"displayName desc"},
+				"$search":  []string{`"displayName:test"`},
+			},
+			expectedHeaders: http.Header{
+				"Consistencylevel": []string{"eventual"},
+			},
+		},
+		{
+			query: odata.Query{
+				ConsistencyLevel: odata.ConsistencyLevelEventual,
+				Filter:           "endswith(mail,'@example.com')",
+			},
+			expectedValues: url.Values{
+				"$filter": []string{"endswith(mail,'@example.com')"},
+			},
+			expectedHeaders: http.Header{
+				"Consistencylevel": []string{"eventual"},
+			},
+		},
+	}
+	for n, c := range testCases {
+		if v := c.query.Values(); !reflect.DeepEqual(v, c.expectedValues) {
+			t.Errorf("test case %d: expected values %#v, got %#v", n, c.expectedValues, v)
+		}
+		if h := c.query.Headers(); !reflect.DeepEqual(h, c.expectedHeaders) {
+			t.Errorf("test case %d: expected headers %#v, got %#v", n, c.expectedHeaders, h)
+		}
+	}
+}
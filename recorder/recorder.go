@@ -0,0 +1,335 @@
+// Package recorder provides a transport which records HTTP interactions with Microsoft Graph, Azure AD Graph and
+// token endpoints to a cassette file, and replays them deterministically so that clients can be tested offline.
+//
+// Recorded interactions are scrubbed before being written: sensitive headers, tokens, client secrets and passwords are
+// redacted using the logging package, tenant IDs found in the URLs of API and token requests are replaced with
+// TenantPlaceholder, and any other values registered using Replace(), such as domain names, are replaced wherever they
+// appear.
+//
+// A typical test records interactions with a real tenant once, and replays them thereafter:
+//
+//	rec, err := recorder.New("testdata/users.json", recorder.ModeFromEnv())
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	defer rec.Stop()
+//	rec.Replace(domainName, "example.com")
+//
+//	client := msgraph.NewUsersClient(tenantId)
+//	client.BaseClient.HttpClient = rec.Client()
+//
+// The same client should be passed to auth.Config{}.HttpClient so that token requests are also recorded.
+//
+// Recording is a manual step which requires credentials for a real tenant. Cassettes should be reviewed before being
+// committed, and should not be recorded against msgraphtest since they would then only reflect the fake server.
+package recorder
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/manicminer/hamilton/logging"
+	"github.com/manicminer/hamilton/transport"
+)
+
+// ModeEnvVar is the environment variable read by ModeFromEnv.
+const ModeEnvVar = "HAMILTON_RECORDER_MODE"
+
+// TenantPlaceholder replaces tenant IDs found in the URLs of recorded requests.
+const TenantPlaceholder = "00000000-0000-0000-0000-000000000000"
+
+// tenantPattern matches tenant IDs, which are either GUIDs or domain names.
+var tenantPattern = regexp.MustCompile(`^(?i:[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}|[a-z0-9-]+(\.[a-z0-9-]+)+)$`)
+
+// Mode determines whether a Recorder records or replays interactions.
+type Mode int
+
+const (
+	// ModeReplay replays interactions from an existing cassette. Requests which were not recorded fail with an error.
+	ModeReplay Mode = iota
+
+	// ModeRecord sends requests using the underlying transport and records the interactions, replacing any existing
+	// cassette when the Recorder is stopped.
+	ModeRecord
+
+	// ModePassthrough sends requests using the underlying transport without recording them.
+	ModePassthrough
+)
+
+// ModeFromEnv returns the Mode specified by the HAMILTON_RECORDER_MODE environment variable, which may be "record",
+// "replay" or "passthrough". ModeReplay is returned when the variable is not set.
+func ModeFromEnv() Mode {
+	switch strings.ToLower(os.Getenv(ModeEnvVar)) {
+	case "record":
+		return ModeRecord
+	case "passthrough":
+		return ModePassthrough
+	}
+	return ModeReplay
+}
+
+// Cassette is a sequence of recorded interactions, stored as JSON.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a single recorded request and its response.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded request.
+type Request struct {
+	Method  string      `json:"method"`
+	Url     string      `json:"url"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+}
+
+// Response is a recorded response.
+type Response struct {
+	StatusCode int         `json:"status"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Matcher reports whether a request being replayed matches a recorded request. The URL of req has already been
+// scrubbed of tenant IDs and any values registered with Replace().
+type Matcher func(req *http.Request, recorded Request) bool
+
+// DefaultMatcher matches requests with the same method and URL.
+func DefaultMatcher(req *http.Request, recorded Request) bool {
+	return req.Method == recorded.Method && req.URL.String() == recorded.Url
+}
+
+// Recorder records or replays HTTP interactions. It is safe for concurrent use.
+type Recorder struct {
+	// Matcher determines whether a request matches a recorded request when replaying, and defaults to DefaultMatcher.
+	// Recorded interactions are considered in order and each is replayed at most once, so repeated requests receive
+	// the responses in the order they were recorded.
+	Matcher Matcher
+
+	mode         Mode
+	path         string
+	cassette     Cassette
+	replayed     []bool
+	replacements []string
+	mutex        sync.Mutex
+}
+
+// New returns a Recorder for the cassette at path. In ModeReplay, the cassette must already exist.
+func New(path string, mode Mode) (*Recorder, error) {
+	r := &Recorder{
+		mode: mode,
+		path: path,
+	}
+	if mode == ModeReplay {
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading cassette: %v", err)
+		}
+		if err := json.Unmarshal(b, &r.cassette); err != nil {
+			return nil, fmt.Errorf("parsing cassette %q: %v", path, err)
+		}
+		r.replayed = make([]bool, len(r.cassette.Interactions))
+	}
+	return r, nil
+}
+
+// Mode returns the mode of the Recorder.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// Replace registers a sensitive value, such as a tenant ID, which is replaced with replacement wherever it appears in
+// a recorded interaction. When replaying, the value is also replaced in request URLs before matching them, so tests
+// may use either the real or the replacement value. Empty values are ignored.
+func (r *Recorder) Replace(value, replacement string) {
+	if value == "" {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.replacements = append(r.replacements, value, replacement)
+}
+
+// Middleware returns a transport.Middleware which records or replays requests. When replaying, requests are never
+// passed to the next transport.
+func (r *Recorder) Middleware() transport.Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return transport.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			switch r.mode {
+			case ModeReplay:
+				return r.replay(req)
+			case ModeRecord:
+				return r.record(next, req)
+			}
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+// Client returns a new http.Client which records or replays requests using http.DefaultTransport.
+func (r *Recorder) Client() *http.Client {
+	return transport.NewClient(nil, r.Middleware())
+}
+
+// Stop writes the cassette when recording. It should be called once all requests have completed.
+func (r *Recorder) Stop() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	b, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return fmt.Errorf("json.MarshalIndent(): %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return fmt.Errorf("creating cassette directory: %v", err)
+	}
+	if err := ioutil.WriteFile(r.path, append(b, '\n'), 0644); err != nil {
+		return fmt.Errorf("writing cassette: %v", err)
+	}
+	return nil
+}
+
+// record sends req using next and records the scrubbed interaction.
+func (r *Recorder) record(next http.RoundTripper, req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		if reqBody, err = ioutil.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+		req.Body = ioutil.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	resp, err := next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.replaceTenant(req.URL)
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: Request{
+			Method:  req.Method,
+			Url:     r.scrub(req.URL.String()),
+			Headers: r.scrubHeaders(req.Header),
+			Body:    r.scrub(string(logging.RedactBody(reqBody))),
+		},
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Headers:    r.scrubHeaders(resp.Header),
+			Body:       r.scrub(string(logging.RedactBody(respBody))),
+		},
+	})
+	return resp, nil
+}
+
+// replay returns the response from the first recorded interaction matching req which has not yet been replayed.
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.replaceTenant(req.URL)
+
+	scrubbed := *req
+	u := *req.URL
+	if parsed, err := u.Parse(r.scrub(req.URL.String())); err == nil {
+		u = *parsed
+	}
+	scrubbed.URL = &u
+
+	matcher := r.Matcher
+	if matcher == nil {
+		matcher = DefaultMatcher
+	}
+	for i, interaction := range r.cassette.Interactions {
+		if r.replayed[i] || !matcher(&scrubbed, interaction.Request) {
+			continue
+		}
+		r.replayed[i] = true
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        interaction.Response.Headers.Clone(),
+			Body:          ioutil.NopCloser(strings.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("recorder: no unplayed interaction in %q matches %s %s", r.path, req.Method, u.String())
+}
+
+// replaceTenant registers the tenant ID found in the URL of a request, if any, to be replaced with TenantPlaceholder.
+// Tenant IDs are found in token endpoints, e.g. https://login.microsoftonline.com/{tenant}/oauth2/v2.0/token, in
+// Microsoft Graph requests, e.g. https://graph.microsoft.com/v1.0/{tenant}/users, and in Azure AD Graph requests, e.g.
+// https://graph.windows.net/{tenant}/applications.
+func (r *Recorder) replaceTenant(u *url.URL) {
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	var tenant string
+	switch {
+	case len(segments) > 1 && segments[1] == "oauth2":
+		tenant = segments[0]
+	case len(segments) > 1 && (segments[0] == "v1.0" || segments[0] == "beta"):
+		tenant = segments[1]
+	case len(segments) > 0:
+		tenant = segments[0]
+	}
+	if tenant == TenantPlaceholder || !tenantPattern.MatchString(tenant) {
+		return
+	}
+	for i := 0; i < len(r.replacements); i += 2 {
+		if r.replacements[i] == tenant {
+			return
+		}
+	}
+	r.replacements = append(r.replacements, tenant, TenantPlaceholder)
+}
+
+// scrub replaces all registered sensitive values in s.
+func (r *Recorder) scrub(s string) string {
+	if len(r.replacements) == 0 {
+		return s
+	}
+	return strings.NewReplacer(r.replacements...).Replace(s)
+}
+
+// scrubHeaders returns a copy of headers with sensitive headers redacted and registered values replaced.
+func (r *Recorder) scrubHeaders(headers http.Header) http.Header {
+	ret := logging.RedactHeaders(headers)
+	for k, values := range ret {
+		for i, v := range values {
+			values[i] = r.scrub(v)
+		}
+		ret[k] = values
+	}
+	return ret
+}
@@ -0,0 +1,133 @@
+package recorder_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/manicminer/hamilton/auth"
+	"github.com/manicminer/hamilton/environments"
+	"github.com/manicminer/hamilton/internal/utils"
+	"github.com/manicminer/hamilton/msgraph"
+	"github.com/manicminer/hamilton/msgraph/msgraphtest"
+	"github.com/manicminer/hamilton/odata"
+	"github.com/manicminer/hamilton/recorder"
+	"github.com/manicminer/hamilton/retry"
+	"github.com/manicminer/hamilton/transport"
+)
+
+const (
+	tenantId     = "11111111-2222-3333-4444-555555555555"
+	clientSecret = "super-secret-value"
+	accessToken  = "eyJ0eXAiOiJKV1QiLCJhbGciOiJub25lIn0.e30."
+)
+
+// fakeTokenEndpoint responds to token requests, and passes all other requests to next.
+func fakeTokenEndpoint(next http.RoundTripper) http.RoundTripper {
+	return transport.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if !strings.HasSuffix(req.URL.Path, "/token") {
+			return next.RoundTrip(req)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       ioutil.NopCloser(strings.NewReader(`{"access_token": "` + accessToken + `", "token_type": "Bearer", "expires_in": 3600}`)),
+			Request:    req,
+		}, nil
+	})
+}
+
+// offline fails every request, to ensure that replayed requests are never sent.
+var offline = transport.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+	return nil, errors.New("network access is not allowed")
+})
+
+func exercise(t *testing.T, httpClient *http.Client, endpoint environments.ApiEndpoint) []string {
+	authConfig := auth.Config{
+		Environment:            environments.Global,
+		TenantID:               tenantId,
+		ClientID:               "client",
+		ClientSecret:           clientSecret,
+		EnableClientSecretAuth: true,
+		HttpClient:             httpClient,
+	}
+	authorizer, err := authConfig.NewAuthorizer(context.Background(), auth.MsGraph)
+	if err != nil {
+		t.Fatalf("Config.NewAuthorizer(): %v", err)
+	}
+
+	client := msgraph.NewUsersClient(tenantId)
+	client.BaseClient.Authorizer = authorizer
+	client.BaseClient.Endpoint = endpoint
+	client.BaseClient.HttpClient = httpClient
+	client.BaseClient.RetryPolicy = retry.Disabled
+	ctx := context.Background()
+
+	user, _, err := client.Create(ctx, msgraph.User{
+		DisplayName:       utils.StringPtr("test-user"),
+		UserPrincipalName: utils.StringPtr("test-user@example.com"),
+		PasswordProfile:   &msgraph.UserPasswordProfile{Password: utils.StringPtr("hunter2")},
+	})
+	if err != nil {
+		t.Fatalf("UsersClient.Create(): %v", err)
+	}
+	if _, _, err := client.Get(ctx, *user.ID); err != nil {
+		t.Fatalf("UsersClient.Get(): %v", err)
+	}
+	users, _, err := client.List(ctx, odata.Query{Filter: "displayName eq 'test-user'"})
+	if err != nil {
+		t.Fatalf("UsersClient.List(): %v", err)
+	}
+	ids := []string{*user.ID}
+	for _, u := range *users {
+		ids = append(ids, *u.ID)
+	}
+	return ids
+}
+
+func TestRecorder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "testdata", "users.json")
+	server := msgraphtest.NewServer()
+	endpoint := server.Endpoint()
+
+	rec, err := recorder.New(path, recorder.ModeRecord)
+	if err != nil {
+		t.Fatalf("recorder.New(): %v", err)
+	}
+	recorded := exercise(t, transport.NewClient(&http.Client{Transport: fakeTokenEndpoint(http.DefaultTransport)}, rec.Middleware()), endpoint)
+	if err := rec.Stop(); err != nil {
+		t.Fatalf("Recorder.Stop(): %v", err)
+	}
+	server.Close()
+
+	cassette, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading cassette: %v", err)
+	}
+	for _, secret := range []string{tenantId, clientSecret, accessToken, "hunter2"} {
+		if strings.Contains(string(cassette), secret) {
+			t.Errorf("expected %q to be scrubbed from cassette", secret)
+		}
+	}
+
+	rec, err = recorder.New(path, recorder.ModeReplay)
+	if err != nil {
+		t.Fatalf("recorder.New(): %v", err)
+	}
+	replayed := exercise(t, transport.NewClient(&http.Client{Transport: offline}, rec.Middleware()), endpoint)
+	if strings.Join(recorded, ",") != strings.Join(replayed, ",") {
+		t.Errorf("expected replayed IDs %v to match recorded IDs %v", replayed, recorded)
+	}
+
+	client := msgraph.NewUsersClient(tenantId)
+	client.BaseClient.Endpoint = endpoint
+	client.BaseClient.HttpClient = rec.Client()
+	client.BaseClient.RetryPolicy = retry.Disabled
+	if _, _, err := client.Get(context.Background(), recorded[0]); err == nil || !strings.Contains(err.Error(), "no unplayed interaction") {
+		t.Errorf("expected an error for a request which was not recorded, got: %v", err)
+	}
+}
@@ -0,0 +1,249 @@
+package retry
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/manicminer/hamilton/odata"
+)
+
+// Attempt describes the outcome of a single attempt at performing a request.
+type Attempt struct {
+	// Number is the number of attempts made so far, starting at 1.
+	Number int
+
+	// Start is the time at which the first attempt was made.
+	Start time.Time
+
+	// Request is the request that was attempted.
+	Request *http.Request
+
+	// Response is the response received, which is nil when Err is set.
+	Response *http.Response
+
+	// OData is the OData metadata parsed from the response, if any.
+	OData *odata.OData
+
+	// Err is the error returned by the HTTP client, usually a network error.
+	Err error
+}
+
+// Policy determines whether a failed request should be retried, and how long to wait beforehand.
+type Policy interface {
+	// Retry is called after each unsuccessful attempt, and returns the delay before the next attempt along with
+	// whether the request should be retried.
+	Retry(attempt Attempt) (time.Duration, bool)
+}
+
+// Disabled is a Policy that never retries requests.
+var Disabled Policy = disabled{}
+
+type disabled struct{}
+
+func (disabled) Retry(Attempt) (time.Duration, bool) {
+	return 0, false
+}
+
+// ExponentialBackoff is a Policy that retries requests using an exponential backoff with random jitter.
+// Any delay requested by the API using a Retry-After header or a BackoffTime error value takes precedence.
+type ExponentialBackoff struct {
+	// MaxAttempts is the maximum number of attempts to make, including the initial request.
+	MaxAttempts int
+
+	// InitialBackoff is the delay before the first retry, which doubles with each subsequent retry.
+	InitialBackoff time.Duration
+
+	// MaxBackoff is the maximum delay between attempts, excluding any delay requested by the API.
+	MaxBackoff time.Duration
+
+	// Jitter is the fraction by which the delay is randomly varied in either direction, between 0 and 1.
+	Jitter float64
+
+	// RetryableStatusCodes are the response status codes for which requests are retried.
+	RetryableStatusCodes []int
+
+	// RetryNetworkErrors enables retrying of idempotent requests which fail with a network error.
+	RetryNetworkErrors bool
+}
+
+// DefaultPolicy returns the Policy used by API clients unless otherwise configured.
+func DefaultPolicy() ExponentialBackoff {
+	return ExponentialBackoff{
+		MaxAttempts:    10,
+		InitialBackoff: 1 * time.Second,
+		MaxBackoff:     64 * time.Second,
+		Jitter:         0.2,
+		RetryableStatusCodes: []int{
+			http.StatusFailedDependency,
+			http.StatusTooManyRequests,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		RetryNetworkErrors: true,
+	}
+}
+
+// Retry implements Policy.
+func (p ExponentialBackoff) Retry(attempt Attempt) (time.Duration, bool) {
+	if attempt.Number >= p.MaxAttempts {
+		return 0, false
+	}
+
+	switch {
+	case attempt.Err != nil:
+		if !p.RetryNetworkErrors || attempt.Request == nil || !isIdempotent(attempt.Request.Method) {
+			return 0, false
+		}
+	case attempt.Response != nil:
+		retryable := false
+		for _, s := range p.RetryableStatusCodes {
+			if attempt.Response.StatusCode == s {
+				retryable = true
+				break
+			}
+		}
+		if !retryable {
+			return 0, false
+		}
+		if d, ok := RetryAfter(attempt.Response, attempt.OData); ok {
+			return d, true
+		}
+	default:
+		return 0, false
+	}
+
+	backoff := p.InitialBackoff
+	for i := 1; i < attempt.Number && backoff < p.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if p.MaxBackoff > 0 && backoff > p.MaxBackoff {
+		backoff = p.MaxBackoff
+	}
+	if p.Jitter > 0 {
+		backoff += time.Duration((rand.Float64()*2 - 1) * p.Jitter * float64(backoff))
+	}
+	return backoff, true
+}
+
+// EventualConsistency is a Policy which retries GET requests that fail with a 404 Not Found response until Window has
+// elapsed since the first attempt. Objects in Azure Active Directory are not always immediately available after they
+// are created, so this can be used to avoid errors when reading newly created objects. Requests using other methods are
+// not retried when they return 404 Not Found, since they are not necessarily safe to repeat. All other failures are
+// retried according to Policy, or DefaultPolicy when Policy is nil.
+type EventualConsistency struct {
+	// Policy determines how all other failures are retried.
+	Policy Policy
+
+	// Window is the duration for which requests returning 404 Not Found are retried.
+	Window time.Duration
+
+	// Interval is the delay between attempts for requests returning 404 Not Found, which defaults to 2 seconds.
+	Interval time.Duration
+}
+
+// Retry implements Policy.
+func (p EventualConsistency) Retry(attempt Attempt) (time.Duration, bool) {
+	if attempt.Response != nil && attempt.Response.StatusCode == http.StatusNotFound && isRead(attempt.Request) {
+		interval := p.Interval
+		if interval <= 0 {
+			interval = 2 * time.Second
+		}
+		if attempt.Start.IsZero() || time.Since(attempt.Start)+interval > p.Window {
+			return 0, false
+		}
+		return interval, true
+	}
+	return p.inner().Retry(attempt)
+}
+
+// inner returns the Policy used for failures other than 404 Not Found.
+func (p EventualConsistency) inner() Policy {
+	if p.Policy == nil {
+		return DefaultPolicy()
+	}
+	return p.Policy
+}
+
+// WithoutEventualConsistency returns policy with any EventualConsistency wrappers removed, including nested wrappers
+// and those specified by pointer, which is useful when deliberately checking for the absence of an object.
+func WithoutEventualConsistency(policy Policy) Policy {
+	switch p := policy.(type) {
+	case EventualConsistency:
+		return WithoutEventualConsistency(p.inner())
+	case *EventualConsistency:
+		if p != nil {
+			return WithoutEventualConsistency(p.inner())
+		}
+	}
+	return policy
+}
+
+// RetryAfter returns the delay requested by the API before retrying, from either a Retry-After header containing a
+// number of seconds or an HTTP date, or a BackoffTime value in an OData error.
+func RetryAfter(resp *http.Response, o *odata.OData) (time.Duration, bool) {
+	if resp != nil {
+		if v := resp.Header.Get("Retry-After"); v != "" {
+			if s, err := strconv.ParseFloat(v, 64); err == nil {
+				if s > 0 {
+					return time.Duration(s * float64(time.Second)), true
+				}
+			} else if t, err := http.ParseTime(v); err == nil {
+				if d := time.Until(t); d > 0 {
+					return d, true
+				}
+			}
+		}
+	}
+	if o != nil && o.Error != nil && o.Error.Values != nil {
+		for _, v := range *o.Error.Values {
+			if v.Item == "BackoffTime" {
+				if s, err := strconv.ParseFloat(v.Value, 64); err == nil && s > 0 {
+					return time.Duration(s * float64(time.Second)), true
+				}
+				break
+			}
+		}
+	}
+	return 0, false
+}
+
+// Sleep waits for the specified duration, returning early with an error if ctx is cancelled first.
+func Sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+type contextKey struct{}
+
+// WithPolicy returns a copy of ctx which overrides the retry policy for any requests made using it.
+// Use WithPolicy(ctx, Disabled) to disable retries for latency sensitive requests.
+func WithPolicy(ctx context.Context, policy Policy) context.Context {
+	return context.WithValue(ctx, contextKey{}, policy)
+}
+
+// FromContext returns the retry policy set using WithPolicy, if any.
+func FromContext(ctx context.Context) (Policy, bool) {
+	p, ok := ctx.Value(contextKey{}).(Policy)
+	return p, ok && p != nil
+}
+
+func isRead(req *http.Request) bool {
+	return req != nil && (req.Method == http.MethodGet || req.Method == http.MethodHead)
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,131 @@
+package retry_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/manicminer/hamilton/odata"
+	"github.com/manicminer/hamilton/retry"
+)
+
+func TestExponentialBackoff(t *testing.T) {
+	policy := retry.DefaultPolicy()
+	policy.Jitter = 0
+
+	response := func(status int, retryAfter string) *http.Response {
+		resp := &http.Response{StatusCode: status, Header: http.Header{}}
+		if retryAfter != "" {
+			resp.Header.Set("Retry-After", retryAfter)
+		}
+		return resp
+	}
+	get, _ := http.NewRequest(http.MethodGet, "https://graph.microsoft.com/v1.0/users", nil)
+	post, _ := http.NewRequest(http.MethodPost, "https://graph.microsoft.com/v1.0/users", nil)
+
+	type testCase struct {
+		attempt       retry.Attempt
+		expectedRetry bool
+		expectedDelay time.Duration
+	}
+	testCases := []testCase{
+		{
+			attempt:       retry.Attempt{Number: 1, Request: get, Response: response(http.StatusTooManyRequests, "")},
+			expectedRetry: true,
+			expectedDelay: 1 * time.Second,
+		},
+		{
+			attempt:       retry.Attempt{Number: 3, Request: get, Response: response(http.StatusServiceUnavailable, "")},
+			expectedRetry: true,
+			expectedDelay: 4 * time.Second,
+		},
+		{
+			attempt:       retry.Attempt{Number: 9, Request: get, Response: response(http.StatusServiceUnavailable, "")},
+			expectedRetry: true,
+			expectedDelay: 64 * time.Second,
+		},
+		{
+			attempt:       retry.Attempt{Number: 10, Request: get, Response: response(http.StatusServiceUnavailable, "")},
+			expectedRetry: false,
+		},
+		{
+			attempt:       retry.Attempt{Number: 1, Request: get, Response: response(http.StatusTooManyRequests, "30")},
+			expectedRetry: true,
+			expectedDelay: 30 * time.Second,
+		},
+		{
+			attempt:       retry.Attempt{Number: 1, Request: get, Response: response(http.StatusNotFound, "")},
+			expectedRetry: false,
+		},
+		{
+			attempt:       retry.Attempt{Number: 1, Request: get, Err: errors.New("connection reset by peer")},
+			expectedRetry: true,
+			expectedDelay: 1 * time.Second,
+		},
+		{
+			attempt:       retry.Attempt{Number: 1, Request: post, Err: errors.New("connection reset by peer")},
+			expectedRetry: false,
+		},
+	}
+	for n, c := range testCases {
+		delay, ok := policy.Retry(c.attempt)
+		if ok != c.expectedRetry {
+			t.Errorf("test case %d: expected retry %t, got %t", n, c.expectedRetry, ok)
+		}
+		if ok && delay != c.expectedDelay {
+			t.Errorf("test case %d: expected delay %s, got %s", n, c.expectedDelay, delay)
+		}
+	}
+
+	if _, ok := retry.Disabled.Retry(testCases[0].attempt); ok {
+		t.Errorf("expected Disabled policy not to retry")
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	resp := &http.Response{Header: http.Header{}}
+	resp.Header.Set("Retry-After", time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))
+	if d, ok := retry.RetryAfter(resp, nil); !ok || d <= 50*time.Second || d > time.Minute {
+		t.Errorf("expected delay of about 1 minute from HTTP date, got %s", d)
+	}
+
+	backoffTime := `{"odata.error": {"code": "Request_ThrottledTemporarily", "values": [{"item": "BackoffTime", "value": "5"}]}}`
+	var o odata.OData
+	if err := o.UnmarshalJSON([]byte(backoffTime)); err != nil {
+		t.Fatalf("OData.UnmarshalJSON(): %v", err)
+	}
+	if d, ok := retry.RetryAfter(&http.Response{Header: http.Header{}}, &o); !ok || d != 5*time.Second {
+		t.Errorf("expected delay of 5s from BackoffTime, got %s", d)
+	}
+}
+
+func TestEventualConsistency(t *testing.T) {
+	policy := retry.EventualConsistency{Window: time.Minute, Interval: 5 * time.Second}
+	notFound := &http.Response{StatusCode: http.StatusNotFound, Header: http.Header{}}
+	get := &http.Request{Method: http.MethodGet}
+
+	if d, ok := policy.Retry(retry.Attempt{Number: 1, Start: time.Now(), Request: get, Response: notFound}); !ok || d != 5*time.Second {
+		t.Errorf("expected 404 to be retried after 5s within the window, got %t after %s", ok, d)
+	}
+	if _, ok := policy.Retry(retry.Attempt{Number: 5, Start: time.Now().Add(-2 * time.Minute), Request: get, Response: notFound}); ok {
+		t.Errorf("expected 404 not to be retried after the window has elapsed")
+	}
+	for _, method := range []string{http.MethodPost, http.MethodPatch, http.MethodDelete} {
+		if _, ok := policy.Retry(retry.Attempt{Number: 1, Start: time.Now(), Request: &http.Request{Method: method}, Response: notFound}); ok {
+			t.Errorf("expected 404 not to be retried for a %s request", method)
+		}
+	}
+	if _, ok := policy.Retry(retry.Attempt{Number: 1, Start: time.Now(), Response: &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}}); !ok {
+		t.Errorf("expected throttled request to be retried by the default policy")
+	}
+	for _, p := range []retry.Policy{
+		policy,
+		&policy,
+		retry.EventualConsistency{Policy: &policy, Window: time.Minute},
+	} {
+		if _, ok := retry.WithoutEventualConsistency(p).Retry(retry.Attempt{Number: 1, Start: time.Now(), Request: get, Response: notFound}); ok {
+			t.Errorf("expected 404 not to be retried without eventual consistency for %T", p)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+// Package telemetry provides optional tracing and metrics instrumentation for API requests and token acquisition.
+//
+// To avoid a dependency on any particular library, instrumentation is exposed using the small Tracer, Span and Meter
+// interfaces, which are straightforward to implement using OpenTelemetry. For example:
+//
+//	type otelTracer struct{ trace.Tracer }
+//
+//	func (t otelTracer) Start(ctx context.Context, name string, attrs ...telemetry.Attribute) (context.Context, telemetry.Span) {
+//		ctx, span := t.Tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(convert(attrs)...))
+//		return ctx, otelSpan{span}
+//	}
+//
+// When no Tracer or Meter is configured, no spans or measurements are created.
+package telemetry
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Attribute keys used for spans and measurements.
+const (
+	AttributeAttempt     = "hamilton.attempt"
+	AttributeAuthMethod  = "hamilton.auth.method"
+	AttributeEntity      = "hamilton.entity"
+	AttributeRequestId   = "hamilton.request_id"
+	AttributeThrottled   = "hamilton.throttled"
+	AttributeHttpMethod  = "http.method"
+	AttributeHttpStatus  = "http.status_code"
+	AttributeHttpUrl     = "http.url"
+	AttributeServiceName = "rpc.service"
+)
+
+// Metric names used for measurements.
+const (
+	// MetricRequestDuration is a histogram of the duration of each request attempt, in seconds.
+	MetricRequestDuration = "hamilton.request.duration"
+
+	// MetricRequests is a counter of request attempts.
+	MetricRequests = "hamilton.requests"
+
+	// MetricThrottledRequests is a counter of request attempts which were throttled with a 429 response.
+	MetricThrottledRequests = "hamilton.requests.throttled"
+)
+
+// Attribute is a key/value pair describing a span or measurement.
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// String returns a string Attribute.
+func String(key, value string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Int returns an integer Attribute.
+func Int(key string, value int) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Bool returns a boolean Attribute.
+func Bool(key string, value bool) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Tracer starts spans.
+type Tracer interface {
+	// Start begins a span, returning a context containing the span which should be used for any child spans.
+	Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span)
+}
+
+// Span is a single operation within a trace.
+type Span interface {
+	SetAttributes(attrs ...Attribute)
+	RecordError(err error)
+	End()
+}
+
+// Meter records measurements.
+type Meter interface {
+	// AddCounter adds value to the named counter.
+	AddCounter(ctx context.Context, name string, value int64, attrs ...Attribute)
+
+	// RecordHistogram records value in the named histogram.
+	RecordHistogram(ctx context.Context, name string, value float64, attrs ...Attribute)
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttributes(...Attribute) {}
+func (noopSpan) RecordError(error)          {}
+func (noopSpan) End()                       {}
+
+// StartSpan begins a span using tracer, or returns ctx with a span that does nothing when tracer is nil.
+func StartSpan(ctx context.Context, tracer Tracer, name string, attrs ...Attribute) (context.Context, Span) {
+	if tracer == nil {
+		return ctx, noopSpan{}
+	}
+	return tracer.Start(ctx, name, attrs...)
+}
+
+// EndSpan records err, if any, and ends span.
+func EndSpan(span Span, err error, attrs ...Attribute) {
+	if _, ok := span.(noopSpan); ok {
+		return
+	}
+	span.SetAttributes(attrs...)
+	if err != nil {
+		span.RecordError(err)
+	}
+	span.End()
+}
+
+// RecordAttempt ends span, which describes a single attempt at sending req, and records measurements for the attempt
+// using meter, which may be nil.
+func RecordAttempt(ctx context.Context, span Span, meter Meter, entity string, attempt int, req *http.Request, resp *http.Response, latency time.Duration, err error) {
+	_, noop := span.(noopSpan)
+	if noop && meter == nil {
+		return
+	}
+
+	attrs := []Attribute{
+		String(AttributeHttpMethod, req.Method),
+		String(AttributeEntity, entity),
+	}
+	throttled := false
+	if resp != nil {
+		throttled = resp.StatusCode == http.StatusTooManyRequests
+		attrs = append(attrs, Int(AttributeHttpStatus, resp.StatusCode), Bool(AttributeThrottled, throttled))
+	}
+
+	if !noop {
+		spanAttrs := append(attrs, Int(AttributeAttempt, attempt))
+		if resp != nil {
+			if requestId := resp.Header.Get("request-id"); requestId != "" {
+				spanAttrs = append(spanAttrs, String(AttributeRequestId, requestId))
+			}
+		}
+		EndSpan(span, err, spanAttrs...)
+	}
+
+	if meter != nil {
+		meter.RecordHistogram(ctx, MetricRequestDuration, latency.Seconds(), attrs...)
+		meter.AddCounter(ctx, MetricRequests, 1, attrs...)
+		if throttled {
+			meter.AddCounter(ctx, MetricThrottledRequests, 1, attrs...)
+		}
+	}
+}
+
+// Entity returns the name of the entity addressed by a request path, which is the first path segment that is not
+// one of the specified prefixes, such as the API version or tenant ID.
+func Entity(path string, prefixes ...string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, s := range segments {
+		if i < len(prefixes) && strings.EqualFold(s, prefixes[i]) {
+			continue
+		}
+		return s
+	}
+	return ""
+}
+
+type contextKey struct{}
+
+// WithTracer returns a copy of ctx containing tracer, which is used by authorizers in the auth package to trace
+// token acquisition.
+func WithTracer(ctx context.Context, tracer Tracer) context.Context {
+	return context.WithValue(ctx, contextKey{}, tracer)
+}
+
+// TracerFromContext returns the Tracer set using WithTracer, or nil if none was set.
+func TracerFromContext(ctx context.Context) Tracer {
+	if ctx == nil {
+		return nil
+	}
+	if t, ok := ctx.Value(contextKey{}).(Tracer); ok {
+		return t
+	}
+	return nil
+}
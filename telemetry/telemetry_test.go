@@ -0,0 +1,43 @@
+package telemetry_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/manicminer/hamilton/telemetry"
+)
+
+func TestEntity(t *testing.T) {
+	testCases := []struct {
+		path     string
+		prefixes []string
+		expected string
+	}{
+		{"/beta/tenant/users/1234", []string{"beta", "tenant"}, "users"},
+		{"/v1.0/tenant/groups/1234/members/$ref", []string{"v1.0", "tenant"}, "groups"},
+		{"/beta/servicePrincipals", []string{"beta", "tenant"}, "servicePrincipals"},
+		{"/beta/tenant", []string{"beta", "tenant"}, ""},
+		{"/tenant/applicationRefs/1234", []string{"tenant"}, "applicationRefs"},
+	}
+	for _, c := range testCases {
+		if entity := telemetry.Entity(c.path, c.prefixes...); entity != c.expected {
+			t.Errorf("Entity(%q): expected %q, got %q", c.path, c.expected, entity)
+		}
+	}
+}
+
+func TestNoop(t *testing.T) {
+	ctx := context.Background()
+	if telemetry.TracerFromContext(ctx) != nil {
+		t.Fatalf("expected no tracer in context")
+	}
+	spanCtx, span := telemetry.StartSpan(ctx, nil, "test")
+	if spanCtx != ctx {
+		t.Errorf("expected context to be unchanged without a tracer")
+	}
+	req, _ := http.NewRequest(http.MethodGet, "https://graph.microsoft.com/beta/users", nil)
+	telemetry.RecordAttempt(ctx, span, nil, "users", 1, req, &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}, time.Second, nil)
+	telemetry.EndSpan(span, nil)
+}
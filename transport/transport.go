@@ -0,0 +1,44 @@
+package transport
+
+import (
+	"net/http"
+)
+
+// Middleware wraps a http.RoundTripper to add behaviour such as logging, metrics or modifying requests.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc is an adapter to allow the use of ordinary functions as a http.RoundTripper.
+type RoundTripperFunc func(req *http.Request) (*http.Response, error)
+
+// RoundTrip calls f(req).
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Chain returns a http.RoundTripper which passes requests through each of the provided middleware in order, before
+// sending them using base. The first middleware is therefore the outermost, and sees each request first and each
+// response last. When base is nil, http.DefaultTransport is used.
+func Chain(base http.RoundTripper, middleware ...Middleware) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	rt := base
+	for i := len(middleware) - 1; i >= 0; i-- {
+		if middleware[i] != nil {
+			rt = middleware[i](rt)
+		}
+	}
+	return rt
+}
+
+// NewClient returns a copy of client with its transport wrapped by the provided middleware, in order. When client is
+// nil, a new http.Client is returned using http.DefaultTransport. The resulting client can be shared by the auth,
+// msgraph and aadgraph packages so that all requests pass through the same middleware.
+func NewClient(client *http.Client, middleware ...Middleware) *http.Client {
+	var c http.Client
+	if client != nil {
+		c = *client
+	}
+	c.Transport = Chain(c.Transport, middleware...)
+	return &c
+}
@@ -0,0 +1,87 @@
+package transport_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/manicminer/hamilton/auth"
+	"github.com/manicminer/hamilton/environments"
+	"github.com/manicminer/hamilton/msgraph"
+	"github.com/manicminer/hamilton/odata"
+	"github.com/manicminer/hamilton/transport"
+)
+
+// fakeApi responds to token and Microsoft Graph requests without making any network connections.
+var fakeApi = transport.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+	body := `{"value": []}`
+	if strings.HasSuffix(req.URL.Path, "/token") {
+		body = `{"access_token": "fake-token", "token_type": "Bearer", "expires_in": 3600}`
+	} else if req.Header.Get("Authorization") != "Bearer fake-token" {
+		return &http.Response{StatusCode: http.StatusUnauthorized, Header: http.Header{}, Body: http.NoBody, Request: req}, nil
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+})
+
+func recorder(name string, calls *[]string) transport.Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return transport.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			*calls = append(*calls, name+" "+req.URL.Host+req.URL.Path)
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+func TestChain(t *testing.T) {
+	var calls []string
+	rt := transport.Chain(fakeApi, recorder("first", &calls), nil, recorder("second", &calls))
+	req, _ := http.NewRequest(http.MethodGet, "https://graph.microsoft.com/v1.0/token", nil)
+	if _, err := rt.RoundTrip(req); err != nil {
+		t.Fatalf("RoundTrip(): %v", err)
+	}
+	expected := []string{"first graph.microsoft.com/v1.0/token", "second graph.microsoft.com/v1.0/token"}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Fatalf("expected middleware to be called in order %v, got %v", expected, calls)
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	var calls []string
+	httpClient := transport.NewClient(&http.Client{Transport: fakeApi}, recorder("recorder", &calls))
+
+	authConfig := auth.Config{
+		Environment:            environments.Global,
+		TenantID:               "tenant",
+		ClientID:               "client",
+		ClientSecret:           "secret",
+		EnableClientSecretAuth: true,
+		HttpClient:             httpClient,
+	}
+	authorizer, err := authConfig.NewAuthorizer(context.Background(), auth.MsGraph)
+	if err != nil {
+		t.Fatalf("Config.NewAuthorizer(): %v", err)
+	}
+
+	client := msgraph.NewUsersClient("tenant")
+	client.BaseClient.Authorizer = authorizer
+	client.BaseClient.HttpClient = httpClient
+	if _, _, err := client.List(context.Background(), odata.Query{}); err != nil {
+		t.Fatalf("UsersClient.List(): %v", err)
+	}
+
+	expected := []string{
+		"recorder login.microsoftonline.com/tenant/oauth2/v2.0/token",
+		"recorder graph.microsoft.com/beta/tenant/users",
+	}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Fatalf("expected requests %v, got %v", expected, calls)
+	}
+}